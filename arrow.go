@@ -0,0 +1,254 @@
+package exasol
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/decimal128"
+	"github.com/apache/arrow/go/v15/arrow/ipc"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+)
+
+// DefaultArrowBatchSize is the number of rows per record batch used if the given batch size is not positive.
+const DefaultArrowBatchSize = 65536
+
+// maxDecimal128Precision is the largest precision of Exasol decimals, which all fit into Arrow's 128 bit decimals.
+const maxDecimal128Precision = 36
+
+// ArrowMessage is an encoded message of the Arrow IPC format: the FlatBuffers metadata and the body containing
+// the buffers of a record batch, e.g. for sending them as data header and body of Arrow Flight data.
+type ArrowMessage struct {
+	Metadata []byte
+	Body     []byte
+}
+
+// ArrowRecordBatches reads the rows and converts them into Arrow record batches with up to batchSize rows each.
+// The given function receives the schema message first and then a message per record batch.
+// It returns the number of converted rows. The rows are read until the end but not closed.
+//
+// BOOLEAN and DOUBLE columns become bool and float64 fields, DECIMAL columns int64 fields for a scale of 0 and
+// a precision up to 18 and decimal128 fields otherwise. DATE columns become date32 and TIMESTAMP columns timestamp
+// fields with microseconds and without time zone. All other columns are converted to utf8 fields.
+func ArrowRecordBatches(rows *sql.Rows, batchSize int, emit func(ArrowMessage) error) (int64, error) {
+	return writeArrowRecords(rows, batchSize, func(schema *arrow.Schema) *ipc.Writer {
+		return ipc.NewWriterWithPayloadWriter(arrowMessageWriter(emit), ipc.WithSchema(schema))
+	})
+}
+
+// WriteArrowStream writes the rows in the Arrow IPC streaming format like ArrowRecordBatches and returns the number
+// of written rows. Arrow libraries read the stream e.g. with pyarrow.ipc.open_stream or ipc.NewReader of Arrow Go.
+func WriteArrowStream(writer io.Writer, rows *sql.Rows, batchSize int) (int64, error) {
+	return writeArrowRecords(rows, batchSize, func(schema *arrow.Schema) *ipc.Writer {
+		return ipc.NewWriter(writer, ipc.WithSchema(schema))
+	})
+}
+
+// writeArrowRecords converts the rows into record batches and writes them with the writer created for their schema.
+func writeArrowRecords(rows *sql.Rows, batchSize int, newWriter func(*arrow.Schema) *ipc.Writer) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultArrowBatchSize
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	fields := make([]arrow.Field, len(columnTypes))
+	for i, columnType := range columnTypes {
+		fields[i] = arrowField(columnType)
+	}
+	schema := arrow.NewSchema(fields, nil)
+	writer := newWriter(schema)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	flush := func() error {
+		record := builder.NewRecord()
+		defer record.Release()
+		return writer.Write(record)
+	}
+	values := make([]any, len(fields))
+	pointers := make([]any, len(fields))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	var count, batchRows int64
+	for rows.Next() {
+		if err = rows.Scan(pointers...); err != nil {
+			return count, err
+		}
+		for i, value := range values {
+			converted, err := arrowValue(fields[i], value)
+			if err == nil {
+				err = appendArrowValue(builder.Field(i), converted)
+			}
+			if err != nil {
+				return count, fmt.Errorf("invalid value %v of column %q: %w", value, fields[i].Name, err)
+			}
+		}
+		batchRows++
+		if batchRows >= int64(batchSize) {
+			count += batchRows
+			batchRows = 0
+			if err = flush(); err != nil {
+				return count, err
+			}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return count, err
+	}
+	if batchRows > 0 {
+		count += batchRows
+		if err = flush(); err != nil {
+			return count, err
+		}
+	}
+	return count, writer.Close()
+}
+
+// arrowMessageWriter passes the payloads of an ipc.Writer as messages to the given function.
+type arrowMessageWriter func(ArrowMessage) error
+
+func (w arrowMessageWriter) Start() error {
+	return nil
+}
+
+func (w arrowMessageWriter) WritePayload(payload ipc.Payload) error {
+	metadata := payload.Meta()
+	defer metadata.Release()
+	var body bytes.Buffer
+	if err := payload.SerializeBody(&body); err != nil {
+		return err
+	}
+	return w(ArrowMessage{Metadata: append([]byte(nil), metadata.Bytes()...), Body: body.Bytes()})
+}
+
+func (w arrowMessageWriter) Close() error {
+	return nil
+}
+
+func arrowField(columnType *sql.ColumnType) arrow.Field {
+	field := arrow.Field{Name: columnType.Name(), Nullable: true}
+	switch columnType.DatabaseTypeName() {
+	case "BOOLEAN":
+		field.Type = arrow.FixedWidthTypes.Boolean
+	case "DOUBLE":
+		field.Type = arrow.PrimitiveTypes.Float64
+	case "DECIMAL":
+		precision, scale, ok := columnType.DecimalSize()
+		if !ok {
+			precision, scale = maxDecimal128Precision, 0
+		}
+		if scale == 0 && precision <= maxInt64Precision {
+			field.Type = arrow.PrimitiveTypes.Int64
+		} else {
+			field.Type = &arrow.Decimal128Type{Precision: int32(precision), Scale: int32(scale)}
+		}
+	case "DATE":
+		field.Type = arrow.FixedWidthTypes.Date32
+	case "TIMESTAMP", "TIMESTAMP WITH LOCAL TIME ZONE":
+		field.Type = &arrow.TimestampType{Unit: arrow.Microsecond}
+	default:
+		field.Type = arrow.BinaryTypes.String
+	}
+	return field
+}
+
+// arrowValue converts a value of the driver into the value of the given field.
+func arrowValue(field arrow.Field, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch fieldType := field.Type.(type) {
+	case *arrow.Int64Type:
+		switch v := value.(type) {
+		case float64:
+			return int64(v), nil
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case *arrow.Decimal128Type:
+		switch v := value.(type) {
+		case float64:
+			return parseUnscaledDecimal(strconv.FormatFloat(v, 'f', -1, 64), int(fieldType.Scale))
+		case int64:
+			return new(big.Int).Mul(big.NewInt(v), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(fieldType.Scale)), nil)), nil
+		case string:
+			return parseUnscaledDecimal(v, int(fieldType.Scale))
+		}
+	case *arrow.Date32Type:
+		if v, ok := value.(string); ok {
+			return parseDate(v)
+		}
+	case *arrow.TimestampType:
+		if v, ok := value.(string); ok {
+			return parseTimestamp(v)
+		}
+	case *arrow.StringType:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case []byte:
+			return string(v), nil
+		}
+		return fmt.Sprint(value), nil
+	}
+	return value, nil
+}
+
+// maxDecimal128 is the largest absolute unscaled value of a decimal128 field.
+var maxDecimal128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+
+// appendArrowValue appends a value converted by arrowValue to the builder of its field.
+func appendArrowValue(builder array.Builder, value any) error {
+	if value == nil {
+		builder.AppendNull()
+		return nil
+	}
+	switch b := builder.(type) {
+	case *array.BooleanBuilder:
+		if v, ok := value.(bool); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.Int64Builder:
+		if v, ok := value.(int64); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.Float64Builder:
+		if v, ok := value.(float64); ok {
+			b.Append(v)
+			return nil
+		}
+	case *array.Decimal128Builder:
+		if v, ok := value.(*big.Int); ok {
+			if v.CmpAbs(maxDecimal128) > 0 {
+				return fmt.Errorf("value does not fit into a 128 bit decimal")
+			}
+			b.Append(decimal128.FromBigInt(v))
+			return nil
+		}
+	case *array.Date32Builder:
+		if v, ok := value.(int32); ok {
+			b.Append(arrow.Date32(v))
+			return nil
+		}
+	case *array.TimestampBuilder:
+		if v, ok := value.(int64); ok {
+			b.Append(arrow.Timestamp(v))
+			return nil
+		}
+	case *array.StringBuilder:
+		if v, ok := value.(string); ok {
+			b.Append(v)
+			return nil
+		}
+	}
+	return fmt.Errorf("unexpected value of type %T", value)
+}
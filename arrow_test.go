@@ -0,0 +1,291 @@
+package exasol
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/ipc"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrowRecordBatches(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{{name: "ID", databaseType: "DECIMAL", precision: 18}}, [][]driver.Value{{float64(1)}, {float64(2)}, {nil}})
+	var messages []ArrowMessage
+	count, err := ArrowRecordBatches(rows, 2, func(message ArrowMessage) error {
+		messages = append(messages, message)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	assert.Len(t, messages, 3)
+	assert.Empty(t, messages[0].Body)
+	reader := readArrowMessages(t, messages)
+	assert.Equal(t, arrow.NewSchema([]arrow.Field{{Name: "ID", Type: arrow.PrimitiveTypes.Int64, Nullable: true}}, nil), reader.Schema())
+	assert.Equal(t, [][][]any{{{int64(1)}, {int64(2)}}, {{nil}}}, readArrowBatches(t, reader))
+}
+
+func TestArrowRecordBatchesEmitError(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{{name: "ID", databaseType: "DOUBLE"}}, [][]driver.Value{{1.5}})
+	_, err := ArrowRecordBatches(rows, 0, func(ArrowMessage) error {
+		return io.ErrShortWrite
+	})
+	assert.ErrorIs(t, err, io.ErrShortWrite)
+}
+
+func TestArrowRecordBatchesInvalidValue(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{{name: "D", databaseType: "DATE"}}, [][]driver.Value{{"02.01.2023"}})
+	_, err := ArrowRecordBatches(rows, 0, func(ArrowMessage) error { return nil })
+	assert.ErrorContains(t, err, `invalid value 02.01.2023 of column "D"`)
+}
+
+func TestWriteArrowStream(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{
+		{name: "B", databaseType: "BOOLEAN"},
+		{name: "F", databaseType: "DOUBLE"},
+		{name: "I", databaseType: "DECIMAL", precision: 18},
+		{name: "D", databaseType: "DECIMAL", precision: 36, scale: 2},
+		{name: "DT", databaseType: "DATE"},
+		{name: "TS", databaseType: "TIMESTAMP"},
+		{name: "S", databaseType: "VARCHAR"},
+	}, [][]driver.Value{
+		{true, 1.5, int64(math.MaxInt64), "-99999999999999999999999999999999.99", "1970-01-03", "1970-01-01 00:00:00.123000", "ä\U0001F600"},
+		{nil, nil, nil, nil, nil, nil, nil},
+		{false, math.Inf(1), "-9223372036854775808", "0.01", "2023-02-01", "2023-02-01 12:00:00.5", ""},
+	})
+	var stream bytes.Buffer
+	count, err := WriteArrowStream(&stream, rows, 2)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	reader, err := ipc.NewReader(&stream)
+	assert.NoError(t, err)
+	defer reader.Release()
+	largest, _ := new(big.Int).SetString("-9999999999999999999999999999999999", 10)
+	assert.Equal(t, [][][]any{
+		{
+			{true, 1.5, int64(math.MaxInt64), largest.String(), int32(2), int64(123000), "ä\U0001F600"},
+			{nil, nil, nil, nil, nil, nil, nil},
+		},
+		{{false, math.Inf(1), int64(math.MinInt64), "1", int32(19389), int64(1675252800500000), ""}},
+	}, readArrowBatches(t, reader))
+}
+
+func TestWriteArrowStreamWithoutRows(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{{name: "NAME", databaseType: "VARCHAR"}}, nil)
+	var stream bytes.Buffer
+	count, err := WriteArrowStream(&stream, rows, 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+	reader, err := ipc.NewReader(&stream)
+	assert.NoError(t, err)
+	defer reader.Release()
+	assert.Equal(t, "NAME", reader.Schema().Field(0).Name)
+	assert.Empty(t, readArrowBatches(t, reader))
+}
+
+func TestArrowField(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{
+		{name: "B", databaseType: "BOOLEAN"},
+		{name: "F", databaseType: "DOUBLE"},
+		{name: "I", databaseType: "DECIMAL", precision: 18},
+		{name: "D", databaseType: "DECIMAL", precision: 18, scale: 2},
+		{name: "L", databaseType: "DECIMAL", precision: 36},
+		{name: "DT", databaseType: "DATE"},
+		{name: "TS", databaseType: "TIMESTAMP WITH LOCAL TIME ZONE"},
+		{name: "S", databaseType: "HASHTYPE"},
+	}, nil)
+	columnTypes, err := rows.ColumnTypes()
+	assert.NoError(t, err)
+	var fields []arrow.Field
+	for _, columnType := range columnTypes {
+		fields = append(fields, arrowField(columnType))
+	}
+	assert.Equal(t, []arrow.Field{
+		{Name: "B", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		{Name: "F", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "I", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "D", Type: &arrow.Decimal128Type{Precision: 18, Scale: 2}, Nullable: true},
+		{Name: "L", Type: &arrow.Decimal128Type{Precision: 36}, Nullable: true},
+		{Name: "DT", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+		{Name: "TS", Type: &arrow.TimestampType{Unit: arrow.Microsecond}, Nullable: true},
+		{Name: "S", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, fields)
+}
+
+func TestArrowValue(t *testing.T) {
+	decimal := arrow.Field{Type: &arrow.Decimal128Type{Precision: 36, Scale: 2}}
+	int64Field := arrow.Field{Type: arrow.PrimitiveTypes.Int64}
+	stringField := arrow.Field{Type: arrow.BinaryTypes.String}
+	tests := []struct {
+		field    arrow.Field
+		value    any
+		expected any
+	}{
+		{int64Field, float64(42), int64(42)},
+		{int64Field, int64(1) << 60, int64(1) << 60},
+		{int64Field, "123", int64(123)},
+		{decimal, 1.5, big.NewInt(150)},
+		{decimal, int64(1) << 60, new(big.Int).Mul(big.NewInt(int64(1)<<60), big.NewInt(100))},
+		{decimal, "-12345678901234567890.12", func() *big.Int { v, _ := new(big.Int).SetString("-1234567890123456789012", 10); return v }()},
+		{arrow.Field{Type: arrow.FixedWidthTypes.Date32}, "1970-01-03", int32(2)},
+		{arrow.Field{Type: &arrow.TimestampType{Unit: arrow.Microsecond}}, "1970-01-01 00:00:00.123000", int64(123000)},
+		{stringField, []byte("raw"), "raw"},
+		{stringField, float64(1), "1"},
+		{arrow.Field{Type: arrow.FixedWidthTypes.Boolean}, true, true},
+		{arrow.Field{Type: arrow.FixedWidthTypes.Boolean}, nil, nil},
+	}
+	for _, test := range tests {
+		value, err := arrowValue(test.field, test.value)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, value)
+	}
+}
+
+func TestAppendArrowValueErrors(t *testing.T) {
+	builder := array.NewDecimal128Builder(memory.DefaultAllocator, &arrow.Decimal128Type{Precision: 38})
+	defer builder.Release()
+	assert.EqualError(t, appendArrowValue(builder, new(big.Int).Lsh(big.NewInt(1), 127)), "value does not fit into a 128 bit decimal")
+	assert.EqualError(t, appendArrowValue(builder, "1"), "unexpected value of type string")
+}
+
+// readArrowMessages returns a reader for the stream of the given messages in the encapsulated format.
+func readArrowMessages(t *testing.T, messages []ArrowMessage) *ipc.Reader {
+	var stream bytes.Buffer
+	for _, message := range messages {
+		metadata := append([]byte(nil), message.Metadata...)
+		for len(metadata)%8 != 0 {
+			metadata = append(metadata, 0)
+		}
+		stream.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF})
+		stream.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(metadata))))
+		stream.Write(metadata)
+		stream.Write(message.Body)
+	}
+	reader, err := ipc.NewReader(&stream)
+	assert.NoError(t, err)
+	t.Cleanup(reader.Release)
+	return reader
+}
+
+// readArrowBatches returns the values of the rows of each record batch. Decimals are returned as text.
+func readArrowBatches(t *testing.T, reader *ipc.Reader) [][][]any {
+	var batches [][][]any
+	for reader.Next() {
+		record := reader.Record()
+		rows := make([][]any, record.NumRows())
+		for i := range rows {
+			rows[i] = make([]any, record.NumCols())
+			for column, values := range record.Columns() {
+				rows[i][column] = arrowArrayValue(values, i)
+			}
+		}
+		batches = append(batches, rows)
+	}
+	assert.NoError(t, reader.Err())
+	return batches
+}
+
+func arrowArrayValue(values arrow.Array, row int) any {
+	if values.IsNull(row) {
+		return nil
+	}
+	switch v := values.(type) {
+	case *array.Decimal128:
+		return v.Value(row).BigInt().String()
+	case *array.Date32:
+		return int32(v.Value(row))
+	case *array.Timestamp:
+		return int64(v.Value(row))
+	default:
+		return v.GetOneForMarshal(row)
+	}
+}
+
+type staticColumn struct {
+	name         string
+	databaseType string
+	precision    int64
+	scale        int64
+}
+
+// queryStaticRows returns rows with the given columns and data of a fake driver.
+func queryStaticRows(t *testing.T, columns []staticColumn, data [][]driver.Value) *sql.Rows {
+	db := sql.OpenDB(&staticConnector{columns: columns, data: data})
+	t.Cleanup(func() { db.Close() })
+	rows, err := db.Query("SELECT")
+	assert.NoError(t, err)
+	t.Cleanup(func() { rows.Close() })
+	return rows
+}
+
+type staticConnector struct {
+	columns []staticColumn
+	data    [][]driver.Value
+}
+
+func (c *staticConnector) Connect(context.Context) (driver.Conn, error) {
+	return c, nil
+}
+
+func (c *staticConnector) Driver() driver.Driver {
+	return &ExasolDriver{}
+}
+
+func (c *staticConnector) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &staticRows{columns: c.columns, data: c.data}, nil
+}
+
+func (c *staticConnector) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *staticConnector) Close() error {
+	return nil
+}
+
+func (c *staticConnector) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+type staticRows struct {
+	columns []staticColumn
+	data    [][]driver.Value
+}
+
+func (r *staticRows) Columns() []string {
+	names := make([]string, len(r.columns))
+	for i, column := range r.columns {
+		names[i] = column.name
+	}
+	return names
+}
+
+func (r *staticRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.columns[index].databaseType
+}
+
+func (r *staticRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	return r.columns[index].precision, r.columns[index].scale, r.columns[index].databaseType == "DECIMAL"
+}
+
+func (r *staticRows) Close() error {
+	return nil
+}
+
+func (r *staticRows) Next(dest []driver.Value) error {
+	if len(r.data) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.data[0])
+	r.data = r.data[1:]
+	return nil
+}
@@ -0,0 +1,109 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+)
+
+// QueryStatus is the state of a query submitted with SubmitQuery.
+type QueryStatus string
+
+// States of a submitted query.
+const (
+	QueryRunning   QueryStatus = "RUNNING"   // The database is still executing the query
+	QuerySucceeded QueryStatus = "SUCCEEDED" // The query finished, its result is available with Result
+	QueryFailed    QueryStatus = "FAILED"    // The query failed, the error is available with Result
+	QueryCancelled QueryStatus = "CANCELLED" // The query was cancelled with Cancel or Close
+)
+
+// QueryHandle references a query that runs in the background on a dedicated connection.
+// The connection is reserved for the query until the handle is closed,
+// because result sets of Exasol belong to the session that created them and can't be fetched by other connections.
+type QueryHandle struct {
+	conn   *sql.Conn
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+
+	rows *sql.Rows
+	err  error
+}
+
+// SubmitQuery starts the given query on a connection of the given database and returns immediately.
+// The context only limits waiting for a free connection, the query runs until it finishes or the handle is cancelled,
+// so it may outlive e.g. the HTTP request that submitted it. Poll the status with Status,
+// wait for the result with Result and release the connection with Close.
+func SubmitQuery(ctx context.Context, db *sql.DB, query string, args ...any) (*QueryHandle, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queryCtx, cancel := context.WithCancel(context.Background())
+	handle := &QueryHandle{conn: conn, cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(handle.done)
+		handle.rows, handle.err = conn.QueryContext(queryCtx, query, args...)
+	}()
+	return handle, nil
+}
+
+// Status returns the current state of the query without blocking.
+func (h *QueryHandle) Status() QueryStatus {
+	select {
+	case <-h.done:
+	default:
+		return QueryRunning
+	}
+	switch {
+	case h.err == nil:
+		return QuerySucceeded
+	case errors.Is(h.err, context.Canceled):
+		return QueryCancelled
+	default:
+		return QueryFailed
+	}
+}
+
+// Done returns a channel that is closed when the query has finished.
+func (h *QueryHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Result waits until the query has finished and returns its rows or its error.
+// The context only limits waiting, the query continues when it is done.
+// The rows stay valid until the handle is closed.
+func (h *QueryHandle) Result(ctx context.Context) (*sql.Rows, error) {
+	select {
+	case <-h.done:
+		return h.rows, h.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Cancel aborts the query if it is still running. The result of a finished query stays available.
+func (h *QueryHandle) Cancel() {
+	select {
+	case <-h.done:
+	default:
+		h.cancel()
+	}
+}
+
+// Close cancels the query if it is still running, closes its rows and releases the connection.
+func (h *QueryHandle) Close() error {
+	var err error
+	h.once.Do(func() {
+		h.cancel()
+		<-h.done
+		if h.rows != nil {
+			err = h.rows.Close()
+		}
+		if closeErr := h.conn.Close(); err == nil {
+			err = closeErr
+		}
+	})
+	return err
+}
@@ -0,0 +1,159 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AsyncQueryTestSuite struct {
+	suite.Suite
+	release chan error
+	db      *sql.DB
+}
+
+func TestAsyncQuerySuite(t *testing.T) {
+	suite.Run(t, new(AsyncQueryTestSuite))
+}
+
+func (suite *AsyncQueryTestSuite) SetupTest() {
+	suite.release = make(chan error, 1)
+	suite.db = sql.OpenDB(&blockingConnector{release: suite.release})
+}
+
+func (suite *AsyncQueryTestSuite) TearDownTest() {
+	suite.NoError(suite.db.Close())
+}
+
+func (suite *AsyncQueryTestSuite) TestSucceeded() {
+	handle, err := SubmitQuery(context.Background(), suite.db, "SELECT 1")
+	suite.NoError(err)
+	defer handle.Close()
+	suite.Equal(QueryRunning, handle.Status())
+	suite.release <- nil
+	rows, err := handle.Result(context.Background())
+	suite.NoError(err)
+	suite.Equal(QuerySucceeded, handle.Status())
+	suite.True(rows.Next())
+	var value int64
+	suite.NoError(rows.Scan(&value))
+	suite.Equal(int64(42), value)
+}
+
+func (suite *AsyncQueryTestSuite) TestFailed() {
+	handle, err := SubmitQuery(context.Background(), suite.db, "SELECT 1")
+	suite.NoError(err)
+	defer handle.Close()
+	suite.release <- fmt.Errorf("mock error")
+	<-handle.Done()
+	suite.Equal(QueryFailed, handle.Status())
+	_, err = handle.Result(context.Background())
+	suite.EqualError(err, "mock error")
+}
+
+func (suite *AsyncQueryTestSuite) TestCancel() {
+	handle, err := SubmitQuery(context.Background(), suite.db, "SELECT 1")
+	suite.NoError(err)
+	defer handle.Close()
+	handle.Cancel()
+	<-handle.Done()
+	suite.Equal(QueryCancelled, handle.Status())
+}
+
+func (suite *AsyncQueryTestSuite) TestResultWaitingTimesOut() {
+	handle, err := SubmitQuery(context.Background(), suite.db, "SELECT 1")
+	suite.NoError(err)
+	defer handle.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = handle.Result(ctx)
+	suite.ErrorIs(err, context.DeadlineExceeded)
+	suite.Equal(QueryRunning, handle.Status())
+}
+
+func (suite *AsyncQueryTestSuite) TestQueryOutlivesSubmitContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	handle, err := SubmitQuery(ctx, suite.db, "SELECT 1")
+	suite.NoError(err)
+	defer handle.Close()
+	cancel()
+	suite.release <- nil
+	_, err = handle.Result(context.Background())
+	suite.NoError(err)
+}
+
+func (suite *AsyncQueryTestSuite) TestCloseCancelsRunningQuery() {
+	handle, err := SubmitQuery(context.Background(), suite.db, "SELECT 1")
+	suite.NoError(err)
+	suite.NoError(handle.Close())
+	suite.Equal(QueryCancelled, handle.Status())
+	suite.NoError(handle.Close())
+}
+
+// blockingConnector creates connections whose queries block until an error or nil is sent to the release channel.
+type blockingConnector struct {
+	release chan error
+}
+
+func (c *blockingConnector) Connect(context.Context) (driver.Conn, error) {
+	return &blockingConn{release: c.release}, nil
+}
+
+func (c *blockingConnector) Driver() driver.Driver {
+	return &ExasolDriver{}
+}
+
+type blockingConn struct {
+	release chan error
+}
+
+func (c *blockingConn) QueryContext(ctx context.Context, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+	select {
+	case err := <-c.release:
+		if err != nil {
+			return nil, err
+		}
+		return &singleValueRows{}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *blockingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *blockingConn) Close() error {
+	return nil
+}
+
+func (c *blockingConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+type singleValueRows struct {
+	read bool
+}
+
+func (r *singleValueRows) Columns() []string {
+	return []string{"VALUE"}
+}
+
+func (r *singleValueRows) Close() error {
+	return nil
+}
+
+func (r *singleValueRows) Next(dest []driver.Value) error {
+	if r.read {
+		return io.EOF
+	}
+	r.read = true
+	dest[0] = int64(42)
+	return nil
+}
@@ -0,0 +1,56 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+)
+
+// SplitStatements splits SQL text, e.g. the content of an SQL file, into statements for ExecBatch.
+// It splits at the semicolons outside of string literals, quoted identifiers and comments. The bodies of CREATE SCRIPT
+// and CREATE FUNCTION statements contain semicolons, so they end at a line containing only a slash like in EXAplus
+// or at the end of the text. Statements are trimmed and empty statements omitted.
+func SplitStatements(text string) []string {
+	return utils.SplitStatements(text)
+}
+
+// ExecBatch executes the given statements one after the other on the given connection and returns the number of
+// affected rows of each statement in the result's RowCounts, which sql.Result of database/sql can't expose.
+// If a statement fails, the remaining statements are skipped and the returned *errors.BatchError contains its index
+// and the row counts of the executed statements. Run the batch in a transaction to roll back the executed statements.
+func ExecBatch(ctx context.Context, conn *sql.Conn, statements []string) (*connection.BatchResult, error) {
+	var result *connection.BatchResult
+	err := withRawConnection(conn, func(exasolConn *connection.Connection) error {
+		var err error
+		result, err = exasolConn.ExecBatch(ctx, statements)
+		return err
+	})
+	return result, err
+}
+
+// ExecPreparedBatch prepares the given statement once and executes it for all rows of arguments, sending up to
+// chunkSize rows per execution or all rows at once if chunkSize is not positive. The result's RowCounts contain the
+// number of affected rows of each chunk, so use a chunk size of 1 for the counts of single rows.
+// If a chunk fails, the remaining chunks are skipped and the returned *errors.BatchError contains its index
+// and the row counts of the executed chunks.
+// Chunks of INSERT ... VALUES (?, ...) statements exceeding the thresholds of options batchimportrows and
+// batchimportbytes are converted to CSV and uploaded with an IMPORT statement instead.
+func ExecPreparedBatch(ctx context.Context, conn *sql.Conn, query string, rows [][]any, chunkSize int) (*connection.BatchResult, error) {
+	var result *connection.BatchResult
+	err := withRawConnection(conn, func(exasolConn *connection.Connection) error {
+		namedRows := make([][]driver.NamedValue, len(rows))
+		for i, row := range rows {
+			var err error
+			if namedRows[i], err = namedValues(exasolConn, row); err != nil {
+				return err
+			}
+		}
+		var err error
+		result, err = exasolConn.ExecPreparedBatch(ctx, query, namedRows, chunkSize)
+		return err
+	})
+	return result, err
+}
@@ -0,0 +1,80 @@
+package exasol
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"strconv"
+)
+
+// CSVOptions configures WriteCSV.
+type CSVOptions struct {
+	// Header writes the column names as first record.
+	Header bool
+	// NullToken is written for NULL values. String values equal to the token are rejected,
+	// as they could not be told apart from NULL values. If it is empty, NULL values are written as empty fields.
+	NullToken string
+}
+
+// WriteCSV reads the rows and writes them as CSV records to the given writer, which is flushed at the end.
+// It returns the number of written rows without the header. The rows are read until the end but not closed.
+//
+// DECIMAL values are written with the scale of their column and without exponent, DOUBLE values with the shortest
+// representation that reads back unchanged. DATE and TIMESTAMP values are written in the format of the session,
+// e.g. 2023-01-02 and 2023-01-02 10:00:00.000000, and BOOLEAN values as true and false.
+func WriteCSV(writer *csv.Writer, rows *sql.Rows, options CSVOptions) (int64, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	record := make([]string, len(columnTypes))
+	if options.Header {
+		for i, columnType := range columnTypes {
+			record[i] = columnType.Name()
+		}
+		if err = writer.Write(record); err != nil {
+			return 0, err
+		}
+	}
+	values := make([]any, len(columnTypes))
+	pointers := make([]any, len(columnTypes))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	var count int64
+	for rows.Next() {
+		if err = rows.Scan(pointers...); err != nil {
+			return count, err
+		}
+		for i, value := range values {
+			if record[i], err = csvColumnField(columnTypes[i], value, options.NullToken); err != nil {
+				return count, err
+			}
+		}
+		if err = writer.Write(record); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err = rows.Err(); err != nil {
+		return count, err
+	}
+	writer.Flush()
+	return count, writer.Error()
+}
+
+// csvColumnField formats numbers as described by their column and all other values like csvField.
+func csvColumnField(columnType *sql.ColumnType, value any, nullToken string) (string, error) {
+	number, ok := value.(float64)
+	if !ok {
+		return csvField(value, nullToken)
+	}
+	switch columnType.DatabaseTypeName() {
+	case "DECIMAL":
+		_, scale, _ := columnType.DecimalSize()
+		return strconv.FormatFloat(number, 'f', int(scale), 64), nil
+	case "DOUBLE":
+		return strconv.FormatFloat(number, 'g', -1, 64), nil
+	default:
+		return csvField(value, nullToken)
+	}
+}
@@ -0,0 +1,45 @@
+package exasol
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/csv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteCSV(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{
+		{name: "ID", databaseType: "DECIMAL", precision: 18},
+		{name: "PRICE", databaseType: "DECIMAL", precision: 18, scale: 2},
+		{name: "RATIO", databaseType: "DOUBLE"},
+		{name: "NAME", databaseType: "VARCHAR"},
+		{name: "ACTIVE", databaseType: "BOOLEAN"},
+		{name: "CREATED", databaseType: "DATE"},
+	}, [][]driver.Value{
+		{float64(1e17), 1.5, 1e300, "a,b", true, "2023-01-02"},
+		{float64(2), nil, 0.1, "", false, nil},
+	})
+	var buffer bytes.Buffer
+	count, err := WriteCSV(csv.NewWriter(&buffer), rows, CSVOptions{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	assert.Equal(t, "100000000000000000,1.50,1e+300,\"a,b\",true,2023-01-02\n2,,0.1,,false,\n", buffer.String())
+}
+
+func TestWriteCSVWithHeaderAndNullToken(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{{name: "ID", databaseType: "DECIMAL", precision: 18}, {name: "NAME", databaseType: "VARCHAR"}},
+		[][]driver.Value{{float64(1), nil}})
+	var buffer bytes.Buffer
+	count, err := WriteCSV(csv.NewWriter(&buffer), rows, CSVOptions{Header: true, NullToken: `\N`})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	assert.Equal(t, "ID,NAME\n1,\\N\n", buffer.String())
+}
+
+func TestWriteCSVValueEqualsNullToken(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{{name: "NAME", databaseType: "VARCHAR"}}, [][]driver.Value{{"NULL"}})
+	_, err := WriteCSV(csv.NewWriter(&bytes.Buffer{}), rows, CSVOptions{NullToken: "NULL"})
+	assert.ErrorContains(t, err, "E-EGOD-57")
+}
@@ -0,0 +1,87 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+)
+
+// Cursor gives random access to the rows of a result set that stays open on the database,
+// e.g. for server-side pagination of a large result without executing the query again for each page.
+type Cursor struct {
+	conn    *sql.Conn
+	results *connection.QueryResults
+}
+
+// OpenCursor executes the given query on the given connection and keeps its result set open until the cursor is closed.
+// Result sets belong to the session that created them, so keep the connection until the cursor is closed.
+func OpenCursor(ctx context.Context, conn *sql.Conn, query string, args ...any) (*Cursor, error) {
+	var results *connection.QueryResults
+	err := withRawConnection(conn, func(exasolConn *connection.Connection) error {
+		namedArgs, err := namedValues(exasolConn, args)
+		if err != nil {
+			return err
+		}
+		rows, err := exasolConn.QueryContext(ctx, query, namedArgs)
+		if err != nil {
+			return err
+		}
+		results = rows.(*connection.QueryResults)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Cursor{conn: conn, results: results}, nil
+}
+
+// Columns returns the names of the result columns.
+func (c *Cursor) Columns() []string {
+	return c.results.Columns()
+}
+
+// NumRows returns the total number of rows of the result, e.g. for calculating the number of pages.
+func (c *Cursor) NumRows() int {
+	return c.results.NumRows()
+}
+
+// Fetch returns up to count rows starting at the zero-based offset. Fewer rows are returned at the end of the result.
+func (c *Cursor) Fetch(ctx context.Context, offset, count int) ([][]driver.Value, error) {
+	var rows [][]driver.Value
+	err := withRawConnection(c.conn, func(*connection.Connection) error {
+		var err error
+		rows, err = c.results.FetchRows(ctx, offset, count)
+		return err
+	})
+	return rows, err
+}
+
+// Close closes the result set on the database. The connection stays open.
+func (c *Cursor) Close() error {
+	return withRawConnection(c.conn, func(*connection.Connection) error {
+		return c.results.Close()
+	})
+}
+
+// namedValues converts the given arguments like database/sql does for Query and Exec.
+func namedValues(conn *connection.Connection, args []any) ([]driver.NamedValue, error) {
+	values := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		value := driver.NamedValue{Ordinal: i + 1, Value: arg}
+		if named, ok := arg.(sql.NamedArg); ok {
+			value.Name = named.Name
+			value.Value = named.Value
+		}
+		err := conn.CheckNamedValue(&value)
+		if err == driver.ErrSkip {
+			value.Value, err = driver.DefaultParameterConverter.ConvertValue(value.Value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
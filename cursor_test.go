@@ -0,0 +1,37 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedValues(t *testing.T) {
+	values, err := namedValues(&connection.Connection{}, []any{42, sql.Named("name", "value"), connection.ListParam{1, "a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.NamedValue{
+		{Ordinal: 1, Value: int64(42)},
+		{Ordinal: 2, Name: "name", Value: "value"},
+		{Ordinal: 3, Value: connection.ListParam{int64(1), "a"}},
+	}, values)
+}
+
+func TestNamedValuesUnsupportedType(t *testing.T) {
+	_, err := namedValues(&connection.Connection{}, []any{struct{}{}})
+	assert.Error(t, err)
+}
+
+func TestOpenCursorRequiresExasolConnection(t *testing.T) {
+	db := sql.OpenDB(&blockingConnector{})
+	defer db.Close()
+	conn, err := db.Conn(context.Background())
+	assert.NoError(t, err)
+	defer conn.Close()
+	_, err = OpenCursor(context.Background(), conn, "SELECT 1")
+	assert.ErrorIs(t, err, errors.ErrUnsupportedConnection)
+}
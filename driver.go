@@ -10,52 +10,137 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"sync"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/internal/utils"
 	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
 	"github.com/exasol/exasol-driver-go/pkg/dsn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
 )
 
 func init() {
 	sql.Register("exasol", &ExasolDriver{})
 }
 
+// driverStats collects the statistics of all connectors.
+var driverStats = connection.NewStatsCollector(nil)
+
+// driverShutdown tracks the connections of all connectors.
+var driverShutdown = connection.NewShutdownGroup(nil)
+
 // ExasolDriver is an implementation of the [database/sql/driver.Driver] interface.
-type ExasolDriver struct{}
+type ExasolDriver struct {
+	// profile contains default properties of drivers registered with RegisterProfile.
+	profile string
+}
+
+// Stats returns the statistics of all connections opened by the driver.
+func (e ExasolDriver) Stats() connection.Stats {
+	return driverStats.Snapshot()
+}
+
+// Shutdown gracefully shuts down all connectors of the driver, see [Connector.Shutdown].
+// Afterwards no new connections can be opened with the driver.
+func (e ExasolDriver) Shutdown(ctx context.Context) error {
+	return driverShutdown.Shutdown(ctx)
+}
 
 // Open implements the driver.Driver interface.
 func (e ExasolDriver) Open(input string) (driver.Conn, error) {
-	dsnConfig, err := dsn.ParseDSN(input)
+	dsnConfig, err := dsn.ParseDSN(applyProfile(input, e.profile))
 	if err != nil {
 		return nil, err
 	}
-	c := &Connector{
-		Config: dsn.ToInternalConfig(dsnConfig),
-	}
-	return c.Connect(context.Background())
+	return NewConnector(dsn.ToInternalConfig(dsnConfig)).Connect(context.Background())
 }
 
 // OpenConnector implements the driver.DriverContext interface.
 func (e ExasolDriver) OpenConnector(input string) (driver.Connector, error) {
-	dsnConfig, err := dsn.ParseDSN(input)
+	dsnConfig, err := dsn.ParseDSN(applyProfile(input, e.profile))
 	if err != nil {
 		return nil, err
 	}
-	return &Connector{
-		Config: dsn.ToInternalConfig(dsnConfig),
-	}, nil
+	connector := NewConnector(dsn.ToInternalConfig(dsnConfig))
+	connector.driver = e
+	return connector, nil
+}
+
+// NewConnector creates a connector for the given configuration.
+// Connectors that are not created with NewConnector or [ExasolDriver.OpenConnector] create their statistics,
+// shutdown group and token cache on first use, which must not happen concurrently.
+func NewConnector(config *config.Config) *Connector {
+	return &Connector{Config: config, state: newConnectorState()}
+}
+
+// connectorState is the state shared by all connections of a connector.
+type connectorState struct {
+	mutex        sync.Mutex
+	stats        *connection.StatsCollector
+	shutdown     *connection.ShutdownGroup
+	tokens       *connection.TokenCache
+	interceptors []connection.QueryInterceptor
+	hooks        []connection.StatementHooks
+}
+
+func newConnectorState() *connectorState {
+	return &connectorState{
+		stats:    connection.NewStatsCollector(driverStats),
+		shutdown: connection.NewShutdownGroup(driverShutdown),
+		tokens:   &connection.TokenCache{},
+	}
 }
 
 // Connector implements the [database/sql/driver.Connector] interface.
 type Connector struct {
 	Config *config.Config
+	// SlowQueryCallback is called for statements exceeding the configured slow query threshold.
+	// If it is nil, slow queries are logged via the trace logger.
+	SlowQueryCallback connection.SlowQueryCallback
+	// SessionAttributes are set for each new connection after login, if not nil.
+	SessionAttributes *types.Attributes
+	// DialFunc opens the websocket connections. If it is nil, real websocket connections are opened.
+	DialFunc connection.DialFunc
+	// DialOptions customize the handshake of the websocket connections if DialFunc is nil, e.g. with a dialer
+	// with handshake timeout, subprotocols or a custom network dial function, or with headers for routing.
+	DialOptions wsconn.DialOptions
+	// JSONCodec encodes commands and decodes responses. If it is nil, encoding/json is used.
+	JSONCodec connection.JSONCodec
+	// Authenticator performs the authentication during login, e.g. for proprietary authentication methods.
+	// If it is nil, the authentication method is selected by the credentials in the configuration.
+	Authenticator connection.Authenticator
+	// TokenProvider returns a new access token when the configured access token expired.
+	// The new token is used by all connections opened afterwards.
+	// If it is nil, the configured refresh token is used instead, if one is set.
+	TokenProvider connection.TokenProvider
+	// NoticeCallback is called for each response containing warnings or changed session attributes, if not nil.
+	NoticeCallback connection.NoticeCallback
+	state          *connectorState
+	// driver is the driver that opened the connector, e.g. with the defaults of a profile.
+	driver ExasolDriver
 }
 
 func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	state := c.connectorState()
 	conn := &connection.Connection{
-		Config:   c.Config,
-		Ctx:      ctx,
-		IsClosed: true,
+		Config:            c.Config,
+		Ctx:               ctx,
+		IsClosed:          true,
+		Stats:             state.stats,
+		ShutdownGroup:     state.shutdown,
+		SlowQueryCallback: c.SlowQueryCallback,
+		DialFunc:          c.DialFunc,
+		DialOptions:       c.DialOptions,
+		JSONCodec:         c.JSONCodec,
+		QueryInterceptors: state.queryInterceptors(),
+		StatementHooks:    state.statementHooks(),
+		Authenticator:     c.Authenticator,
+		TokenProvider:     c.TokenProvider,
+		TokenCache:        state.tokens,
+		NoticeCallback:    c.NoticeCallback,
+		Connector:         c,
 	}
 	err := conn.Connect()
 	if err != nil {
@@ -67,11 +152,125 @@ func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
 		return nil, err
 	}
 
+	if attributes := c.sessionAttributes(); attributes != nil {
+		err = conn.SetSessionAttributes(ctx, attributes)
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	if c.Config.ConsumerGroup != "" {
+		err = conn.CheckSupports(connection.FeatureConsumerGroups)
+		if err == nil {
+			// The name is validated as regular identifier when parsing the DSN
+			_, err = conn.SimpleExec(ctx, "ALTER SESSION SET CONSUMER_GROUP = "+c.Config.ConsumerGroup)
+		}
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
 	return conn, err
 }
 
+// sessionAttributes returns the SessionAttributes completed with the time zone and formats of the configuration
+// or nil if no attributes need to be set.
+func (c *Connector) sessionAttributes() *types.Attributes {
+	attributes := types.Attributes{}
+	if c.SessionAttributes != nil {
+		attributes = *c.SessionAttributes
+	}
+	if attributes.Timezone == "" {
+		attributes.Timezone = c.Config.Timezone
+	}
+	if attributes.DateFormat == "" {
+		attributes.DateFormat = c.Config.DateFormat
+	}
+	if attributes.NumericCharacters == "" {
+		attributes.NumericCharacters = c.Config.NumericCharacters
+	}
+	if c.SessionAttributes == nil && attributes == (types.Attributes{}) {
+		return nil
+	}
+	return &attributes
+}
+
 func (c Connector) Driver() driver.Driver {
-	return &ExasolDriver{}
+	return &ExasolDriver{profile: c.driver.profile}
+}
+
+// Stats returns the statistics of all connections opened by this connector,
+// e.g. for exposing them in a health endpoint of an application.
+func (c *Connector) Stats() connection.Stats {
+	return c.connectorState().stats.Snapshot()
+}
+
+// WithQueryInterceptor adds an interceptor that can rewrite or reject the SQL text of statements before they are sent
+// to the database, e.g. for adding query hints, enforcing limits or injecting tenant filters centrally.
+// Interceptors are called in the order they were added and apply to connections opened afterwards.
+func (c *Connector) WithQueryInterceptor(interceptor connection.QueryInterceptor) *Connector {
+	state := c.connectorState()
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	state.interceptors = append(state.interceptors, interceptor)
+	return c
+}
+
+// WithStatementHooks adds hooks that are called before and after each statement is executed,
+// e.g. for observing all statements in an APM or audit system without wrapping database/sql.
+// Hooks are called in the order they were added and apply to connections opened afterwards.
+func (c *Connector) WithStatementHooks(hooks connection.StatementHooks) *Connector {
+	state := c.connectorState()
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	state.hooks = append(state.hooks, hooks)
+	return c
+}
+
+// Shutdown stops opening new connections and executing new statements, waits for in-flight queries, result sets
+// and IMPORT/EXPORT transfers up to the deadline of the given context and then marks all connections of the connector
+// as invalid, e.g. for a clean termination of a Kubernetes pod. database/sql closes invalid connections instead of reusing
+// them, so close the database afterwards. Connections that are still busy when the context is done are closed forcibly.
+func (c *Connector) Shutdown(ctx context.Context) error {
+	return c.connectorState().shutdown.Shutdown(ctx)
+}
+
+// clone returns a copy of the connector sharing its statistics, shutdown and refreshed access token.
+// Interceptors and hooks added to the copy do not apply to the original connector.
+func (c *Connector) clone() *Connector {
+	state := c.connectorState()
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	clone := *c
+	clone.state = &connectorState{
+		stats:        state.stats,
+		shutdown:     state.shutdown,
+		tokens:       state.tokens,
+		interceptors: append([]connection.QueryInterceptor(nil), state.interceptors...),
+		hooks:        append([]connection.StatementHooks(nil), state.hooks...),
+	}
+	return &clone
+}
+
+func (c *Connector) connectorState() *connectorState {
+	if c.state == nil {
+		c.state = newConnectorState()
+	}
+	return c.state
+}
+
+func (s *connectorState) queryInterceptors() []connection.QueryInterceptor {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]connection.QueryInterceptor(nil), s.interceptors...)
+}
+
+func (s *connectorState) statementHooks() []connection.StatementHooks {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]connection.StatementHooks(nil), s.hooks...)
 }
 
 // NewConfig creates a new builder with username/password authentication.
@@ -97,6 +296,23 @@ func NewConfigWithAccessToken(token string) *dsn.DSNConfigBuilder {
 	}
 }
 
+// NewConfigForSaaS creates a new builder for connecting to an Exasol SaaS database with a personal access token.
+// It validates the server certificate and sends keepalive pings while waiting for the response of a long-running statement,
+// so that the load balancer does not close the connection during the statement. Idle connections are not pinged.
+func NewConfigForSaaS(host, personalAccessToken string) *dsn.DSNConfigBuilder {
+	return &dsn.DSNConfigBuilder{
+		Config: &dsn.DSNConfig{
+			Host:                      host,
+			Port:                      8563,
+			AccessToken:               personalAccessToken,
+			Encryption:                utils.BoolToPtr(true),
+			ValidateServerCertificate: utils.BoolToPtr(true),
+			KeepaliveInterval:         30 * time.Second,
+			CloseTimeout:              10 * time.Second,
+		},
+	}
+}
+
 // NewConfigWithRefreshToken creates a new builder with refresh token authentication.
 func NewConfigWithRefreshToken(token string) *dsn.DSNConfigBuilder {
 	return &dsn.DSNConfigBuilder{
@@ -107,3 +323,29 @@ func NewConfigWithRefreshToken(token string) *dsn.DSNConfigBuilder {
 		},
 	}
 }
+
+// NewConfigWithPasswordFile creates a new builder with username and password authentication.
+// The password is read from the given file at each login, e.g. a Docker or Kubernetes secret mount,
+// so that it neither appears in the connection string nor in the environment.
+func NewConfigWithPasswordFile(user, path string) *dsn.DSNConfigBuilder {
+	return &dsn.DSNConfigBuilder{
+		Config: &dsn.DSNConfig{
+			Host:         "localhost",
+			Port:         8563,
+			User:         user,
+			PasswordFile: path,
+		},
+	}
+}
+
+// NewConfigWithTokenFile creates a new builder with access token authentication.
+// The token is read from the given file at each login, so that new connections use the current token of a rotated secret.
+func NewConfigWithTokenFile(path string) *dsn.DSNConfigBuilder {
+	return &dsn.DSNConfigBuilder{
+		Config: &dsn.DSNConfig{
+			Host:      "localhost",
+			Port:      8563,
+			TokenFile: path,
+		},
+	}
+}
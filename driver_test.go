@@ -1,8 +1,13 @@
 package exasol
 
 import (
+	"context"
 	"testing"
 
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+
 	"github.com/stretchr/testify/suite"
 )
 
@@ -35,6 +40,36 @@ func (suite *DriverTestSuite) TestOpen() {
 	suite.Nil(conn)
 }
 
+func (suite *DriverTestSuite) TestConnectorStats() {
+	exasolDriver := ExasolDriver{}
+	driverStatsBefore := exasolDriver.Stats()
+	connector, err := exasolDriver.OpenConnector("exa:localhost:1234")
+	suite.NoError(err)
+	_, err = connector.Connect(context.Background())
+	suite.Error(err)
+	suite.Equal(connection.Stats{FailedHandshakes: 1}, connector.(*Connector).Stats())
+	suite.Equal(driverStatsBefore.FailedHandshakes+1, exasolDriver.Stats().FailedHandshakes)
+}
+
+func (suite *DriverTestSuite) TestCopiedConnectorSharesState() {
+	connector := NewConnector(&config.Config{})
+	copied := *connector
+	copied.WithQueryInterceptor(func(ctx context.Context, query string) (string, error) { return query, nil })
+	suite.Same(connector.state, copied.state)
+	suite.Len(connector.state.queryInterceptors(), 1)
+}
+
+func (suite *DriverTestSuite) TestClonedConnectorSharesStatsButNotInterceptors() {
+	connector := NewConnector(&config.Config{})
+	clone := connector.clone()
+	clone.WithQueryInterceptor(func(ctx context.Context, query string) (string, error) { return query, nil })
+	suite.Same(connector.state.stats, clone.state.stats)
+	suite.Same(connector.state.shutdown, clone.state.shutdown)
+	suite.Same(connector.state.tokens, clone.state.tokens)
+	suite.Empty(connector.state.queryInterceptors())
+	suite.Len(clone.state.queryInterceptors(), 1)
+}
+
 func (suite *DriverTestSuite) TestOpenBadDsn() {
 	exasolDriver := ExasolDriver{}
 	conn, err := exasolDriver.Open("")
@@ -83,7 +118,45 @@ func (suite *DriverTestSuite) TestConfigWithAccessToken() {
 	suite.Equal("exa:localhost:8563;accesstoken=TOKEN.JWT.TEST", config.String())
 }
 
+func (suite *DriverTestSuite) TestConfigForSaaS() {
+	config := NewConfigForSaaS("abc.clusters.exasol.com", "exa_pat_token")
+	suite.Equal("exa:abc.clusters.exasol.com:8563;accesstoken=exa_pat_token;encryption=1;validateservercertificate=1;keepaliveinterval=30s;closetimeout=10s", config.String())
+}
+
 func (suite *DriverTestSuite) TestConfigWithrefreshToken() {
 	config := NewConfigWithRefreshToken("RefreshToken")
 	suite.Equal("exa:localhost:8563;refreshtoken=RefreshToken", config.String())
 }
+
+func (suite *DriverTestSuite) TestConfigWithPasswordFile() {
+	config := NewConfigWithPasswordFile("sys", "/run/secrets/exasol_password")
+	suite.Equal("exa:localhost:8563;user=sys;passwordfile=/run/secrets/exasol_password", config.String())
+}
+
+func (suite *DriverTestSuite) TestConfigWithTokenFile() {
+	config := NewConfigWithTokenFile("/run/secrets/exasol_token")
+	suite.Equal("exa:localhost:8563;tokenfile=/run/secrets/exasol_token", config.String())
+}
+
+func (suite *DriverTestSuite) TestConfigWithSessionFormatAttributes() {
+	config := NewConfig("sys", "exasol").Timezone("UTC").DateFormat("YYYY-MM-DD").NumericCharacters(".,")
+	suite.Equal("exa:localhost:8563;user=sys;password=exasol;timezone=UTC;dateformat=YYYY-MM-DD;numericcharacters=.,", config.String())
+}
+
+func (suite *DriverTestSuite) TestConnectorSessionAttributesNilWithoutAttributes() {
+	connector := &Connector{Config: &config.Config{}}
+	suite.Nil(connector.sessionAttributes())
+}
+
+func (suite *DriverTestSuite) TestConnectorSessionAttributesFromConfig() {
+	connector := &Connector{Config: &config.Config{Timezone: "UTC", DateFormat: "YYYY-MM-DD", NumericCharacters: ".,"}}
+	suite.Equal(&types.Attributes{Timezone: "UTC", DateFormat: "YYYY-MM-DD", NumericCharacters: ".,"}, connector.sessionAttributes())
+}
+
+func (suite *DriverTestSuite) TestConnectorSessionAttributesOverrideConfig() {
+	connector := &Connector{
+		Config:            &config.Config{Timezone: "UTC", DateFormat: "YYYY-MM-DD"},
+		SessionAttributes: &types.Attributes{Timezone: "EUROPE/BERLIN", CurrentSchema: "S"},
+	}
+	suite.Equal(&types.Attributes{Timezone: "EUROPE/BERLIN", DateFormat: "YYYY-MM-DD", CurrentSchema: "S"}, connector.sessionAttributes())
+}
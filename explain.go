@@ -0,0 +1,67 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// PushdownPlan is a row returned by EXPLAIN VIRTUAL describing a query pushed down to a virtual schema.
+type PushdownPlan struct {
+	PushdownID     int64  // Consecutive number of the pushdown
+	InvolvedTables string // Tables involved in the pushdown
+	PushdownSQL    string // SQL statement sent to the data source
+	PushdownJSON   string // Pushdown request in JSON format
+}
+
+// DryRun lets the database compile the given statement without executing it.
+// This validates syntax, referenced objects and privileges and returns the parameter and result set metadata.
+func DryRun(ctx context.Context, db *sql.DB, query string) (*connection.StatementDescription, error) {
+	var description *connection.StatementDescription
+	err := withConnection(ctx, db, func(conn *connection.Connection) error {
+		var err error
+		description, err = conn.DryRun(ctx, query)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return description, nil
+}
+
+// Explain returns the execution plan of a query as its profiled execution parts, e.g. the scans and joins with the
+// number of processed rows and the memory usage. Exasol has no EXPLAIN statement for regular queries and collects
+// the plan only when a query is executed, so Explain executes the query with profiling enabled like [Profile] and
+// discards its result. Only SELECT queries are accepted, so that explaining never modifies data.
+// Use [DryRun] for validating other statements without executing them.
+func Explain(ctx context.Context, conn *sql.Conn, query string, args ...any) (*ProfileReport, error) {
+	if !utils.IsReadOnlyQuery(query) {
+		return nil, errors.ErrExplainRequiresQuery
+	}
+	return Profile(ctx, conn, query, args...)
+}
+
+// ExplainVirtual returns the pushdown plan of a query on virtual schemas using EXPLAIN VIRTUAL
+// without executing the query.
+func ExplainVirtual(ctx context.Context, db *sql.DB, query string) ([]PushdownPlan, error) {
+	rows, err := db.QueryContext(ctx, "EXPLAIN VIRTUAL "+query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var plans []PushdownPlan
+	for rows.Next() {
+		var plan PushdownPlan
+		var involvedTables, pushdownJSON sql.NullString
+		if err = rows.Scan(&plan.PushdownID, &involvedTables, &plan.PushdownSQL, &pushdownJSON); err != nil {
+			return nil, err
+		}
+		plan.InvolvedTables = involvedTables.String
+		plan.PushdownJSON = pushdownJSON.String
+		plans = append(plans, plan)
+	}
+	return plans, rows.Err()
+}
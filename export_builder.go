@@ -0,0 +1,243 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// Delimit modes for ExportStatementBuilder.Delimit.
+const (
+	DelimitAuto   = "AUTO"   // Enclose values containing special characters (default)
+	DelimitAlways = "ALWAYS" // Enclose all values
+	DelimitNever  = "NEVER"  // Enclose no values
+)
+
+// ExportStatementBuilder builds EXPORT statements into local CSV files.
+// Statements executed with Exec are downloaded without parsing the SQL text.
+type ExportStatementBuilder struct {
+	table           string
+	columns         []string
+	query           string
+	files           []string
+	writer          io.Writer
+	encoding        string
+	rowSeparator    string
+	columnSeparator rune
+	columnDelimiter rune
+	delimit         string
+	nullToken       *string
+	withColumnNames bool
+	parquet         bool
+	hosts           []string
+	excludedHosts   []string
+}
+
+// ExportBuilder creates a builder for an EXPORT statement into local CSV files, e.g.
+//
+//	exasol.ExportBuilder().FromTable("CUSTOMERS", "ID", "NAME").IntoLocalFiles("customers.csv").WithColumnNames().Exec(ctx, database)
+//
+// Defaults are encoding UTF-8, row separator LF, column separator ',' and column delimiter '"'.
+func ExportBuilder() *ExportStatementBuilder {
+	return &ExportStatementBuilder{encoding: "UTF-8", rowSeparator: RowSeparatorLF, columnSeparator: ',', columnDelimiter: '"', delimit: DelimitAuto}
+}
+
+// FromTable exports the given table and optionally only the given columns. Names are inserted into the statement as they are.
+func (b *ExportStatementBuilder) FromTable(table string, columns ...string) *ExportStatementBuilder {
+	b.table = table
+	b.columns = columns
+	b.query = ""
+	return b
+}
+
+// FromQuery exports the result of the given SELECT statement.
+func (b *ExportStatementBuilder) FromQuery(query string) *ExportStatementBuilder {
+	b.query = query
+	b.table = ""
+	b.columns = nil
+	return b
+}
+
+// IntoLocalFiles adds local files to write. The database splits the exported data between them.
+// A file name ending with .gz, .bz2 or .zip is compressed by the database.
+func (b *ExportStatementBuilder) IntoLocalFiles(paths ...string) *ExportStatementBuilder {
+	b.files = append(b.files, paths...)
+	return b
+}
+
+// IntoWriter writes the exported CSV data to the given writer instead of files.
+func (b *ExportStatementBuilder) IntoWriter(writer io.Writer) *ExportStatementBuilder {
+	b.writer = writer
+	return b
+}
+
+// Encoding sets the encoding of the files (default: UTF-8).
+func (b *ExportStatementBuilder) Encoding(encoding string) *ExportStatementBuilder {
+	b.encoding = encoding
+	return b
+}
+
+// RowSeparator sets the row separator of the files, RowSeparatorLF (default), RowSeparatorCRLF or RowSeparatorCR.
+func (b *ExportStatementBuilder) RowSeparator(separator string) *ExportStatementBuilder {
+	b.rowSeparator = separator
+	return b
+}
+
+// ColumnSeparator sets the character separating columns (default: ',').
+func (b *ExportStatementBuilder) ColumnSeparator(separator rune) *ExportStatementBuilder {
+	b.columnSeparator = separator
+	return b
+}
+
+// ColumnDelimiter sets the character enclosing column values (default: '"').
+func (b *ExportStatementBuilder) ColumnDelimiter(delimiter rune) *ExportStatementBuilder {
+	b.columnDelimiter = delimiter
+	return b
+}
+
+// Delimit sets which values are enclosed in column delimiters, DelimitAuto (default), DelimitAlways or DelimitNever.
+func (b *ExportStatementBuilder) Delimit(mode string) *ExportStatementBuilder {
+	b.delimit = mode
+	return b
+}
+
+// Null sets the token written for NULL values (default: empty fields).
+func (b *ExportStatementBuilder) Null(token string) *ExportStatementBuilder {
+	b.nullToken = &token
+	return b
+}
+
+// WithColumnNames writes the column names as first row of each file.
+func (b *ExportStatementBuilder) WithColumnNames() *ExportStatementBuilder {
+	b.withColumnNames = true
+	return b
+}
+
+// AsParquet converts the exported CSV data into a Parquet file while downloading it, e.g. for data lake pipelines.
+// The schema of the file is derived from the exported columns: BOOLEAN, DOUBLE, DATE and TIMESTAMP columns keep their types,
+// DECIMAL columns with a precision up to 18 are stored as decimals and all other columns as UTF-8 text.
+// It requires a single local file or a writer and the default encoding, column delimiter and NULL token.
+func (b *ExportStatementBuilder) AsParquet() *ExportStatementBuilder {
+	b.parquet = true
+	return b
+}
+
+// OnHosts pins the download to the given database nodes instead of the transferhosts or hosts of the connection.
+func (b *ExportStatementBuilder) OnHosts(hosts ...string) *ExportStatementBuilder {
+	b.hosts = append(b.hosts, hosts...)
+	return b
+}
+
+// ExcludeHosts skips the given database nodes for the download, e.g. a node under maintenance.
+func (b *ExportStatementBuilder) ExcludeHosts(hosts ...string) *ExportStatementBuilder {
+	b.excludedHosts = append(b.excludedHosts, hosts...)
+	return b
+}
+
+// String returns the EXPORT statement with LOCAL CSV FILE clauses, e.g. for logging or executing it with Exec of sql.DB.
+func (b *ExportStatementBuilder) String() string {
+	files := b.files
+	if b.writer != nil {
+		files = []string{"data.csv"}
+	}
+	target := "LOCAL CSV"
+	for _, file := range files {
+		target += " FILE " + quoteLiteral(file)
+	}
+	return b.statement(target)
+}
+
+// Exec executes the export and returns the number of exported rows.
+func (b *ExportStatementBuilder) Exec(ctx context.Context, db *sql.DB) (int64, error) {
+	if err := b.validate(); err != nil {
+		return 0, err
+	}
+	if b.parquet {
+		var rowsAffected int64
+		err := withConnection(ctx, db, func(conn *connection.Connection) error {
+			var err error
+			rowsAffected, err = b.execParquet(ctx, conn)
+			return err
+		})
+		return rowsAffected, err
+	}
+	localExport := connection.LocalExport{Query: b.String(), Statement: b.statement, Files: b.files, Writer: b.writer,
+		Hosts: b.hosts, ExcludeHosts: b.excludedHosts}
+	var rowsAffected int64
+	err := withConnection(ctx, db, func(conn *connection.Connection) error {
+		result, err := conn.ExecLocalExport(ctx, localExport)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	return rowsAffected, err
+}
+
+func (b *ExportStatementBuilder) validate() error {
+	switch {
+	case b.table == "" && b.query == "":
+		return errors.NewInvalidExportBuilder("no source table or query given")
+	case len(b.files) == 0 && b.writer == nil:
+		return errors.NewInvalidExportBuilder("no files or writer given")
+	case len(b.files) > 0 && b.writer != nil:
+		return errors.NewInvalidExportBuilder("both files and a writer given")
+	case rowSeparators[b.rowSeparator] == "":
+		return errors.NewInvalidExportBuilder(fmt.Sprintf("unsupported row separator '%s'", b.rowSeparator))
+	case b.delimit != DelimitAuto && b.delimit != DelimitAlways && b.delimit != DelimitNever:
+		return errors.NewInvalidExportBuilder(fmt.Sprintf("unsupported delimit mode '%s'", b.delimit))
+	case b.parquet:
+		return b.validateParquet()
+	}
+	return nil
+}
+
+func (b *ExportStatementBuilder) validateParquet() error {
+	switch {
+	case len(b.files) > 1:
+		return errors.NewInvalidExportBuilder("Parquet output requires a single file")
+	case b.encoding != "UTF-8":
+		return errors.NewInvalidExportBuilder("Parquet output requires encoding UTF-8")
+	case b.rowSeparator == RowSeparatorCR:
+		return errors.NewInvalidExportBuilder("Parquet output does not support row separator CR")
+	case b.columnDelimiter != '"':
+		return errors.NewInvalidExportBuilder("Parquet output requires the column delimiter '\"'")
+	case b.nullToken != nil:
+		return errors.NewInvalidExportBuilder("Parquet output does not support a NULL token")
+	}
+	return nil
+}
+
+func (b *ExportStatementBuilder) statement(target string) string {
+	var sb strings.Builder
+	sb.WriteString("EXPORT ")
+	if b.query != "" {
+		sb.WriteString("(" + b.query + ")")
+	} else {
+		sb.WriteString(b.table)
+		if len(b.columns) > 0 {
+			sb.WriteString(" (" + strings.Join(b.columns, ", ") + ")")
+		}
+	}
+	sb.WriteString(" INTO " + target)
+	sb.WriteString(" ENCODING = " + quoteLiteral(b.encoding))
+	if b.nullToken != nil {
+		sb.WriteString(" NULL = " + quoteLiteral(*b.nullToken))
+	}
+	sb.WriteString(" ROW SEPARATOR = " + quoteLiteral(b.rowSeparator))
+	sb.WriteString(" COLUMN SEPARATOR = " + quoteLiteral(string(b.columnSeparator)))
+	sb.WriteString(" COLUMN DELIMITER = " + quoteLiteral(string(b.columnDelimiter)))
+	if b.delimit != DelimitAuto {
+		sb.WriteString(" DELIMIT = " + b.delimit)
+	}
+	if b.withColumnNames {
+		sb.WriteString(" WITH COLUMN NAMES")
+	}
+	return sb.String()
+}
@@ -0,0 +1,59 @@
+package exasol
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportBuilderDefaults(t *testing.T) {
+	builder := ExportBuilder().FromTable("S.T").IntoLocalFiles("data.csv")
+	assert.Equal(t, `EXPORT S.T INTO LOCAL CSV FILE 'data.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '"'`,
+		builder.String())
+}
+
+func TestExportBuilderAllOptions(t *testing.T) {
+	builder := ExportBuilder().FromTable("S.T", "A", "B").IntoLocalFiles("it's.csv", "part2.csv.gz").
+		Encoding("ISO-8859-1").RowSeparator(RowSeparatorCRLF).ColumnSeparator(';').ColumnDelimiter('\'').
+		Delimit(DelimitAlways).Null(`\N`).WithColumnNames()
+	assert.Equal(t, `EXPORT S.T (A, B) INTO LOCAL CSV FILE 'it''s.csv' FILE 'part2.csv.gz' ENCODING = 'ISO-8859-1' NULL = '\N' ROW SEPARATOR = 'CRLF' COLUMN SEPARATOR = ';' COLUMN DELIMITER = '''' DELIMIT = ALWAYS WITH COLUMN NAMES`,
+		builder.String())
+}
+
+func TestExportBuilderFromQueryIntoWriter(t *testing.T) {
+	builder := ExportBuilder().FromTable("T", "A").FromQuery("SELECT 1").IntoWriter(&bytes.Buffer{})
+	assert.Equal(t, `EXPORT (SELECT 1) INTO LOCAL CSV FILE 'data.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '"'`,
+		builder.String())
+}
+
+func TestExportBuilderStatement(t *testing.T) {
+	builder := ExportBuilder().FromTable("T").IntoLocalFiles("a (1).csv")
+	assert.Equal(t, `EXPORT T INTO CSV AT 'http://host:1234' FILE 'data_1.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '"'`,
+		builder.statement("CSV AT 'http://host:1234' FILE 'data_1.csv'"))
+}
+
+func TestExportBuilderInvalid(t *testing.T) {
+	tests := []struct {
+		builder      *ExportStatementBuilder
+		errorMessage string
+	}{
+		{ExportBuilder().IntoLocalFiles("a.csv"), "E-EGOD-59: invalid EXPORT statement: no source table or query given"},
+		{ExportBuilder().FromTable("T"), "E-EGOD-59: invalid EXPORT statement: no files or writer given"},
+		{ExportBuilder().FromTable("T").IntoLocalFiles("a.csv").IntoWriter(&bytes.Buffer{}), "E-EGOD-59: invalid EXPORT statement: both files and a writer given"},
+		{ExportBuilder().FromTable("T").IntoLocalFiles("a.csv").RowSeparator("NL"), "E-EGOD-59: invalid EXPORT statement: unsupported row separator 'NL'"},
+		{ExportBuilder().FromTable("T").IntoLocalFiles("a.csv").Delimit("SOMETIMES"), "E-EGOD-59: invalid EXPORT statement: unsupported delimit mode 'SOMETIMES'"},
+		{ExportBuilder().FromTable("T").IntoLocalFiles("a.parquet", "b.parquet").AsParquet(), "E-EGOD-59: invalid EXPORT statement: Parquet output requires a single file"},
+		{ExportBuilder().FromTable("T").IntoLocalFiles("a.parquet").Encoding("ASCII").AsParquet(), "E-EGOD-59: invalid EXPORT statement: Parquet output requires encoding UTF-8"},
+		{ExportBuilder().FromTable("T").IntoLocalFiles("a.parquet").RowSeparator(RowSeparatorCR).AsParquet(), "E-EGOD-59: invalid EXPORT statement: Parquet output does not support row separator CR"},
+		{ExportBuilder().FromTable("T").IntoLocalFiles("a.parquet").ColumnDelimiter('\'').AsParquet(), `E-EGOD-59: invalid EXPORT statement: Parquet output requires the column delimiter '"'`},
+		{ExportBuilder().FromTable("T").IntoWriter(&bytes.Buffer{}).Null("NULL").AsParquet(), "E-EGOD-59: invalid EXPORT statement: Parquet output does not support a NULL token"},
+	}
+	for _, test := range tests {
+		t.Run(test.errorMessage, func(t *testing.T) {
+			_, err := test.builder.Exec(context.Background(), nil)
+			assert.EqualError(t, err, test.errorMessage)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package exasol
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/parquet"
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// Formats of date and timestamp columns in CSV data converted from or to Parquet files and the matching layouts.
+// Timestamps are parsed with any number of fractional digits and formatted with microseconds.
+const (
+	parquetDateFormat      = "YYYY-MM-DD"
+	parquetTimestampFormat = "YYYY-MM-DD HH24:MI:SS.FF6"
+	parquetDateLayout      = "2006-01-02"
+	parquetTimestampLayout = "2006-01-02 15:04:05"
+)
+
+// maxInt64Precision is the largest precision of decimals stored as Int64 in Parquet files.
+const maxInt64Precision = 18
+
+// parquetConverter parses a CSV field into a value for the parquet.Writer.
+type parquetConverter func(field string) (interface{}, error)
+
+// execParquet exports the data as CSV and converts it into a Parquet file on the fly.
+// The schema of the file is derived from the metadata of the exported columns.
+// It writes the single file or the writer of the builder, exports into several files are rejected.
+func (b *ExportStatementBuilder) execParquet(ctx context.Context, conn *connection.Connection) (int64, error) {
+	if err := b.validateParquet(); err != nil {
+		return 0, err
+	}
+	description, err := conn.DryRun(ctx, b.sourceQuery())
+	if err != nil {
+		return 0, err
+	}
+	output := b.writer
+	if output == nil {
+		file, err := os.Create(b.files[0])
+		if err != nil {
+			return 0, err
+		}
+		defer file.Close()
+		output = file
+	}
+	pipeReader, pipeWriter := io.Pipe()
+	converted := make(chan error, 1)
+	go func() {
+		err := convertCSVToParquet(pipeReader, output, description.Columns, b.columnSeparator, b.withColumnNames)
+		pipeReader.CloseWithError(err)
+		converted <- err
+	}()
+	csvColumns := csvColumnFormats(description.Columns)
+	result, err := conn.ExecLocalExport(ctx, connection.LocalExport{
+		Query:        b.String(),
+		Statement:    func(target string) string { return b.statement(target + " " + csvColumns) },
+		Writer:       pipeWriter,
+		Hosts:        b.hosts,
+		ExcludeHosts: b.excludedHosts,
+	})
+	pipeWriter.CloseWithError(err)
+	if convertErr := <-converted; err == nil {
+		err = convertErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// sourceQuery returns a query with the same result columns as the exported data.
+func (b *ExportStatementBuilder) sourceQuery() string {
+	if b.query != "" {
+		return b.query
+	}
+	columns := "*"
+	if len(b.columns) > 0 {
+		columns = strings.Join(b.columns, ", ")
+	}
+	return "SELECT " + columns + " FROM " + b.table
+}
+
+// csvColumnFormats returns the csv_cols clause fixing the formats of date and timestamp columns for the conversion.
+func csvColumnFormats(columns []types.SqlQueryColumn) string {
+	formats := make([]string, len(columns))
+	for i, column := range columns {
+		formats[i] = strconv.Itoa(i + 1)
+		switch column.DataType.Type {
+		case "DATE":
+			formats[i] += " FORMAT = " + quoteLiteral(parquetDateFormat)
+		case "TIMESTAMP", "TIMESTAMP WITH LOCAL TIME ZONE":
+			formats[i] += " FORMAT = " + quoteLiteral(parquetTimestampFormat)
+		}
+	}
+	return "(" + strings.Join(formats, ", ") + ")"
+}
+
+// convertCSVToParquet reads CSV records with the given columns and writes them into a Parquet file.
+// Empty fields are NULL values.
+func convertCSVToParquet(reader io.Reader, writer io.Writer, columns []types.SqlQueryColumn, separator rune, header bool) error {
+	parquetColumns := make([]parquet.Column, len(columns))
+	converters := make([]parquetConverter, len(columns))
+	for i, column := range columns {
+		parquetColumns[i], converters[i] = parquetColumn(column)
+	}
+	parquetWriter, err := parquet.NewWriter(writer, parquetColumns)
+	if err != nil {
+		return err
+	}
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = separator
+	csvReader.FieldsPerRecord = len(columns)
+	csvReader.ReuseRecord = true
+	row := make([]interface{}, len(columns))
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if header {
+			header = false
+			continue
+		}
+		for i, field := range record {
+			row[i] = nil
+			if field == "" {
+				continue
+			}
+			if row[i], err = converters[i](field); err != nil {
+				return fmt.Errorf("invalid value %q of column %q: %w", field, columns[i].Name, err)
+			}
+		}
+		if err = parquetWriter.Write(row); err != nil {
+			return err
+		}
+	}
+	return parquetWriter.Close()
+}
+
+// parquetColumn maps an Exasol column to a Parquet column. Types without a matching Parquet type are stored as text.
+func parquetColumn(column types.SqlQueryColumn) (parquet.Column, parquetConverter) {
+	dataType := column.DataType
+	switch dataType.Type {
+	case "BOOLEAN":
+		return parquet.Column{Name: column.Name, Type: parquet.Boolean}, parseBoolean
+	case "DOUBLE":
+		return parquet.Column{Name: column.Name, Type: parquet.Double}, func(field string) (interface{}, error) {
+			return strconv.ParseFloat(field, 64)
+		}
+	case "DECIMAL":
+		if dataType.Precision != nil && *dataType.Precision <= maxInt64Precision {
+			var scale int64
+			if dataType.Scale != nil {
+				scale = *dataType.Scale
+			}
+			return parquet.Column{Name: column.Name, Type: parquet.Int64, ConvertedType: parquet.Decimal,
+					Precision: int32(*dataType.Precision), Scale: int32(scale)},
+				func(field string) (interface{}, error) {
+					value, err := parseUnscaledDecimal(field, int(scale))
+					if err != nil {
+						return nil, err
+					}
+					if !value.IsInt64() {
+						return nil, fmt.Errorf("decimal out of range")
+					}
+					return value.Int64(), nil
+				}
+		}
+	case "DATE":
+		return parquet.Column{Name: column.Name, Type: parquet.Int32, ConvertedType: parquet.Date}, parseDate
+	case "TIMESTAMP", "TIMESTAMP WITH LOCAL TIME ZONE":
+		return parquet.Column{Name: column.Name, Type: parquet.Int64, ConvertedType: parquet.TimestampMicros}, parseTimestamp
+	}
+	return parquet.Column{Name: column.Name, Type: parquet.ByteArray, ConvertedType: parquet.UTF8}, func(field string) (interface{}, error) {
+		return field, nil
+	}
+}
+
+func parseBoolean(field string) (interface{}, error) {
+	switch strings.ToUpper(field) {
+	case "TRUE", "1":
+		return true, nil
+	case "FALSE", "0":
+		return false, nil
+	}
+	return nil, fmt.Errorf("not a boolean")
+}
+
+// parseUnscaledDecimal returns the decimal as integer multiplied by 10^scale, e.g. 1234 for "12.34" with scale 2.
+func parseUnscaledDecimal(field string, scale int) (*big.Int, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(field, "-"), "+")
+	integer, fraction, _ := strings.Cut(digits, ".")
+	if len(fraction) > scale || integer+fraction == "" || strings.Trim(integer+fraction, "0123456789") != "" {
+		return nil, fmt.Errorf("not a decimal with scale %d", scale)
+	}
+	value, _ := new(big.Int).SetString(integer+fraction+strings.Repeat("0", scale-len(fraction)), 10)
+	if strings.HasPrefix(field, "-") {
+		value.Neg(value)
+	}
+	return value, nil
+}
+
+func parseDate(field string) (interface{}, error) {
+	date, err := time.Parse(parquetDateLayout, field)
+	if err != nil {
+		return nil, err
+	}
+	return int32(date.Unix() / (24 * 60 * 60)), nil
+}
+
+func parseTimestamp(field string) (interface{}, error) {
+	timestamp, err := time.Parse(parquetTimestampLayout, field)
+	if err != nil {
+		return nil, err
+	}
+	return timestamp.UnixMicro(), nil
+}
@@ -0,0 +1,149 @@
+package exasol
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/parquet"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func int64Pointer(value int64) *int64 {
+	return &value
+}
+
+var parquetTestColumns = []types.SqlQueryColumn{
+	{Name: "ID", DataType: types.SqlQueryColumnType{Type: "DECIMAL", Precision: int64Pointer(18), Scale: int64Pointer(2)}},
+	{Name: "NAME", DataType: types.SqlQueryColumnType{Type: "VARCHAR"}},
+	{Name: "ACTIVE", DataType: types.SqlQueryColumnType{Type: "BOOLEAN"}},
+	{Name: "BIRTHDAY", DataType: types.SqlQueryColumnType{Type: "DATE"}},
+	{Name: "CREATED", DataType: types.SqlQueryColumnType{Type: "TIMESTAMP"}},
+}
+
+func TestParquetColumn(t *testing.T) {
+	tests := []struct {
+		dataType types.SqlQueryColumnType
+		expected parquet.Column
+	}{
+		{types.SqlQueryColumnType{Type: "BOOLEAN"}, parquet.Column{Name: "C", Type: parquet.Boolean}},
+		{types.SqlQueryColumnType{Type: "DOUBLE"}, parquet.Column{Name: "C", Type: parquet.Double}},
+		{types.SqlQueryColumnType{Type: "DECIMAL", Precision: int64Pointer(18), Scale: int64Pointer(3)},
+			parquet.Column{Name: "C", Type: parquet.Int64, ConvertedType: parquet.Decimal, Precision: 18, Scale: 3}},
+		{types.SqlQueryColumnType{Type: "DECIMAL", Precision: int64Pointer(36), Scale: int64Pointer(0)},
+			parquet.Column{Name: "C", Type: parquet.ByteArray, ConvertedType: parquet.UTF8}},
+		{types.SqlQueryColumnType{Type: "DATE"}, parquet.Column{Name: "C", Type: parquet.Int32, ConvertedType: parquet.Date}},
+		{types.SqlQueryColumnType{Type: "TIMESTAMP WITH LOCAL TIME ZONE"}, parquet.Column{Name: "C", Type: parquet.Int64, ConvertedType: parquet.TimestampMicros}},
+		{types.SqlQueryColumnType{Type: "INTERVAL DAY TO SECOND"}, parquet.Column{Name: "C", Type: parquet.ByteArray, ConvertedType: parquet.UTF8}},
+	}
+	for _, test := range tests {
+		t.Run(test.dataType.Type, func(t *testing.T) {
+			column, _ := parquetColumn(types.SqlQueryColumn{Name: "C", DataType: test.dataType})
+			assert.Equal(t, test.expected, column)
+		})
+	}
+}
+
+func TestParquetConverters(t *testing.T) {
+	tests := []struct {
+		dataType types.SqlQueryColumnType
+		field    string
+		expected interface{}
+	}{
+		{types.SqlQueryColumnType{Type: "BOOLEAN"}, "TRUE", true},
+		{types.SqlQueryColumnType{Type: "BOOLEAN"}, "0", false},
+		{types.SqlQueryColumnType{Type: "DOUBLE"}, "1.5E3", 1500.0},
+		{types.SqlQueryColumnType{Type: "DECIMAL", Precision: int64Pointer(10), Scale: int64Pointer(2)}, "12.3", int64(1230)},
+		{types.SqlQueryColumnType{Type: "DECIMAL", Precision: int64Pointer(10), Scale: int64Pointer(2)}, "-.5", int64(-50)},
+		{types.SqlQueryColumnType{Type: "DECIMAL", Precision: int64Pointer(10), Scale: int64Pointer(0)}, "42", int64(42)},
+		{types.SqlQueryColumnType{Type: "DECIMAL", Precision: int64Pointer(36), Scale: int64Pointer(0)}, "123456789012345678901234567890", "123456789012345678901234567890"},
+		{types.SqlQueryColumnType{Type: "DATE"}, "1970-01-02", int32(1)},
+		{types.SqlQueryColumnType{Type: "DATE"}, "1969-12-31", int32(-1)},
+		{types.SqlQueryColumnType{Type: "TIMESTAMP"}, "1970-01-01 00:00:01.000002", int64(1000002)},
+		{types.SqlQueryColumnType{Type: "TIMESTAMP"}, "1970-01-01 00:00:01", int64(1000000)},
+		{types.SqlQueryColumnType{Type: "VARCHAR"}, "text", "text"},
+	}
+	for _, test := range tests {
+		t.Run(test.dataType.Type+" "+test.field, func(t *testing.T) {
+			_, converter := parquetColumn(types.SqlQueryColumn{Name: "C", DataType: test.dataType})
+			value, err := converter(test.field)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
+func TestParquetConvertersInvalid(t *testing.T) {
+	tests := []struct {
+		dataType types.SqlQueryColumnType
+		field    string
+	}{
+		{types.SqlQueryColumnType{Type: "BOOLEAN"}, "yes"},
+		{types.SqlQueryColumnType{Type: "DOUBLE"}, "one"},
+		{types.SqlQueryColumnType{Type: "DECIMAL", Precision: int64Pointer(10), Scale: int64Pointer(1)}, "1.25"},
+		{types.SqlQueryColumnType{Type: "DECIMAL", Precision: int64Pointer(10), Scale: int64Pointer(1)}, "."},
+		{types.SqlQueryColumnType{Type: "DECIMAL", Precision: int64Pointer(10), Scale: int64Pointer(1)}, "--1"},
+		{types.SqlQueryColumnType{Type: "DATE"}, "02.01.1970"},
+		{types.SqlQueryColumnType{Type: "TIMESTAMP"}, "1970-01-01"},
+	}
+	for _, test := range tests {
+		t.Run(test.dataType.Type+" "+test.field, func(t *testing.T) {
+			_, converter := parquetColumn(types.SqlQueryColumn{Name: "C", DataType: test.dataType})
+			_, err := converter(test.field)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCSVColumnFormats(t *testing.T) {
+	assert.Equal(t, "(1, 2, 3, 4 FORMAT = 'YYYY-MM-DD', 5 FORMAT = 'YYYY-MM-DD HH24:MI:SS.FF6')", csvColumnFormats(parquetTestColumns))
+}
+
+func TestParquetSourceQuery(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM S.T", ExportBuilder().FromTable("S.T").sourceQuery())
+	assert.Equal(t, "SELECT A, B FROM S.T", ExportBuilder().FromTable("S.T", "A", "B").sourceQuery())
+	assert.Equal(t, "SELECT 1", ExportBuilder().FromQuery("SELECT 1").sourceQuery())
+}
+
+func TestParquetExportStatement(t *testing.T) {
+	builder := ExportBuilder().FromTable("T").IntoWriter(&bytes.Buffer{}).AsParquet()
+	statement := builder.statement("CSV AT 'http://host:1234' FILE 'data_1.csv' " + csvColumnFormats(parquetTestColumns[3:4]))
+	assert.Equal(t, `EXPORT T INTO CSV AT 'http://host:1234' FILE 'data_1.csv' (1 FORMAT = 'YYYY-MM-DD') ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '"'`,
+		statement)
+}
+
+func TestExecParquetRejectsSeveralFiles(t *testing.T) {
+	builder := ExportBuilder().FromTable("T").IntoLocalFiles("a.parquet", "b.parquet").AsParquet()
+	_, err := builder.execParquet(context.Background(), nil)
+	assert.EqualError(t, err, "E-EGOD-59: invalid EXPORT statement: Parquet output requires a single file")
+}
+
+func TestConvertCSVToParquet(t *testing.T) {
+	var output bytes.Buffer
+	data := "ID;NAME;ACTIVE;BIRTHDAY;CREATED\r\n1.5;\"a;b\";TRUE;2000-01-01;2000-01-01 12:00:00.5\r\n;;;;\r\n"
+	err := convertCSVToParquet(strings.NewReader(data), &output, parquetTestColumns, ';', true)
+	assert.NoError(t, err)
+	reader, err := parquet.NewReader(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	assert.NoError(t, err)
+	row, err := reader.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(150), []byte("a;b"), true, int32(10957), int64(946728000500000)}, row)
+	row, err = reader.Read()
+	assert.NoError(t, err)
+	assert.Equal(t, make([]interface{}, 5), row)
+	_, err = reader.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestConvertCSVToParquetInvalidValue(t *testing.T) {
+	err := convertCSVToParquet(strings.NewReader("1,a,maybe,,\n"), &bytes.Buffer{}, parquetTestColumns, ',', false)
+	assert.EqualError(t, err, `invalid value "maybe" of column "ACTIVE": not a boolean`)
+}
+
+func TestConvertCSVToParquetWrongNumberOfFields(t *testing.T) {
+	err := convertCSVToParquet(strings.NewReader("1,a\n"), &bytes.Buffer{}, parquetTestColumns, ',', false)
+	assert.ErrorContains(t, err, "wrong number of fields")
+}
@@ -0,0 +1,140 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/dsn"
+)
+
+// DefaultHealthCheckTimeout is the deadline of a health check used if HealthCheck.Timeout is not positive.
+const DefaultHealthCheckTimeout = 5 * time.Second
+
+// HealthCheck checks if the database is reachable and answers queries, e.g. for the readiness or liveness probe
+// of a Kubernetes pod. Create it with NewHealthCheck.
+type HealthCheck struct {
+	// Timeout is the deadline of the whole check (default: DefaultHealthCheckTimeout).
+	Timeout time.Duration
+	// Query is the statement executed via the connection pool (default: SELECT 1).
+	Query  string
+	db     *sql.DB
+	config *config.Config
+}
+
+// NewHealthCheck creates a health check for the given connection pool. The hosts of the given connection string are
+// probed in parallel before running the query. If the connection string is empty, only the query is run.
+func NewHealthCheck(db *sql.DB, dataSourceName string) (*HealthCheck, error) {
+	check := &HealthCheck{db: db}
+	if dataSourceName != "" {
+		dsnConfig, err := dsn.ParseDSN(dataSourceName)
+		if err != nil {
+			return nil, err
+		}
+		check.config = dsn.ToInternalConfig(dsnConfig)
+	}
+	return check, nil
+}
+
+// HealthStatus is the result of a health check. It is encoded as JSON by HealthCheck.ServeHTTP.
+type HealthStatus struct {
+	// Healthy is true if at least one host accepted a connection and the query succeeded.
+	Healthy bool `json:"healthy"`
+	// Hosts contains the results of probing the resolved hosts, if a connection string was given.
+	Hosts []HostStatus `json:"hosts,omitempty"`
+	// QueryLatency is the duration of the query in nanoseconds, including opening a connection if none was idle.
+	QueryLatency time.Duration `json:"queryLatency"`
+	// Error describes why the check failed.
+	Error string `json:"error,omitempty"`
+}
+
+// HostStatus is the result of probing a host.
+type HostStatus struct {
+	Host      string        `json:"host"`
+	Reachable bool          `json:"reachable"`
+	Latency   time.Duration `json:"latency"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// Check probes the hosts and runs the query until the timeout elapsed.
+// The query is skipped if no host is reachable, as opening a connection would fail anyway.
+func (h *HealthCheck) Check(ctx context.Context) HealthStatus {
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = DefaultHealthCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var status HealthStatus
+	if h.config != nil {
+		if err := h.probeHosts(ctx, &status); err != nil {
+			status.Error = err.Error()
+			return status
+		}
+	}
+	query := h.Query
+	if query == "" {
+		query = "SELECT 1"
+	}
+	start := time.Now()
+	err := h.runQuery(ctx, query)
+	status.QueryLatency = time.Since(start)
+	if err != nil {
+		status.Error = "query failed: " + err.Error()
+		return status
+	}
+	status.Healthy = true
+	return status
+}
+
+func (h *HealthCheck) probeHosts(ctx context.Context, status *HealthStatus) error {
+	probes, err := connection.ProbeHosts(ctx, h.config)
+	if err != nil {
+		return err
+	}
+	var lastErr error
+	reachable := false
+	for _, probe := range probes {
+		hostStatus := HostStatus{Host: probe.Host, Reachable: probe.Err == nil, Latency: probe.Latency}
+		if probe.Err != nil {
+			hostStatus.Error = probe.Err.Error()
+			lastErr = probe.Err
+		}
+		reachable = reachable || hostStatus.Reachable
+		status.Hosts = append(status.Hosts, hostStatus)
+	}
+	if !reachable {
+		return fmt.Errorf("no host reachable: %w", lastErr)
+	}
+	return nil
+}
+
+func (h *HealthCheck) runQuery(ctx context.Context, query string) error {
+	rows, err := h.db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		// Read all rows so that errors of later rows are reported
+	}
+	return rows.Err()
+}
+
+// ServeHTTP runs the check with the context of the request and responds with the status as JSON,
+// with status code 200 if healthy and 503 otherwise, so that the check can back a /readyz endpoint.
+func (h *HealthCheck) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	status := h.Check(request.Context())
+	writer.Header().Set("Content-Type", "application/json")
+	if status.Healthy {
+		writer.WriteHeader(http.StatusOK)
+	} else {
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(writer).Encode(status)
+}
@@ -0,0 +1,99 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCheckWithoutHosts(t *testing.T) {
+	check, err := NewHealthCheck(sql.OpenDB(&staticConnector{}), "")
+	assert.NoError(t, err)
+	status := check.Check(context.Background())
+	assert.True(t, status.Healthy)
+	assert.Empty(t, status.Hosts)
+	assert.Empty(t, status.Error)
+}
+
+func TestHealthCheckQueryFails(t *testing.T) {
+	check, err := NewHealthCheck(sql.OpenDB(&countingConnector{err: fmt.Errorf("connection refused")}), "")
+	assert.NoError(t, err)
+	status := check.Check(context.Background())
+	assert.False(t, status.Healthy)
+	assert.Equal(t, "query failed: connection refused", status.Error)
+}
+
+func TestHealthCheckProbesHosts(t *testing.T) {
+	reachable := listenLocally(t)
+	unreachable := listenLocally(t)
+	unreachable.Close()
+	check, err := NewHealthCheck(sql.OpenDB(&staticConnector{}), fmt.Sprintf("exa:%s,%s:8563;encryption=0", reachable.Addr(), unreachable.Addr()))
+	assert.NoError(t, err)
+	status := check.Check(context.Background())
+	assert.True(t, status.Healthy)
+	assert.Len(t, status.Hosts, 2)
+	assert.True(t, status.Hosts[0].Reachable)
+	assert.False(t, status.Hosts[1].Reachable)
+	assert.NotEmpty(t, status.Hosts[1].Error)
+}
+
+func TestHealthCheckSkipsQueryIfNoHostIsReachable(t *testing.T) {
+	unreachable := listenLocally(t)
+	unreachable.Close()
+	connector := &countingConnector{}
+	check, err := NewHealthCheck(sql.OpenDB(connector), fmt.Sprintf("exa:%s;encryption=0", unreachable.Addr()))
+	assert.NoError(t, err)
+	status := check.Check(context.Background())
+	assert.False(t, status.Healthy)
+	assert.Contains(t, status.Error, "no host reachable: ")
+	assert.EqualValues(t, 0, connector.connects.Load())
+}
+
+func TestNewHealthCheckWithInvalidDSN(t *testing.T) {
+	_, err := NewHealthCheck(sql.OpenDB(&staticConnector{}), "invalid")
+	assert.ErrorContains(t, err, "E-EGOD-")
+}
+
+func TestHealthCheckServeHTTP(t *testing.T) {
+	for _, test := range []struct {
+		connector      *countingConnector
+		expectedStatus int
+	}{
+		{&countingConnector{}, http.StatusOK},
+		{&countingConnector{err: fmt.Errorf("connection refused")}, http.StatusServiceUnavailable},
+	} {
+		check, err := NewHealthCheck(sql.OpenDB(test.connector), "")
+		assert.NoError(t, err)
+		recorder := httptest.NewRecorder()
+		check.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, test.expectedStatus, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		var status HealthStatus
+		assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+		assert.Equal(t, test.expectedStatus == http.StatusOK, status.Healthy)
+	}
+}
+
+// listenLocally returns a listener on a free local port that accepts and closes connections.
+func listenLocally(t *testing.T) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	return listener
+}
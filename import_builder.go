@@ -0,0 +1,272 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// Row separators of CSV files for ImportStatementBuilder.RowSeparator.
+const (
+	RowSeparatorLF   = "LF"
+	RowSeparatorCRLF = "CRLF"
+	RowSeparatorCR   = "CR"
+)
+
+var rowSeparators = map[string]string{RowSeparatorLF: "\n", RowSeparatorCRLF: "\r\n", RowSeparatorCR: "\r"}
+
+// ImportStatementBuilder builds IMPORT statements for local CSV files.
+// Statements executed with Exec are uploaded without parsing the SQL text.
+type ImportStatementBuilder struct {
+	table           string
+	columns         []string
+	files           []string
+	reader          io.Reader
+	parquetFiles    []string
+	encoding        string
+	rowSeparator    string
+	columnSeparator rune
+	columnDelimiter rune
+	skip            int
+	nullToken       *string
+	trim            bool
+	rejectLimit     int
+	hosts           []string
+	excludedHosts   []string
+}
+
+// ImportBuilder creates a builder for an IMPORT statement of local CSV files, e.g.
+//
+//	exasol.ImportBuilder().IntoTable("CUSTOMERS").FromLocalFiles("customers.csv").ColumnSeparator(';').Skip(1).Exec(ctx, database)
+//
+// Defaults are encoding UTF-8, row separator LF, column separator ',' and column delimiter '"'.
+func ImportBuilder() *ImportStatementBuilder {
+	return &ImportStatementBuilder{encoding: "UTF-8", rowSeparator: RowSeparatorLF, columnSeparator: ',', columnDelimiter: '"'}
+}
+
+// IntoTable sets the target table and optionally the target columns. Names are inserted into the statement as they are.
+func (b *ImportStatementBuilder) IntoTable(table string, columns ...string) *ImportStatementBuilder {
+	b.table = table
+	b.columns = columns
+	return b
+}
+
+// FromLocalFiles adds local files to import. Paths may be glob patterns, directories or URLs of registered file openers.
+func (b *ImportStatementBuilder) FromLocalFiles(paths ...string) *ImportStatementBuilder {
+	b.files = append(b.files, paths...)
+	return b
+}
+
+// FromReader imports the CSV data of the given reader instead of files.
+func (b *ImportStatementBuilder) FromReader(reader io.Reader) *ImportStatementBuilder {
+	b.reader = reader
+	return b
+}
+
+// FromParquetFiles adds local Parquet files to import instead of CSV files. The files are converted to CSV while uploading.
+// Their columns are matched by name to the target columns, so they may contain additional columns in any order.
+// Files must have flat schemas, i.e. no nested or repeated columns. The Parquet files require encoding UTF-8,
+// the column delimiter '"' and no skipped rows, other CSV options describe the converted data.
+func (b *ImportStatementBuilder) FromParquetFiles(paths ...string) *ImportStatementBuilder {
+	b.parquetFiles = append(b.parquetFiles, paths...)
+	return b
+}
+
+// Encoding sets the encoding of the files (default: UTF-8).
+// Encodings supported by the importencoding option are converted to UTF-8 during the upload.
+func (b *ImportStatementBuilder) Encoding(encoding string) *ImportStatementBuilder {
+	b.encoding = encoding
+	return b
+}
+
+// RowSeparator sets the row separator of the files, RowSeparatorLF (default), RowSeparatorCRLF or RowSeparatorCR.
+func (b *ImportStatementBuilder) RowSeparator(separator string) *ImportStatementBuilder {
+	b.rowSeparator = separator
+	return b
+}
+
+// ColumnSeparator sets the character separating columns (default: ',').
+func (b *ImportStatementBuilder) ColumnSeparator(separator rune) *ImportStatementBuilder {
+	b.columnSeparator = separator
+	return b
+}
+
+// ColumnDelimiter sets the character enclosing column values (default: '"').
+func (b *ImportStatementBuilder) ColumnDelimiter(delimiter rune) *ImportStatementBuilder {
+	b.columnDelimiter = delimiter
+	return b
+}
+
+// Skip sets the number of rows skipped at the beginning of each file, e.g. 1 for a header row.
+func (b *ImportStatementBuilder) Skip(rows int) *ImportStatementBuilder {
+	b.skip = rows
+	return b
+}
+
+// Null sets the token representing NULL values (default: empty fields).
+func (b *ImportStatementBuilder) Null(token string) *ImportStatementBuilder {
+	b.nullToken = &token
+	return b
+}
+
+// Trim removes leading and trailing spaces from all values.
+func (b *ImportStatementBuilder) Trim() *ImportStatementBuilder {
+	b.trim = true
+	return b
+}
+
+// RejectLimit sets the number of invalid rows that are ignored before the import fails (default: 0).
+func (b *ImportStatementBuilder) RejectLimit(rows int) *ImportStatementBuilder {
+	b.rejectLimit = rows
+	return b
+}
+
+// OnHosts pins the upload to the given database nodes instead of the transferhosts or hosts of the connection.
+func (b *ImportStatementBuilder) OnHosts(hosts ...string) *ImportStatementBuilder {
+	b.hosts = append(b.hosts, hosts...)
+	return b
+}
+
+// ExcludeHosts skips the given database nodes for the upload, e.g. a node under maintenance.
+func (b *ImportStatementBuilder) ExcludeHosts(hosts ...string) *ImportStatementBuilder {
+	b.excludedHosts = append(b.excludedHosts, hosts...)
+	return b
+}
+
+// String returns the IMPORT statement with LOCAL CSV FILE clauses, e.g. for logging or executing it with Exec of sql.DB.
+func (b *ImportStatementBuilder) String() string {
+	return b.statement(b.localFilesClause(), b.encoding)
+}
+
+// Exec executes the import and returns the number of imported rows.
+func (b *ImportStatementBuilder) Exec(ctx context.Context, db *sql.DB) (int64, error) {
+	if err := b.validate(); err != nil {
+		return 0, err
+	}
+	if len(b.parquetFiles) > 0 {
+		var rowsAffected int64
+		err := withConnection(ctx, db, func(conn *connection.Connection) error {
+			var err error
+			rowsAffected, err = b.execParquet(ctx, conn)
+			return err
+		})
+		return rowsAffected, err
+	}
+	localImport := b.localImport()
+	var rowsAffected int64
+	err := withConnection(ctx, db, func(conn *connection.Connection) error {
+		result, err := conn.ExecLocalImport(ctx, localImport)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	return rowsAffected, err
+}
+
+func (b *ImportStatementBuilder) validate() error {
+	switch {
+	case b.table == "":
+		return errors.NewInvalidImportBuilder("no target table given")
+	case len(b.files) == 0 && b.reader == nil && len(b.parquetFiles) == 0:
+		return errors.NewInvalidImportBuilder("no files or reader given")
+	case len(b.files) > 0 && b.reader != nil:
+		return errors.NewInvalidImportBuilder("both files and a reader given")
+	case rowSeparators[b.rowSeparator] == "":
+		return errors.NewInvalidImportBuilder(fmt.Sprintf("unsupported row separator '%s'", b.rowSeparator))
+	case b.skip < 0 || b.rejectLimit < 0:
+		return errors.NewInvalidImportBuilder("negative skip or reject limit")
+	case len(b.parquetFiles) > 0:
+		return b.validateParquet()
+	}
+	return nil
+}
+
+func (b *ImportStatementBuilder) validateParquet() error {
+	switch {
+	case len(b.files) > 0 || b.reader != nil:
+		return errors.NewInvalidImportBuilder("both CSV and Parquet files given")
+	case b.encoding != "UTF-8":
+		return errors.NewInvalidImportBuilder("Parquet files require encoding UTF-8")
+	case b.rowSeparator == RowSeparatorCR:
+		return errors.NewInvalidImportBuilder("Parquet files do not support row separator CR")
+	case b.columnDelimiter != '"':
+		return errors.NewInvalidImportBuilder("Parquet files require the column delimiter '\"'")
+	case b.skip > 0:
+		return errors.NewInvalidImportBuilder("Parquet files do not support skipping rows")
+	}
+	return nil
+}
+
+func (b *ImportStatementBuilder) localImport() connection.LocalImport {
+	localImport := connection.LocalImport{
+		Query:        b.String(),
+		Files:        b.files,
+		Reader:       b.reader,
+		Hosts:        b.hosts,
+		ExcludeHosts: b.excludedHosts,
+		Dialect: utils.CSVDialect{
+			RowSeparator:    rowSeparators[b.rowSeparator],
+			ColumnSeparator: string(b.columnSeparator),
+			ColumnDelimiter: string(b.columnDelimiter),
+		},
+	}
+	encoding := b.encoding
+	if utils.NormalizeEncoding(encoding) != "" {
+		localImport.Encoding = encoding
+		encoding = "UTF-8"
+	}
+	localImport.Statement = func(source string) string {
+		return b.statement(source, encoding)
+	}
+	return localImport
+}
+
+func (b *ImportStatementBuilder) localFilesClause() string {
+	files := b.files
+	if b.reader != nil || len(b.parquetFiles) > 0 {
+		files = []string{"data.csv"}
+	}
+	clause := "LOCAL CSV"
+	for _, file := range files {
+		clause += " FILE " + quoteLiteral(file)
+	}
+	return clause
+}
+
+func (b *ImportStatementBuilder) statement(source string, encoding string) string {
+	var sb strings.Builder
+	sb.WriteString("IMPORT INTO " + b.table)
+	if len(b.columns) > 0 {
+		sb.WriteString(" (" + strings.Join(b.columns, ", ") + ")")
+	}
+	sb.WriteString(" FROM " + source)
+	sb.WriteString(" ENCODING = " + quoteLiteral(encoding))
+	if b.skip > 0 {
+		sb.WriteString(fmt.Sprintf(" SKIP = %d", b.skip))
+	}
+	if b.nullToken != nil {
+		sb.WriteString(" NULL = " + quoteLiteral(*b.nullToken))
+	}
+	if b.trim {
+		sb.WriteString(" TRIM")
+	}
+	sb.WriteString(" ROW SEPARATOR = " + quoteLiteral(b.rowSeparator))
+	sb.WriteString(" COLUMN SEPARATOR = " + quoteLiteral(string(b.columnSeparator)))
+	sb.WriteString(" COLUMN DELIMITER = " + quoteLiteral(string(b.columnDelimiter)))
+	if b.rejectLimit > 0 {
+		sb.WriteString(fmt.Sprintf(" REJECT LIMIT %d", b.rejectLimit))
+	}
+	return sb.String()
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,77 @@
+package exasol
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportBuilderDefaults(t *testing.T) {
+	builder := ImportBuilder().IntoTable("S.T").FromLocalFiles("data.csv")
+	assert.Equal(t, `IMPORT INTO S.T FROM LOCAL CSV FILE 'data.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '"'`,
+		builder.String())
+}
+
+func TestImportBuilderAllOptions(t *testing.T) {
+	builder := ImportBuilder().IntoTable("S.T", "A", "B").FromLocalFiles("/data/it's.csv", "/data/part-*.csv").
+		Encoding("ISO-8859-1").RowSeparator(RowSeparatorCRLF).ColumnSeparator(';').ColumnDelimiter('\'').
+		Skip(1).Null(`\N`).Trim().RejectLimit(10)
+	assert.Equal(t, `IMPORT INTO S.T (A, B) FROM LOCAL CSV FILE '/data/it''s.csv' FILE '/data/part-*.csv' ENCODING = 'ISO-8859-1' SKIP = 1 NULL = '\N' TRIM ROW SEPARATOR = 'CRLF' COLUMN SEPARATOR = ';' COLUMN DELIMITER = '''' REJECT LIMIT 10`,
+		builder.String())
+}
+
+func TestImportBuilderFromReader(t *testing.T) {
+	builder := ImportBuilder().IntoTable("T").FromReader(strings.NewReader("1\n"))
+	assert.Equal(t, `IMPORT INTO T FROM LOCAL CSV FILE 'data.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '"'`,
+		builder.String())
+}
+
+func TestImportBuilderLocalImport(t *testing.T) {
+	localImport := ImportBuilder().IntoTable("T").FromLocalFiles("a (1).csv").Encoding("ISO-8859-1").
+		RowSeparator(RowSeparatorCR).ColumnSeparator('|').localImport()
+	assert.Equal(t, []string{"a (1).csv"}, localImport.Files)
+	assert.Equal(t, "ISO-8859-1", localImport.Encoding)
+	assert.Equal(t, utils.CSVDialect{RowSeparator: "\r", ColumnSeparator: "|", ColumnDelimiter: `"`}, localImport.Dialect)
+	assert.Equal(t, `IMPORT INTO T FROM LOCAL CSV FILE 'a (1).csv' ENCODING = 'ISO-8859-1' ROW SEPARATOR = 'CR' COLUMN SEPARATOR = '|' COLUMN DELIMITER = '"'`, localImport.Query)
+	assert.Equal(t, `IMPORT INTO T FROM CSV AT 'http://host:1234' FILE 'data.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'CR' COLUMN SEPARATOR = '|' COLUMN DELIMITER = '"'`,
+		localImport.Statement("CSV AT 'http://host:1234' FILE 'data.csv'"))
+}
+
+func TestImportBuilderHosts(t *testing.T) {
+	localImport := ImportBuilder().IntoTable("T").FromLocalFiles("a.csv").OnHosts("exasol1..3").ExcludeHosts("exasol2").localImport()
+	assert.Equal(t, []string{"exasol1..3"}, localImport.Hosts)
+	assert.Equal(t, []string{"exasol2"}, localImport.ExcludeHosts)
+}
+
+func TestImportBuilderKeepsEncodingNotConvertedByDriver(t *testing.T) {
+	localImport := ImportBuilder().IntoTable("T").FromLocalFiles("a.csv").Encoding("ASCII").localImport()
+	assert.Empty(t, localImport.Encoding)
+	assert.Contains(t, localImport.Statement("CSV AT 'x'"), "ENCODING = 'ASCII'")
+}
+
+func TestImportBuilderInvalid(t *testing.T) {
+	tests := []struct {
+		builder      *ImportStatementBuilder
+		errorMessage string
+	}{
+		{ImportBuilder().FromLocalFiles("a.csv"), "E-EGOD-58: invalid IMPORT statement: no target table given"},
+		{ImportBuilder().IntoTable("T"), "E-EGOD-58: invalid IMPORT statement: no files or reader given"},
+		{ImportBuilder().IntoTable("T").FromLocalFiles("a.csv").FromReader(strings.NewReader("")), "E-EGOD-58: invalid IMPORT statement: both files and a reader given"},
+		{ImportBuilder().IntoTable("T").FromLocalFiles("a.csv").RowSeparator("NL"), "E-EGOD-58: invalid IMPORT statement: unsupported row separator 'NL'"},
+		{ImportBuilder().IntoTable("T").FromLocalFiles("a.csv").Skip(-1), "E-EGOD-58: invalid IMPORT statement: negative skip or reject limit"},
+		{ImportBuilder().IntoTable("T").FromLocalFiles("a.csv").FromParquetFiles("a.parquet"), "E-EGOD-58: invalid IMPORT statement: both CSV and Parquet files given"},
+		{ImportBuilder().IntoTable("T").FromParquetFiles("a.parquet").Encoding("ISO-8859-1"), "E-EGOD-58: invalid IMPORT statement: Parquet files require encoding UTF-8"},
+		{ImportBuilder().IntoTable("T").FromParquetFiles("a.parquet").RowSeparator(RowSeparatorCR), "E-EGOD-58: invalid IMPORT statement: Parquet files do not support row separator CR"},
+		{ImportBuilder().IntoTable("T").FromParquetFiles("a.parquet").ColumnDelimiter('\''), `E-EGOD-58: invalid IMPORT statement: Parquet files require the column delimiter '"'`},
+		{ImportBuilder().IntoTable("T").FromParquetFiles("a.parquet").Skip(1), "E-EGOD-58: invalid IMPORT statement: Parquet files do not support skipping rows"},
+	}
+	for _, test := range tests {
+		t.Run(test.errorMessage, func(t *testing.T) {
+			_, err := test.builder.Exec(context.Background(), nil)
+			assert.EqualError(t, err, test.errorMessage)
+		})
+	}
+}
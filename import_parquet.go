@@ -0,0 +1,219 @@
+package exasol
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/parquet"
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// julianDayOfUnixEpoch is the Julian day number of 1970-01-01 used by Int96 timestamps.
+const julianDayOfUnixEpoch = 2440588
+
+// execParquet converts the Parquet files into CSV rows in the order of the target columns while uploading them.
+func (b *ImportStatementBuilder) execParquet(ctx context.Context, conn *connection.Connection) (int64, error) {
+	description, err := conn.DryRun(ctx, b.targetQuery())
+	if err != nil {
+		return 0, err
+	}
+	reader, writer := io.Pipe()
+	defer reader.Close()
+	go func() {
+		writer.CloseWithError(b.writeParquetFiles(writer, description.Columns))
+	}()
+	localImport := b.localImport()
+	localImport.Reader = reader
+	statement := localImport.Statement
+	csvColumns := csvColumnFormats(description.Columns)
+	localImport.Statement = func(source string) string {
+		return statement(source + " " + csvColumns)
+	}
+	result, err := conn.ExecLocalImport(ctx, localImport)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// targetQuery returns a query with the target columns of the import as result columns.
+func (b *ImportStatementBuilder) targetQuery() string {
+	columns := "*"
+	if len(b.columns) > 0 {
+		columns = strings.Join(b.columns, ", ")
+	}
+	return "SELECT " + columns + " FROM " + b.table
+}
+
+// writeParquetFiles writes the rows of all Parquet files as CSV records with the given columns.
+func (b *ImportStatementBuilder) writeParquetFiles(writer io.Writer, columns []types.SqlQueryColumn) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Comma = b.columnSeparator
+	csvWriter.UseCRLF = b.rowSeparator == RowSeparatorCRLF
+	var nullToken string
+	if b.nullToken != nil {
+		nullToken = *b.nullToken
+	}
+	for _, path := range b.parquetFiles {
+		if err := writeParquetFile(csvWriter, path, columns, nullToken); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func writeParquetFile(csvWriter *csv.Writer, path string, columns []types.SqlQueryColumn, nullToken string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.NewFileReadError(path, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return errors.NewFileReadError(path, err)
+	}
+	reader, err := parquet.NewReader(file, info.Size())
+	if err != nil {
+		return errors.NewFileReadError(path, err)
+	}
+	indices, err := parquetColumnIndices(path, reader.Columns(), columns)
+	if err != nil {
+		return err
+	}
+	record := make([]string, len(columns))
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.NewFileReadError(path, err)
+		}
+		for i, index := range indices {
+			if row[index] == nil {
+				record[i] = nullToken
+				continue
+			}
+			if record[i], err = parquetCSVField(reader.Columns()[index], row[index], columns[i]); err != nil {
+				return errors.NewFileReadError(path, err)
+			}
+		}
+		if err = csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+}
+
+// parquetColumnIndices returns the index of the Parquet column for each target column.
+// Names are compared case-insensitively if there is no exact match, as unquoted identifiers are upper case in Exasol.
+func parquetColumnIndices(path string, parquetColumns []parquet.Column, columns []types.SqlQueryColumn) ([]int, error) {
+	indices := make([]int, len(columns))
+	for i, column := range columns {
+		indices[i] = -1
+		for j, parquetColumn := range parquetColumns {
+			if parquetColumn.Name == column.Name {
+				indices[i] = j
+				break
+			}
+			if indices[i] < 0 && strings.EqualFold(parquetColumn.Name, column.Name) {
+				indices[i] = j
+			}
+		}
+		if indices[i] < 0 {
+			return nil, errors.NewMissingParquetColumn(path, column.Name)
+		}
+	}
+	return indices, nil
+}
+
+// parquetCSVField formats a non-null value of a Parquet column for the given target column.
+func parquetCSVField(column parquet.Column, value interface{}, target types.SqlQueryColumn) (string, error) {
+	switch v := value.(type) {
+	case bool:
+		return strings.ToUpper(strconv.FormatBool(v)), nil
+	case int32:
+		switch column.ConvertedType {
+		case parquet.Decimal:
+			return formatUnscaledDecimal(big.NewInt(int64(v)), column.Scale), nil
+		case parquet.Date:
+			return formatParquetTime(time.Unix(int64(v)*24*60*60, 0), target), nil
+		}
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		switch column.ConvertedType {
+		case parquet.Decimal:
+			return formatUnscaledDecimal(big.NewInt(v), column.Scale), nil
+		case parquet.TimestampMillis:
+			return formatParquetTime(time.UnixMilli(v), target), nil
+		case parquet.TimestampMicros:
+			return formatParquetTime(time.UnixMicro(v), target), nil
+		case parquet.TimestampNanos:
+			return formatParquetTime(time.Unix(0, v), target), nil
+		}
+		return strconv.FormatInt(v, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []byte:
+		switch {
+		case column.Type == parquet.Int96:
+			return formatParquetTime(int96Time(v), target), nil
+		case column.ConvertedType == parquet.Decimal:
+			return formatUnscaledDecimal(bigEndianSigned(v), column.Scale), nil
+		}
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T in column %q", value, column.Name)
+	}
+}
+
+// formatParquetTime formats dates and timestamps in the formats set for the target columns by csvColumnFormats.
+func formatParquetTime(value time.Time, target types.SqlQueryColumn) string {
+	if target.DataType.Type == "DATE" {
+		return value.UTC().Format(parquetDateLayout)
+	}
+	return value.UTC().Format(parquetTimestampLayout + ".000000")
+}
+
+// int96Time converts the legacy Int96 timestamp of Impala and Spark: nanoseconds of the day followed by the Julian day.
+func int96Time(value []byte) time.Time {
+	nanos := int64(binary.LittleEndian.Uint64(value[:8]))
+	days := int64(binary.LittleEndian.Uint32(value[8:])) - julianDayOfUnixEpoch
+	return time.Unix(days*24*60*60, nanos)
+}
+
+// bigEndianSigned converts the big-endian two's complement of a decimal stored as byte array.
+func bigEndianSigned(value []byte) *big.Int {
+	result := new(big.Int).SetBytes(value)
+	if len(value) > 0 && value[0]&0x80 != 0 {
+		result.Sub(result, new(big.Int).Lsh(big.NewInt(1), uint(len(value)*8)))
+	}
+	return result
+}
+
+// formatUnscaledDecimal formats the decimal value unscaled/10^scale, e.g. "-1.05" for -105 with scale 2.
+func formatUnscaledDecimal(unscaled *big.Int, scale int32) string {
+	digits := new(big.Int).Abs(unscaled).String()
+	if scale > 0 {
+		if len(digits) <= int(scale) {
+			digits = strings.Repeat("0", int(scale)-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-int(scale)] + "." + digits[len(digits)-int(scale):]
+	}
+	if unscaled.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
@@ -0,0 +1,117 @@
+package exasol
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/parquet"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportParquetTargetQuery(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM S.T", ImportBuilder().IntoTable("S.T").targetQuery())
+	assert.Equal(t, "SELECT A, B FROM S.T", ImportBuilder().IntoTable("S.T", "A", "B").targetQuery())
+}
+
+func TestImportParquetString(t *testing.T) {
+	builder := ImportBuilder().IntoTable("T").FromParquetFiles("a.parquet")
+	assert.Equal(t, `IMPORT INTO T FROM LOCAL CSV FILE 'data.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '"'`,
+		builder.String())
+}
+
+func TestWriteParquetFiles(t *testing.T) {
+	path := writeParquetTestFile(t, []parquet.Column{
+		{Name: "name", Type: parquet.ByteArray, ConvertedType: parquet.UTF8},
+		{Name: "UNUSED", Type: parquet.Boolean},
+		{Name: "id", Type: parquet.Int64, ConvertedType: parquet.Decimal, Precision: 18, Scale: 2},
+	}, [][]interface{}{
+		{"a,b", true, int64(150)},
+		{nil, nil, int64(-5)},
+	})
+	columns := []types.SqlQueryColumn{{Name: "ID"}, {Name: "NAME"}}
+	var data bytes.Buffer
+	err := ImportBuilder().IntoTable("T").FromParquetFiles(path, path).Null("NULL").writeParquetFiles(&data, columns)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.50,\"a,b\"\n-0.05,NULL\n1.50,\"a,b\"\n-0.05,NULL\n", data.String())
+}
+
+func TestWriteParquetFilesMissingColumn(t *testing.T) {
+	path := writeParquetTestFile(t, []parquet.Column{{Name: "ID", Type: parquet.Int64}}, nil)
+	err := ImportBuilder().IntoTable("T").FromParquetFiles(path).writeParquetFiles(&bytes.Buffer{}, []types.SqlQueryColumn{{Name: "ID"}, {Name: "NAME"}})
+	assert.ErrorContains(t, err, "E-EGOD-66: Parquet file '"+path+"' has no column 'NAME' of the target table")
+}
+
+func TestWriteParquetFilesNotAParquetFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	assert.NoError(t, os.WriteFile(path, []byte("1,a\n2,b\n3,c\n"), 0o600))
+	err := ImportBuilder().IntoTable("T").FromParquetFiles(path).writeParquetFiles(&bytes.Buffer{}, []types.SqlQueryColumn{{Name: "ID"}})
+	assert.ErrorContains(t, err, "E-EGOD-40: could not read file '"+path+"': 'not a Parquet file: ")
+}
+
+func TestParquetColumnIndicesPrefersExactMatch(t *testing.T) {
+	indices, err := parquetColumnIndices("a.parquet", []parquet.Column{{Name: "id"}, {Name: "ID"}}, []types.SqlQueryColumn{{Name: "ID"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, indices)
+}
+
+func TestParquetCSVField(t *testing.T) {
+	date := types.SqlQueryColumn{DataType: types.SqlQueryColumnType{Type: "DATE"}}
+	timestamp := types.SqlQueryColumn{DataType: types.SqlQueryColumnType{Type: "TIMESTAMP"}}
+	int96 := binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint64(nil, uint64(time.Hour+500*time.Microsecond)), 2440589)
+	tests := []struct {
+		name     string
+		column   parquet.Column
+		value    interface{}
+		target   types.SqlQueryColumn
+		expected string
+	}{
+		{"boolean", parquet.Column{Type: parquet.Boolean}, false, timestamp, "FALSE"},
+		{"int32", parquet.Column{Type: parquet.Int32}, int32(-7), timestamp, "-7"},
+		{"int32 decimal", parquet.Column{Type: parquet.Int32, ConvertedType: parquet.Decimal, Scale: 3}, int32(5), timestamp, "0.005"},
+		{"date", parquet.Column{Type: parquet.Int32, ConvertedType: parquet.Date}, int32(-1), date, "1969-12-31"},
+		{"int64", parquet.Column{Type: parquet.Int64}, int64(42), timestamp, "42"},
+		{"timestamp millis", parquet.Column{Type: parquet.Int64, ConvertedType: parquet.TimestampMillis}, int64(1500), timestamp, "1970-01-01 00:00:01.500000"},
+		{"timestamp micros", parquet.Column{Type: parquet.Int64, ConvertedType: parquet.TimestampMicros}, int64(86400000001), timestamp, "1970-01-02 00:00:00.000001"},
+		{"timestamp nanos into date", parquet.Column{Type: parquet.Int64, ConvertedType: parquet.TimestampNanos}, int64(86400000000000), date, "1970-01-02"},
+		{"int96", parquet.Column{Type: parquet.Int96}, int96, timestamp, "1970-01-02 01:00:00.000500"},
+		{"float", parquet.Column{Type: parquet.Float}, float32(0.1), timestamp, "0.1"},
+		{"double", parquet.Column{Type: parquet.Double}, 1e20, timestamp, "100000000000000000000"},
+		{"byte array decimal", parquet.Column{Type: parquet.FixedLenByteArray, ConvertedType: parquet.Decimal, Scale: 1}, []byte{0xFF, 0x85}, timestamp, "-12.3"},
+		{"string", parquet.Column{Type: parquet.ByteArray, ConvertedType: parquet.UTF8}, []byte("text"), timestamp, "text"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field, err := parquetCSVField(test.column, test.value, test.target)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, field)
+		})
+	}
+}
+
+func TestFormatUnscaledDecimal(t *testing.T) {
+	assert.Equal(t, "123", formatUnscaledDecimal(big.NewInt(123), 0))
+	assert.Equal(t, "1.23", formatUnscaledDecimal(big.NewInt(123), 2))
+	assert.Equal(t, "0.123", formatUnscaledDecimal(big.NewInt(123), 3))
+	assert.Equal(t, "-0.0123", formatUnscaledDecimal(big.NewInt(-123), 4))
+	assert.Equal(t, "0.00", formatUnscaledDecimal(big.NewInt(0), 2))
+}
+
+func writeParquetTestFile(t *testing.T, columns []parquet.Column, rows [][]interface{}) string {
+	path := filepath.Join(t.TempDir(), "data.parquet")
+	file, err := os.Create(path)
+	assert.NoError(t, err)
+	defer file.Close()
+	writer, err := parquet.NewWriter(file, columns)
+	assert.NoError(t, err)
+	for _, row := range rows {
+		assert.NoError(t, writer.Write(row))
+	}
+	assert.NoError(t, writer.Close())
+	return path
+}
@@ -0,0 +1,142 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+const csvTimestampFormat = "2006-01-02 15:04:05.000"
+
+// InsertStream inserts all rows received from the given channel into the columns of the given table and returns the number of inserted rows.
+// The rows are converted to CSV and streamed to the database with an IMPORT statement while they are received.
+// Sending blocks while the database is busy, so producers are slowed down instead of buffering rows in memory.
+// The import finishes when the channel is closed. Table and column names are inserted into the statement as they are.
+func InsertStream(ctx context.Context, db *sql.DB, table string, columns []string, rows <-chan []any) (int64, error) {
+	return InsertStreamWithOptions(ctx, db, table, columns, rows, InsertStreamOptions{})
+}
+
+// InsertStreamOptions configures the CSV data written by InsertStreamWithOptions.
+type InsertStreamOptions struct {
+	// NullToken is written for nil values and set as NULL option of the IMPORT statement.
+	// String values equal to the token are rejected, as the database would import them as NULL.
+	// If it is empty, nil values are written as empty fields. Note that Exasol imports empty strings as NULL, too.
+	NullToken string
+}
+
+// InsertStreamWithOptions works like InsertStream but writes the CSV data as configured by the given options.
+func InsertStreamWithOptions(ctx context.Context, db *sql.DB, table string, columns []string, rows <-chan []any, options InsertStreamOptions) (int64, error) {
+	if strings.ContainsAny(options.NullToken, ",\"\r\n") {
+		return 0, errors.NewInvalidNullToken(options.NullToken)
+	}
+	var rowsAffected int64
+	err := withConnection(ctx, db, func(conn *connection.Connection) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		reader, writer := io.Pipe()
+		defer reader.Close()
+		go writeCSVRows(ctx, writer, len(columns), options.NullToken, rows)
+
+		result, err := conn.ImportReader(ctx, insertStreamQuery(table, columns, options.NullToken), reader)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err = result.RowsAffected()
+		return err
+	})
+	return rowsAffected, err
+}
+
+func insertStreamQuery(table string, columns []string, nullToken string) string {
+	query := fmt.Sprintf("IMPORT INTO %s (%s) FROM LOCAL CSV FILE 'stream.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '\"'",
+		table, strings.Join(columns, ", "))
+	if nullToken != "" {
+		query += fmt.Sprintf(" NULL = '%s'", strings.ReplaceAll(nullToken, "'", "''"))
+	}
+	return query
+}
+
+// writeCSVRows writes the rows as CSV to the writer until the channel is closed or the context is done.
+func writeCSVRows(ctx context.Context, writer *io.PipeWriter, columnCount int, nullToken string, rows <-chan []any) {
+	csvWriter := csv.NewWriter(writer)
+	for {
+		select {
+		case <-ctx.Done():
+			writer.CloseWithError(ctx.Err())
+			return
+		case row, ok := <-rows:
+			if !ok {
+				csvWriter.Flush()
+				writer.CloseWithError(csvWriter.Error())
+				return
+			}
+			record, err := csvRecord(row, columnCount, nullToken)
+			if err == nil {
+				err = csvWriter.Write(record)
+			}
+			if err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+		}
+	}
+}
+
+func csvRecord(row []any, columnCount int, nullToken string) ([]string, error) {
+	if len(row) != columnCount {
+		return nil, errors.NewInvalidStreamRowLength(len(row), columnCount)
+	}
+	record := make([]string, len(row))
+	for i, value := range row {
+		field, err := csvField(value, nullToken)
+		if err != nil {
+			return nil, err
+		}
+		record[i] = field
+	}
+	return record, nil
+}
+
+func csvField(value any, nullToken string) (string, error) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		var err error
+		value, err = valuer.Value()
+		if err != nil {
+			return "", err
+		}
+	}
+	switch v := value.(type) {
+	case nil:
+		return nullToken, nil
+	case string:
+		return checkNotNullToken(v, nullToken)
+	case []byte:
+		return checkNotNullToken(string(v), nullToken)
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return v.Format(csvTimestampFormat), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+func checkNotNullToken(value string, nullToken string) (string, error) {
+	if nullToken != "" && value == nullToken {
+		return "", errors.NewValueEqualsNullToken(value)
+	}
+	return value, nil
+}
@@ -0,0 +1,83 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertStreamQuery(t *testing.T) {
+	assert.Equal(t, `IMPORT INTO S.T (A, B) FROM LOCAL CSV FILE 'stream.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '"'`,
+		insertStreamQuery("S.T", []string{"A", "B"}, ""))
+}
+
+func TestInsertStreamQueryWithNullToken(t *testing.T) {
+	assert.Equal(t, `IMPORT INTO S.T (A) FROM LOCAL CSV FILE 'stream.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '"' NULL = 'it''s null'`,
+		insertStreamQuery("S.T", []string{"A"}, "it's null"))
+}
+
+func TestInsertStreamWithOptionsInvalidNullToken(t *testing.T) {
+	_, err := InsertStreamWithOptions(context.Background(), nil, "T", []string{"A"}, nil, InsertStreamOptions{NullToken: "a\nb"})
+	assert.EqualError(t, err, "E-EGOD-56: invalid NULL token 'a\nb', it must not contain column separators, delimiters or line breaks")
+}
+
+func TestWriteCSVRows(t *testing.T) {
+	rows := make(chan []any, 3)
+	rows <- []any{int64(1), "a,b", nil}
+	rows <- []any{2.5, "quote \"x\"", true}
+	rows <- []any{sql.NullString{String: "valuer", Valid: true}, []byte("bytes"), time.Date(2023, 1, 2, 3, 4, 5, 6000000, time.UTC)}
+	close(rows)
+	reader, writer := io.Pipe()
+	go writeCSVRows(context.Background(), writer, 3, "", rows)
+
+	data, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "1,\"a,b\",\n2.5,\"quote \"\"x\"\"\",true\nvaluer,bytes,2023-01-02 03:04:05.006\n", string(data))
+}
+
+func TestWriteCSVRowsWithNullToken(t *testing.T) {
+	rows := make(chan []any, 2)
+	rows <- []any{nil, "", sql.NullString{}}
+	rows <- []any{"a", []byte("b"), int64(1)}
+	close(rows)
+	reader, writer := io.Pipe()
+	go writeCSVRows(context.Background(), writer, 3, `\N`, rows)
+
+	data, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "\\N,,\\N\na,b,1\n", string(data))
+}
+
+func TestWriteCSVRowsValueEqualsNullToken(t *testing.T) {
+	rows := make(chan []any, 1)
+	rows <- []any{[]byte(`\N`)}
+	reader, writer := io.Pipe()
+	go writeCSVRows(context.Background(), writer, 1, `\N`, rows)
+
+	_, err := io.ReadAll(reader)
+	assert.ErrorContains(t, err, "E-EGOD-57: value '\\N' equals the NULL token")
+}
+
+func TestWriteCSVRowsInvalidRowLength(t *testing.T) {
+	rows := make(chan []any, 1)
+	rows <- []any{1, 2, 3}
+	reader, writer := io.Pipe()
+	go writeCSVRows(context.Background(), writer, 2, "", rows)
+
+	_, err := io.ReadAll(reader)
+	assert.EqualError(t, err, "E-EGOD-41: row has 3 values but 2 columns are inserted")
+}
+
+func TestWriteCSVRowsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, writer := io.Pipe()
+	go writeCSVRows(ctx, writer, 1, "", make(chan []any))
+	cancel()
+
+	_, err := io.ReadAll(reader)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -1,10 +1,14 @@
 package config
 
+import "time"
+
 type Config struct {
 	User                      string
 	Password                  string
 	AccessToken               string
 	RefreshToken              string
+	PasswordFile              string // Read the password from this file at each login if Password is empty
+	TokenFile                 string // Read the access token from this file at each login if AccessToken is empty
 	Host                      string
 	Port                      int
 	Params                    map[string]string // Connection parameters
@@ -20,4 +24,43 @@ type Config struct {
 	Encryption                bool
 	ValidateServerCertificate bool
 	CertificateFingerprint    string
+	QueryLog                  bool          // Log executed statements via the trace logger
+	QueryLogParameters        bool          // Include parameter values in the query log
+	SlowQueryThreshold        time.Duration // Report statements running longer than this, 0 disables reporting
+	RecordFrames              string        // Append all websocket frames to this file, empty disables recording
+	DebugFrames               bool          // Log all websocket frames via the trace logger
+	DebugProtocol             bool          // Log command names, message sizes and latencies via the trace logger
+	DebugPayloads             bool          // Include the redacted request payloads in the protocol trace
+	KeepaliveInterval         time.Duration // Interval of websocket pings while waiting for a response, 0 disables pings
+	ImportEncoding            string        // Source encoding of local import files without ENCODING clause
+	TrimChar                  bool          // Remove trailing spaces from values of CHAR columns
+	CloseTimeout              time.Duration // Maximum duration of the graceful disconnect, 0 disables the limit
+	ReadOnly                  bool          // Reject all statements except SELECT
+	CompressionThreshold      int           // Send messages smaller than this number of bytes uncompressed
+	PermessageDeflate         bool          // Negotiate the websocket permessage-deflate extension
+	WebsocketScheme           string        // Scheme of the websocket URL, empty selects it by the encryption flag
+	WebsocketPath             string        // Path of the websocket URL
+	WaitForDatabase           time.Duration // Retry failed connection attempts for this duration, 0 disables retries
+	InterpolateParams         bool          // Insert parameters into statements as literals instead of preparing them
+	PlaceholderStyle          string        // Style of the placeholders translated to ? placeholders, empty for ? placeholders only
+	Timezone                  string        // Session time zone set after login, empty keeps the database default
+	DateFormat                string        // Session date format set after login, empty keeps the database default
+	NumericCharacters         string        // Session decimal and group separators set after login, empty keeps the database default
+	NaNAsNull                 bool          // Bind and return NaN and infinite doubles as NULL instead of failing
+	HostProbeTimeout          time.Duration // Probe all hosts in parallel before connecting with this timeout, 0 disables probing
+	ExcludeHosts              string        // Comma-separated hosts never connected to, e.g. nodes under maintenance
+	TransferHosts             string        // Comma-separated hosts the proxies of imports and exports connect to, empty uses Host
+	CommitTimeout             time.Duration // Maximum duration of commits and rollbacks, 0 disables the limit
+	MaxQueuedCommands         int           // Maximum number of commands waiting for a busy connection, 0 disables the limit
+	CommandWaitTimeout        time.Duration // Maximum duration a command waits for a busy connection, 0 disables the limit
+	LoginTimeout              time.Duration // Maximum duration of the login, 0 disables the limit
+	RawBytes                  bool          // Return values of string columns as []byte referencing the fetched data
+	DialRetries               int           // Number of retries of the websocket dial across all hosts, 0 disables retries
+	DialRetryDelay            time.Duration // Delay before the first dial retry, 0 uses the default
+	DialRetryMaxDelay         time.Duration // Maximum delay between dial retries, 0 uses the default
+	DialRetryJitter           bool          // Randomize the delays between dial retries
+	TLSServerName             string        // Server name sent in the TLS handshake and verified against the certificate, empty uses the dialed host
+	ConsumerGroup             string        // Consumer group of the session set after login, empty keeps the consumer group of the user
+	BatchImportRows           int           // Upload chunks of prepared batch inserts with more rows with IMPORT, 0 disables the limit
+	BatchImportBytes          int           // Upload chunks of prepared batch inserts with more bytes of values with IMPORT, 0 disables the limit
 }
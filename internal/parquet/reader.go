@@ -0,0 +1,216 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v15/parquet"
+	"github.com/apache/arrow/go/v15/parquet/file"
+	"github.com/apache/arrow/go/v15/parquet/schema"
+)
+
+// Reader reads the rows of a Parquet file. The values of a row group are kept in memory while it is read.
+type Reader struct {
+	reader  *file.Reader
+	columns []Column
+	group   int
+	values  [][]interface{}
+	row     int
+}
+
+// NewReader reads the metadata of the Parquet file with the given size.
+func NewReader(input io.ReaderAt, size int64) (_ *Reader, err error) {
+	defer recoverReadError(&err)
+	if size < 12 {
+		return nil, fmt.Errorf("file of %d bytes is too small for a Parquet file", size)
+	}
+	reader, err := file.NewParquetReader(io.NewSectionReader(input, 0, size))
+	if err != nil {
+		return nil, fmt.Errorf("not a Parquet file: %w", err)
+	}
+	r := &Reader{reader: reader}
+	fileSchema := reader.MetaData().Schema
+	for i := 0; i < fileSchema.NumColumns(); i++ {
+		descriptor := fileSchema.Column(i)
+		if descriptor.MaxRepetitionLevel() > 0 || descriptor.MaxDefinitionLevel() > 1 || len(descriptor.ColumnPath()) > 1 {
+			return nil, fmt.Errorf("nested or repeated column %q is not supported", descriptor.Path())
+		}
+		r.columns = append(r.columns, readColumn(descriptor))
+	}
+	return r, nil
+}
+
+// readColumn returns the column of the given descriptor. Logical types without matching converted type are read
+// as plain physical types, except for enums and JSON, which are read as text.
+func readColumn(descriptor *schema.Column) Column {
+	column := Column{Name: descriptor.Name(), Type: Type(descriptor.PhysicalType()), Length: int32(descriptor.TypeLength())}
+	if column.Type != FixedLenByteArray {
+		column.Length = 0
+	}
+	switch logicalType := descriptor.LogicalType().(type) {
+	case schema.StringLogicalType, schema.EnumLogicalType, schema.JSONLogicalType:
+		column.ConvertedType = UTF8
+	case *schema.DecimalLogicalType:
+		column.ConvertedType = Decimal
+		column.Precision = logicalType.Precision()
+		column.Scale = logicalType.Scale()
+	case schema.DateLogicalType:
+		column.ConvertedType = Date
+	case *schema.TimestampLogicalType:
+		switch logicalType.TimeUnit() {
+		case schema.TimeUnitMillis:
+			column.ConvertedType = TimestampMillis
+		case schema.TimeUnitMicros:
+			column.ConvertedType = TimestampMicros
+		case schema.TimeUnitNanos:
+			column.ConvertedType = TimestampNanos
+		}
+	}
+	return column
+}
+
+// Columns returns the columns of the file.
+func (r *Reader) Columns() []Column {
+	return r.columns
+}
+
+// NumRows returns the number of rows of the file.
+func (r *Reader) NumRows() int64 {
+	return r.reader.NumRows()
+}
+
+// Read returns the next row or io.EOF after the last row. Values are nil for nulls or have the types of the columns:
+// bool for Boolean, int32 for Int32, int64 for Int64, float32 for Float, float64 for Double
+// and []byte for Int96, ByteArray and FixedLenByteArray.
+func (r *Reader) Read() ([]interface{}, error) {
+	for r.values == nil || r.row >= len(r.values[0]) {
+		if r.group >= r.reader.NumRowGroups() {
+			return nil, io.EOF
+		}
+		if err := r.readRowGroup(r.reader.RowGroup(r.group)); err != nil {
+			return nil, err
+		}
+		r.group++
+	}
+	row := make([]interface{}, len(r.columns))
+	for i := range row {
+		row[i] = r.values[i][r.row]
+	}
+	r.row++
+	return row, nil
+}
+
+func (r *Reader) readRowGroup(group *file.RowGroupReader) (err error) {
+	defer recoverReadError(&err)
+	values := make([][]interface{}, len(r.columns))
+	for i := range r.columns {
+		var chunk file.ColumnChunkReader
+		chunk, err = group.Column(i)
+		if err != nil {
+			return err
+		}
+		if values[i], err = readColumnChunk(chunk, group.NumRows()); err != nil {
+			return fmt.Errorf("failed to read column %q: %w", r.columns[i].Name, err)
+		}
+	}
+	r.values = values
+	r.row = 0
+	return nil
+}
+
+// recoverReadError returns the panics of the Arrow reader, e.g. on corrupt pages, as error.
+func recoverReadError(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("invalid Parquet file: %v", r)
+	}
+}
+
+// readColumnChunk reads all values of a column chunk with nil for nulls.
+func readColumnChunk(chunk file.ColumnChunkReader, rows int64) ([]interface{}, error) {
+	levels := make([]int16, rows)
+	var present []interface{}
+	var read int64
+	var err error
+	// The values are read densely, i.e. without gaps for nulls. Byte arrays reference the decoded pages,
+	// which are reused for the following pages, so they are copied.
+	switch reader := chunk.(type) {
+	case *file.BooleanColumnChunkReader:
+		typed := make([]bool, rows)
+		var count int
+		read, count, err = reader.ReadBatch(rows, typed, levels, nil)
+		for _, value := range typed[:count] {
+			present = append(present, value)
+		}
+	case *file.Int32ColumnChunkReader:
+		typed := make([]int32, rows)
+		var count int
+		read, count, err = reader.ReadBatch(rows, typed, levels, nil)
+		for _, value := range typed[:count] {
+			present = append(present, value)
+		}
+	case *file.Int64ColumnChunkReader:
+		typed := make([]int64, rows)
+		var count int
+		read, count, err = reader.ReadBatch(rows, typed, levels, nil)
+		for _, value := range typed[:count] {
+			present = append(present, value)
+		}
+	case *file.Int96ColumnChunkReader:
+		typed := make([]parquet.Int96, rows)
+		var count int
+		read, count, err = reader.ReadBatch(rows, typed, levels, nil)
+		for _, value := range typed[:count] {
+			present = append(present, append([]byte(nil), value[:]...))
+		}
+	case *file.Float32ColumnChunkReader:
+		typed := make([]float32, rows)
+		var count int
+		read, count, err = reader.ReadBatch(rows, typed, levels, nil)
+		for _, value := range typed[:count] {
+			present = append(present, value)
+		}
+	case *file.Float64ColumnChunkReader:
+		typed := make([]float64, rows)
+		var count int
+		read, count, err = reader.ReadBatch(rows, typed, levels, nil)
+		for _, value := range typed[:count] {
+			present = append(present, value)
+		}
+	case *file.ByteArrayColumnChunkReader:
+		typed := make([]parquet.ByteArray, rows)
+		var count int
+		read, count, err = reader.ReadBatch(rows, typed, levels, nil)
+		for _, value := range typed[:count] {
+			present = append(present, append([]byte{}, value...))
+		}
+	case *file.FixedLenByteArrayColumnChunkReader:
+		typed := make([]parquet.FixedLenByteArray, rows)
+		var count int
+		read, count, err = reader.ReadBatch(rows, typed, levels, nil)
+		for _, value := range typed[:count] {
+			present = append(present, append([]byte{}, value...))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported column reader %T", chunk)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if read != rows {
+		return nil, fmt.Errorf("column chunk contains %d values, expected %d", read, rows)
+	}
+	return expandNulls(present, levels, chunk.Descriptor().MaxDefinitionLevel()), nil
+}
+
+// expandNulls places the densely read values at the rows whose definition level marks them as not null.
+func expandNulls(present []interface{}, levels []int16, maxDefinitionLevel int16) []interface{} {
+	values := make([]interface{}, len(levels))
+	next := 0
+	for i, level := range levels {
+		if maxDefinitionLevel == 0 || level == maxDefinitionLevel {
+			values[i] = present[next]
+			next++
+		}
+	}
+	return values
+}
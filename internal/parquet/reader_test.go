@@ -0,0 +1,131 @@
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReaderTestSuite struct {
+	suite.Suite
+}
+
+func TestReaderSuite(t *testing.T) {
+	suite.Run(t, new(ReaderTestSuite))
+}
+
+func (suite *ReaderTestSuite) TestReadWrittenFile() {
+	rows := [][]interface{}{
+		{int64(1), []byte("a"), true, 1.5, int32(10)},
+		{int64(2), nil, false, nil, nil},
+		{nil, []byte("c"), true, 2.5, int32(12)},
+	}
+	file := suite.writeFile(2, rows)
+	reader, err := NewReader(bytes.NewReader(file), int64(len(file)))
+	suite.NoError(err)
+	suite.Equal(testColumns, reader.Columns())
+	suite.EqualValues(3, reader.NumRows())
+	suite.Equal(rows, suite.readAll(reader))
+}
+
+func (suite *ReaderTestSuite) TestReadEmptyFile() {
+	file := suite.writeFile(10, nil)
+	reader, err := NewReader(bytes.NewReader(file), int64(len(file)))
+	suite.NoError(err)
+	suite.Empty(suite.readAll(reader))
+}
+
+func (suite *ReaderTestSuite) TestReadInvalidFile() {
+	_, err := NewReader(bytes.NewReader([]byte("PAR1 not parquet")), 16)
+	suite.ErrorContains(err, "not a Parquet file")
+	_, err = NewReader(bytes.NewReader([]byte("PAR1")), 4)
+	suite.EqualError(err, "file of 4 bytes is too small for a Parquet file")
+}
+
+func (suite *ReaderTestSuite) TestReadGoldenFiles() {
+	columns := []Column{
+		{Name: "ID", Type: Int64},
+		{Name: "NAME", Type: ByteArray, ConvertedType: UTF8},
+		{Name: "ACTIVE", Type: Boolean},
+		{Name: "RATIO", Type: Double},
+		{Name: "SCORE", Type: Float},
+		{Name: "PRICE", Type: Int64, ConvertedType: Decimal, Precision: 10, Scale: 2},
+		{Name: "DAY", Type: Int32, ConvertedType: Date},
+		{Name: "CREATED", Type: Int64, ConvertedType: TimestampMicros},
+		{Name: "NANOS", Type: Int64, ConvertedType: TimestampNanos},
+		{Name: "DATA", Type: ByteArray},
+	}
+	rows := [][]interface{}{
+		{int64(1), []byte("a"), true, 1.5, float32(0.25), int64(150), int32(19000), int64(1_700_000_000_000_000), int64(1_700_000_000_000_000_001), []byte{1}},
+		{int64(2), []byte("b"), false, -2.25, float32(-1), int64(-1), int32(-1), int64(0), int64(-1), []byte{}},
+		{int64(3), nil, nil, nil, nil, nil, nil, nil, nil, nil},
+		{int64(4), []byte("a"), true, 0.0, float32(3.5), int64(99999), int32(20000), int64(1), int64(2), []byte{0xFF, 0}},
+		{int64(5), []byte("a"), nil, 1e300, nil, int64(0), nil, int64(-1), nil, []byte("a")},
+		{int64(6), []byte("ä"), false, nil, float32(2), nil, int32(0), nil, int64(3), nil},
+	}
+	for _, name := range []string{"plain.parquet", "dictionary_snappy.parquet", "v2_gzip.parquet"} {
+		file, err := os.ReadFile(filepath.Join("testdata", name))
+		suite.Require().NoError(err)
+		reader, err := NewReader(bytes.NewReader(file), int64(len(file)))
+		suite.Require().NoError(err, name)
+		suite.Equal(columns, reader.Columns(), name)
+		suite.EqualValues(6, reader.NumRows(), name)
+		suite.Equal(rows, suite.readAll(reader), name)
+	}
+}
+
+func (suite *ReaderTestSuite) writeFile(rowGroupSize int, rows [][]interface{}) []byte {
+	var buffer bytes.Buffer
+	writer, err := NewWriter(&buffer, testColumns)
+	suite.NoError(err)
+	writer.RowGroupSize = rowGroupSize
+	for _, row := range rows {
+		suite.NoError(writer.Write(row))
+	}
+	suite.NoError(writer.Close())
+	return buffer.Bytes()
+}
+
+func (suite *ReaderTestSuite) readAll(reader *Reader) [][]interface{} {
+	var rows [][]interface{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return rows
+		}
+		suite.NoError(err)
+		rows = append(rows, row)
+	}
+}
+
+func (suite *ReaderTestSuite) TestReadRowGroupsWithManyPages() {
+	var rows [][]interface{}
+	for i := 0; i < 5000; i++ {
+		rows = append(rows, []interface{}{int64(i), []byte(strings.Repeat("x", i%100)), i%2 == 0, nil, int32(i)})
+	}
+	file := suite.writeFile(3000, rows)
+	reader, err := NewReader(bytes.NewReader(file), int64(len(file)))
+	suite.Require().NoError(err)
+	suite.Equal(rows, suite.readAll(reader))
+}
+
+// TestReadCorruptFiles checks that corrupt metadata and pages are reported as errors instead of panics.
+func (suite *ReaderTestSuite) TestReadCorruptFiles() {
+	file, err := os.ReadFile(filepath.Join("testdata", "dictionary_snappy.parquet"))
+	suite.Require().NoError(err)
+	for i := 4; i < len(file)-8; i += 7 {
+		corrupt := append([]byte(nil), file...)
+		corrupt[i] ^= 0xFF
+		suite.NotPanics(func() {
+			reader, err := NewReader(bytes.NewReader(corrupt), int64(len(corrupt)))
+			for err == nil {
+				_, err = reader.Read()
+			}
+		}, "corrupt byte at offset %d", i)
+	}
+}
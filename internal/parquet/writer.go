@@ -0,0 +1,242 @@
+// Package parquet reads and writes Parquet files with flat schemas, i.e. without nested or repeated columns,
+// row by row. It adapts the Parquet implementation of Apache Arrow to the types needed for converting Exasol
+// result sets. Files are written uncompressed with optional columns.
+package parquet
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v15/parquet"
+	"github.com/apache/arrow/go/v15/parquet/file"
+	"github.com/apache/arrow/go/v15/parquet/schema"
+)
+
+// Type is the physical type of a column. The values are the ones of [parquet.Type].
+type Type int32
+
+// Physical types of columns.
+const (
+	Boolean           Type = 0
+	Int32             Type = 1
+	Int64             Type = 2
+	Int96             Type = 3
+	Float             Type = 4
+	Double            Type = 5
+	ByteArray         Type = 6
+	FixedLenByteArray Type = 7
+)
+
+// ConvertedType describes how to interpret the physical type of a column.
+type ConvertedType int32
+
+// Converted types of columns.
+const (
+	None            ConvertedType = iota // Plain physical type
+	UTF8                                 // Byte array containing UTF-8 text
+	Decimal                              // Int64 containing the unscaled value of a decimal with Precision and Scale
+	Date                                 // Int32 containing the days since 1970-01-01
+	TimestampMicros                      // Int64 containing the microseconds since 1970-01-01 00:00:00
+	TimestampMillis                      // Int64 containing the milliseconds since 1970-01-01 00:00:00
+	TimestampNanos                       // Int64 containing the nanoseconds since 1970-01-01 00:00:00, only read
+)
+
+// DefaultRowGroupSize is the number of rows buffered before a row group is written.
+const DefaultRowGroupSize = 65536
+
+// Column describes a column of the file. Written columns are optional, i.e. they may contain nulls.
+type Column struct {
+	Name          string
+	Type          Type
+	ConvertedType ConvertedType
+	Precision     int32 // Precision of Decimal columns
+	Scale         int32 // Scale of Decimal columns
+	Length        int32 // Length of FixedLenByteArray columns
+}
+
+// logicalType returns the logical type of the file schema for the converted type of the column.
+func (c Column) logicalType() schema.LogicalType {
+	switch c.ConvertedType {
+	case UTF8:
+		return schema.StringLogicalType{}
+	case Decimal:
+		return schema.NewDecimalLogicalType(c.Precision, c.Scale)
+	case Date:
+		return schema.DateLogicalType{}
+	case TimestampMicros:
+		return schema.NewTimestampLogicalType(true, schema.TimeUnitMicros)
+	case TimestampMillis:
+		return schema.NewTimestampLogicalType(true, schema.TimeUnitMillis)
+	case TimestampNanos:
+		return schema.NewTimestampLogicalType(true, schema.TimeUnitNanos)
+	default:
+		return schema.NoLogicalType{}
+	}
+}
+
+// Writer writes rows into a Parquet file. Rows are buffered and written in row groups.
+// Close must be called to write the remaining rows and the file metadata.
+type Writer struct {
+	writer       *file.Writer
+	columns      []Column
+	values       [][]interface{}
+	RowGroupSize int
+}
+
+// NewWriter writes the header of a Parquet file with the given columns to the given writer.
+func NewWriter(writer io.Writer, columns []Column) (_ *Writer, err error) {
+	defer recoverWriteError(&err)
+	fields := make(schema.FieldList, len(columns))
+	for i, column := range columns {
+		fields[i], err = schema.NewPrimitiveNodeLogical(column.Name, parquet.Repetitions.Optional, column.logicalType(),
+			parquet.Type(column.Type), int(column.Length), -1)
+		if err != nil {
+			return nil, fmt.Errorf("invalid column %q: %w", column.Name, err)
+		}
+	}
+	root, err := schema.NewGroupNode("schema", parquet.Repetitions.Required, fields, -1)
+	if err != nil {
+		return nil, err
+	}
+	// The file writer closes its sink, so hide the Close method of the given writer
+	sink := struct{ io.Writer }{writer}
+	return &Writer{
+		writer:       file.NewParquetWriter(sink, root, file.WithWriterProps(parquet.NewWriterProperties())),
+		columns:      columns,
+		values:       make([][]interface{}, len(columns)),
+		RowGroupSize: DefaultRowGroupSize,
+	}, nil
+}
+
+// Write adds a row. Values must be nil for nulls or match the types of the columns:
+// bool for Boolean, int32 for Int32, int64 for Int64, float64 for Double and string or []byte for ByteArray.
+func (w *Writer) Write(row []interface{}) error {
+	if len(row) != len(w.columns) {
+		return fmt.Errorf("row has %d values, expected %d", len(row), len(w.columns))
+	}
+	for i, value := range row {
+		if !matchesType(w.columns[i], value) {
+			return typeError(w.columns[i], value)
+		}
+	}
+	for i, value := range row {
+		w.values[i] = append(w.values[i], value)
+	}
+	if len(w.values[0]) >= w.RowGroupSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func matchesType(column Column, value interface{}) bool {
+	switch value.(type) {
+	case nil:
+		return true
+	case bool:
+		return column.Type == Boolean
+	case int32:
+		return column.Type == Int32
+	case int64:
+		return column.Type == Int64
+	case float64:
+		return column.Type == Double
+	case string, []byte:
+		return column.Type == ByteArray
+	default:
+		return false
+	}
+}
+
+func typeError(column Column, value interface{}) error {
+	return fmt.Errorf("value %v of type %T does not match the type of column %q", value, value, column.Name)
+}
+
+// Close writes the buffered rows and the file metadata. It does not close the underlying writer.
+func (w *Writer) Close() (err error) {
+	defer recoverWriteError(&err)
+	if len(w.values) > 0 && len(w.values[0]) > 0 {
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+	return w.writer.Close()
+}
+
+// flush writes the buffered rows as row group.
+func (w *Writer) flush() (err error) {
+	defer recoverWriteError(&err)
+	rowGroup := w.writer.AppendRowGroup()
+	for i, values := range w.values {
+		columnWriter, err := rowGroup.NextColumn()
+		if err != nil {
+			return err
+		}
+		if err = writeColumnChunk(columnWriter, values); err != nil {
+			return fmt.Errorf("failed to write column %q: %w", w.columns[i].Name, err)
+		}
+		if err = columnWriter.Close(); err != nil {
+			return err
+		}
+		w.values[i] = values[:0]
+	}
+	return rowGroup.Close()
+}
+
+// recoverWriteError returns the panics of the Arrow writer, e.g. on errors of the underlying writer, as error.
+func recoverWriteError(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("failed to write Parquet file: %v", r)
+	}
+}
+
+// writeColumnChunk writes the non-null values and the definition levels of all values of a column.
+func writeColumnChunk(columnWriter file.ColumnChunkWriter, values []interface{}) error {
+	levels := make([]int16, len(values))
+	var present []interface{}
+	for i, value := range values {
+		if value != nil {
+			levels[i] = 1
+			present = append(present, value)
+		}
+	}
+	var err error
+	switch writer := columnWriter.(type) {
+	case *file.BooleanColumnChunkWriter:
+		typed := make([]bool, len(present))
+		for i, value := range present {
+			typed[i] = value.(bool)
+		}
+		_, err = writer.WriteBatch(typed, levels, nil)
+	case *file.Int32ColumnChunkWriter:
+		typed := make([]int32, len(present))
+		for i, value := range present {
+			typed[i] = value.(int32)
+		}
+		_, err = writer.WriteBatch(typed, levels, nil)
+	case *file.Int64ColumnChunkWriter:
+		typed := make([]int64, len(present))
+		for i, value := range present {
+			typed[i] = value.(int64)
+		}
+		_, err = writer.WriteBatch(typed, levels, nil)
+	case *file.Float64ColumnChunkWriter:
+		typed := make([]float64, len(present))
+		for i, value := range present {
+			typed[i] = value.(float64)
+		}
+		_, err = writer.WriteBatch(typed, levels, nil)
+	case *file.ByteArrayColumnChunkWriter:
+		typed := make([]parquet.ByteArray, len(present))
+		for i, value := range present {
+			if s, ok := value.(string); ok {
+				typed[i] = parquet.ByteArray(s)
+			} else {
+				typed[i] = value.([]byte)
+			}
+		}
+		_, err = writer.WriteBatch(typed, levels, nil)
+	default:
+		err = fmt.Errorf("unsupported column writer %T", columnWriter)
+	}
+	return err
+}
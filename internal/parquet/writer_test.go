@@ -0,0 +1,146 @@
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/apache/arrow/go/v15/parquet"
+	"github.com/apache/arrow/go/v15/parquet/compress"
+	"github.com/apache/arrow/go/v15/parquet/file"
+	"github.com/apache/arrow/go/v15/parquet/schema"
+	"github.com/stretchr/testify/suite"
+)
+
+type WriterTestSuite struct {
+	suite.Suite
+}
+
+func TestWriterSuite(t *testing.T) {
+	suite.Run(t, new(WriterTestSuite))
+}
+
+var testColumns = []Column{
+	{Name: "ID", Type: Int64, ConvertedType: Decimal, Precision: 18, Scale: 0},
+	{Name: "NAME", Type: ByteArray, ConvertedType: UTF8},
+	{Name: "ACTIVE", Type: Boolean},
+	{Name: "SCORE", Type: Double},
+	{Name: "BIRTHDAY", Type: Int32, ConvertedType: Date},
+}
+
+func (suite *WriterTestSuite) TestFileStructure() {
+	data := suite.writeFile(2, [][]interface{}{
+		{int64(1), "a", true, 1.5, int32(10)},
+		{int64(2), nil, false, nil, nil},
+		{nil, "c", true, 2.5, int32(12)},
+	})
+	reader, err := file.NewParquetReader(bytes.NewReader(data))
+	suite.Require().NoError(err)
+	defer reader.Close()
+	suite.EqualValues(3, reader.NumRows())
+	suite.Equal(2, reader.NumRowGroups())
+	suite.EqualValues(2, reader.RowGroup(0).NumRows())
+	suite.EqualValues(1, reader.RowGroup(1).NumRows())
+
+	fileSchema := reader.MetaData().Schema
+	suite.Equal(5, fileSchema.NumColumns())
+	for i, expected := range []struct {
+		physicalType  parquet.Type
+		convertedType schema.ConvertedType
+	}{
+		{parquet.Types.Int64, schema.ConvertedTypes.Decimal},
+		{parquet.Types.ByteArray, schema.ConvertedTypes.UTF8},
+		{parquet.Types.Boolean, schema.ConvertedTypes.None},
+		{parquet.Types.Double, schema.ConvertedTypes.None},
+		{parquet.Types.Int32, schema.ConvertedTypes.Date},
+	} {
+		column := fileSchema.Column(i)
+		suite.Equal(testColumns[i].Name, column.Name())
+		suite.Equal(expected.physicalType, column.PhysicalType(), column.Name())
+		suite.Equal(expected.convertedType, column.ConvertedType(), column.Name())
+		suite.EqualValues(1, column.MaxDefinitionLevel(), column.Name())
+	}
+	suite.Equal(schema.DecimalMetadata{IsSet: true, Precision: 18, Scale: 0},
+		fileSchema.Column(0).SchemaNode().(*schema.PrimitiveNode).DecimalMetadata())
+
+	chunk, err := reader.MetaData().RowGroup(0).ColumnChunk(1)
+	suite.Require().NoError(err)
+	suite.Equal(compress.Codecs.Uncompressed, chunk.Compression())
+}
+
+func (suite *WriterTestSuite) TestEmptyFile() {
+	data := suite.writeFile(10, nil)
+	reader, err := file.NewParquetReader(bytes.NewReader(data))
+	suite.Require().NoError(err)
+	defer reader.Close()
+	suite.EqualValues(0, reader.NumRows())
+	suite.Equal(0, reader.NumRowGroups())
+}
+
+func (suite *WriterTestSuite) TestWrongValueType() {
+	writer, err := NewWriter(&bytes.Buffer{}, testColumns)
+	suite.NoError(err)
+	suite.EqualError(writer.Write([]interface{}{"1", nil, nil, nil, nil}), `value 1 of type string does not match the type of column "ID"`)
+}
+
+func (suite *WriterTestSuite) TestWrongNumberOfValues() {
+	writer, err := NewWriter(&bytes.Buffer{}, testColumns)
+	suite.NoError(err)
+	suite.EqualError(writer.Write([]interface{}{int64(1)}), "row has 1 values, expected 5")
+}
+
+func (suite *WriterTestSuite) TestDoesNotCloseWriter() {
+	output := &closeRecorder{}
+	writer, err := NewWriter(output, testColumns)
+	suite.NoError(err)
+	suite.NoError(writer.Close())
+	suite.False(output.closed)
+	suite.Equal([]byte("PAR1"), output.Bytes()[:4])
+}
+
+func (suite *WriterTestSuite) TestWriteErrorOfHeader() {
+	_, err := NewWriter(&failingWriter{}, testColumns)
+	suite.EqualError(err, "failed to write Parquet file: failed to write magic number")
+}
+
+func (suite *WriterTestSuite) TestWriteErrorOfRowGroup() {
+	writer, err := NewWriter(&failingWriter{remaining: 4}, testColumns)
+	suite.NoError(err)
+	writer.RowGroupSize = 1
+	suite.ErrorContains(writer.Write([]interface{}{int64(1), "a", true, 1.5, int32(10)}), "disk full")
+}
+
+func (suite *WriterTestSuite) writeFile(rowGroupSize int, rows [][]interface{}) []byte {
+	var buffer bytes.Buffer
+	writer, err := NewWriter(&buffer, testColumns)
+	suite.NoError(err)
+	writer.RowGroupSize = rowGroupSize
+	for _, row := range rows {
+		suite.NoError(writer.Write(row))
+	}
+	suite.NoError(writer.Close())
+	return buffer.Bytes()
+}
+
+type closeRecorder struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+// failingWriter fails after the given number of bytes.
+type failingWriter struct {
+	remaining int
+}
+
+func (w *failingWriter) Write(data []byte) (int, error) {
+	if len(data) > w.remaining {
+		return 0, errors.New("disk full")
+	}
+	w.remaining -= len(data)
+	return len(data), nil
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var columnSeparatorQueryRegex = regexp.MustCompile(`(?i)COLUMN\s+SEPARATOR\s*=\s*'(?P<ColumnSeparator>(?:[^']|'')*)'`)
+var columnDelimiterQueryRegex = regexp.MustCompile(`(?i)COLUMN\s+DELIMITER\s*=\s*'(?P<ColumnDelimiter>(?:[^']|'')*)'`)
+
+// CSVDialect describes the format of the CSV files transferred by an IMPORT or EXPORT statement.
+type CSVDialect struct {
+	RowSeparator    string // Separator between rows, default "\n"
+	ColumnSeparator string // Separator between columns, default ","
+	ColumnDelimiter string // Character enclosing column values, default "\"", empty if values are not enclosed
+}
+
+// GetCSVDialect returns the CSV format specified by the file options of the query.
+func GetCSVDialect(query string) CSVDialect {
+	return CSVDialect{
+		RowSeparator:    GetRowSeparator(query),
+		ColumnSeparator: getStringOption(columnSeparatorQueryRegex, query, ","),
+		ColumnDelimiter: getStringOption(columnDelimiterQueryRegex, query, `"`),
+	}
+}
+
+func getStringOption(regex *regexp.Regexp, query string, defaultValue string) string {
+	matches := regex.FindStringSubmatch(query)
+	if matches == nil {
+		return defaultValue
+	}
+	return strings.ReplaceAll(matches[1], "''", "'")
+}
+
+// NewRowCounter creates a counter for rows written in this dialect.
+func (d CSVDialect) NewRowCounter() *RowCounter {
+	counter := &RowCounter{rowEnd: '\n'}
+	if d.RowSeparator != "" {
+		counter.rowEnd = d.RowSeparator[len(d.RowSeparator)-1]
+	}
+	if len(d.ColumnDelimiter) == 1 {
+		counter.delimiter = d.ColumnDelimiter[0]
+		counter.hasDelimiter = true
+	}
+	return counter
+}
+
+// RowCounter counts the rows of CSV data written to it. Row separators inside enclosed column values are ignored.
+type RowCounter struct {
+	rowEnd       byte
+	delimiter    byte
+	hasDelimiter bool
+	enclosed     bool
+	pendingRow   bool
+	rows         int64
+}
+
+// Write counts the rows in the given data. It never returns an error.
+func (c *RowCounter) Write(data []byte) (int, error) {
+	for _, b := range data {
+		switch {
+		case c.hasDelimiter && b == c.delimiter:
+			c.enclosed = !c.enclosed
+			c.pendingRow = true
+		case b == c.rowEnd && !c.enclosed:
+			c.rows++
+			c.pendingRow = false
+		case !c.enclosed && (b == '\r' || b == '\n'):
+			// Part of a multi-byte row separator
+		default:
+			c.pendingRow = true
+		}
+	}
+	return len(data), nil
+}
+
+// Rows returns the number of rows written so far including a last row without row separator.
+func (c *RowCounter) Rows() int64 {
+	if c.pendingRow {
+		return c.rows + 1
+	}
+	return c.rows
+}
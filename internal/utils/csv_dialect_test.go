@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCSVDialectDefaults(t *testing.T) {
+	assert.Equal(t, CSVDialect{RowSeparator: "\n", ColumnSeparator: ",", ColumnDelimiter: `"`},
+		GetCSVDialect("IMPORT INTO t FROM LOCAL CSV FILE 'a.csv'"))
+}
+
+func TestGetCSVDialect(t *testing.T) {
+	query := `IMPORT INTO t FROM LOCAL CSV FILE 'a.csv' COLUMN SEPARATOR = ';' COLUMN DELIMITER = '''' ROW SEPARATOR = 'CRLF'`
+	assert.Equal(t, CSVDialect{RowSeparator: "\r\n", ColumnSeparator: ";", ColumnDelimiter: "'"}, GetCSVDialect(query))
+}
+
+func TestGetCSVDialectWithoutDelimiter(t *testing.T) {
+	query := `IMPORT INTO t FROM LOCAL CSV FILE 'a.csv' column separator='|' column delimiter=''`
+	assert.Equal(t, CSVDialect{RowSeparator: "\n", ColumnSeparator: "|", ColumnDelimiter: ""}, GetCSVDialect(query))
+}
+
+func TestRowCounter(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect CSVDialect
+		data    []string
+		want    int64
+	}{
+		{"empty", CSVDialect{RowSeparator: "\n", ColumnDelimiter: `"`}, nil, 0},
+		{"LF", CSVDialect{RowSeparator: "\n", ColumnDelimiter: `"`}, []string{"1,a\n2,b\n"}, 2},
+		{"last row without separator", CSVDialect{RowSeparator: "\n", ColumnDelimiter: `"`}, []string{"1,a\n2,b"}, 2},
+		{"CRLF", CSVDialect{RowSeparator: "\r\n", ColumnDelimiter: `"`}, []string{"1,a\r\n2,b\r\n"}, 2},
+		{"CR", CSVDialect{RowSeparator: "\r", ColumnDelimiter: `"`}, []string{"1,a\r2,b\r"}, 2},
+		{"enclosed separator", CSVDialect{RowSeparator: "\n", ColumnDelimiter: `"`}, []string{"1,\"a\nb\"\n2,\"c\"\"\n\"\n"}, 2},
+		{"other delimiter", CSVDialect{RowSeparator: "\n", ColumnDelimiter: "'"}, []string{"1,'a\nb'\n2,\"c\n"}, 2},
+		{"no delimiter", CSVDialect{RowSeparator: "\n"}, []string{"1,\"a\nb\"\n"}, 2},
+		{"split writes", CSVDialect{RowSeparator: "\r\n", ColumnDelimiter: `"`}, []string{"1,\"a\r", "\nb\"\r", "\n2,c"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := tt.dialect.NewRowCounter()
+			for _, data := range tt.data {
+				_, err := counter.Write([]byte(data))
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, counter.Rows())
+		})
+	}
+}
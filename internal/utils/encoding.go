@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Source encodings of local import files that the driver converts to UTF-8 while uploading.
+const (
+	EncodingLatin1      = "ISO-8859-1"
+	EncodingWindows1252 = "WINDOWS-1252"
+	EncodingUTF16       = "UTF-16" // Byte order detected by the byte order mark, little endian if missing
+	EncodingUTF16LE     = "UTF-16LE"
+	EncodingUTF16BE     = "UTF-16BE"
+)
+
+var encodingQueryRegex = regexp.MustCompile(`(?i)(ENCODING\s*=\s*(["|'])?(?P<Encoding>[a-zA-Z0-9_-]+)(["|']?))`)
+var importFileOptionRegex = regexp.MustCompile(`(?i)\b(COLUMN\s+SEPARATOR|COLUMN\s+DELIMITER|ROW\s+SEPARATOR|ROW\s+SIZE|SKIP|TRIM|LTRIM|RTRIM|NULL|REJECT|ERRORS)\b`)
+
+var encodingAliases = map[string]string{
+	"ISO88591":    EncodingLatin1,
+	"LATIN1":      EncodingLatin1,
+	"WINDOWS1252": EncodingWindows1252,
+	"CP1252":      EncodingWindows1252,
+	"UTF16":       EncodingUTF16,
+	"UTF16LE":     EncodingUTF16LE,
+	"UTF16BE":     EncodingUTF16BE,
+}
+
+// windows1252 contains the characters of windows-1252 that differ from latin-1.
+// Undefined bytes are mapped to the control character with the same code point.
+var windows1252 = [32]rune{
+	0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021, 0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+	0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014, 0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+}
+
+// NormalizeEncoding returns the canonical name of an encoding that the driver converts to UTF-8
+// or an empty string if the driver does not convert the encoding.
+func NormalizeEncoding(encoding string) string {
+	key := strings.NewReplacer("-", "", "_", "").Replace(strings.ToUpper(encoding))
+	return encodingAliases[key]
+}
+
+// GetEncoding returns the value of the ENCODING clause of the query or an empty string if the query has none.
+func GetEncoding(query string) string {
+	matches := encodingQueryRegex.FindStringSubmatch(query)
+	if matches == nil {
+		return ""
+	}
+	return matches[encodingQueryRegex.SubexpIndex("Encoding")]
+}
+
+// UpdateImportEncoding sets the ENCODING clause of a query updated with UpdateImportQuery to UTF-8.
+// The clause is added before the first file option if the query has none.
+func UpdateImportEncoding(query string) string {
+	const utf8Encoding = "ENCODING = 'UTF-8'"
+	if encodingQueryRegex.MatchString(query) {
+		return encodingQueryRegex.ReplaceAllString(query, utf8Encoding)
+	}
+	const file = "FILE 'data.csv' "
+	fileEnd := 0
+	if index := strings.Index(query, file); index >= 0 {
+		fileEnd = index + len(file)
+	}
+	if location := importFileOptionRegex.FindStringIndex(query[fileEnd:]); location != nil {
+		position := fileEnd + location[0]
+		return query[:position] + utf8Encoding + " " + query[position:]
+	}
+	trimmed := strings.TrimRight(query, " \t\r\n;")
+	return trimmed + " " + utf8Encoding + query[len(trimmed):]
+}
+
+// NewTranscodingReader returns a reader that converts the content of the given reader from the given encoding to UTF-8.
+// The reader is returned unchanged if the driver does not convert the encoding.
+func NewTranscodingReader(reader io.Reader, encoding string) io.Reader {
+	var decode func(*bufio.Reader) (rune, error)
+	switch NormalizeEncoding(encoding) {
+	case EncodingLatin1:
+		decode = decodeLatin1
+	case EncodingWindows1252:
+		decode = decodeWindows1252
+	case EncodingUTF16, EncodingUTF16LE:
+		decode = decodeUTF16(false)
+	case EncodingUTF16BE:
+		decode = decodeUTF16(true)
+	default:
+		return reader
+	}
+	bufferedReader := bufio.NewReader(reader)
+	if NormalizeEncoding(encoding) == EncodingUTF16 {
+		decode = detectByteOrder(bufferedReader, decode)
+	}
+	return &transcodingReader{reader: bufferedReader, decode: decode}
+}
+
+type transcodingReader struct {
+	reader  *bufio.Reader
+	decode  func(*bufio.Reader) (rune, error)
+	pending []byte
+}
+
+func (r *transcodingReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.pending) > 0 {
+			copied := copy(p[n:], r.pending)
+			r.pending = r.pending[copied:]
+			n += copied
+			continue
+		}
+		char, err := r.decode(r.reader)
+		if err != nil {
+			return n, err
+		}
+		var encoded [utf8.UTFMax]byte
+		size := utf8.EncodeRune(encoded[:], char)
+		copied := copy(p[n:], encoded[:size])
+		r.pending = append(r.pending[:0], encoded[copied:size]...)
+		n += copied
+	}
+	return n, nil
+}
+
+func decodeLatin1(reader *bufio.Reader) (rune, error) {
+	b, err := reader.ReadByte()
+	return rune(b), err
+}
+
+func decodeWindows1252(reader *bufio.Reader) (rune, error) {
+	b, err := reader.ReadByte()
+	if b >= 0x80 && b < 0xA0 {
+		return windows1252[b-0x80], err
+	}
+	return rune(b), err
+}
+
+func decodeUTF16(bigEndian bool) func(*bufio.Reader) (rune, error) {
+	readUnit := func(reader *bufio.Reader) (rune, error) {
+		var unit [2]byte
+		if _, err := io.ReadFull(reader, unit[:]); err != nil {
+			return 0, err
+		}
+		if bigEndian {
+			return rune(unit[0])<<8 | rune(unit[1]), nil
+		}
+		return rune(unit[1])<<8 | rune(unit[0]), nil
+	}
+	return func(reader *bufio.Reader) (rune, error) {
+		first, err := readUnit(reader)
+		if err != nil || !utf16.IsSurrogate(first) {
+			return first, err
+		}
+		second, err := readUnit(reader)
+		if err == io.EOF {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		return utf16.DecodeRune(first, second), nil
+	}
+}
+
+// detectByteOrder consumes a UTF-16 byte order mark and returns the matching decoder.
+func detectByteOrder(reader *bufio.Reader, defaultDecode func(*bufio.Reader) (rune, error)) func(*bufio.Reader) (rune, error) {
+	bom, _ := reader.Peek(2)
+	switch {
+	case len(bom) == 2 && bom[0] == 0xFE && bom[1] == 0xFF:
+		_, _ = reader.Discard(2)
+		return decodeUTF16(true)
+	case len(bom) == 2 && bom[0] == 0xFF && bom[1] == 0xFE:
+		_, _ = reader.Discard(2)
+		return decodeUTF16(false)
+	}
+	return defaultDecode
+}
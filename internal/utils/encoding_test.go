@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEncoding(t *testing.T) {
+	encodings := map[string]string{
+		"ISO-8859-1":   EncodingLatin1,
+		"latin1":       EncodingLatin1,
+		"windows-1252": EncodingWindows1252,
+		"CP1252":       EncodingWindows1252,
+		"UTF-16":       EncodingUTF16,
+		"utf16le":      EncodingUTF16LE,
+		"UTF_16BE":     EncodingUTF16BE,
+		"UTF-8":        "",
+		"ASCII":        "",
+	}
+	for encoding, expected := range encodings {
+		assert.Equal(t, expected, NormalizeEncoding(encoding), encoding)
+	}
+}
+
+func TestGetEncoding(t *testing.T) {
+	assert.Equal(t, "ISO-8859-1", GetEncoding("IMPORT INTO t FROM LOCAL CSV FILE 'a.csv' ENCODING = 'ISO-8859-1' ROW SEPARATOR = 'LF'"))
+	assert.Equal(t, "UTF-16", GetEncoding("IMPORT INTO t FROM LOCAL CSV FILE 'a.csv' encoding='UTF-16'"))
+	assert.Equal(t, "", GetEncoding("IMPORT INTO t FROM LOCAL CSV FILE 'a.csv'"))
+}
+
+func TestUpdateImportEncodingReplacesClause(t *testing.T) {
+	query := "IMPORT INTO t FROM CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' ENCODING = 'latin1' ROW SEPARATOR = 'LF'"
+	assert.Equal(t, "IMPORT INTO t FROM CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF'", UpdateImportEncoding(query))
+}
+
+func TestUpdateImportEncodingInjectsClauseBeforeFileOptions(t *testing.T) {
+	query := "IMPORT INTO t FROM CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' (1..2) COLUMN SEPARATOR = ';' SKIP = 1"
+	assert.Equal(t, "IMPORT INTO t FROM CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' (1..2) ENCODING = 'UTF-8' COLUMN SEPARATOR = ';' SKIP = 1", UpdateImportEncoding(query))
+}
+
+func TestUpdateImportEncodingInjectsClauseAtEnd(t *testing.T) {
+	query := "IMPORT INTO t FROM CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' ;"
+	assert.Equal(t, "IMPORT INTO t FROM CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' ENCODING = 'UTF-8' ;", UpdateImportEncoding(query))
+}
+
+func TestTranscodeLatin1(t *testing.T) {
+	assert.Equal(t, "Grüße;é\n", transcode(t, []byte("Gr\xfc\xdfe;\xe9\n"), "latin1"))
+}
+
+func TestTranscodeWindows1252(t *testing.T) {
+	assert.Equal(t, "€ – “quoted” ü\n", transcode(t, []byte("\x80 \x96 \x93quoted\x94 \xfc\n"), "windows-1252"))
+}
+
+func TestTranscodeUTF16LittleEndianWithByteOrderMark(t *testing.T) {
+	assert.Equal(t, "aß€😀\n", transcode(t, []byte("\xff\xfea\x00\xdf\x00\xac\x20\x3d\xd8\x00\xde\n\x00"), "UTF-16"))
+}
+
+func TestTranscodeUTF16BigEndianWithByteOrderMark(t *testing.T) {
+	assert.Equal(t, "aß\n", transcode(t, []byte("\xfe\xff\x00a\x00\xdf\x00\n"), "UTF-16"))
+}
+
+func TestTranscodeUTF16WithoutByteOrderMarkIsLittleEndian(t *testing.T) {
+	assert.Equal(t, "ab", transcode(t, []byte("a\x00b\x00"), "UTF-16"))
+}
+
+func TestTranscodeUTF16BigEndian(t *testing.T) {
+	assert.Equal(t, "ab", transcode(t, []byte("\x00a\x00b"), "UTF-16BE"))
+}
+
+func TestTranscodeUTF16TruncatedInput(t *testing.T) {
+	_, err := io.ReadAll(NewTranscodingReader(bytes.NewReader([]byte("a\x00b")), "UTF-16LE"))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestTranscodeUnsupportedEncodingReturnsReader(t *testing.T) {
+	reader := bytes.NewReader([]byte("data"))
+	assert.Same(t, reader, NewTranscodingReader(reader, "UTF-8"))
+}
+
+func TestTranscodingReaderWithSmallBuffers(t *testing.T) {
+	reader := NewTranscodingReader(bytes.NewReader([]byte("\x80\xfc")), "windows-1252")
+	assert.NoError(t, iotest.TestReader(reader, []byte("€ü")))
+}
+
+func transcode(t *testing.T, data []byte, encoding string) string {
+	result, err := io.ReadAll(NewTranscodingReader(bytes.NewReader(data), encoding))
+	assert.NoError(t, err)
+	return string(result)
+}
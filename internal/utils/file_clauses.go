@@ -0,0 +1,84 @@
+package utils
+
+import "strings"
+
+// fileClause is a FILE clause of an IMPORT or EXPORT statement.
+// The clause spans from the FILE keyword to the closing quote of the path including one following space.
+type fileClause struct {
+	start int
+	end   int
+	path  string
+}
+
+// findFileClauses returns the FILE clauses of the given query in order.
+// Paths are string literals in single or double quotes where doubled quotes are escaped quotes,
+// so paths may contain apostrophes, spaces and any unicode characters.
+// FILE keywords in string literals, quoted identifiers and comments are ignored.
+func findFileClauses(query string) []fileClause {
+	var clauses []fileClause
+	for i := 0; i < len(query); i++ {
+		if end := skipLiteralOrComment(query, i); end > i {
+			i = end - 1
+			continue
+		}
+		clause, ok := parseFileClause(query, i)
+		if !ok {
+			continue
+		}
+		clauses = append(clauses, clause)
+		i = clause.end - 1
+	}
+	return clauses
+}
+
+func parseFileClause(query string, start int) (fileClause, bool) {
+	const keyword = "FILE"
+	end := start + len(keyword)
+	if end > len(query) || !strings.EqualFold(query[start:end], keyword) {
+		return fileClause{}, false
+	}
+	if start > 0 && isIdentifierChar(query[start-1]) {
+		return fileClause{}, false
+	}
+	pathStart := end
+	for pathStart < len(query) && isSpace(query[pathStart]) {
+		pathStart++
+	}
+	if pathStart == end || pathStart == len(query) || (query[pathStart] != '\'' && query[pathStart] != '"') {
+		return fileClause{}, false
+	}
+	quote := query[pathStart]
+	pathEnd := closingQuote(query, pathStart)
+	if pathEnd-pathStart < 2 || query[pathEnd-1] != quote {
+		return fileClause{}, false
+	}
+	literal := query[pathStart+1 : pathEnd-1]
+	if strings.Count(literal, string(quote))%2 != 0 {
+		// unterminated literal ending with an escaped quote
+		return fileClause{}, false
+	}
+	path := strings.ReplaceAll(literal, string([]byte{quote, quote}), string(quote))
+	if pathEnd < len(query) && query[pathEnd] == ' ' {
+		pathEnd++
+	}
+	return fileClause{start: start, end: pathEnd, path: path}, true
+}
+
+// replaceFileClauses replaces each FILE clause with the result of the given function
+// and each LOCAL CSV outside of the clauses with the given source.
+func replaceFileClauses(query string, source string, replace func(index int, path string) string) string {
+	var builder strings.Builder
+	builder.Grow(len(query))
+	last := 0
+	for index, clause := range findFileClauses(query) {
+		builder.WriteString(localCSVRegex.ReplaceAllLiteralString(query[last:clause.start], source))
+		builder.WriteString(replace(index, clause.path))
+		last = clause.end
+	}
+	builder.WriteString(localCSVRegex.ReplaceAllLiteralString(query[last:], source))
+	return builder.String()
+}
+
+func isSpace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\r'
+}
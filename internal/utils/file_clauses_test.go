@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFilePathsWithExoticCharacters(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		paths []string
+	}{
+		{name: "Escaped single quote", query: "IMPORT INTO t FROM LOCAL CSV FILE '/data/O''Brien.csv'", paths: []string{"/data/O'Brien.csv"}},
+		{name: "Escaped double quote", query: `IMPORT INTO t FROM LOCAL CSV FILE "/data/say ""hi"".csv"`, paths: []string{`/data/say "hi".csv`}},
+		{name: "Mixed quoting", query: `IMPORT INTO t FROM LOCAL CSV FILE '/data/"a".csv' FILE "/data/b's.csv"`, paths: []string{`/data/"a".csv`, "/data/b's.csv"}},
+		{name: "Spaces", query: "IMPORT INTO t FROM LOCAL CSV FILE '/my data/annual report (2023).csv' SKIP = 1", paths: []string{"/my data/annual report (2023).csv"}},
+		{name: "Unicode", query: "IMPORT INTO t FROM LOCAL CSV FILE '/données/käse_日本語.csv'", paths: []string{"/données/käse_日本語.csv"}},
+		{name: "Windows path", query: `IMPORT INTO t FROM LOCAL CSV FILE 'C:\Users\Zoë\My Files\data,1.csv'`, paths: []string{`C:\Users\Zoë\My Files\data,1.csv`}},
+		{name: "Line break before path", query: "IMPORT INTO t FROM LOCAL CSV FILE\n\t'/data/a.csv'", paths: []string{"/data/a.csv"}},
+		{name: "Keyword in literal", query: "IMPORT INTO t FROM LOCAL CSV FILE '/data/FILE ''x''.csv' NULL = 'FILE ''y'''", paths: []string{"/data/FILE 'x'.csv"}},
+		{name: "Keyword in comment", query: "IMPORT INTO t FROM LOCAL CSV -- FILE 'old.csv'\nFILE 'new.csv' /* FILE 'other.csv' */", paths: []string{"new.csv"}},
+		{name: "Keyword as identifier part", query: "IMPORT INTO PROFILE FROM LOCAL CSV FILE 'a.csv'", paths: []string{"a.csv"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := GetFilePaths(tt.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.paths, paths)
+		})
+	}
+}
+
+func TestGetFilePathsWithUnterminatedPath(t *testing.T) {
+	for _, query := range []string{"IMPORT INTO t FROM LOCAL CSV FILE '/data/a.csv", "IMPORT INTO t FROM LOCAL CSV FILE '/data/a.csv''", "IMPORT INTO t FROM LOCAL CSV FILE '"} {
+		_, err := GetFilePaths(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestUpdateImportQueryWithEscapedQuotes(t *testing.T) {
+	query := "IMPORT INTO t FROM LOCAL CSV FILE '/local csv/O''Brien.csv' FILE '/data/b.csv' SKIP = 1"
+	newQuery := UpdateImportQuery(query, "127.0.0.1", 4333)
+	assert.Equal(t, "IMPORT INTO t FROM CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' SKIP = 1", newQuery)
+}
+
+func TestUpdateExportQueryWithUnicodePath(t *testing.T) {
+	query := "EXPORT t INTO LOCAL CSV FILE '/données/käse''s.csv.gz' WITH COLUMN NAMES"
+	newQuery := UpdateExportQuery(query, []string{"http://127.0.0.1:4333"})
+	assert.Equal(t, "EXPORT t INTO CSV AT 'http://127.0.0.1:4333' FILE 'data_1.csv.gz' WITH COLUMN NAMES", newQuery)
+}
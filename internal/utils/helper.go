@@ -5,6 +5,7 @@ import (
 	"fmt"
 	mathRand "math/rand"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -14,7 +15,12 @@ import (
 )
 
 var localImportRegex = regexp.MustCompile(`(?i)(FROM LOCAL CSV )`)
-var fileQueryRegex = regexp.MustCompile(`(?i)(FILE\s+(["|'])?(?P<File>[a-zA-Z0-9:<> \\\/._]+)(["|']? ?))`)
+var localExportRegex = regexp.MustCompile(`(?i)(INTO LOCAL CSV )`)
+var localCSVRegex = regexp.MustCompile(`(?i)(LOCAL CSV)`)
+var urlSchemeRegex = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*)://`)
+var leadingCommentsRegex = regexp.MustCompile(`^(\s|\(|--[^\n]*|/\*(?s:.*?)\*/)*`)
+var readOnlyQueryRegex = regexp.MustCompile(`(?i)^(SELECT|WITH|EXPLAIN\s+VIRTUAL)\b`)
+var hostRangeRegex = regexp.MustCompile(`^((.+?)(\d+))\.\.(\d+)$`)
 var rowSeparatorQueryRegex = regexp.MustCompile(`(?i)(ROW\s+SEPARATOR\s+=\s+(["|'])?(?P<RowSeparator>[a-zA-Z]+)(["|']?))`)
 
 func NamedValuesToValues(namedValues []driver.NamedValue) ([]driver.Value, error) {
@@ -39,8 +45,21 @@ func BoolToPtr(b bool) *bool {
 	return &b
 }
 
+// IsImportQuery checks if the query imports local CSV files. Script definitions are never imports,
+// even if their bodies contain IMPORT statements.
 func IsImportQuery(query string) bool {
-	return localImportRegex.MatchString(query)
+	return localImportRegex.MatchString(query) && !IsScriptDefinition(query)
+}
+
+// IsExportQuery checks if the query exports into local CSV files. Script definitions are never exports.
+func IsExportQuery(query string) bool {
+	return localExportRegex.MatchString(query) && !IsScriptDefinition(query)
+}
+
+// IsReadOnlyQuery checks if the query is a SELECT statement or the EXPLAIN VIRTUAL of one, ignoring leading comments
+// and parentheses.
+func IsReadOnlyQuery(query string) bool {
+	return readOnlyQueryRegex.MatchString(leadingCommentsRegex.ReplaceAllString(query, ""))
 }
 
 func GetRowSeparator(query string) string {
@@ -62,22 +81,119 @@ func GetRowSeparator(query string) string {
 	}
 }
 
+// GetFilePaths returns the paths of the FILE clauses of an IMPORT or EXPORT query.
 func GetFilePaths(query string) ([]string, error) {
-	r := fileQueryRegex.FindAllStringSubmatch(query, -1)
 	var files []string
-	for _, matches := range r {
-		for i, name := range fileQueryRegex.SubexpNames() {
-			if name == "File" {
-				files = append(files, matches[i])
+	for _, clause := range findFileClauses(query) {
+		files = append(files, clause.path)
+	}
+	if len(files) == 0 {
+		return nil, errors.ErrInvalidImportQuery
+	}
+	return files, nil
+}
+
+// ExpandFilePaths replaces glob patterns like /data/part-*.csv with the sorted list of matching files
+// and directories with the sorted list of CSV files they contain. Other paths and URLs are returned unchanged.
+func ExpandFilePaths(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		if GetURLScheme(path) != "" {
+			files = append(files, path)
+			continue
+		}
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			csvFiles, err := listCSVFiles(path)
+			if err != nil {
+				return nil, err
 			}
+			files = append(files, csvFiles...)
+			continue
+		}
+		if !isGlobPattern(path) {
+			files = append(files, path)
+			continue
+		}
+		matches, err := globLongPath(path)
+		if err != nil {
+			return nil, errors.NewInvalidFilePattern(path)
+		}
+		if len(matches) == 0 {
+			return nil, errors.NewFileNotFound(path)
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// Windows prefixes of extended-length paths like \\?\C:\data\part1.csv and \\?\UNC\server\share\part1.csv
+// and of device paths like \\.\C:\data\part1.csv. The ? of a prefix is not a glob pattern.
+const (
+	longUNCPathPrefix = `\\?\UNC\`
+	longPathPrefix    = `\\?\`
+	devicePathPrefix  = `\\.\`
+)
+
+func splitLongPathPrefix(path string) (string, string) {
+	for _, prefix := range []string{longUNCPathPrefix, longPathPrefix, devicePathPrefix} {
+		if strings.HasPrefix(path, prefix) {
+			return prefix, path[len(prefix):]
+		}
+	}
+	return "", path
+}
+
+func isGlobPattern(path string) bool {
+	_, path = splitLongPathPrefix(path)
+	return strings.ContainsAny(path, "*?[")
+}
+
+// globLongPath expands a glob pattern keeping the extended-length prefix of the pattern in all matches.
+func globLongPath(pattern string) ([]string, error) {
+	prefix, path := splitLongPathPrefix(pattern)
+	if prefix == longUNCPathPrefix {
+		// \\?\UNC\server\share is the long form of \\server\share
+		path = `\\` + path
+	}
+	matches, err := filepath.Glob(path)
+	if err != nil {
+		return nil, err
+	}
+	for i, match := range matches {
+		if prefix == longUNCPathPrefix {
+			match = strings.TrimPrefix(match, `\\`)
+		}
+		matches[i] = prefix + match
+	}
+	return matches, nil
+}
+
+func listCSVFiles(directory string) ([]string, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, errors.NewFileReadError(directory, err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".csv") {
+			files = append(files, filepath.Join(directory, entry.Name()))
 		}
 	}
 	if len(files) == 0 {
-		return nil, errors.ErrInvalidImportQuery
+		return nil, errors.NewNoCSVFilesInDirectory(directory)
 	}
 	return files, nil
 }
 
+// GetURLScheme returns the lower case scheme of a path like s3://bucket/key or an empty string for local paths.
+func GetURLScheme(path string) string {
+	matches := urlSchemeRegex.FindStringSubmatch(path)
+	if matches == nil {
+		return ""
+	}
+	return strings.ToLower(matches[1])
+}
+
 func OpenFile(path string) (*os.File, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -87,33 +203,61 @@ func OpenFile(path string) (*os.File, error) {
 }
 
 func UpdateImportQuery(query string, host string, port int) string {
-	r := fileQueryRegex.FindAllStringSubmatch(query, -1)
-	for i, matches := range r {
-		if i == 0 {
-			query = strings.Replace(query, matches[0], "FILE 'data.csv' ", 1)
-		} else {
-			query = strings.Replace(query, matches[0], "", 1)
+	proxyURL := fmt.Sprintf("http://%s:%d", host, port)
+	updatedImport := fmt.Sprintf("CSV AT '%s'", proxyURL)
+	return replaceFileClauses(query, updatedImport, func(index int, path string) string {
+		if index == 0 {
+			return "FILE 'data.csv' "
 		}
+		return ""
+	})
+}
+
+// UpdateExportQuery replaces the local files of an export query with one proxy URL per file.
+// The database then splits the exported data between the files and sends each file to its own proxy.
+func UpdateExportQuery(query string, proxyURLs []string) string {
+	return replaceFileClauses(query, "CSV", func(index int, path string) string {
+		return exportTarget(path, proxyURLs[index], index) + " "
+	})
+}
+
+// ExportTargets returns the AT and FILE clauses sending the file with the given path to the proxy with the same index.
+func ExportTargets(paths []string, proxyURLs []string) string {
+	targets := make([]string, len(paths))
+	for i, path := range paths {
+		targets[i] = exportTarget(path, proxyURLs[i], i)
 	}
+	return strings.Join(targets, " ")
+}
 
-	proxyURL := fmt.Sprintf("http://%s:%d", host, port)
-	updatedImport := fmt.Sprintf("CSV AT '%s'", proxyURL)
-	var importQueryRegex = regexp.MustCompile(`(?i)(LOCAL CSV)`)
+func exportTarget(path string, proxyURL string, index int) string {
+	return fmt.Sprintf("AT '%s' FILE 'data_%d%s'", proxyURL, index+1, exportFileExtension(path))
+}
 
-	return string(importQueryRegex.ReplaceAll([]byte(query), []byte(updatedImport)))
+// exportFileExtension keeps the compression suffix of a local file so that the database compresses the exported data.
+func exportFileExtension(path string) string {
+	for _, compression := range []string{".gz", ".bz2", ".zip"} {
+		if strings.HasSuffix(strings.ToLower(path), compression) {
+			return ".csv" + compression
+		}
+	}
+	return ".csv"
 }
 
+// ResolveHosts splits a comma-separated host list and expands host ranges like exasol1..3.
+// Hosts may carry their own port like exasol1:8564, the port of a range applies to all hosts of the range.
 func ResolveHosts(h string) ([]string, error) {
 	var hosts []string
-	hostRangeRegex := regexp.MustCompile(`^((.+?)(\d+))\.\.(\d+)$`)
-
 	for _, host := range strings.Split(h, ",") {
-		if hostRangeRegex.MatchString(host) {
-			parsedHosts, err := ParseRange(hostRangeRegex, host)
+		name, port := splitPort(host)
+		if hostRangeRegex.MatchString(name) {
+			parsedHosts, err := ParseRange(hostRangeRegex, name)
 			if err != nil {
 				return nil, err
 			}
-			hosts = append(hosts, parsedHosts...)
+			for _, parsedHost := range parsedHosts {
+				hosts = append(hosts, parsedHost+port)
+			}
 		} else {
 			hosts = append(hosts, host)
 		}
@@ -121,6 +265,61 @@ func ResolveHosts(h string) ([]string, error) {
 	return hosts, nil
 }
 
+// ExcludeHosts returns the hosts that are not excluded. An excluded host without port excludes the host with any port.
+func ExcludeHosts(hosts []string, excluded []string) []string {
+	var remaining []string
+	for _, host := range hosts {
+		if !isExcludedHost(host, excluded) {
+			remaining = append(remaining, host)
+		}
+	}
+	return remaining
+}
+
+func isExcludedHost(host string, excluded []string) bool {
+	name, _ := splitPort(host)
+	for _, excludedHost := range excluded {
+		if strings.EqualFold(host, excludedHost) || strings.EqualFold(name, excludedHost) {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitHostPort returns the name and port of a host like exasol1:8564.
+// Hosts without port use the given default port.
+func SplitHostPort(host string, defaultPort int) (string, int, error) {
+	name, port := splitPort(host)
+	if port == "" {
+		return name, defaultPort, nil
+	}
+	parsedPort, err := strconv.Atoi(port[1:])
+	if err != nil || parsedPort <= 0 || parsedPort > 65535 {
+		return "", 0, errors.NewInvalidConnectionStringInvalidPort(port[1:])
+	}
+	return name, parsedPort, nil
+}
+
+// splitPort returns the name and the port suffix including the colon of a host like exasol1:8564.
+func splitPort(host string) (string, string) {
+	index := strings.LastIndex(host, ":")
+	if index < 0 {
+		return host, ""
+	}
+	return host[:index], host[index:]
+}
+
+// IsSaaSHost returns true if one of the given comma-separated hosts is an Exasol SaaS cluster.
+func IsSaaSHost(h string) bool {
+	for _, host := range strings.Split(h, ",") {
+		name, _ := splitPort(strings.TrimSpace(host))
+		if strings.HasSuffix(strings.ToLower(name), ".clusters.exasol.com") {
+			return true
+		}
+	}
+	return false
+}
+
 func ParseRange(hostRangeRegex *regexp.Regexp, host string) ([]string, error) {
 	matches := hostRangeRegex.FindStringSubmatch(host)
 	prefix := matches[2]
@@ -139,9 +338,15 @@ func ParseRange(hostRangeRegex *regexp.Regexp, host string) ([]string, error) {
 		return nil, errors.NewInvalidHostRangeLimits(host)
 	}
 
+	// A zero-padded start like exasol01..10 keeps the width of all host numbers.
+	width := 0
+	if len(matches[3]) > 1 && matches[3][0] == '0' {
+		width = len(matches[3])
+	}
+
 	var hosts []string
 	for i := start; i <= stop; i++ {
-		hosts = append(hosts, fmt.Sprintf("%s%d", prefix, i))
+		hosts = append(hosts, fmt.Sprintf("%s%0*d", prefix, width, i))
 	}
 	return hosts, nil
 }
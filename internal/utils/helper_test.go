@@ -3,6 +3,8 @@ package utils
 import (
 	"database/sql/driver"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -29,6 +31,42 @@ func TestIsImportQuery(t *testing.T) {
 	assert.True(t, IsImportQuery("IMPORT into <targettable> from local CSV file '/path/to/filename.csv' <optional options>;\n"))
 }
 
+func TestScriptDefinitionIsNoImportOrExport(t *testing.T) {
+	script := "CREATE LUA SCRIPT s AS\nquery([[IMPORT INTO t FROM LOCAL CSV FILE 'a.csv']])\nquery([[EXPORT t INTO LOCAL CSV FILE 'b.csv']])"
+	assert.False(t, IsImportQuery(script))
+	assert.False(t, IsExportQuery(script))
+}
+
+func TestIsReadOnlyQuery(t *testing.T) {
+	for _, query := range []string{
+		"SELECT * FROM T",
+		"select 1",
+		"  \n\tSELECT 1",
+		"(SELECT 1) UNION (SELECT 2)",
+		"WITH X AS (SELECT 1) SELECT * FROM X",
+		"-- comment\nSELECT 1",
+		"/* multi\nline */ SELECT 1",
+		"EXPLAIN VIRTUAL SELECT * FROM VS.T",
+	} {
+		assert.True(t, IsReadOnlyQuery(query), query)
+	}
+	for _, query := range []string{
+		"INSERT INTO T SELECT 1",
+		"DELETE FROM T",
+		"UPDATE T SET A = 1",
+		"CREATE TABLE SELECTED (A INT)",
+		"SELECTX",
+		"-- SELECT 1\nDROP TABLE T",
+		"/* SELECT */ DROP TABLE T",
+		"EXPORT (SELECT 1) INTO LOCAL CSV FILE 'data.csv'",
+		"IMPORT INTO T FROM LOCAL CSV FILE 'data.csv'",
+		"EXPLAIN SELECT 1",
+		"",
+	} {
+		assert.False(t, IsReadOnlyQuery(query), query)
+	}
+}
+
 func TestGetFilePathNotFound(t *testing.T) {
 	query := "SELECT * FROM table"
 	_, err := GetFilePaths(query)
@@ -64,6 +102,28 @@ func TestUpdateImportQueryMulti2(t *testing.T) {
 	assert.Equal(t, "IMPORT INTO table_1 FROM CSV AT 'http://127.0.0.1:4333' USER 'agent_007' IDENTIFIED BY 'secret' FILE 'data.csv' COLUMN SEPARATOR = ';' SKIP = 5;", newQuery)
 }
 
+func TestIsExportQuery(t *testing.T) {
+	assert.True(t, IsExportQuery("EXPORT table INTO LOCAL CSV FILE '/path/to/filename.csv'"))
+	assert.False(t, IsExportQuery("IMPORT INTO table FROM LOCAL CSV FILE '/path/to/filename.csv'"))
+}
+
+func TestUpdateExportQuery(t *testing.T) {
+	query := "EXPORT table INTO LOCAL CSV FILE '/path/to/filename.csv'"
+	newQuery := UpdateExportQuery(query, []string{"http://127.0.0.1:4333"})
+	assert.Equal(t, "EXPORT table INTO CSV AT 'http://127.0.0.1:4333' FILE 'data_1.csv' ", newQuery)
+}
+
+func TestUpdateExportQueryMulti(t *testing.T) {
+	query := "EXPORT table_1 INTO LOCAL CSV FILE 'part1.csv' FILE 'part2.csv.gz' COLUMN SEPARATOR = ';'"
+	newQuery := UpdateExportQuery(query, []string{"http://127.0.0.1:4333", "http://127.0.0.2:4334"})
+	assert.Equal(t, "EXPORT table_1 INTO CSV AT 'http://127.0.0.1:4333' FILE 'data_1.csv' AT 'http://127.0.0.2:4334' FILE 'data_2.csv.gz' COLUMN SEPARATOR = ';'", newQuery)
+}
+
+func TestExportTargets(t *testing.T) {
+	targets := ExportTargets([]string{"/data/customers (1).csv", "part2.CSV.BZ2"}, []string{"http://127.0.0.1:4333", "http://127.0.0.2:4334"})
+	assert.Equal(t, "AT 'http://127.0.0.1:4333' FILE 'data_1.csv' AT 'http://127.0.0.2:4334' FILE 'data_2.csv.bz2'", targets)
+}
+
 func TestGetFilePaths(t *testing.T) {
 	quotes := []struct {
 		name  string
@@ -84,6 +144,9 @@ func TestGetFilePaths(t *testing.T) {
 		{name: "Relative paths", paths: []string{"./tab1_part1.csv", "./tab1_part2.csv"}},
 		{name: "Windows paths", paths: []string{"C:\\Documents\\Newsletters\\Summer2018.csv", "\\Program Files\\Custom Utilities\\StringFinder.csv"}},
 		{name: "Unix paths", paths: []string{"/Users/User/Documents/Data/test.csv"}},
+		{name: "Glob patterns", paths: []string{"/data/part-*.csv", "/data/file?.csv", "/data/[ab].csv"}},
+		{name: "UNC paths", paths: []string{"\\\\fileserver\\share\\data.csv", "\\\\fileserver\\share name\\sub dir\\data.csv"}},
+		{name: "Long paths", paths: []string{"\\\\?\\C:\\Data\\data.csv", "\\\\?\\UNC\\fileserver\\share\\data.csv"}},
 	}
 
 	for _, quote := range quotes {
@@ -106,6 +169,75 @@ func TestGetFilePaths(t *testing.T) {
 	}
 }
 
+func TestExpandFilePaths(t *testing.T) {
+	files, err := ExpandFilePaths([]string{"../../testData/data_*.csv", "../../testData/data.csv"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"../../testData/data_cr.csv", "../../testData/data_part2.csv", "../../testData/data.csv"}, files)
+}
+
+func TestExpandFilePathsWithoutMatch(t *testing.T) {
+	_, err := ExpandFilePaths([]string{"../../testData/missing-*.csv"})
+	assert.EqualError(t, err, "E-EGOD-28: file '../../testData/missing-*.csv' not found")
+}
+
+func TestExpandFilePathsWithInvalidPattern(t *testing.T) {
+	_, err := ExpandFilePaths([]string{"../../testData/[.csv"})
+	assert.EqualError(t, err, "E-EGOD-38: invalid file pattern '../../testData/[.csv'")
+}
+
+func TestExpandFilePathsWithDirectory(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.csv", "a.CSV", "c.txt", "sub.csv/d.csv"} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0700))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("1\n"), 0600))
+	}
+	files, err := ExpandFilePaths([]string{dir + "/"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.CSV"), filepath.Join(dir, "b.csv")}, files)
+}
+
+func TestExpandFilePathsWithEmptyDirectory(t *testing.T) {
+	dir := t.TempDir()
+	_, err := ExpandFilePaths([]string{dir})
+	assert.EqualError(t, err, fmt.Sprintf("E-EGOD-39: directory '%s' contains no CSV files", dir))
+}
+
+func TestExpandFilePathsKeepsWindowsPaths(t *testing.T) {
+	paths := []string{`\\fileserver\share\data.csv`, `\\?\C:\Data\data.csv`, `\\?\UNC\fileserver\share\data.csv`, `\\.\C:\Data\data.csv`}
+	files, err := ExpandFilePaths(paths)
+	assert.NoError(t, err)
+	assert.Equal(t, paths, files)
+}
+
+func TestExpandFilePathsWithLongPathPrefix(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.csv", "a.csv"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("1\n"), 0600))
+	}
+	files, err := ExpandFilePaths([]string{`\\?\` + dir + "/*.csv"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`\\?\` + filepath.Join(dir, "a.csv"), `\\?\` + filepath.Join(dir, "b.csv")}, files)
+}
+
+func TestUpdateImportQueryWithUNCPath(t *testing.T) {
+	query := `IMPORT INTO t FROM LOCAL CSV FILE '\\fileserver\share\data.csv' FILE '\\?\UNC\fileserver\share\data2.csv'`
+	newQuery := UpdateImportQuery(query, "127.0.0.1", 4333)
+	assert.Equal(t, "IMPORT INTO t FROM CSV AT 'http://127.0.0.1:4333' FILE 'data.csv' ", newQuery)
+}
+
+func TestExpandFilePathsKeepsURLs(t *testing.T) {
+	files, err := ExpandFilePaths([]string{"s3://bucket/part-*.csv"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"s3://bucket/part-*.csv"}, files)
+}
+
+func TestGetURLScheme(t *testing.T) {
+	assert.Equal(t, "s3", GetURLScheme("S3://bucket/key.csv"))
+	assert.Equal(t, "gs", GetURLScheme("gs://bucket/key.csv"))
+	assert.Equal(t, "", GetURLScheme("/data/key.csv"))
+	assert.Equal(t, "", GetURLScheme("C:\\data\\key.csv"))
+}
+
 func TestGetRowSeparatorLF(t *testing.T) {
 	query := "IMPORT into table FROM LOCAL CSV file '/path/to/filename.csv' ROW SEPARATOR = 'LF'"
 	assert.Equal(t, GetRowSeparator(query), "\n")
@@ -161,6 +293,14 @@ func TestMultipleHostResolve(t *testing.T) {
 	assert.Equal(t, "exasol3", hosts[2])
 }
 
+func TestIsSaaSHost(t *testing.T) {
+	assert.True(t, IsSaaSHost("abc.clusters.exasol.com"))
+	assert.True(t, IsSaaSHost("localhost,ABC.Clusters.Exasol.com"))
+	assert.True(t, IsSaaSHost("abc.clusters.exasol.com:8563"))
+	assert.False(t, IsSaaSHost("exasol.com"))
+	assert.False(t, IsSaaSHost("localhost"))
+}
+
 func TestHostSuffixRangeResolve(t *testing.T) {
 	hosts, err := ResolveHosts("exasol1..3")
 
@@ -171,6 +311,68 @@ func TestHostSuffixRangeResolve(t *testing.T) {
 	assert.Equal(t, "exasol3", hosts[2])
 }
 
+func TestResolvingZeroPaddedHostRange(t *testing.T) {
+	tests := []struct {
+		hosts    string
+		expected []string
+	}{
+		{hosts: "exasol01..03", expected: []string{"exasol01", "exasol02", "exasol03"}},
+		{hosts: "exasol08..11", expected: []string{"exasol08", "exasol09", "exasol10", "exasol11"}},
+		{hosts: "exasol001..003", expected: []string{"exasol001", "exasol002", "exasol003"}},
+		{hosts: "exasol98..100", expected: []string{"exasol98", "exasol99", "exasol100"}},
+		{hosts: "exasol099..100", expected: []string{"exasol099", "exasol100"}},
+		{hosts: "exasol0..2", expected: []string{"exasol0", "exasol1", "exasol2"}},
+		{hosts: "exasol-2-01..02", expected: []string{"exasol-2-01", "exasol-2-02"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hosts, func(t *testing.T) {
+			hosts, err := ResolveHosts(tt.hosts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, hosts)
+		})
+	}
+}
+
+func TestResolvingHostsWithPorts(t *testing.T) {
+	hosts, err := ResolveHosts("exasol1:8564,exasol2,exasol3..4:8565")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"exasol1:8564", "exasol2", "exasol3:8565", "exasol4:8565"}, hosts)
+}
+
+func TestExcludeHosts(t *testing.T) {
+	hosts := []string{"exasol1", "exasol2:8564", "exasol3:8565", "Exasol4"}
+	assert.Equal(t, []string{"exasol1", "exasol3:8565"}, ExcludeHosts(hosts, []string{"exasol2", "exasol3:8564", "exasol4"}))
+	assert.Equal(t, hosts, ExcludeHosts(hosts, nil))
+	assert.Empty(t, ExcludeHosts(hosts, []string{"exasol1", "exasol2", "exasol3", "exasol4"}))
+}
+
+func TestSplitHostPort(t *testing.T) {
+	tests := []struct {
+		host         string
+		expectedName string
+		expectedPort int
+	}{
+		{host: "exasol1", expectedName: "exasol1", expectedPort: 8563},
+		{host: "exasol1:8564", expectedName: "exasol1", expectedPort: 8564},
+		{host: "10.0.0.1:1", expectedName: "10.0.0.1", expectedPort: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			name, port, err := SplitHostPort(tt.host, 8563)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedName, name)
+			assert.Equal(t, tt.expectedPort, port)
+		})
+	}
+}
+
+func TestSplitHostPortWithInvalidPort(t *testing.T) {
+	for _, host := range []string{"exasol1:", "exasol1:abc", "exasol1:0", "exasol1:65536"} {
+		_, _, err := SplitHostPort(host, 8563)
+		assert.ErrorContains(t, err, "E-EGOD-23", host)
+	}
+}
+
 func TestResolvingHostRangeWithCompleteHostnameNotSupported(t *testing.T) {
 	hosts, err := ResolveHosts("exasol1..exasol3")
 
@@ -0,0 +1,18 @@
+package utils
+
+import "regexp"
+
+// insertValuesRegex matches INSERT statements with a single row of placeholders, e.g. INSERT INTO t (a, b) VALUES (?, ?).
+// The target is a table name of quoted and unquoted parts followed by an optional column list.
+var insertValuesRegex = regexp.MustCompile(`(?is)^INSERT\s+INTO\s+((?:"(?:[^"]|"")*"|[^\s"(),?;])+(?:\s*\((?:[^()"?;]|"(?:[^"]|"")*")*\))?)\s*VALUES\s*\(\s*\?(?:\s*,\s*\?)*\s*\)\s*;?\s*$`)
+
+// GetInsertTarget returns the table and the optional column list of an INSERT statement whose VALUES clause only
+// contains placeholders, e.g. s.t (a, b) for INSERT INTO s.t (a, b) VALUES (?, ?), ignoring leading comments.
+// It returns false for other statements, e.g. with expressions in the VALUES clause or a subquery instead of VALUES.
+func GetInsertTarget(query string) (string, bool) {
+	match := insertValuesRegex.FindStringSubmatch(leadingCommentsRegex.ReplaceAllString(query, ""))
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
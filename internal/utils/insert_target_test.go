@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInsertTarget(t *testing.T) {
+	tests := []struct {
+		query    string
+		target   string
+		expected bool
+	}{
+		{"INSERT INTO t VALUES (?)", "t", true},
+		{"insert into s.t (a, b) values (?, ?)", "s.t (a, b)", true},
+		{"INSERT INTO \"s\".\"t\"(\"a\",\"b\")VALUES(?,?);", "\"s\".\"t\"(\"a\",\"b\")", true},
+		{"-- load\nINSERT INTO t\n  VALUES (?, ?, ?)\n", "t", true},
+		{"INSERT INTO t VALUES (?, CURRENT_TIMESTAMP)", "", false},
+		{"INSERT INTO t VALUES (?), (?)", "", false},
+		{"INSERT INTO t SELECT * FROM u WHERE a = ?", "", false},
+		{"INSERT INTO t (a) SELECT ? FROM DUAL", "", false},
+		{"INSERT INTO t SELECT * FROM VALUES (?, ?)", "", false},
+		{"INSERT INTO \"my table\" (\"a)\") VALUES (?)", "\"my table\" (\"a)\")", true},
+		{"UPDATE t SET a = ?", "", false},
+		{"SELECT 'INSERT INTO t VALUES (?)'", "", false},
+	}
+	for _, test := range tests {
+		target, ok := GetInsertTarget(test.query)
+		assert.Equal(t, test.expected, ok, test.query)
+		assert.Equal(t, test.target, target, test.query)
+	}
+}
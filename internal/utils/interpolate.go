@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"database/sql/driver"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// InterpolateParams replaces the ? placeholders of the query with SQL literals of the given values.
+// It returns false if the number of placeholders does not match the number of values
+// or a value has a type without an exact literal representation, e.g. time.Time or []byte.
+func InterpolateParams(query string, args []driver.Value) (string, bool) {
+	interpolated, placeholders, ok := ReplacePlaceholders(query, func(index int) (string, bool) {
+		if index >= len(args) {
+			return "", false
+		}
+		return sqlLiteral(args[index])
+	})
+	if !ok || placeholders != len(args) {
+		return "", false
+	}
+	return interpolated, true
+}
+
+// ReplacePlaceholders replaces each ? placeholder of the query with the result of replace for its zero-based index
+// and returns the number of placeholders. Placeholders in string literals, quoted identifiers and comments are ignored.
+// Script definitions have no placeholders. It stops and returns false as soon as replace returns false.
+func ReplacePlaceholders(query string, replace func(index int) (string, bool)) (string, int, bool) {
+	if IsScriptDefinition(query) {
+		return query, 0, true
+	}
+	var builder strings.Builder
+	builder.Grow(len(query))
+	index := 0
+	for i := 0; i < len(query); i++ {
+		if end := skipLiteralOrComment(query, i); end > i {
+			builder.WriteString(query[i:end])
+			i = end - 1
+			continue
+		}
+		if query[i] != '?' {
+			builder.WriteByte(query[i])
+			continue
+		}
+		replacement, ok := replace(index)
+		if !ok {
+			return "", index, false
+		}
+		builder.WriteString(replacement)
+		index++
+	}
+	return builder.String(), index, true
+}
+
+// skipLiteralOrComment returns the index after the string literal, quoted identifier or comment
+// starting at the given position or the position itself if none starts there.
+func skipLiteralOrComment(query string, start int) int {
+	switch {
+	case query[start] == '\'' || query[start] == '"':
+		return closingQuote(query, start)
+	case strings.HasPrefix(query[start:], "--"):
+		end := strings.IndexByte(query[start:], '\n')
+		if end < 0 {
+			return len(query)
+		}
+		return start + end
+	case strings.HasPrefix(query[start:], "/*"):
+		end := strings.Index(query[start+2:], "*/")
+		if end < 0 {
+			return len(query)
+		}
+		return start + end + 4
+	default:
+		return start
+	}
+}
+
+// closingQuote returns the index after the quote closing the quote at the given position.
+// Doubled quotes are escaped quotes.
+func closingQuote(query string, start int) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		if query[i] != quote {
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(query)
+}
+
+func sqlLiteral(value driver.Value) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "NULL", true
+	case bool:
+		if v {
+			return "TRUE", true
+		}
+		return "FALSE", true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case int:
+		return strconv.Itoa(v), true
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return "", false
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64), true
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'", true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolateParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		args     []driver.Value
+		expected string
+	}{
+		{"no placeholders", "SELECT 1", nil, "SELECT 1"},
+		{"integer", "SELECT * FROM t WHERE id = ?", []driver.Value{int64(42)}, "SELECT * FROM t WHERE id = 42"},
+		{"string", "INSERT INTO t VALUES (?)", []driver.Value{"it's"}, "INSERT INTO t VALUES ('it''s')"},
+		{"float", "SELECT ?", []driver.Value{1.5}, "SELECT 1.5"},
+		{"bool and null", "SELECT ?, ?, ?", []driver.Value{true, false, nil}, "SELECT TRUE, FALSE, NULL"},
+		{"string literal", "SELECT '?', ? FROM t", []driver.Value{int64(1)}, "SELECT '?', 1 FROM t"},
+		{"escaped quote in literal", "SELECT 'a''?', ?", []driver.Value{int64(1)}, "SELECT 'a''?', 1"},
+		{"quoted identifier", `SELECT "col?" FROM t WHERE x = ?`, []driver.Value{int64(1)}, `SELECT "col?" FROM t WHERE x = 1`},
+		{"line comment", "SELECT ? -- why?\nFROM t", []driver.Value{int64(1)}, "SELECT 1 -- why?\nFROM t"},
+		{"block comment", "SELECT /* ? */ ?", []driver.Value{int64(1)}, "SELECT /* ? */ 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, ok := InterpolateParams(tt.query, tt.args)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestInterpolateParamsNotPossible(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		args  []driver.Value
+	}{
+		{"too few values", "SELECT ?, ?", []driver.Value{int64(1)}},
+		{"too many values", "SELECT ?", []driver.Value{int64(1), int64(2)}},
+		{"time", "SELECT ?", []driver.Value{time.Now()}},
+		{"bytes", "SELECT ?", []driver.Value{[]byte("a")}},
+		{"NaN", "SELECT ?", []driver.Value{math.NaN()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := InterpolateParams(tt.query, tt.args)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestReplacePlaceholders(t *testing.T) {
+	replaced, count, ok := ReplacePlaceholders("SELECT ?, '?' FROM t WHERE a IN (?)", func(index int) (string, bool) {
+		return fmt.Sprintf("<%d>", index), true
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "SELECT <0>, '?' FROM t WHERE a IN (<1>)", replaced)
+}
+
+func TestReplacePlaceholdersStops(t *testing.T) {
+	_, count, ok := ReplacePlaceholders("SELECT ?, ?, ?", func(index int) (string, bool) {
+		return "", index < 1
+	})
+	assert.False(t, ok)
+	assert.Equal(t, 1, count)
+}
+
+func TestReplacePlaceholdersIgnoresScriptDefinition(t *testing.T) {
+	script := "CREATE PYTHON3 SCALAR SCRIPT s() RETURNS VARCHAR(10) AS\n# isn't this ?\ndef run(ctx):\n  return '?'"
+	replaced, count, ok := ReplacePlaceholders(script, func(index int) (string, bool) {
+		return "<replaced>", true
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, script, replaced)
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"database/sql/driver"
+	"strconv"
+	"strings"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// Placeholder styles accepted in addition to the positional ? placeholders of the protocol.
+const (
+	PlaceholderStyleQuestion = "question" // ? placeholders only
+	PlaceholderStyleColon    = "colon"    // :1 and :name placeholders
+	PlaceholderStyleDollar   = "dollar"   // $1 placeholders
+)
+
+// Placeholder references the argument of a translated placeholder by its one-based position or by its name.
+type Placeholder struct {
+	Position int
+	Name     string
+}
+
+// TranslatePlaceholders replaces the placeholders of the given style with ? placeholders
+// and returns the referenced arguments in the order of the placeholders.
+// Placeholders in string literals, quoted identifiers and comments are ignored.
+// The query is returned unchanged for the question style and for script definitions.
+func TranslatePlaceholders(query string, style string) (string, []Placeholder, error) {
+	if style == "" || style == PlaceholderStyleQuestion || IsScriptDefinition(query) {
+		return query, nil, nil
+	}
+	prefix := placeholderPrefix(style)
+	var builder strings.Builder
+	builder.Grow(len(query))
+	var placeholders []Placeholder
+	for i := 0; i < len(query); i++ {
+		if end := skipLiteralOrComment(query, i); end > i {
+			builder.WriteString(query[i:end])
+			i = end - 1
+			continue
+		}
+		if query[i] == '?' {
+			return "", nil, errors.NewMixedPlaceholders(style)
+		}
+		if query[i] != prefix || (i > 0 && isIdentifierChar(query[i-1])) {
+			builder.WriteByte(query[i])
+			continue
+		}
+		end := i + 1
+		for end < len(query) && isIdentifierChar(query[end]) {
+			end++
+		}
+		placeholder, ok := parsePlaceholder(query[i+1:end], style)
+		if !ok {
+			builder.WriteByte(query[i])
+			continue
+		}
+		placeholders = append(placeholders, placeholder)
+		builder.WriteByte('?')
+		i = end - 1
+	}
+	return builder.String(), placeholders, nil
+}
+
+func parsePlaceholder(reference string, style string) (Placeholder, bool) {
+	if reference == "" {
+		return Placeholder{}, false
+	}
+	if position, err := strconv.Atoi(reference); err == nil {
+		return Placeholder{Position: position}, position > 0
+	}
+	if style != PlaceholderStyleColon || isDigit(reference[0]) {
+		return Placeholder{}, false
+	}
+	return Placeholder{Name: reference}, true
+}
+
+// BindPlaceholders returns the values of the arguments referenced by the given placeholders.
+// Positions refer to the ordinal of an argument, names to the name given with sql.Named.
+func BindPlaceholders(placeholders []Placeholder, style string, args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(placeholders))
+	for index, placeholder := range placeholders {
+		arg, ok := findArgument(placeholder, args)
+		if !ok {
+			return nil, errors.NewMissingPlaceholderArgument(placeholderText(placeholder, style))
+		}
+		values[index] = arg.Value
+	}
+	return values, nil
+}
+
+func placeholderPrefix(style string) byte {
+	if style == PlaceholderStyleDollar {
+		return '$'
+	}
+	return ':'
+}
+
+func placeholderText(placeholder Placeholder, style string) string {
+	if placeholder.Name != "" {
+		return string(placeholderPrefix(style)) + placeholder.Name
+	}
+	return string(placeholderPrefix(style)) + strconv.Itoa(placeholder.Position)
+}
+
+func findArgument(placeholder Placeholder, args []driver.NamedValue) (driver.NamedValue, bool) {
+	for _, arg := range args {
+		if placeholder.Name != "" && arg.Name == placeholder.Name {
+			return arg, true
+		}
+		if placeholder.Name == "" && arg.Ordinal == placeholder.Position {
+			return arg, true
+		}
+	}
+	return driver.NamedValue{}, false
+}
+
+func isIdentifierChar(char byte) bool {
+	return char == '_' || isDigit(char) || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
+
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslatePlaceholders(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		style        string
+		expected     string
+		placeholders []Placeholder
+	}{
+		{"question style", "SELECT ? FROM t", PlaceholderStyleQuestion, "SELECT ? FROM t", nil},
+		{"default style", "SELECT :1 FROM t", "", "SELECT :1 FROM t", nil},
+		{"colon positions", "SELECT * FROM t WHERE a = :2 AND b = :1", PlaceholderStyleColon, "SELECT * FROM t WHERE a = ? AND b = ?", []Placeholder{{Position: 2}, {Position: 1}}},
+		{"colon names", "INSERT INTO t VALUES (:id, :name, :id)", PlaceholderStyleColon, "INSERT INTO t VALUES (?, ?, ?)", []Placeholder{{Name: "id"}, {Name: "name"}, {Name: "id"}}},
+		{"dollar positions", "SELECT $1, $2", PlaceholderStyleDollar, "SELECT ?, ?", []Placeholder{{Position: 1}, {Position: 2}}},
+		{"dollar ignores names", "SELECT $name, $1", PlaceholderStyleDollar, "SELECT $name, ?", []Placeholder{{Position: 1}}},
+		{"string literal", "SELECT ':1', '12:30', :1", PlaceholderStyleColon, "SELECT ':1', '12:30', ?", []Placeholder{{Position: 1}}},
+		{"quoted identifier", `SELECT "a:b" FROM t WHERE x = :x`, PlaceholderStyleColon, `SELECT "a:b" FROM t WHERE x = ?`, []Placeholder{{Name: "x"}}},
+		{"comments", "SELECT :1 -- :2?\n/* $1 ? */", PlaceholderStyleColon, "SELECT ? -- :2?\n/* $1 ? */", []Placeholder{{Position: 1}}},
+		{"prefix inside identifier", "SELECT a:b, :1", PlaceholderStyleColon, "SELECT a:b, ?", []Placeholder{{Position: 1}}},
+		{"zero position", "SELECT :0", PlaceholderStyleColon, "SELECT :0", nil},
+		{"script definition", "CREATE PYTHON3 SCALAR SCRIPT s() RETURNS INT AS\n# it's ?\nd = {'a': 1, 'b':2}", PlaceholderStyleColon, "CREATE PYTHON3 SCALAR SCRIPT s() RETURNS INT AS\n# it's ?\nd = {'a': 1, 'b':2}", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			translated, placeholders, err := TranslatePlaceholders(test.query, test.style)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, translated)
+			assert.Equal(t, test.placeholders, placeholders)
+		})
+	}
+}
+
+func TestTranslatePlaceholdersFailsForQuestionMark(t *testing.T) {
+	_, _, err := TranslatePlaceholders("SELECT :1, ?", PlaceholderStyleColon)
+	assert.EqualError(t, err, "E-EGOD-51: statement mixes ? placeholders with placeholders of style 'colon' Use only placeholders of the configured placeholderstyle.")
+}
+
+func TestBindPlaceholders(t *testing.T) {
+	args := []driver.NamedValue{{Ordinal: 1, Value: "a"}, {Ordinal: 2, Name: "id", Value: int64(42)}}
+	values, err := BindPlaceholders([]Placeholder{{Name: "id"}, {Position: 1}, {Position: 2}, {Name: "id"}}, PlaceholderStyleColon, args)
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Value{int64(42), "a", int64(42), int64(42)}, values)
+}
+
+func TestBindPlaceholdersFailsForMissingArgument(t *testing.T) {
+	tests := []struct {
+		style        string
+		placeholder  Placeholder
+		errorMessage string
+	}{
+		{PlaceholderStyleColon, Placeholder{Name: "name"}, "E-EGOD-52: no argument given for placeholder ':name'"},
+		{PlaceholderStyleColon, Placeholder{Position: 2}, "E-EGOD-52: no argument given for placeholder ':2'"},
+		{PlaceholderStyleDollar, Placeholder{Position: 3}, "E-EGOD-52: no argument given for placeholder '$3'"},
+	}
+	for _, test := range tests {
+		t.Run(test.errorMessage, func(t *testing.T) {
+			_, err := BindPlaceholders([]Placeholder{test.placeholder}, test.style, []driver.NamedValue{{Ordinal: 1, Value: "a"}})
+			assert.EqualError(t, err, test.errorMessage)
+		})
+	}
+}
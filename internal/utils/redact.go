@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+const redacted = "***"
+
+var identifiedByRegex = regexp.MustCompile(`(?is)(IDENTIFIED\s+BY\s+)('(?:[^']|'')*'|"(?:[^"]|"")*")(\s+REPLACE\s+)?('(?:[^']|'')*'|"(?:[^"]|"")*")?`)
+var secretKeyValueRegex = regexp.MustCompile(`(?i)\b(password|pwd|secret|token|accesstoken|refreshtoken|access_token|refresh_token)(\s*=\s*)[^;,'"\s]+`)
+
+// RedactSQL removes credentials like passwords in IDENTIFIED BY clauses and
+// key=value tokens from the given SQL text so that it can be logged safely.
+func RedactSQL(query string) string {
+	query = identifiedByRegex.ReplaceAllStringFunc(query, func(match string) string {
+		groups := identifiedByRegex.FindStringSubmatch(match)
+		result := groups[1] + "'" + redacted + "'"
+		if groups[3] != "" && groups[4] != "" {
+			result += groups[3] + "'" + redacted + "'"
+		} else {
+			result += groups[3]
+		}
+		return result
+	})
+	return secretKeyValueRegex.ReplaceAllString(query, "${1}${2}"+redacted)
+}
+
+// secretFrameKeys contains the keys of protocol messages with credentials, in lower case.
+var secretFrameKeys = map[string]bool{"password": true, "accesstoken": true, "refreshtoken": true}
+
+// RedactFrame removes credentials from the given JSON protocol message so that it can be logged or recorded safely.
+// Messages that are not valid JSON are returned unchanged.
+func RedactFrame(message []byte) []byte {
+	var value any
+	if err := json.Unmarshal(message, &value); err != nil {
+		return message
+	}
+	redactedMessage, err := json.Marshal(redactValue("", value))
+	if err != nil {
+		return message
+	}
+	return redactedMessage
+}
+
+func redactValue(key string, value any) any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for childKey, childValue := range typedValue {
+			typedValue[childKey] = redactValue(childKey, childValue)
+		}
+		return typedValue
+	case []any:
+		for i, childValue := range typedValue {
+			typedValue[i] = redactValue(key, childValue)
+		}
+		return typedValue
+	case string:
+		if secretFrameKeys[strings.ToLower(key)] {
+			return redacted
+		}
+		if key == "sqlText" || key == "sqlTexts" {
+			return RedactSQL(typedValue)
+		}
+		return typedValue
+	default:
+		return value
+	}
+}
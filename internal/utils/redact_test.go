@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "no credentials", query: "SELECT * FROM T WHERE A = 'secret value'", expected: "SELECT * FROM T WHERE A = 'secret value'"},
+		{name: "create user", query: "CREATE USER u IDENTIFIED BY \"pass word\"", expected: "CREATE USER u IDENTIFIED BY '***'"},
+		{name: "alter user with replace", query: "ALTER USER u IDENTIFIED BY 'new' REPLACE 'old';", expected: "ALTER USER u IDENTIFIED BY '***' REPLACE '***';"},
+		{name: "lowercase with escaped quote", query: "create connection c to 'ftp://host' user 'u' identified by 'it''s'", expected: "create connection c to 'ftp://host' user 'u' identified by '***'"},
+		{name: "import with credentials", query: "IMPORT INTO T FROM CSV AT 'http://h' USER 'agent' IDENTIFIED BY 'secret' FILE 'a.csv'", expected: "IMPORT INTO T FROM CSV AT 'http://h' USER 'agent' IDENTIFIED BY '***' FILE 'a.csv'"},
+		{name: "token key value", query: "CREATE CONNECTION c TO 'https://host?token=abc123&x=1'", expected: "CREATE CONNECTION c TO 'https://host?token=***'"},
+		{name: "password key value", query: "SELECT 'user=u;password=secret;schema=s'", expected: "SELECT 'user=u;password=***;schema=s'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RedactSQL(tt.query))
+		})
+	}
+}
+
+func TestRedactFrame(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{name: "no credentials", message: `{"command":"execute","sqlText":"SELECT 1"}`, expected: `{"command":"execute","sqlText":"SELECT 1"}`},
+		{name: "password", message: `{"password":"secret","username":"sys"}`, expected: `{"password":"***","username":"sys"}`},
+		{name: "tokens", message: `{"accessToken":"a","refreshToken":"r"}`, expected: `{"accessToken":"***","refreshToken":"***"}`},
+		{name: "sql text", message: `{"sqlText":"CREATE USER u IDENTIFIED BY 'pwd'"}`, expected: `{"sqlText":"CREATE USER u IDENTIFIED BY '***'"}`},
+		{name: "batch sql texts", message: `{"sqlTexts":["ALTER USER u IDENTIFIED BY 'pwd'","SELECT 1"]}`, expected: `{"sqlTexts":["ALTER USER u IDENTIFIED BY '***'","SELECT 1"]}`},
+		{name: "nested", message: `{"attributes":{"password":"secret"}}`, expected: `{"attributes":{"password":"***"}}`},
+		{name: "invalid json", message: `not json`, expected: `not json`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, string(RedactFrame([]byte(test.message))))
+		})
+	}
+}
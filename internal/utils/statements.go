@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// scriptDefinitionRegex matches the header of CREATE SCRIPT and CREATE FUNCTION statements with their optional
+// OR REPLACE, language and SCALAR, SET or ADAPTER modifiers, e.g. CREATE OR REPLACE PYTHON3 SCALAR SCRIPT.
+var scriptDefinitionRegex = regexp.MustCompile(`(?i)^CREATE\s+(?:OR\s+REPLACE\s+)?(?:([A-Z_][A-Z0-9_]*)\s+)??(?:(?:SCALAR|SET|ADAPTER)\s+)?(?:SCRIPT|FUNCTION)\s`)
+
+// objectKeywords are the words between CREATE and SCRIPT that make the statement create another object named SCRIPT,
+// e.g. CREATE TABLE SCRIPT (...).
+var objectKeywords = map[string]bool{"TABLE": true, "VIEW": true, "SCHEMA": true, "USER": true, "ROLE": true, "CONNECTION": true, "FORCE": true, "VIRTUAL": true}
+
+// IsScriptDefinition checks if the query creates a script or function, ignoring leading comments.
+// Their bodies are Lua, Python, Java, R or PL/SQL code that may contain semicolons, quotes, question marks and
+// comments in other syntaxes, so the query must be sent to the database unchanged.
+func IsScriptDefinition(query string) bool {
+	match := scriptDefinitionRegex.FindStringSubmatch(leadingCommentsRegex.ReplaceAllString(query, ""))
+	return match != nil && !objectKeywords[strings.ToUpper(match[1])]
+}
+
+// SplitStatements splits SQL text into statements at the semicolons outside of string literals, quoted identifiers
+// and comments. Script definitions end at a line containing only a slash like in EXAplus, as their bodies contain
+// semicolons, or at the end of the text. Statements are trimmed and empty statements omitted.
+func SplitStatements(text string) []string {
+	var statements []string
+	for start := 0; start < len(text); {
+		end, next := statementEnd(text, start)
+		if statement := strings.TrimSpace(text[start:end]); statement != "" && !isOnlyComments(statement) {
+			statements = append(statements, statement)
+		}
+		start = next
+	}
+	return statements
+}
+
+// statementEnd returns the end of the statement starting at the given position and the start of the next statement.
+func statementEnd(text string, start int) (int, int) {
+	if IsScriptDefinition(text[start:]) {
+		return scriptEnd(text, start)
+	}
+	for i := start; i < len(text); i++ {
+		if end := skipLiteralOrComment(text, i); end > i {
+			i = end - 1
+			continue
+		}
+		if text[i] == ';' {
+			return i, i + 1
+		}
+	}
+	return len(text), len(text)
+}
+
+// scriptEnd returns the start and end of the first line after the given position that contains only a slash.
+func scriptEnd(text string, start int) (int, int) {
+	for lineStart := start; lineStart < len(text); {
+		lineEnd := strings.IndexByte(text[lineStart:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(text)
+		} else {
+			lineEnd += lineStart
+		}
+		if strings.TrimSpace(text[lineStart:lineEnd]) == "/" {
+			return lineStart, lineEnd
+		}
+		lineStart = lineEnd + 1
+	}
+	return len(text), len(text)
+}
+
+func isOnlyComments(statement string) bool {
+	for i := 0; i < len(statement); i++ {
+		if end := skipLiteralOrComment(statement, i); end > i && statement[i] != '\'' && statement[i] != '"' {
+			i = end - 1
+			continue
+		}
+		if !isSpace(statement[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsScriptDefinition(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"CREATE SCRIPT s AS output('a;b')", true},
+		{"create or replace script s(a) returns rowcount as\nquery([[DELETE FROM t WHERE a = :a]])", true},
+		{"CREATE OR REPLACE PYTHON3 SCALAR SCRIPT s(a INT) RETURNS INT AS\ndef run(ctx):\n  return 1", true},
+		{"CREATE LUA SET SCRIPT s(a INT) EMITS (b INT) AS", true},
+		{"CREATE JAVA ADAPTER SCRIPT s AS", true},
+		{"CREATE SCALAR SCRIPT s() RETURNS INT AS", true},
+		{"-- comment\n/* comment */ CREATE FUNCTION f(a NUMBER) RETURN NUMBER IS BEGIN RETURN a; END f;", true},
+		{"CREATE OR REPLACE FUNCTION f RETURN NUMBER IS", true},
+		{"CREATE TABLE script (a INT)", false},
+		{"CREATE TABLE SCRIPT (a INT)", false},
+		{"CREATE VIEW function AS SELECT 1", false},
+		{"CREATE TABLE script_log (a INT)", false},
+		{"SELECT 'CREATE SCRIPT s AS'", false},
+		{"EXECUTE SCRIPT s()", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsScriptDefinition(test.query), test.query)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"single statement", "SELECT 1", []string{"SELECT 1"}},
+		{"semicolons", "SELECT 1;\nSELECT 2;\n", []string{"SELECT 1", "SELECT 2"}},
+		{"empty statements", " ; SELECT 1;;", []string{"SELECT 1"}},
+		{"literals", `SELECT ';', "a;b" FROM t; SELECT 'it''s;'`, []string{`SELECT ';', "a;b" FROM t`, `SELECT 'it''s;'`}},
+		{"comments", "SELECT 1 -- ;\n; /* ; */ SELECT 2; -- end", []string{"SELECT 1 -- ;", "/* ; */ SELECT 2"}},
+		{"script ended by slash",
+			"CREATE SCRIPT s AS\n  query([[DELETE FROM t]]);\n  output('done');\n/\nSELECT 1;",
+			[]string{"CREATE SCRIPT s AS\n  query([[DELETE FROM t]]);\n  output('done');", "SELECT 1"}},
+		{"python script with quotes",
+			"CREATE PYTHON3 SCALAR SCRIPT s() RETURNS INT AS\n# don't split; here\ndef run(ctx):\n  return 1\n  /\nEXECUTE SCRIPT s()",
+			[]string{"CREATE PYTHON3 SCALAR SCRIPT s() RETURNS INT AS\n# don't split; here\ndef run(ctx):\n  return 1", "EXECUTE SCRIPT s()"}},
+		{"script until end", "SELECT 1; CREATE FUNCTION f RETURN NUMBER IS BEGIN RETURN 1; END f;",
+			[]string{"SELECT 1", "CREATE FUNCTION f RETURN NUMBER IS BEGIN RETURN 1; END f;"}},
+		{"division is no terminator", "SELECT 4\n/ 2", []string{"SELECT 4\n/ 2"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, SplitStatements(test.text))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package itest_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/csv"
@@ -8,6 +9,7 @@ import (
 	"log"
 	"os"
 	"os/user"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"strings"
@@ -15,8 +17,10 @@ import (
 	"time"
 
 	"github.com/exasol/exasol-driver-go"
+	"github.com/exasol/exasol-driver-go/pkg/connection"
 	"github.com/exasol/exasol-driver-go/pkg/dsn"
-	"github.com/exasol/exasol-driver-go/pkg/integrationTesting"
+	"github.com/exasol/exasol-driver-go/pkg/exasoltest"
+	"github.com/exasol/exasol-driver-go/pkg/metadata"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/goleak"
@@ -28,7 +32,7 @@ import (
 type IntegrationTestSuite struct {
 	suite.Suite
 	ctx    context.Context
-	exasol *integrationTesting.DbTestSetup
+	exasol *exasoltest.Database
 	port   int
 	host   string
 }
@@ -42,14 +46,9 @@ func TestIntegrationSuite(t *testing.T) {
 
 func (suite *IntegrationTestSuite) SetupSuite() {
 	suite.ctx = context.Background()
-	var err error
-	suite.exasol = integrationTesting.StartDbSetup(&suite.Suite)
-	connectionInfo := suite.exasol.ConnectionInfo
-	if err != nil {
-		suite.FailNowf("setup failed", "failed to get connection info: %v", err)
-	}
-	suite.port = connectionInfo.Port
-	suite.host = connectionInfo.Host
+	suite.exasol = exasoltest.Start(suite.T())
+	suite.port = suite.exasol.Port
+	suite.host = suite.exasol.Host
 }
 
 func (suite *IntegrationTestSuite) TestConnect() {
@@ -77,7 +76,7 @@ func (suite *IntegrationTestSuite) TestConnection() {
 	}
 
 	var errorMsgEncryptionOff string
-	if suite.exasol.IsExasolVersion8() {
+	if suite.exasol.MajorVersion(suite.T()) == "8" {
 		errorMsgEncryptionOff = "EGOD-11: execution failed with SQL error code '08004' and message 'Connection exception - Only TLS connections are allowed.'"
 	} else {
 		errorMsgEncryptionOff = noError
@@ -302,6 +301,18 @@ func (suite *IntegrationTestSuite) TestBeginWithCancelledContext() {
 	suite.EqualError(err, "context canceled")
 }
 
+func (suite *IntegrationTestSuite) TestQueryPreservesNumberPrecision() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	var bigint int64
+	var decimal string
+	var small interface{}
+	err := database.QueryRow("SELECT CAST(9007199254740993 AS DECIMAL(18,0)), CAST(123456789012345678.12 AS DECIMAL(36,2)), CAST(42 AS DECIMAL(18,0))").Scan(&bigint, &decimal, &small)
+	suite.NoError(err)
+	suite.Equal(int64(9007199254740993), bigint)
+	suite.Equal("123456789012345678.12", decimal)
+	suite.Equal(float64(42), small)
+}
+
 func (suite *IntegrationTestSuite) TestSimpleImportStatement() {
 	database := suite.openConnection(suite.createDefaultConfig())
 	ctx := context.Background()
@@ -327,6 +338,307 @@ func (suite *IntegrationTestSuite) TestSimpleImportStatement() {
 	)
 }
 
+func (suite *IntegrationTestSuite) TestImportStatementConvertsEncoding() {
+	database := suite.openConnection(suite.createDefaultConfig().ImportEncoding("UTF-16"))
+	ctx := context.Background()
+	schemaName := "TEST_SCHEMA_ENCODING"
+	tableName := "TEST_TABLE"
+	_, _ = database.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s.%s (a int , b VARCHAR(20))", schemaName, tableName))
+
+	dir := suite.T().TempDir()
+	latin1File := filepath.Join(dir, "latin1.csv")
+	utf16File := filepath.Join(dir, "utf16.csv")
+	suite.NoError(os.WriteFile(latin1File, []byte("1;Gr\xfc\xdfe\n"), 0600))
+	suite.NoError(os.WriteFile(utf16File, []byte("\xff\xfe2\x00;\x00\xac\x20\n\x00"), 0600))
+
+	_, err := database.ExecContext(ctx, fmt.Sprintf(`IMPORT INTO %s.%s FROM LOCAL CSV FILE '%s' COLUMN SEPARATOR = ';' ENCODING = 'ISO-8859-1'`, schemaName, tableName, latin1File))
+	suite.NoError(err, "import with encoding clause should be successful")
+	_, err = database.ExecContext(ctx, fmt.Sprintf(`IMPORT INTO %s.%s FROM LOCAL CSV FILE '%s' COLUMN SEPARATOR = ';'`, schemaName, tableName, utf16File))
+	suite.NoError(err, "import with default encoding should be successful")
+
+	rows, _ := database.Query(fmt.Sprintf("SELECT * FROM %s.%s ORDER BY a", schemaName, tableName))
+	suite.assertTableResult(rows,
+		[]string{"A", "B"},
+		[][]interface{}{
+			{float64(1), "Grüße"},
+			{float64(2), "€"},
+		},
+	)
+}
+
+func (suite *IntegrationTestSuite) TestImportStatementWithGlobPattern() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	ctx := context.Background()
+	schemaName := "TEST_SCHEMA_GLOB"
+	tableName := "TEST_TABLE"
+	_, _ = database.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s.%s (a int , b VARCHAR(20))", schemaName, tableName))
+
+	dir := suite.T().TempDir()
+	for i := 1; i <= 3; i++ {
+		suite.NoError(os.WriteFile(filepath.Join(dir, fmt.Sprintf("part-%d.csv", i)), []byte(fmt.Sprintf("%d;part%d\n", i, i)), 0600))
+	}
+
+	result, err := database.ExecContext(ctx, fmt.Sprintf(`IMPORT INTO %s.%s FROM LOCAL CSV FILE '%s' COLUMN SEPARATOR = ';'`, schemaName, tableName, filepath.Join(dir, "part-*.csv")))
+	suite.NoError(err, "import should be successful")
+	affectedRows, _ := result.RowsAffected()
+	suite.Equal(int64(3), affectedRows)
+}
+
+func (suite *IntegrationTestSuite) TestImportBuilder() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	ctx := context.Background()
+	schemaName := "TEST_SCHEMA_IMPORT_BUILDER"
+	_, _ = database.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.ExecContext(ctx, "CREATE TABLE "+schemaName+".TEST_TABLE (a int, b VARCHAR(20))")
+
+	file := filepath.Join(suite.T().TempDir(), "customers (2023).csv")
+	suite.NoError(os.WriteFile(file, []byte("A;B\n1;x\n2;NULL\n"), 0600))
+
+	rowsAffected, err := exasol.ImportBuilder().IntoTable(schemaName+".TEST_TABLE", "A", "B").FromLocalFiles(file).
+		ColumnSeparator(';').Skip(1).Null("NULL").Exec(ctx, database)
+	suite.NoError(err, "import should be successful")
+	suite.Equal(int64(2), rowsAffected)
+
+	rows, _ := database.Query("SELECT * FROM " + schemaName + ".TEST_TABLE ORDER BY a")
+	suite.assertTableResult(rows,
+		[]string{"A", "B"},
+		[][]interface{}{
+			{float64(1), "x"},
+			{float64(2), nil},
+		},
+	)
+}
+
+func (suite *IntegrationTestSuite) TestExportBuilder() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	ctx := context.Background()
+	schemaName := "TEST_SCHEMA_EXPORT_BUILDER"
+	_, _ = database.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.ExecContext(ctx, "CREATE TABLE "+schemaName+".TEST_TABLE (a int, b VARCHAR(20))")
+	_, _ = database.ExecContext(ctx, "INSERT INTO "+schemaName+".TEST_TABLE VALUES (1, 'x'), (2, NULL)")
+
+	var data bytes.Buffer
+	rowsAffected, err := exasol.ExportBuilder().FromQuery("SELECT * FROM "+schemaName+".TEST_TABLE ORDER BY a").IntoWriter(&data).
+		ColumnSeparator(';').Null("NULL").WithColumnNames().Exec(ctx, database)
+	suite.NoError(err, "export should be successful")
+	suite.Equal(int64(2), rowsAffected)
+	suite.Equal("A;B\n1;x\n2;NULL\n", data.String())
+}
+
+func (suite *IntegrationTestSuite) TestExportBuilderAsParquet() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	ctx := context.Background()
+	schemaName := "TEST_SCHEMA_EXPORT_PARQUET"
+	_, _ = database.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.ExecContext(ctx, "CREATE TABLE "+schemaName+".TEST_TABLE (a DECIMAL(10,2), b VARCHAR(20), c BOOLEAN, d DATE, e TIMESTAMP, f DOUBLE)")
+	_, _ = database.ExecContext(ctx, "INSERT INTO "+schemaName+".TEST_TABLE VALUES (1.5, 'x', TRUE, '2023-01-02', '2023-01-02 03:04:05.678', 0.25), (NULL, NULL, NULL, NULL, NULL, NULL)")
+
+	file := filepath.Join(suite.T().TempDir(), "data.parquet")
+	rowsAffected, err := exasol.ExportBuilder().FromTable(schemaName+".TEST_TABLE").IntoLocalFiles(file).AsParquet().Exec(ctx, database)
+	suite.NoError(err, "export should be successful")
+	suite.Equal(int64(2), rowsAffected)
+	data, err := os.ReadFile(file)
+	suite.NoError(err)
+	suite.Equal("PAR1", string(data[:4]))
+	suite.Equal("PAR1", string(data[len(data)-4:]))
+}
+
+func (suite *IntegrationTestSuite) TestImportBuilderFromParquetFiles() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	ctx := context.Background()
+	schemaName := "TEST_SCHEMA_IMPORT_PARQUET"
+	_, _ = database.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.ExecContext(ctx, "CREATE TABLE "+schemaName+".SOURCE (a DECIMAL(10,2), b VARCHAR(20), c DATE, d TIMESTAMP)")
+	_, _ = database.ExecContext(ctx, "INSERT INTO "+schemaName+".SOURCE VALUES (1.5, 'x', '2023-01-02', '2023-01-02 03:04:05.678'), (NULL, NULL, NULL, NULL)")
+	_, _ = database.ExecContext(ctx, "CREATE TABLE "+schemaName+".TARGET (d TIMESTAMP, b VARCHAR(20), a DECIMAL(10,2), c DATE)")
+
+	file := filepath.Join(suite.T().TempDir(), "data.parquet")
+	_, err := exasol.ExportBuilder().FromTable(schemaName+".SOURCE").IntoLocalFiles(file).AsParquet().Exec(ctx, database)
+	suite.NoError(err, "export should be successful")
+	rowsAffected, err := exasol.ImportBuilder().IntoTable(schemaName+".TARGET").FromParquetFiles(file).Exec(ctx, database)
+	suite.NoError(err, "import should be successful")
+	suite.Equal(int64(2), rowsAffected)
+
+	rows, _ := database.Query("SELECT COUNT(*) FROM (SELECT a, b, c, d FROM " + schemaName + ".SOURCE MINUS SELECT a, b, c, d FROM " + schemaName + ".TARGET)")
+	suite.assertTableResult(rows, []string{"COUNT(*)"}, [][]interface{}{{float64(0)}})
+}
+
+func (suite *IntegrationTestSuite) TestWriteArrowStream() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	rows, err := database.Query("SELECT 1 AS A, 1.5 AS B, 'x' AS C, DATE '2023-01-02' AS D UNION ALL SELECT 2, NULL, NULL, NULL")
+	suite.NoError(err)
+	defer rows.Close()
+	var messages []exasol.ArrowMessage
+	convertedRows, err := exasol.ArrowRecordBatches(rows, 1, func(message exasol.ArrowMessage) error {
+		messages = append(messages, message)
+		return nil
+	})
+	suite.NoError(err)
+	suite.Equal(int64(2), convertedRows)
+	suite.Len(messages, 3)
+}
+
+func (suite *IntegrationTestSuite) TestQueryRawBytes() {
+	database := suite.openConnection(suite.createDefaultConfig().FetchSize(1).RawBytes(true))
+	rows, err := database.Query("SELECT 'row' || LEVEL FROM DUAL CONNECT BY LEVEL <= 1000")
+	suite.NoError(err)
+	defer rows.Close()
+	var value sql.RawBytes
+	count := 0
+	for rows.Next() {
+		suite.NoError(rows.Scan(&value))
+		count++
+		suite.True(strings.HasPrefix(string(value), "row"), string(value))
+	}
+	suite.NoError(rows.Err())
+	suite.Equal(1000, count)
+}
+
+func (suite *IntegrationTestSuite) TestWarmUp() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	database.SetMaxIdleConns(3)
+	suite.NoError(exasol.WarmUpWithOptions(context.Background(), database, 3, exasol.WarmUpOptions{SpreadHosts: true}))
+	suite.Equal(3, database.Stats().Idle)
+}
+
+func (suite *IntegrationTestSuite) TestExecPreparedBatch() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	ctx := context.Background()
+	schemaName := "TEST_SCHEMA_BATCH"
+	_, _ = database.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, err := database.ExecContext(ctx, "CREATE TABLE "+schemaName+".TEST_TABLE (a int PRIMARY KEY, b VARCHAR(20))")
+	suite.NoError(err)
+	conn, err := database.Conn(ctx)
+	suite.NoError(err)
+	defer conn.Close()
+
+	rows := [][]any{{1, "a"}, {2, "b"}, {3, "c"}}
+	result, err := exasol.ExecPreparedBatch(ctx, conn, "INSERT INTO "+schemaName+".TEST_TABLE VALUES (?, ?)", rows, 2)
+	suite.NoError(err)
+	suite.Equal([]int64{2, 1}, result.RowCounts())
+
+	deleteResult, err := exasol.ExecBatch(ctx, conn, []string{
+		"DELETE FROM " + schemaName + ".TEST_TABLE WHERE a = 1",
+		"DELETE FROM " + schemaName + ".TEST_TABLE WHERE a > 1",
+	})
+	suite.NoError(err)
+	suite.Equal([]int64{1, 2}, deleteResult.RowCounts())
+}
+
+func (suite *IntegrationTestSuite) TestExecPreparedBatchWithImport() {
+	database := suite.openConnection(suite.createDefaultConfig().BatchImportRows(1))
+	ctx := context.Background()
+	schemaName := "TEST_SCHEMA_BATCH_IMPORT"
+	_, _ = database.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, err := database.ExecContext(ctx, "CREATE TABLE "+schemaName+".TEST_TABLE (a int, b VARCHAR(20))")
+	suite.NoError(err)
+	conn, err := database.Conn(ctx)
+	suite.NoError(err)
+	defer conn.Close()
+
+	rows := [][]any{{"a,b", 1}, {nil, 2}, {"c", 3}}
+	result, err := exasol.ExecPreparedBatch(ctx, conn, "INSERT INTO "+schemaName+".TEST_TABLE (b, a) VALUES (?, ?)", rows, 2)
+	suite.NoError(err)
+	suite.Equal([]int64{2, 1}, result.RowCounts())
+
+	var count int
+	suite.NoError(database.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+schemaName+".TEST_TABLE WHERE (a = 1 AND b = 'a,b') OR (a = 2 AND b IS NULL) OR (a = 3 AND b = 'c')").Scan(&count))
+	suite.Equal(3, count)
+}
+
+func (suite *IntegrationTestSuite) TestCreateScriptWithSemicolons() {
+	database := suite.openConnection(suite.createDefaultConfig().PlaceholderStyle("colon"))
+	ctx := context.Background()
+	schemaName := "TEST_SCHEMA_SCRIPT"
+	_, _ = database.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
+	defer suite.cleanup(database, schemaName)
+	conn, err := database.Conn(ctx)
+	suite.NoError(err)
+	defer conn.Close()
+
+	statements := exasol.SplitStatements(`CREATE OR REPLACE PYTHON3 SCALAR SCRIPT ` + schemaName + `.GREET(name VARCHAR(20)) RETURNS VARCHAR(50) AS
+# isn't split; the dict {'a': 1} has no placeholders
+def run(ctx):
+    return 'Hello; ' + ctx.name
+/
+SELECT 1;`)
+	suite.Len(statements, 2)
+	_, err = exasol.ExecBatch(ctx, conn, statements)
+	suite.NoError(err)
+	var greeting string
+	suite.NoError(conn.QueryRowContext(ctx, "SELECT "+schemaName+".GREET('World')").Scan(&greeting))
+	suite.Equal("Hello; World", greeting)
+}
+
+func (suite *IntegrationTestSuite) TestGetSessionDetails() {
+	database := suite.openConnection(suite.createDefaultConfig().Schema("SYS"))
+	ctx := context.Background()
+	conn, err := database.Conn(ctx)
+	suite.NoError(err)
+	defer conn.Close()
+
+	details, err := exasol.GetSessionDetails(ctx, conn)
+	suite.NoError(err)
+	suite.NotZero(details.SessionID)
+	suite.Equal("SYS", details.User)
+	suite.Equal("SYS", details.CurrentSchema)
+	suite.True(details.Autocommit)
+	suite.False(details.LoginTime.IsZero())
+}
+
+func (suite *IntegrationTestSuite) TestGetServerVersion() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	ctx := context.Background()
+	conn, err := database.Conn(ctx)
+	suite.NoError(err)
+	defer conn.Close()
+
+	version, err := exasol.GetServerVersion(conn)
+	suite.NoError(err)
+	suite.True(version.AtLeast(connection.Version{Major: 7}))
+	supported, err := exasol.Supports(conn, connection.FeatureConsumerGroups)
+	suite.NoError(err)
+	suite.True(supported)
+}
+
+func (suite *IntegrationTestSuite) TestInsertStream() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	ctx := context.Background()
+	schemaName := "TEST_SCHEMA_STREAM"
+	_, _ = database.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.ExecContext(ctx, "CREATE TABLE "+schemaName+".TEST_TABLE (a int, b VARCHAR(20), c TIMESTAMP)")
+
+	rows := make(chan []any)
+	go func() {
+		defer close(rows)
+		for i := 0; i < 1000; i++ {
+			rows <- []any{i, fmt.Sprintf("row, \"%d\"", i), time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)}
+		}
+	}()
+	insertedRows, err := exasol.InsertStream(ctx, database, schemaName+".TEST_TABLE", []string{"a", "b", "c"}, rows)
+	suite.NoError(err)
+	suite.Equal(int64(1000), insertedRows)
+
+	result, _ := database.Query("SELECT a, b, TO_CHAR(c, 'YYYY-MM-DD HH24:MI:SS') AS c FROM " + schemaName + ".TEST_TABLE WHERE a = 42")
+	suite.assertTableResult(result,
+		[]string{"A", "B", "C"},
+		[][]interface{}{
+			{float64(42), "row, \"42\"", "2023-01-02 03:04:05"},
+		},
+	)
+}
+
 func (suite *IntegrationTestSuite) TestSimpleImportStatementBigFile() {
 	database := suite.openConnection(suite.createDefaultConfig())
 	ctx := context.Background()
@@ -369,6 +681,33 @@ func (suite *IntegrationTestSuite) TestSimpleImportStatementBigFile() {
 	)
 }
 
+func (suite *IntegrationTestSuite) TestExportIntoMultipleLocalFiles() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	ctx := context.Background()
+	schemaName := "TEST_SCHEMA_EXPORT"
+	tableName := "TEST_TABLE"
+	_, _ = database.ExecContext(ctx, "CREATE SCHEMA "+schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s.%s (a int , b VARCHAR(20))", schemaName, tableName))
+	_, err := database.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s.%s SELECT LEVEL, 'row' || LEVEL FROM DUAL CONNECT BY LEVEL <= 1000", schemaName, tableName))
+	suite.NoError(err, "insert should be successful")
+
+	dir := suite.T().TempDir()
+	files := []string{filepath.Join(dir, "part1.csv"), filepath.Join(dir, "part2.csv")}
+	result, err := database.ExecContext(ctx, fmt.Sprintf(`EXPORT %s.%s INTO LOCAL CSV FILE '%s' FILE '%s' COLUMN SEPARATOR = ';'`, schemaName, tableName, files[0], files[1]))
+	suite.NoError(err, "export should be successful")
+	affectedRows, _ := result.RowsAffected()
+	suite.Equal(int64(1000), affectedRows)
+
+	exportedRows := 0
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		suite.NoError(err, "exported file should exist")
+		exportedRows += strings.Count(string(content), "\n")
+	}
+	suite.Equal(1000, exportedRows)
+}
+
 // See https://github.com/exasol/exasol-driver-go/issues/79
 func (suite *IntegrationTestSuite) TestNoLeakingGoRoutineDuringFileImport() {
 	database := suite.openConnection(suite.createDefaultConfig())
@@ -518,6 +857,155 @@ func (suite *IntegrationTestSuite) TestQueryTimeoutExpired() {
 	suite.Nil(rows)
 }
 
+func (suite *IntegrationTestSuite) TestDryRun() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	schemaName := "TEST_SCHEMA_DRY_RUN"
+	_, _ = database.Exec("CREATE SCHEMA " + schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, _ = database.Exec("CREATE TABLE " + schemaName + ".TEST_TABLE(x INT)")
+	description, err := exasol.DryRun(context.Background(), database, "SELECT x FROM "+schemaName+".TEST_TABLE WHERE x = ?")
+	suite.NoError(err)
+	suite.Len(description.Parameters, 1)
+	suite.Len(description.Columns, 1)
+	suite.Equal("X", description.Columns[0].Name)
+	_, err = exasol.DryRun(context.Background(), database, "INSERT INTO "+schemaName+".TEST_TABLE VALUES (1)")
+	suite.NoError(err)
+	rows, _ := database.Query("SELECT COUNT(*) FROM " + schemaName + ".TEST_TABLE")
+	suite.assertSingleValueResult(rows, "0")
+}
+
+func (suite *IntegrationTestSuite) TestDryRunWithError() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	defer database.Close()
+	description, err := exasol.DryRun(context.Background(), database, "SELECT x FROM DOES_NOT_EXIST")
+	suite.ErrorContains(err, "object DOES_NOT_EXIST not found")
+	suite.Nil(description)
+}
+
+func (suite *IntegrationTestSuite) TestSubmitQuery() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	defer database.Close()
+	handle, err := exasol.SubmitQuery(context.Background(), database, "SELECT ? FROM DUAL", 42)
+	suite.NoError(err)
+	defer handle.Close()
+	rows, err := handle.Result(context.Background())
+	suite.NoError(err)
+	suite.Equal(exasol.QuerySucceeded, handle.Status())
+	suite.assertSingleValueResult(rows, "42")
+}
+
+func (suite *IntegrationTestSuite) TestCursor() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	defer database.Close()
+	ctx := context.Background()
+	conn, err := database.Conn(ctx)
+	onError(err)
+	defer conn.Close()
+	cursor, err := exasol.OpenCursor(ctx, conn, "SELECT LEVEL FROM DUAL CONNECT BY LEVEL <= ?", 10000)
+	suite.NoError(err)
+	defer cursor.Close()
+	suite.Equal(10000, cursor.NumRows())
+	rows, err := cursor.Fetch(ctx, 9990, 20)
+	suite.NoError(err)
+	suite.Len(rows, 10)
+	suite.EqualValues(9991, rows[0][0])
+	rows, err = cursor.Fetch(ctx, 0, 1)
+	suite.NoError(err)
+	suite.EqualValues(1, rows[0][0])
+}
+
+func (suite *IntegrationTestSuite) TestProfile() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	defer database.Close()
+	conn, err := database.Conn(context.Background())
+	onError(err)
+	defer conn.Close()
+	report, err := exasol.Profile(context.Background(), conn, "SELECT ? FROM DUAL", 42)
+	suite.NoError(err)
+	suite.Equal("SELECT", report.CommandName)
+	suite.NotEmpty(report.Parts)
+}
+
+func (suite *IntegrationTestSuite) TestDescribe() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	schemaName := "TEST_SCHEMA_DESCRIBE"
+	_, _ = database.Exec("CREATE SCHEMA " + schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, err := database.Exec("CREATE TABLE " + schemaName + ".TEST_TABLE(ID DECIMAL(18,0) IDENTITY NOT NULL, NAME VARCHAR(100) DEFAULT 'n/a')")
+	onError(err)
+	columns, err := metadata.Describe(context.Background(), database, schemaName, "TEST_TABLE")
+	suite.NoError(err)
+	suite.Len(columns, 2)
+	suite.Equal("ID", columns[0].Name)
+	suite.Equal(int64(18), columns[0].Precision)
+	suite.True(columns[0].Identity)
+	suite.False(columns[0].Nullable)
+	suite.Equal("NAME", columns[1].Name)
+	suite.Equal(int64(100), columns[1].Length)
+	suite.True(columns[1].Nullable)
+	suite.Equal("'n/a'", columns[1].Default.String)
+}
+
+func (suite *IntegrationTestSuite) TestDescribeMissingTable() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	defer database.Close()
+	_, err := metadata.Describe(context.Background(), database, "MISSING_SCHEMA", "MISSING_TABLE")
+	suite.EqualError(err, "E-EGOD-33: table 'MISSING_SCHEMA'.'MISSING_TABLE' not found")
+}
+
+func (suite *IntegrationTestSuite) TestListMetadata() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	schemaName := "TEST_SCHEMA_LIST_METADATA"
+	_, _ = database.Exec("CREATE SCHEMA " + schemaName)
+	defer suite.cleanup(database, schemaName)
+	_, err := database.Exec("CREATE TABLE " + schemaName + ".TEST_TABLE(ID DECIMAL(18,0), NAME VARCHAR(100))")
+	onError(err)
+	_, err = database.Exec("CREATE FUNCTION " + schemaName + ".TEST_FUNCTION(x DECIMAL) RETURN DECIMAL IS BEGIN RETURN x; END")
+	onError(err)
+	ctx := context.Background()
+
+	schemas, err := metadata.ListSchemas(ctx, database, schemaName)
+	suite.NoError(err)
+	suite.Equal([]metadata.Schema{{Name: schemaName, Owner: "SYS"}}, schemas)
+
+	tables, err := metadata.ListTables(ctx, database, schemaName, "")
+	suite.NoError(err)
+	suite.Len(tables, 1)
+	suite.Equal("TEST_TABLE", tables[0].Name)
+
+	columns, err := metadata.ListColumns(ctx, database, schemaName, "TEST_TABLE", "N%")
+	suite.NoError(err)
+	suite.Len(columns, 1)
+	suite.Equal("NAME", columns[0].Name)
+
+	functions, err := metadata.ListFunctions(ctx, database, schemaName, "")
+	suite.NoError(err)
+	suite.Len(functions, 1)
+	suite.Equal("TEST_FUNCTION", functions[0].Name)
+}
+
+func (suite *IntegrationTestSuite) TestCloneSession() {
+	database := suite.openConnection(suite.createDefaultConfig())
+	defer database.Close()
+	ctx := context.Background()
+	conn, err := database.Conn(ctx)
+	onError(err)
+	defer conn.Close()
+	_, err = conn.ExecContext(ctx, "ALTER SESSION SET TIME_ZONE = 'EUROPE/BERLIN'")
+	onError(err)
+	_, err = conn.ExecContext(ctx, "ALTER SESSION SET NLS_DATE_FORMAT = 'DD.MM.YYYY'")
+	onError(err)
+	clone, err := exasol.CloneSession(ctx, conn)
+	suite.NoError(err)
+	defer clone.Close()
+	rows, err := clone.Query("SELECT SESSIONTIMEZONE")
+	onError(err)
+	suite.assertSingleValueResult(rows, "EUROPE/BERLIN")
+	rows, err = clone.Query("SELECT TO_CHAR(DATE '2023-01-31')")
+	onError(err)
+	suite.assertSingleValueResult(rows, "31.01.2023")
+}
+
 func (suite *IntegrationTestSuite) assertSingleValueResult(rows *sql.Rows, expected string) {
 	rows.Next()
 	var testValue string
@@ -536,12 +1024,12 @@ func (suite *IntegrationTestSuite) cleanup(db *sql.DB, schemaName string) {
 func (suite *IntegrationTestSuite) TearDownSuite() {
 	defer goleak.VerifyNone(suite.T())
 	if suite.exasol != nil {
-		suite.exasol.StopDb()
+		suite.NoError(suite.exasol.Stop())
 	}
 }
 
 func (suite *IntegrationTestSuite) createDefaultConfig() *dsn.DSNConfigBuilder {
-	return exasol.NewConfig("sys", "exasol").ValidateServerCertificate(false).Host(suite.host).Port(suite.port)
+	return suite.exasol.Config()
 }
 
 func (suite *IntegrationTestSuite) openConnection(config *dsn.DSNConfigBuilder) *sql.DB {
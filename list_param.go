@@ -0,0 +1,25 @@
+package exasol
+
+import (
+	"database/sql/driver"
+	"reflect"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+)
+
+// In wraps the values of the given slice as a single parameter that is expanded into one placeholder per value,
+// e.g. for IN (?) clauses. It panics if values is not a slice or an array.
+// The parameter is only supported by Query and Exec of the database or connection, not by prepared statements.
+//
+//	rows, err := database.Query("SELECT * FROM CUSTOMERS WHERE ID IN (?)", exasol.In([]int{1, 2, 3}))
+func In(values interface{}) connection.ListParam {
+	slice := reflect.ValueOf(values)
+	if slice.Kind() != reflect.Slice && slice.Kind() != reflect.Array {
+		panic("exasol.In requires a slice or array, got " + slice.Kind().String())
+	}
+	list := make(connection.ListParam, slice.Len())
+	for i := range list {
+		list[i] = driver.Value(slice.Index(i).Interface())
+	}
+	return list
+}
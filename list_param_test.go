@@ -0,0 +1,30 @@
+package exasol
+
+import (
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListParamTestSuite struct {
+	suite.Suite
+}
+
+func TestListParamSuite(t *testing.T) {
+	suite.Run(t, new(ListParamTestSuite))
+}
+
+func (suite *ListParamTestSuite) TestInWithSlice() {
+	suite.Equal(connection.ListParam{1, 2, 3}, In([]int{1, 2, 3}))
+	suite.Equal(connection.ListParam{"a", "b"}, In([]string{"a", "b"}))
+	suite.Equal(connection.ListParam{}, In([]int{}))
+}
+
+func (suite *ListParamTestSuite) TestInWithArray() {
+	suite.Equal(connection.ListParam{int64(1), int64(2)}, In([2]int64{1, 2}))
+}
+
+func (suite *ListParamTestSuite) TestInPanicsWithoutSlice() {
+	suite.PanicsWithValue("exasol.In requires a slice or array, got int", func() { In(42) })
+}
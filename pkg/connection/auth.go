@@ -0,0 +1,199 @@
+package connection
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// Authenticator performs the authentication part of the login.
+// It starts the login with a login command sent via the exchange and sets the credentials of the auth request,
+// which the driver sends afterwards to complete the login.
+// Implement it for authentication methods that the driver does not support out of the box.
+type Authenticator interface {
+	Authenticate(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error
+}
+
+// AuthenticatorFunc adapts a function to the [Authenticator] interface.
+type AuthenticatorFunc func(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error
+
+// Authenticate calls f.
+func (f AuthenticatorFunc) Authenticate(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error {
+	return f(ctx, exchange, request)
+}
+
+// AuthExchange allows an [Authenticator] to exchange messages with the database.
+type AuthExchange interface {
+	// ProtocolVersion returns the websocket protocol version requested by the driver.
+	ProtocolVersion() int
+	// Send sends the request and decodes the response data into response, if it is not nil.
+	Send(ctx context.Context, request, response interface{}) error
+}
+
+// PasswordAuthenticator logs in with username and password. The password is encrypted with the public key of the database.
+type PasswordAuthenticator struct {
+	User     string
+	Password string
+}
+
+func (a PasswordAuthenticator) Authenticate(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error {
+	loginCommand := &types.LoginCommand{
+		Command:         types.Command{Command: "login"},
+		ProtocolVersion: exchange.ProtocolVersion(),
+	}
+	loginResponse := &types.PublicKeyResponse{}
+	err := exchange.Send(ctx, loginCommand, loginResponse)
+	if err != nil {
+		return err
+	}
+
+	pubKeyMod, _ := hex.DecodeString(loginResponse.PublicKeyModulus)
+	var modulus big.Int
+	modulus.SetBytes(pubKeyMod)
+
+	pubKeyExp, _ := strconv.ParseUint(loginResponse.PublicKeyExponent, 16, 32)
+
+	pubKey := rsa.PublicKey{
+		N: &modulus,
+		E: int(pubKeyExp),
+	}
+	encPass, err := rsa.EncryptPKCS1v15(rand.Reader, &pubKey, []byte(a.Password))
+	if err != nil {
+		logger.ErrorLogger.Print(errors.NewPasswordEncryptionError(err))
+		return errors.NewBadConnError(err)
+	}
+	request.Username = a.User
+	request.Password = base64.StdEncoding.EncodeToString(encPass)
+	return nil
+}
+
+// PasswordFileAuthenticator logs in with username and the password read from a file at each login,
+// e.g. a Docker or Kubernetes secret mount.
+type PasswordFileAuthenticator struct {
+	User string
+	Path string
+}
+
+func (a PasswordFileAuthenticator) Authenticate(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error {
+	password, err := readSecretFile(a.Path)
+	if err != nil {
+		return err
+	}
+	return PasswordAuthenticator{User: a.User, Password: password}.Authenticate(ctx, exchange, request)
+}
+
+// AccessTokenAuthenticator logs in with an OpenID access token.
+type AccessTokenAuthenticator struct {
+	Token string
+}
+
+func (a AccessTokenAuthenticator) Authenticate(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error {
+	if err := loginViaToken(ctx, exchange); err != nil {
+		return fmt.Errorf("access token login failed: %w", err)
+	}
+	request.AccessToken = a.Token
+	return nil
+}
+
+// RefreshTokenAuthenticator logs in with an OpenID refresh token.
+type RefreshTokenAuthenticator struct {
+	Token string
+}
+
+func (a RefreshTokenAuthenticator) Authenticate(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error {
+	if err := loginViaToken(ctx, exchange); err != nil {
+		return fmt.Errorf("refresh token login failed: %w", err)
+	}
+	request.RefreshToken = a.Token
+	return nil
+}
+
+// TokenFileAuthenticator logs in with an OpenID access token read from a file at each login,
+// so that a token rotated in a secret mount is picked up by new connections.
+type TokenFileAuthenticator struct {
+	Path string
+}
+
+func (a TokenFileAuthenticator) Authenticate(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error {
+	token, err := readSecretFile(a.Path)
+	if err != nil {
+		return err
+	}
+	return AccessTokenAuthenticator{Token: token}.Authenticate(ctx, exchange, request)
+}
+
+// readSecretFile returns the content of a secret file without the trailing line break.
+func readSecretFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.NewFileReadError(path, err)
+	}
+	secret := strings.TrimRight(string(content), "\r\n")
+	if secret == "" {
+		return "", errors.NewEmptySecretFile(path)
+	}
+	return secret, nil
+}
+
+func loginViaToken(ctx context.Context, exchange AuthExchange) error {
+	loginCommand := &types.LoginTokenCommand{
+		Command:         types.Command{Command: "loginToken"},
+		ProtocolVersion: exchange.ProtocolVersion(),
+	}
+	return exchange.Send(ctx, loginCommand, nil)
+}
+
+// newAuthenticator selects the authentication method by the credentials in the configuration.
+// Access tokens take precedence over refresh tokens and refresh tokens over username and password.
+// Credentials given directly take precedence over the ones read from files.
+func newAuthenticator(config *config.Config) Authenticator {
+	switch {
+	case config.AccessToken != "":
+		return AccessTokenAuthenticator{Token: config.AccessToken}
+	case config.TokenFile != "":
+		return TokenFileAuthenticator{Path: config.TokenFile}
+	case config.RefreshToken != "":
+		return RefreshTokenAuthenticator{Token: config.RefreshToken}
+	case config.Password == "" && config.PasswordFile != "":
+		return PasswordFileAuthenticator{User: config.User, Path: config.PasswordFile}
+	default:
+		return PasswordAuthenticator{User: config.User, Password: config.Password}
+	}
+}
+
+// authenticator returns the configured authenticator, the access token refreshed by another connection
+// or the one selected by the configuration.
+func (c *Connection) authenticator() Authenticator {
+	if c.Authenticator != nil {
+		return c.Authenticator
+	}
+	if token := c.TokenCache.get(); token != "" && usesAccessToken(c.Config) {
+		return AccessTokenAuthenticator{Token: token}
+	}
+	return newAuthenticator(c.Config)
+}
+
+// connectionAuthExchange exchanges authentication messages via the connection.
+type connectionAuthExchange struct {
+	connection *Connection
+}
+
+func (e connectionAuthExchange) ProtocolVersion() int {
+	return e.connection.Config.ApiVersion
+}
+
+func (e connectionAuthExchange) Send(ctx context.Context, request, response interface{}) error {
+	return e.connection.Send(ctx, request, response)
+}
@@ -0,0 +1,278 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestAuthSuite(t *testing.T) {
+	suite.Run(t, new(AuthTestSuite))
+}
+
+func (suite *AuthTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+func (suite *AuthTestSuite) TestSelectAuthenticatorByConfig() {
+	for _, test := range []struct {
+		config   config.Config
+		expected Authenticator
+	}{
+		{config.Config{User: "user", Password: "password"}, PasswordAuthenticator{User: "user", Password: "password"}},
+		{config.Config{AccessToken: "access"}, AccessTokenAuthenticator{Token: "access"}},
+		{config.Config{RefreshToken: "refresh"}, RefreshTokenAuthenticator{Token: "refresh"}},
+		{config.Config{AccessToken: "access", RefreshToken: "refresh", User: "user"}, AccessTokenAuthenticator{Token: "access"}},
+		{config.Config{User: "user", PasswordFile: "password.txt"}, PasswordFileAuthenticator{User: "user", Path: "password.txt"}},
+		{config.Config{User: "user", Password: "password", PasswordFile: "password.txt"}, PasswordAuthenticator{User: "user", Password: "password"}},
+		{config.Config{TokenFile: "token.txt", RefreshToken: "refresh"}, TokenFileAuthenticator{Path: "token.txt"}},
+		{config.Config{AccessToken: "access", TokenFile: "token.txt"}, AccessTokenAuthenticator{Token: "access"}},
+	} {
+		suite.Equal(test.expected, newAuthenticator(&test.config))
+	}
+}
+
+func (suite *AuthTestSuite) TestTokenFileAuthenticatorReadsTokenAtLogin() {
+	path := filepath.Join(suite.T().TempDir(), "token")
+	suite.NoError(os.WriteFile(path, []byte("first\n"), 0o600))
+	authenticator := TokenFileAuthenticator{Path: path}
+	request := &types.AuthCommand{}
+	suite.NoError(authenticator.Authenticate(context.Background(), &secretFileExchange{}, request))
+	suite.Equal("first", request.AccessToken)
+
+	suite.NoError(os.WriteFile(path, []byte("rotated\r\n"), 0o600))
+	suite.NoError(authenticator.Authenticate(context.Background(), &secretFileExchange{}, request))
+	suite.Equal("rotated", request.AccessToken)
+}
+
+func (suite *AuthTestSuite) TestReadSecretFileFails() {
+	directory := suite.T().TempDir()
+	_, err := readSecretFile(filepath.Join(directory, "missing"))
+	suite.ErrorContains(err, "E-EGOD-40: could not read file")
+	empty := filepath.Join(directory, "empty")
+	suite.NoError(os.WriteFile(empty, []byte("\n"), 0o600))
+	_, err = readSecretFile(empty)
+	suite.ErrorContains(err, "E-EGOD-69: secret file")
+}
+
+func (suite *AuthTestSuite) TestPasswordFileAuthenticatorFailsWithoutFile() {
+	authenticator := PasswordFileAuthenticator{User: "user", Path: filepath.Join(suite.T().TempDir(), "missing")}
+	err := authenticator.Authenticate(context.Background(), &secretFileExchange{}, &types.AuthCommand{})
+	suite.ErrorContains(err, "E-EGOD-40")
+}
+
+// secretFileExchange accepts all commands without a response.
+type secretFileExchange struct{}
+
+func (e *secretFileExchange) ProtocolVersion() int {
+	return 3
+}
+
+func (e *secretFileExchange) Send(context.Context, interface{}, interface{}) error {
+	return nil
+}
+
+func (suite *AuthTestSuite) TestConfiguredAuthenticatorTakesPrecedence() {
+	authenticator := AccessTokenAuthenticator{Token: "custom"}
+	conn := &Connection{Config: &config.Config{User: "user"}, Authenticator: authenticator}
+	suite.Equal(authenticator, conn.authenticator())
+}
+
+func (suite *AuthTestSuite) TestLoginWithCustomAuthenticator() {
+	suite.websocketMock.SimulateOKResponse(map[string]interface{}{"command": "loginCustom", "protocolVersion": 42}, map[string]string{"challenge": "abc"})
+	suite.websocketMock.SimulateOKResponseOnAnyMessage(types.AuthResponse{SessionID: 1})
+	conn := suite.createConnection()
+	conn.Authenticator = AuthenticatorFunc(func(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error {
+		response := map[string]string{}
+		err := exchange.Send(ctx, map[string]interface{}{"command": "loginCustom", "protocolVersion": exchange.ProtocolVersion()}, &response)
+		if err != nil {
+			return err
+		}
+		request.Username = "user"
+		request.Password = "response to " + response["challenge"]
+		return nil
+	})
+
+	suite.NoError(conn.Login(context.Background()))
+	suite.Equal(1, conn.SessionInfo().SessionID)
+	suite.Contains(lastWrittenMessage(suite.websocketMock), `"password":"response to abc"`)
+}
+
+func (suite *AuthTestSuite) TestLoginFailsWhenAuthenticatorFails() {
+	conn := suite.createConnection()
+	conn.Authenticator = AuthenticatorFunc(func(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error {
+		return fmt.Errorf("mock error")
+	})
+	suite.EqualError(conn.Login(context.Background()), "mock error")
+	suite.websocketMock.AssertNotCalled(suite.T(), "WriteMessage")
+}
+
+func (suite *AuthTestSuite) TestLoginTimeout() {
+	conn := suite.createConnection()
+	conn.Config.LoginTimeout = 10 * time.Millisecond
+	conn.Authenticator = AuthenticatorFunc(func(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	suite.EqualError(conn.Login(context.Background()), "E-EGOD-65: login did not complete within 10ms Check the connection to the database or increase logintimeout.")
+	suite.True(conn.IsClosed)
+}
+
+func (suite *AuthTestSuite) TestLoginTimeoutDoesNotReplaceCallerDeadline() {
+	conn := suite.createConnection()
+	conn.Config.LoginTimeout = time.Minute
+	conn.Authenticator = AuthenticatorFunc(func(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	suite.ErrorIs(conn.Login(ctx), context.DeadlineExceeded)
+}
+
+func (suite *AuthTestSuite) TestLoginWithinLoginTimeout() {
+	suite.websocketMock.SimulateOKResponseOnAnyMessage(types.AuthResponse{SessionID: 1})
+	conn := suite.createConnection()
+	conn.Config.LoginTimeout = time.Minute
+	conn.Authenticator = AuthenticatorFunc(func(ctx context.Context, exchange AuthExchange, request *types.AuthCommand) error {
+		return nil
+	})
+	suite.NoError(conn.Login(context.Background()))
+	suite.Equal(1, conn.SessionInfo().SessionID)
+}
+
+func (suite *AuthTestSuite) TestIsTokenExpiredError() {
+	suite.True(isTokenExpiredError(fmt.Errorf("failed to login: %w", errors.NewSQLError("08004", "access denied", nil))))
+	suite.False(isTokenExpiredError(fmt.Errorf("failed to login: %w", errors.NewSQLError("42000", "token expired", nil))))
+	suite.False(isTokenExpiredError(fmt.Errorf("access token expired")))
+}
+
+func (suite *AuthTestSuite) TestLoginRetriesWithTokenFromProvider() {
+	newWebsocket := suite.simulateExpiredTokenLogin()
+	conn := suite.createConnection()
+	conn.Config.AccessToken = "expired"
+	conn.DialFunc = func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) { return newWebsocket, nil }
+	conn.TokenProvider = func(ctx context.Context) (string, error) { return "new token", nil }
+
+	suite.NoError(conn.Login(context.Background()))
+	suite.Equal(2, conn.SessionInfo().SessionID)
+	suite.Contains(lastWrittenMessage(newWebsocket), `"accessToken":"new token"`)
+	suite.websocketMock.AssertCalled(suite.T(), "Close")
+}
+
+func (suite *AuthTestSuite) TestRefreshedTokenIsUsedByNextConnection() {
+	newWebsocket := suite.simulateExpiredTokenLogin()
+	conn := suite.createConnection()
+	conn.Config.AccessToken = "expired"
+	conn.DialFunc = func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) { return newWebsocket, nil }
+	conn.TokenProvider = func(ctx context.Context) (string, error) { return "new token", nil }
+	conn.TokenCache = &TokenCache{}
+	suite.NoError(conn.Login(context.Background()))
+
+	nextWebsocket := wsconn.CreateWebsocketConnectionMock()
+	nextWebsocket.SimulateOKResponseOnAnyMessage(struct{}{})
+	nextWebsocket.SimulateOKResponseOnAnyMessage(types.AuthResponse{SessionID: 3})
+	next := &Connection{Config: conn.Config, Ctx: context.Background(), IsClosed: true, websocket: nextWebsocket,
+		TokenCache: conn.TokenCache, TokenProvider: func(ctx context.Context) (string, error) {
+			suite.Fail("token provider must not be called")
+			return "", nil
+		}}
+
+	suite.NoError(next.Login(context.Background()))
+	suite.Equal(3, next.SessionInfo().SessionID)
+	suite.Contains(lastWrittenMessage(nextWebsocket), `"accessToken":"new token"`)
+}
+
+func (suite *AuthTestSuite) TestLoginRetriesWithRefreshToken() {
+	newWebsocket := suite.simulateExpiredTokenLogin()
+	conn := suite.createConnection()
+	conn.Config.AccessToken = "expired"
+	conn.Config.RefreshToken = "refresh"
+	conn.DialFunc = func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) { return newWebsocket, nil }
+
+	suite.NoError(conn.Login(context.Background()))
+	suite.Contains(lastWrittenMessage(newWebsocket), `"refreshToken":"refresh"`)
+}
+
+func (suite *AuthTestSuite) TestLoginFailsWhenTokenProviderFails() {
+	suite.simulateExpiredTokenLogin()
+	conn := suite.createConnection()
+	conn.Config.AccessToken = "expired"
+	conn.TokenProvider = func(ctx context.Context) (string, error) { return "", fmt.Errorf("mock error") }
+
+	suite.EqualError(conn.Login(context.Background()), "E-EGOD-45: access token expired and a new token could not be obtained: mock error")
+}
+
+func (suite *AuthTestSuite) TestLoginWithExpiredTokenFailsWithoutRefresh() {
+	suite.simulateExpiredTokenLogin()
+	conn := suite.createConnection()
+	conn.Config.AccessToken = "expired"
+
+	suite.EqualError(conn.Login(context.Background()), "failed to login: E-EGOD-11: execution failed with SQL error code '08004' and message 'access token expired'")
+	suite.websocketMock.AssertNotCalled(suite.T(), "Close")
+}
+
+func (suite *AuthTestSuite) TestPasswordLoginToSaaSFailsWithHint() {
+	suite.websocketMock.SimulateErrorResponseOnAnyMessage(types.Exception{Text: "login failed", SQLCode: "08004"})
+	conn := suite.createConnection()
+	conn.Config.Host = "abc.clusters.exasol.com"
+	conn.Config.User = "user"
+
+	err := conn.Login(context.Background())
+	suite.ErrorContains(err, "E-EGOD-47: password login to Exasol SaaS host 'abc.clusters.exasol.com' failed")
+	suite.ErrorContains(err, "personal access token")
+}
+
+func (suite *AuthTestSuite) TestTokenLoginToSaaSFailsWithoutHint() {
+	suite.websocketMock.SimulateErrorResponseOnAnyMessage(types.Exception{Text: "login failed", SQLCode: "08004"})
+	conn := suite.createConnection()
+	conn.Config.Host = "abc.clusters.exasol.com"
+	conn.Config.AccessToken = "token"
+
+	suite.EqualError(conn.Login(context.Background()), "access token login failed: E-EGOD-11: execution failed with SQL error code '08004' and message 'login failed'")
+}
+
+// simulateExpiredTokenLogin rejects the login on the current websocket and returns a new websocket accepting the login.
+func (suite *AuthTestSuite) simulateExpiredTokenLogin() *wsconn.WebsocketConnectionMock {
+	suite.websocketMock.SimulateOKResponseOnAnyMessage(struct{}{})
+	suite.websocketMock.SimulateErrorResponseOnAnyMessage(types.Exception{Text: "access token expired", SQLCode: "08004"})
+	suite.websocketMock.OnClose(nil)
+	newWebsocket := wsconn.CreateWebsocketConnectionMock()
+	newWebsocket.SimulateOKResponseOnAnyMessage(struct{}{})
+	newWebsocket.SimulateOKResponseOnAnyMessage(types.AuthResponse{SessionID: 2})
+	return newWebsocket
+}
+
+func lastWrittenMessage(websocketMock *wsconn.WebsocketConnectionMock) string {
+	var message []byte
+	for _, call := range websocketMock.Calls {
+		if call.Method == "WriteMessage" {
+			message = call.Arguments.Get(1).([]byte)
+		}
+	}
+	return string(message)
+}
+
+func (suite *AuthTestSuite) createConnection() *Connection {
+	return &Connection{
+		Config:    &config.Config{ApiVersion: 42, Host: "localhost", Port: 8563},
+		Ctx:       context.Background(),
+		IsClosed:  true,
+		websocket: suite.websocketMock,
+	}
+}
@@ -0,0 +1,112 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// BatchResult is the result of a batch of statements or chunks of rows.
+// Besides the total it contains the number of affected rows of each statement or chunk.
+type BatchResult struct {
+	rowCounts []int64
+}
+
+func (r *BatchResult) LastInsertId() (int64, error) {
+	return 0, errors.ErrNoLastInsertID
+}
+
+// RowsAffected returns the total number of affected rows of all statements or chunks.
+func (r *BatchResult) RowsAffected() (int64, error) {
+	var total int64
+	for _, count := range r.rowCounts {
+		total += count
+	}
+	return total, nil
+}
+
+// RowCounts returns the number of affected rows of each statement or chunk in the order of execution.
+func (r *BatchResult) RowCounts() []int64 {
+	return r.rowCounts
+}
+
+// ExecBatch executes the given statements one after the other and returns the number of affected rows of each.
+// The execution stops at the first failing statement with an *errors.BatchError containing its index.
+func (c *Connection) ExecBatch(ctx context.Context, queries []string) (*BatchResult, error) {
+	result := &BatchResult{rowCounts: make([]int64, 0, len(queries))}
+	for i, query := range queries {
+		if err := result.add(c.ExecContext(ctx, query, nil)); err != nil {
+			return nil, errors.NewBatchError(i, result.rowCounts, err)
+		}
+	}
+	return result, nil
+}
+
+// ExecPreparedBatch prepares the given statement once and executes it with the given rows of arguments in chunks of
+// up to chunkSize rows, all rows in a single chunk if chunkSize is not positive. It returns the number of affected
+// rows of each chunk. The execution stops at the first failing chunk with an *errors.BatchError containing its index.
+// Chunks of INSERT statements with only placeholders in their VALUES clause are uploaded with an IMPORT statement instead
+// if they exceed the number of rows or bytes configured with batchimportrows or batchimportbytes.
+func (c *Connection) ExecPreparedBatch(ctx context.Context, query string, rows [][]driver.NamedValue, chunkSize int) (*BatchResult, error) {
+	if chunkSize <= 0 {
+		chunkSize = len(rows)
+	}
+	result := &BatchResult{}
+	if len(rows) == 0 {
+		return result, nil
+	}
+	stmt, err := c.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, errors.NewBatchError(0, result.rowCounts, err)
+	}
+	statement := stmt.(*Statement)
+	defer statement.Close()
+	importTarget, _ := utils.GetInsertTarget(statement.query)
+	for chunk := 0; chunk*chunkSize < len(rows); chunk++ {
+		end := chunk*chunkSize + chunkSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		values, err := statement.bindRows(rows[chunk*chunkSize : end])
+		if err == nil {
+			err = result.add(statement.execChunk(ctx, importTarget, values))
+		}
+		if err != nil {
+			return nil, errors.NewBatchError(chunk, result.rowCounts, err)
+		}
+	}
+	return result, nil
+}
+
+func (r *BatchResult) add(result driver.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	r.rowCounts = append(r.rowCounts, count)
+	return nil
+}
+
+// bindRows binds the arguments of each row and concatenates them for executing the statement with multiple rows.
+func (s *Statement) bindRows(rows [][]driver.NamedValue) ([]driver.Value, error) {
+	if len(s.columns) == 0 {
+		return nil, errors.ErrInvalidValuesCount
+	}
+	var values []driver.Value
+	for _, row := range rows {
+		rowValues, err := s.bindArgs(row)
+		if err != nil {
+			return nil, err
+		}
+		if len(rowValues) != len(s.columns) {
+			return nil, errors.ErrInvalidValuesCount
+		}
+		values = append(values, rowValues...)
+	}
+	return values, nil
+}
@@ -0,0 +1,110 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+const batchImportTimestampFormat = "2006-01-02 15:04:05.000"
+
+// execChunk executes the statement with the given rows of arguments. If the statement inserts into the given target,
+// the rows are imported instead if they exceed the thresholds configured with batchimportrows and batchimportbytes.
+func (s *Statement) execChunk(ctx context.Context, importTarget string, values []driver.Value) (driver.Result, error) {
+	if importTarget != "" && s.connection.exceedsBatchImportThreshold(values, len(s.columns)) {
+		return s.connection.importBatch(ctx, importTarget, values, len(s.columns))
+	}
+	return s.execResult(ctx, values)
+}
+
+// exceedsBatchImportThreshold checks if the given rows of arguments exceed the number of rows or the approximate size
+// of values configured with batchimportrows and batchimportbytes. Strings and byte slices count with their length,
+// all other values with 8 bytes.
+func (c *Connection) exceedsBatchImportThreshold(values []driver.Value, columnCount int) bool {
+	if c.Config.BatchImportRows > 0 && len(values)/columnCount > c.Config.BatchImportRows {
+		return true
+	}
+	if c.Config.BatchImportBytes <= 0 {
+		return false
+	}
+	size := 0
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			size += len(v)
+		case []byte:
+			size += len(v)
+		default:
+			size += 8
+		}
+		if size > c.Config.BatchImportBytes {
+			return true
+		}
+	}
+	return false
+}
+
+// importBatch inserts the given rows of arguments into the target of an INSERT statement with an IMPORT statement.
+// The rows are converted to CSV while they are uploaded, so that they are not sent in a single message.
+func (c *Connection) importBatch(ctx context.Context, target string, values []driver.Value, columnCount int) (driver.Result, error) {
+	if hasListParam(values) {
+		return nil, errors.ErrInvalidListParam
+	}
+	values, err := c.convertNonFiniteParams(values)
+	if err != nil {
+		return nil, err
+	}
+	reader, writer := io.Pipe()
+	defer reader.Close()
+	go writeBatchCSV(writer, values, columnCount)
+	return c.ImportReader(ctx, batchImportQuery(target), reader)
+}
+
+func batchImportQuery(target string) string {
+	return fmt.Sprintf("IMPORT INTO %s FROM LOCAL CSV FILE 'batch.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '\"'", target)
+}
+
+// writeBatchCSV writes the values as CSV records of the given number of columns to the writer and closes it.
+// NULL values are written as empty fields, which the database imports as NULL.
+func writeBatchCSV(writer *io.PipeWriter, values []driver.Value, columnCount int) {
+	csvWriter := csv.NewWriter(writer)
+	record := make([]string, columnCount)
+	for row := 0; row < len(values); row += columnCount {
+		for i, value := range values[row : row+columnCount] {
+			record[i] = batchCSVField(value)
+		}
+		if err := csvWriter.Write(record); err != nil {
+			writer.CloseWithError(err)
+			return
+		}
+	}
+	csvWriter.Flush()
+	writer.CloseWithError(csvWriter.Error())
+}
+
+func batchCSVField(value driver.Value) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(batchImportTimestampFormat)
+	default:
+		return fmt.Sprint(v)
+	}
+}
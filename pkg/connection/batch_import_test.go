@@ -0,0 +1,52 @@
+package connection
+
+import (
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type BatchImportTestSuite struct {
+	suite.Suite
+}
+
+func TestBatchImportSuite(t *testing.T) {
+	suite.Run(t, new(BatchImportTestSuite))
+}
+
+func (suite *BatchImportTestSuite) TestThresholdsDisabledByDefault() {
+	conn := &Connection{Config: &config.Config{}}
+	suite.False(conn.exceedsBatchImportThreshold(make([]driver.Value, 100000), 2))
+}
+
+func (suite *BatchImportTestSuite) TestRowThreshold() {
+	conn := &Connection{Config: &config.Config{BatchImportRows: 2}}
+	suite.False(conn.exceedsBatchImportThreshold([]driver.Value{int64(1), "a", int64(2), "b"}, 2))
+	suite.True(conn.exceedsBatchImportThreshold([]driver.Value{int64(1), "a", int64(2), "b", int64(3), "c"}, 2))
+}
+
+func (suite *BatchImportTestSuite) TestByteThreshold() {
+	conn := &Connection{Config: &config.Config{BatchImportBytes: 20}}
+	suite.False(conn.exceedsBatchImportThreshold([]driver.Value{int64(1), "abcdefghij"}, 2))
+	suite.True(conn.exceedsBatchImportThreshold([]driver.Value{int64(1), []byte("abcdefghijklm")}, 2))
+}
+
+func (suite *BatchImportTestSuite) TestWriteBatchCSV() {
+	reader, writer := io.Pipe()
+	go writeBatchCSV(writer, []driver.Value{
+		int64(1), "a,b", 1.5, true, time.Date(2023, 1, 2, 10, 11, 12, 345000000, time.UTC),
+		int64(2), nil, 1e21, false, []byte("x\"y"),
+	}, 5)
+	data, err := io.ReadAll(reader)
+	suite.NoError(err)
+	suite.Equal("1,\"a,b\",1.5,true,2023-01-02 10:11:12.345\n2,,1000000000000000000000,false,\"x\"\"y\"\n", string(data))
+}
+
+func (suite *BatchImportTestSuite) TestBatchImportQuery() {
+	suite.Equal(`IMPORT INTO s.t (a, b) FROM LOCAL CSV FILE 'batch.csv' ENCODING = 'UTF-8' ROW SEPARATOR = 'LF' COLUMN SEPARATOR = ',' COLUMN DELIMITER = '"'`,
+		batchImportQuery("s.t (a, b)"))
+}
@@ -0,0 +1,46 @@
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type BatchTestSuite struct {
+	suite.Suite
+}
+
+func TestBatchSuite(t *testing.T) {
+	suite.Run(t, new(BatchTestSuite))
+}
+
+func (suite *BatchTestSuite) TestRowsAffectedIsTotal() {
+	result := &BatchResult{rowCounts: []int64{1, 2, 3}}
+	rowsAffected, err := result.RowsAffected()
+	suite.NoError(err)
+	suite.Equal(int64(6), rowsAffected)
+	suite.Equal([]int64{1, 2, 3}, result.RowCounts())
+}
+
+func (suite *BatchTestSuite) TestLastInsertIdNotSupported() {
+	_, err := (&BatchResult{}).LastInsertId()
+	suite.ErrorIs(err, errors.ErrNoLastInsertID)
+}
+
+func (suite *BatchTestSuite) TestExecPreparedBatchWithoutRows() {
+	result, err := (&Connection{}).ExecPreparedBatch(context.Background(), "INSERT INTO T VALUES (?)", nil, 10)
+	suite.NoError(err)
+	suite.Empty(result.RowCounts())
+}
+
+func (suite *BatchTestSuite) TestExecBatchReportsIndexOfFailedStatement() {
+	conn := &Connection{Config: &config.Config{}, IsClosed: true}
+	_, err := conn.ExecBatch(context.Background(), []string{"DELETE FROM T"})
+	var batchError *errors.BatchError
+	suite.ErrorAs(err, &batchError)
+	suite.Equal(0, batchError.Index)
+	suite.Empty(batchError.RowCounts)
+}
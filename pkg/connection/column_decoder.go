@@ -0,0 +1,199 @@
+package connection
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// fetchResponse is the response of a fetch command. The data is decoded with decodeColumns.
+type fetchResponse struct {
+	NumRows int             `json:"numRows"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// decodeColumns decodes the column-major result data of a fetch response into the given value buffers and returns them.
+// The buffers are reused, so values are only valid until the next call.
+// Numbers are converted like convertNumber, so that each value needs at most one allocation.
+// With rawBytes, strings of columns scanned as sql.RawBytes are returned as []byte referencing data if they contain
+// no escape sequences, so that they are only valid as long as data is not overwritten.
+func decodeColumns(data []byte, columns []types.SqlQueryColumn, values [][]driver.Value, rawBytes bool) ([][]driver.Value, error) {
+	decoder := &columnDecoder{data: data, rawBytes: rawBytes}
+	if err := decoder.expect('['); err != nil {
+		return nil, err
+	}
+	for column := 0; ; column++ {
+		if decoder.peek() == ']' && column == 0 {
+			decoder.pos++
+			return values[:0], nil
+		}
+		if column >= len(columns) {
+			return nil, decoder.syntaxError("more data columns than result set columns")
+		}
+		if column >= len(values) {
+			values = append(values, nil)
+		}
+		var err error
+		values[column], err = decoder.decodeColumn(values[column][:0], columns[column].DataType)
+		if err != nil {
+			return nil, err
+		}
+		switch decoder.next() {
+		case ',':
+		case ']':
+			return values[:column+1], nil
+		default:
+			return nil, decoder.syntaxError("expected ',' or ']'")
+		}
+	}
+}
+
+type columnDecoder struct {
+	data     []byte
+	pos      int
+	rawBytes bool
+}
+
+func (d *columnDecoder) decodeColumn(values []driver.Value, dataType types.SqlQueryColumnType) ([]driver.Value, error) {
+	if err := d.expect('['); err != nil {
+		return nil, err
+	}
+	if d.peek() == ']' {
+		d.pos++
+		return values, nil
+	}
+	numbers := columnNumberType(dataType)
+	for {
+		value, err := d.decodeValue(dataType.Type, numbers)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		switch d.next() {
+		case ',':
+		case ']':
+			return values, nil
+		default:
+			return nil, d.syntaxError("expected ',' or ']'")
+		}
+	}
+}
+
+func (d *columnDecoder) decodeValue(columnType string, numbers numberType) (driver.Value, error) {
+	switch d.peek() {
+	case 'n':
+		return nil, d.literal("null")
+	case 't':
+		return true, d.literal("true")
+	case 'f':
+		return false, d.literal("false")
+	case '"':
+		if d.rawBytes && rawBytesColumn(columnType) {
+			return d.decodeBytes()
+		}
+		return d.decodeString()
+	default:
+		start := d.pos
+		for d.pos < len(d.data) && strings.IndexByte("+-.0123456789eE", d.data[d.pos]) >= 0 {
+			d.pos++
+		}
+		if start == d.pos {
+			return nil, d.syntaxError("unexpected character")
+		}
+		return parseNumber(string(d.data[start:d.pos]), numbers), nil
+	}
+}
+
+func (d *columnDecoder) decodeString() (driver.Value, error) {
+	raw, escaped, err := d.scanString()
+	if err != nil {
+		return nil, err
+	}
+	if !escaped {
+		return string(raw[1 : len(raw)-1]), nil
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// decodeBytes decodes a string as []byte without copying it unless it contains escape sequences.
+func (d *columnDecoder) decodeBytes() (driver.Value, error) {
+	raw, escaped, err := d.scanString()
+	if err != nil {
+		return nil, err
+	}
+	if !escaped {
+		return raw[1 : len(raw)-1 : len(raw)-1], nil
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return []byte(value), nil
+}
+
+// scanString consumes a string and returns it including the quotes and whether it contains escape sequences.
+func (d *columnDecoder) scanString() ([]byte, bool, error) {
+	start := d.pos
+	d.pos++
+	escaped := false
+	for ; d.pos < len(d.data); d.pos++ {
+		switch d.data[d.pos] {
+		case '\\':
+			escaped = true
+			d.pos++
+		case '"':
+			d.pos++
+			return d.data[start:d.pos], escaped, nil
+		}
+	}
+	return nil, false, d.syntaxError("unterminated string")
+}
+
+func (d *columnDecoder) literal(literal string) error {
+	if !bytes.HasPrefix(d.data[d.pos:], []byte(literal)) {
+		return d.syntaxError("invalid literal")
+	}
+	d.pos += len(literal)
+	return nil
+}
+
+func (d *columnDecoder) skipWhitespace() {
+	for d.pos < len(d.data) && strings.IndexByte(" \t\r\n", d.data[d.pos]) >= 0 {
+		d.pos++
+	}
+}
+
+// peek returns the next non-whitespace character without consuming it or 0 at the end of the data.
+func (d *columnDecoder) peek() byte {
+	d.skipWhitespace()
+	if d.pos >= len(d.data) {
+		return 0
+	}
+	return d.data[d.pos]
+}
+
+// next consumes the next non-whitespace character.
+func (d *columnDecoder) next() byte {
+	c := d.peek()
+	d.pos++
+	return c
+}
+
+func (d *columnDecoder) expect(c byte) error {
+	if d.next() != c {
+		return d.syntaxError(fmt.Sprintf("expected '%c'", c))
+	}
+	return nil
+}
+
+func (d *columnDecoder) syntaxError(message string) error {
+	return fmt.Errorf("failed to parse result data at offset %d: %s", d.pos, message)
+}
@@ -0,0 +1,94 @@
+package connection
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type ColumnDecoderTestSuite struct {
+	suite.Suite
+}
+
+func TestColumnDecoderSuite(t *testing.T) {
+	suite.Run(t, new(ColumnDecoderTestSuite))
+}
+
+func columnsOfType(columnTypes ...string) []types.SqlQueryColumn {
+	columns := make([]types.SqlQueryColumn, len(columnTypes))
+	for i, columnType := range columnTypes {
+		columns[i] = types.SqlQueryColumn{DataType: types.SqlQueryColumnType{Type: columnType}}
+	}
+	return columns
+}
+
+func decimalColumn(precision, scale int64) types.SqlQueryColumn {
+	return types.SqlQueryColumn{DataType: types.SqlQueryColumnType{Type: "DECIMAL", Precision: &precision, Scale: &scale}}
+}
+
+func (suite *ColumnDecoderTestSuite) TestDecodeColumns() {
+	values, err := decodeColumns([]byte(` [ [1, 9007199254740993, null], ["a", "escaped \"quote\" ä", ""], [true, false, null],
+		[1.50, 2, null], [123456789012345678901234567890, 1, null], [0.1, -1.5E+3, 2] ] `),
+		[]types.SqlQueryColumn{decimalColumn(18, 0), columnsOfType("VARCHAR")[0], columnsOfType("BOOLEAN")[0],
+			decimalColumn(10, 2), decimalColumn(36, 0), columnsOfType("DOUBLE")[0]}, nil, false)
+	suite.NoError(err)
+	suite.Equal([][]driver.Value{
+		{int64(1), int64(9007199254740993), nil},
+		{"a", `escaped "quote" ä`, ""},
+		{true, false, nil},
+		{1.5, float64(2), nil},
+		{"123456789012345678901234567890", "1", nil},
+		{0.1, float64(-1500), float64(2)},
+	}, values)
+}
+
+func (suite *ColumnDecoderTestSuite) TestDecodeColumnsReusesBuffers() {
+	columns := columnsOfType("DECIMAL")
+	values, err := decodeColumns([]byte(`[[1, 2, 3]]`), columns, nil, false)
+	suite.NoError(err)
+	buffer := &values[0][0]
+	values, err = decodeColumns([]byte(`[[4, 5]]`), columns, values, false)
+	suite.NoError(err)
+	suite.Equal([][]driver.Value{{int64(4), int64(5)}}, values)
+	suite.Same(buffer, &values[0][0])
+}
+
+func (suite *ColumnDecoderTestSuite) TestDecodeRawBytes() {
+	data := []byte(`[["abc", "escaped \"quote\"", null], ["a", "b", "c"], [1, 2, 3]]`)
+	values, err := decodeColumns(data, columnsOfType("VARCHAR", "DOUBLE", "DECIMAL"), nil, true)
+	suite.NoError(err)
+	suite.Equal([][]driver.Value{
+		{[]byte("abc"), []byte(`escaped "quote"`), nil},
+		{"a", "b", "c"},
+		{int64(1), int64(2), int64(3)},
+	}, values)
+	copy(data[3:], "xyz")
+	suite.Equal([]byte("xyz"), values[0][0], "unescaped values reference the data")
+}
+
+func (suite *ColumnDecoderTestSuite) TestDecodeEmptyColumns() {
+	values, err := decodeColumns([]byte(`[[], []]`), columnsOfType("DECIMAL", "VARCHAR"), nil, false)
+	suite.NoError(err)
+	suite.Len(values, 2)
+	suite.Empty(values[0])
+	suite.Empty(values[1])
+}
+
+func (suite *ColumnDecoderTestSuite) TestDecodeInvalidData() {
+	for _, data := range []string{
+		``,
+		`{}`,
+		`[[1, 2]`,
+		`[[1 2]]`,
+		`[["unterminated]]`,
+		`[[nul]]`,
+		`[[x]]`,
+		`[[1], [2]]`,
+		`[["invalid \x escape"]]`,
+	} {
+		_, err := decodeColumns([]byte(data), columnsOfType("DECIMAL"), nil, false)
+		suite.Error(err, data)
+	}
+}
@@ -0,0 +1,64 @@
+package connection
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// commandQueue serializes the commands of a connection, because the websocket protocol processes one command at a time.
+// It limits the number of waiting commands and their waiting time, so that an overloaded connection fails fast
+// instead of blocking callers without bound. The zero value is ready to use.
+type commandQueue struct {
+	mutex   sync.Mutex // guards following
+	slot    chan struct{}
+	waiting int
+}
+
+// acquire waits until no other command is in flight and returns a function that must be called when the response was read.
+// It returns a [errors.DriverSaturatedError] if maxQueued commands are already waiting (0 = no limit)
+// or if the command waited longer than timeout (0 = no limit).
+func (q *commandQueue) acquire(ctx context.Context, maxQueued int, timeout time.Duration) (func(), error) {
+	q.mutex.Lock()
+	if q.slot == nil {
+		q.slot = make(chan struct{}, 1)
+	}
+	select {
+	case q.slot <- struct{}{}:
+		q.mutex.Unlock()
+		return q.release, nil
+	default:
+	}
+	if maxQueued > 0 && q.waiting >= maxQueued {
+		q.mutex.Unlock()
+		return nil, errors.NewQueueFullError(maxQueued)
+	}
+	q.waiting++
+	q.mutex.Unlock()
+	defer func() {
+		q.mutex.Lock()
+		q.waiting--
+		q.mutex.Unlock()
+	}()
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case q.slot <- struct{}{}:
+		return q.release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-expired:
+		return nil, errors.NewQueueTimeoutError(timeout)
+	}
+}
+
+func (q *commandQueue) release() {
+	<-q.slot
+}
@@ -0,0 +1,98 @@
+package connection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandQueueTestSuite struct {
+	suite.Suite
+	queue *commandQueue
+}
+
+func TestCommandQueueSuite(t *testing.T) {
+	suite.Run(t, new(CommandQueueTestSuite))
+}
+
+func (suite *CommandQueueTestSuite) SetupTest() {
+	suite.queue = &commandQueue{}
+}
+
+func (suite *CommandQueueTestSuite) TestAcquireIdleConnection() {
+	release, err := suite.queue.acquire(context.Background(), 1, time.Millisecond)
+	suite.NoError(err)
+	release()
+	release, err = suite.queue.acquire(context.Background(), 1, time.Millisecond)
+	suite.NoError(err)
+	release()
+}
+
+func (suite *CommandQueueTestSuite) TestWaitForRunningCommand() {
+	release := suite.acquire()
+	acquired := make(chan error)
+	go func() {
+		second, err := suite.queue.acquire(context.Background(), 0, 0)
+		if err == nil {
+			second()
+		}
+		acquired <- err
+	}()
+	select {
+	case <-acquired:
+		suite.Fail("command did not wait")
+	case <-time.After(20 * time.Millisecond):
+	}
+	release()
+	suite.NoError(<-acquired)
+}
+
+func (suite *CommandQueueTestSuite) TestQueueFull() {
+	release := suite.acquire()
+	defer release()
+	waiting := make(chan error)
+	go func() {
+		_, err := suite.queue.acquire(context.Background(), 1, 100*time.Millisecond)
+		waiting <- err
+	}()
+	suite.Eventually(func() bool { return suite.waitingCommands() == 1 }, time.Second, time.Millisecond)
+	_, err := suite.queue.acquire(context.Background(), 1, 0)
+	var saturated *errors.DriverSaturatedError
+	suite.ErrorAs(err, &saturated)
+	suite.Equal(1, saturated.MaxQueuedCommands)
+	suite.Error(<-waiting)
+}
+
+func (suite *CommandQueueTestSuite) TestWaitTimeout() {
+	release := suite.acquire()
+	defer release()
+	_, err := suite.queue.acquire(context.Background(), 0, 10*time.Millisecond)
+	var saturated *errors.DriverSaturatedError
+	suite.ErrorAs(err, &saturated)
+	suite.Equal(10*time.Millisecond, saturated.WaitTimeout)
+	suite.Equal(0, suite.waitingCommands())
+}
+
+func (suite *CommandQueueTestSuite) TestContextDoneWhileWaiting() {
+	release := suite.acquire()
+	defer release()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := suite.queue.acquire(ctx, 0, 0)
+	suite.ErrorIs(err, context.Canceled)
+}
+
+func (suite *CommandQueueTestSuite) acquire() func() {
+	release, err := suite.queue.acquire(context.Background(), 0, 0)
+	suite.Require().NoError(err)
+	return release
+}
+
+func (suite *CommandQueueTestSuite) waitingCommands() int {
+	suite.queue.mutex.Lock()
+	defer suite.queue.mutex.Unlock()
+	return suite.queue.waiting
+}
@@ -2,16 +2,14 @@ package connection
 
 import (
 	"context"
-	"crypto/rand"
-	"crypto/rsa"
 	"database/sql/driver"
-	"encoding/base64"
-	"encoding/hex"
+	goerrors "errors"
 	"fmt"
-	"math/big"
+	"io"
 	"os/user"
 	"runtime"
-	"strconv"
+	"sync"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/internal/config"
 	"github.com/exasol/exasol-driver-go/internal/utils"
@@ -28,10 +26,53 @@ type Connection struct {
 	websocket wsconn.WebsocketConnection
 	Ctx       context.Context
 	IsClosed  bool
+	Stats     *StatsCollector
+	// ShutdownGroup tracks the connection and its in-flight operations for a graceful shutdown, may be nil.
+	ShutdownGroup *ShutdownGroup
+	// SlowQueryCallback is called for statements exceeding the slow query threshold.
+	// If it is nil, slow queries are logged via the trace logger.
+	SlowQueryCallback SlowQueryCallback
+	// DialFunc opens the websocket connection to a host. If it is nil, a real websocket connection is opened.
+	DialFunc DialFunc
+	// DialOptions customize the handshake of real websocket connections, e.g. with a preconfigured dialer or headers.
+	DialOptions wsconn.DialOptions
+	// JSONCodec encodes commands and decodes responses. If it is nil, the StandardJSONCodec is used.
+	JSONCodec JSONCodec
+	// QueryInterceptors rewrite or reject the SQL text of statements before they are sent, in this order.
+	QueryInterceptors []QueryInterceptor
+	// StatementHooks observe each executed statement, in this order.
+	StatementHooks []StatementHooks
+	// Authenticator performs the authentication during login.
+	// If it is nil, the authentication method is selected by the credentials in the configuration.
+	Authenticator Authenticator
+	// TokenProvider returns a new access token when the configured access token expired.
+	// If it is nil, the refresh token of the configuration is used instead, if one is set.
+	TokenProvider TokenProvider
+	// TokenCache shares the access token obtained from the TokenProvider with other connections, may be nil.
+	TokenCache *TokenCache
+	// NoticeCallback is called for each response containing warnings or changed session attributes, if not nil.
+	NoticeCallback NoticeCallback
+	// Connector is the connector that opened the connection, e.g. for opening further connections like it, may be nil.
+	Connector driver.Connector
+	session   SessionInfo
+	// attributes contains the session attributes last returned by the database.
+	attributes types.Attributes
+	// warnings contains the warnings of the last response.
+	warnings []Warning
+	// closedByDatabase is true after the database closed the websocket.
+	closedByDatabase bool
+	// uncommittedChanges is true if statements were executed without autocommit since the last commit or rollback.
+	uncommittedChanges bool
+	// transaction is the transaction started with Begin until it is committed or rolled back.
+	transaction *Transaction
+	// commands serializes the commands sent by concurrent users of the connection, e.g. cursors and result sets.
+	commands commandQueue
+	// websocketMutex guards replacing the websocket against closing it from another goroutine during shutdown.
+	websocketMutex sync.Mutex
 }
 
 func (c *Connection) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
-	values, err := utils.NamedValuesToValues(args)
+	query, values, err := c.bindPlaceholders(query, args)
 	if err != nil {
 		return nil, err
 	}
@@ -39,13 +80,28 @@ func (c *Connection) QueryContext(ctx context.Context, query string, args []driv
 }
 
 func (c *Connection) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
-	values, err := utils.NamedValuesToValues(args)
+	query, values, err := c.bindPlaceholders(query, args)
 	if err != nil {
 		return nil, err
 	}
 	return c.exec(ctx, query, values)
 }
 
+// bindPlaceholders translates the placeholders of the configured placeholder style to ? placeholders
+// and returns the arguments in the order of the placeholders.
+func (c *Connection) bindPlaceholders(query string, args []driver.NamedValue) (string, []driver.Value, error) {
+	query, placeholders, err := utils.TranslatePlaceholders(query, c.Config.PlaceholderStyle)
+	if err != nil {
+		return "", nil, err
+	}
+	if placeholders == nil {
+		values, err := utils.NamedValuesToValues(args)
+		return query, values, err
+	}
+	values, err := utils.BindPlaceholders(placeholders, c.Config.PlaceholderStyle, args)
+	return query, values, err
+}
+
 func (c *Connection) Exec(query string, args []driver.Value) (driver.Result, error) {
 	return c.exec(context.Background(), query, args)
 }
@@ -57,14 +113,33 @@ func (c *Connection) Query(query string, args []driver.Value) (driver.Rows, erro
 func (c *Connection) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
 	if c.IsClosed {
 		logger.ErrorLogger.Print(errors.ErrClosed)
-		return nil, driver.ErrBadConn
+		return nil, errors.NewBadConnError(errors.ErrClosed)
+	}
+
+	query, placeholders, err := utils.TranslatePlaceholders(query, c.Config.PlaceholderStyle)
+	if err != nil {
+		return nil, err
+	}
+	query, err = c.interceptQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.checkReadOnly(query); err != nil {
+		return nil, err
+	}
+	endOperation, err := c.beginOperation()
+	if err != nil {
+		return nil, err
 	}
+	defer endOperation()
 
 	response, err := c.createPreparedStatement(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	return c.createStatement(response), nil
+	statement := c.createStatement(query, response)
+	statement.placeholders = placeholders
+	return statement, nil
 }
 
 func (c *Connection) createPreparedStatement(ctx context.Context, query string) (*types.CreatePreparedStatementResponse, error) {
@@ -72,7 +147,7 @@ func (c *Connection) createPreparedStatement(ctx context.Context, query string)
 
 	err := c.Send(ctx, &types.CreatePreparedStatementCommand{
 		Command: types.Command{Command: "createPreparedStatement"},
-		SQLText: query,
+		SQLText: tagQuery(ctx, query),
 	}, response)
 
 	if err != nil {
@@ -82,65 +157,132 @@ func (c *Connection) createPreparedStatement(ctx context.Context, query string)
 	return response, nil
 }
 
-func (c *Connection) createStatement(result *types.CreatePreparedStatementResponse) *Statement {
-	return NewStatement(c, result)
+func (c *Connection) createStatement(query string, result *types.CreatePreparedStatementResponse) *Statement {
+	statement := NewStatement(c, result)
+	statement.query = query
+	return statement
 }
 
 func (c *Connection) Prepare(query string) (driver.Stmt, error) {
 	return c.PrepareContext(context.Background(), query)
 }
 
+// checkReadOnly rejects all statements except SELECT if the connection is read-only.
+func (c *Connection) checkReadOnly(query string) error {
+	if c.Config.ReadOnly && !utils.IsReadOnlyQuery(query) {
+		return errors.ErrReadOnly
+	}
+	return nil
+}
+
+// Close sends the disconnect command and closes the websocket.
+// If the database does not respond within the configured close timeout, the websocket is closed forcibly.
 func (c *Connection) Close() error {
-	return c.close(context.Background())
+	ctx := context.Background()
+	if c.Config.CloseTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Config.CloseTimeout)
+		defer cancel()
+	}
+	return c.close(ctx)
 }
 
 func (c *Connection) Begin() (driver.Tx, error) {
 	if c.IsClosed {
 		logger.ErrorLogger.Print(errors.ErrClosed)
-		return nil, driver.ErrBadConn
+		return nil, errors.NewBadConnError(errors.ErrClosed)
 	}
 	if c.Config.Autocommit {
 		return nil, errors.ErrAutocommitEnabled
 	}
-	return NewTransaction(c), nil
+	if c.transaction != nil {
+		return nil, errors.ErrTransactionInProgress
+	}
+	c.transaction = NewTransaction(c)
+	return c.transaction, nil
 }
 
 func (c *Connection) query(ctx context.Context, query string, args []driver.Value) (driver.Rows, error) {
 	if c.IsClosed {
 		logger.ErrorLogger.Print(errors.ErrClosed)
-		return nil, driver.ErrBadConn
+		return nil, errors.NewBadConnError(errors.ErrClosed)
 	}
 
+	query, err := c.interceptQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.checkReadOnly(query); err != nil {
+		return nil, err
+	}
+	endOperation, err := c.beginOperation()
+	if err != nil {
+		return nil, err
+	}
+	query, args, err = expandListParams(query, args)
+	if err != nil {
+		endOperation()
+		return nil, err
+	}
+	query, args = c.interpolateParams(query, args)
+	return withOperation(endOperation)(c.executeQuery(ctx, query, args))
+}
+
+// interpolateParams replaces the placeholders with the values if enabled in the configuration,
+// so that the statement is executed directly without creating and closing a prepared statement.
+// Query and values are returned unchanged if the values can't be interpolated.
+func (c *Connection) interpolateParams(query string, args []driver.Value) (string, []driver.Value) {
+	if !c.Config.InterpolateParams || len(args) == 0 {
+		return query, args
+	}
+	if interpolated, ok := utils.InterpolateParams(query, args); ok {
+		return interpolated, nil
+	}
+	return query, args
+}
+
+func (c *Connection) executeQuery(ctx context.Context, query string, args []driver.Value) (driver.Rows, error) {
+	tracker := c.startSlowQueryTracker(query)
+
 	// No values provided, simple execute is enough
 	if len(args) == 0 {
-		return c.executeSimpleWithRows(ctx, query)
+		return c.executeSimpleWithRows(ctx, query, tracker)
 	}
 
 	response, err := c.createPreparedStatement(ctx, query)
 	if err != nil {
+		tracker.finish()
 		return nil, err
 	}
 
-	result, err := c.executePreparedStatement(ctx, response, args)
+	result, err := c.executePreparedStatement(ctx, query, response, args)
 	if err != nil {
+		tracker.finish()
 		return nil, err
 	}
-	return ToRow(result, c)
+	tracker.executed()
+	return toTrackedRows(result, c, tracker)
 }
 
-func (c *Connection) executeSimpleWithRows(ctx context.Context, query string) (driver.Rows, error) {
+func (c *Connection) executeSimpleWithRows(ctx context.Context, query string, tracker *slowQueryTracker) (driver.Rows, error) {
 	result, err := c.SimpleExec(ctx, query)
 	if err != nil {
+		tracker.finish()
 		return nil, err
 	}
-	return ToRow(result, c)
+	tracker.executed()
+	return toTrackedRows(result, c, tracker)
 }
 
-func (c *Connection) executePreparedStatement(ctx context.Context, s *types.CreatePreparedStatementResponse, args []driver.Value) (*types.SqlQueriesResponse, error) {
+func (c *Connection) executePreparedStatement(ctx context.Context, query string, s *types.CreatePreparedStatementResponse, args []driver.Value) (*types.SqlQueriesResponse, error) {
 	columns := s.ParameterData.Columns
 	if len(args)%len(columns) != 0 {
 		return nil, errors.ErrInvalidValuesCount
 	}
+	args, err := c.convertNonFiniteParams(args)
+	if err != nil {
+		return nil, err
+	}
 
 	data := make([][]interface{}, len(columns))
 	for i, arg := range args {
@@ -163,7 +305,13 @@ func (c *Connection) executePreparedStatement(ctx context.Context, s *types.Crea
 		},
 	}
 	result := &types.SqlQueriesResponse{}
-	err := c.Send(ctx, command, result)
+	c.beforeExecute(ctx, query)
+	start := time.Now()
+	err = c.Send(ctx, command, result)
+	duration := time.Since(start)
+	c.logQuery(query, args, duration, result, err)
+	c.afterExecute(ctx, query, duration, result, err)
+	c.statementExecuted()
 	if err != nil {
 		return nil, err
 	}
@@ -182,22 +330,93 @@ func (c *Connection) closePreparedStatement(ctx context.Context, s *types.Create
 }
 
 func (c *Connection) exec(ctx context.Context, query string, args []driver.Value) (driver.Result, error) {
-	if c.IsClosed {
-		logger.ErrorLogger.Print(errors.ErrClosed)
-		return nil, driver.ErrBadConn
+	return c.execWithImportSource(ctx, query, args, nil)
+}
+
+// ImportReader executes an IMPORT FROM LOCAL CSV statement that uploads the CSV data of the given reader
+// instead of the files of the statement. The statement must still contain a FILE clause.
+// The data is streamed to the database while reading, so the reader may block until more data is available.
+func (c *Connection) ImportReader(ctx context.Context, query string, reader io.Reader) (driver.Result, error) {
+	if !utils.IsImportQuery(query) {
+		return nil, errors.ErrInvalidImportQuery
 	}
-	result := make(chan driver.Result, 1)
-	errs, errctx := errgroup.WithContext(ctx)
+	return c.execWithImportSource(ctx, query, nil, reader)
+}
+
+func (c *Connection) execWithImportSource(ctx context.Context, query string, args []driver.Value, source io.Reader) (driver.Result, error) {
+	return c.execWithTransfer(ctx, query, args, func(query string) (localTransfer, error) {
+		return c.newTransfer(query, source)
+	})
+}
+
+// localTransfer uploads or downloads the local files of an import or export while the statement is executed.
+type localTransfer interface {
+	GetUpdatedQuery() string
+	Close()
+	transfer(ctx context.Context) error
+}
 
-	if utils.IsImportQuery(query) {
-		importStatement, err := NewImportStatement(query, c.Config.Host, c.Config.Port)
+// newTransfer parses an import or export of local files from the query or returns nil if it is neither.
+// The source is uploaded instead of the files of an import if it is not nil.
+func (c *Connection) newTransfer(query string, source io.Reader) (localTransfer, error) {
+	if !utils.IsImportQuery(query) && !utils.IsExportQuery(query) {
+		return nil, nil
+	}
+	hosts, err := c.transferHosts(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case utils.IsImportQuery(query):
+		importStatement, err := NewImportStatement(query, hosts, c.Config.Port, c.Config.ImportEncoding)
 		if err != nil {
 			return nil, err
 		}
+		importStatement.source = source
+		return importStatement, nil
+	case utils.IsExportQuery(query):
+		return NewExportStatement(query, hosts, c.Config.Port)
+	default:
+		return nil, nil
+	}
+}
+
+// execWithTransfer executes the statement and transfers the local files of the transfer created by newTransfer, if any.
+func (c *Connection) execWithTransfer(ctx context.Context, query string, args []driver.Value, newTransfer func(query string) (localTransfer, error)) (driver.Result, error) {
+	if c.IsClosed {
+		logger.ErrorLogger.Print(errors.ErrClosed)
+		return nil, errors.NewBadConnError(errors.ErrClosed)
+	}
+	query, err := c.interceptQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.checkReadOnly(query); err != nil {
+		return nil, err
+	}
+	endOperation, err := c.beginOperation()
+	if err != nil {
+		return nil, err
+	}
+	defer endOperation()
+	query, args, err = expandListParams(query, args)
+	if err != nil {
+		return nil, err
+	}
+	query, args = c.interpolateParams(query, args)
+	tracker := c.startSlowQueryTracker(query)
+	defer tracker.finish()
+	result := make(chan driver.Result, 1)
+	errs, errctx := errgroup.WithContext(ctx)
 
-		defer importStatement.Close()
-		query = importStatement.GetUpdatedQuery()
-		errs.Go(func() error { return importStatement.UploadFiles(errctx) })
+	transfer, err := newTransfer(query)
+	if err != nil {
+		return nil, err
+	}
+	if transfer != nil {
+		defer transfer.Close()
+		query = transfer.GetUpdatedQuery()
+		errs.Go(func() error { return transfer.transfer(errctx) })
 	}
 	// No values provided, simple execute is enough
 	if len(args) == 0 {
@@ -205,7 +424,7 @@ func (c *Connection) exec(ctx context.Context, query string, args []driver.Value
 	} else {
 		errs.Go(c.executePreparedStatementWrapper(errctx, query, args, result))
 	}
-	err := errs.Wait()
+	err = errs.Wait()
 	close(result)
 
 	if err != nil {
@@ -231,16 +450,16 @@ func (c *Connection) executePreparedStatementWrapper(ctx context.Context, query
 		prepResponse := &types.CreatePreparedStatementResponse{}
 		err := c.Send(ctx, &types.CreatePreparedStatementCommand{
 			Command: types.Command{Command: "createPreparedStatement"},
-			SQLText: query,
+			SQLText: tagQuery(ctx, query),
 		}, prepResponse)
 		if err != nil {
 			return err
 		}
-		resp, err := c.executePreparedStatement(ctx, prepResponse, args)
+		resp, err := c.executePreparedStatement(ctx, query, prepResponse, args)
 		if err != nil {
 			return err
 		}
-		r, err := ToResult(resp)
+		r, err := toResult(resp, c.jsonCodec())
 		if err != nil {
 			return err
 		}
@@ -254,19 +473,25 @@ func (c *Connection) executeSimpleWithResult(ctx context.Context, query string)
 	if err != nil {
 		return nil, err
 	}
-	return ToResult(result)
+	return toResult(result, c.jsonCodec())
 }
 
 func (c *Connection) SimpleExec(ctx context.Context, query string) (*types.SqlQueriesResponse, error) {
 	command := &types.SqlCommand{
 		Command: types.Command{Command: "execute"},
-		SQLText: query,
+		SQLText: tagQuery(ctx, query),
 		Attributes: types.Attributes{
 			ResultSetMaxRows: c.Config.ResultSetMaxRows,
 		},
 	}
 	result := &types.SqlQueriesResponse{}
+	c.beforeExecute(ctx, query)
+	start := time.Now()
 	err := c.Send(ctx, command, result)
+	duration := time.Since(start)
+	c.logQuery(query, nil, duration, result, err)
+	c.afterExecute(ctx, query, duration, result, err)
+	c.statementExecuted()
 	if err != nil {
 		return nil, err
 	}
@@ -279,9 +504,18 @@ func (c *Connection) SimpleExec(ctx context.Context, query string) (*types.SqlQu
 
 func (c *Connection) close(ctx context.Context) error {
 	c.IsClosed = true
-	err := c.Send(ctx, &types.Command{Command: "disconnect"}, nil)
+	c.ShutdownGroup.unregister(c)
+	if c.websocket == nil {
+		// Already closed, e.g. during shutdown
+		return nil
+	}
+	var err error
+	if !c.closedByDatabase {
+		err = c.disconnect(ctx)
+	}
 	closeError := c.websocket.Close()
-	c.websocket = nil
+	c.setWebsocket(nil)
+	c.Stats.inc(connectionsClosed)
 	if err != nil {
 		return err
 	}
@@ -291,14 +525,66 @@ func (c *Connection) close(ctx context.Context) error {
 	return nil
 }
 
+// disconnect sends the disconnect command and waits for the response until the context is done.
+// In contrast to Send it does not try to abort the command when the context is done.
+func (c *Connection) disconnect(ctx context.Context) error {
+	receiver, err := c.asyncSend(&types.Command{Command: "disconnect"})
+	if err != nil {
+		return err
+	}
+	result := make(chan error, 1)
+	go func() { result <- receiver(nil) }()
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return errors.NewCloseTimeout(c.Config.CloseTimeout)
+	}
+}
+
+// Login authenticates the session. The login including a retry with a refreshed token is limited by the login timeout.
 func (c *Connection) Login(ctx context.Context) error {
+	if c.Config.LoginTimeout > 0 {
+		loginCtx, cancel := context.WithTimeout(ctx, c.Config.LoginTimeout)
+		defer cancel()
+		err := c.authenticate(loginCtx)
+		if ctx.Err() == nil && goerrors.Is(loginCtx.Err(), context.DeadlineExceeded) {
+			c.IsClosed = true
+			return errors.NewLoginTimeout(c.Config.LoginTimeout)
+		}
+		return err
+	}
+	return c.authenticate(ctx)
+}
+
+func (c *Connection) authenticate(ctx context.Context) error {
+	authenticator := c.authenticator()
+	err := c.login(ctx, authenticator)
+	if err == nil {
+		return nil
+	}
+	if c.Authenticator == nil && usesAccessToken(c.Config) && isTokenExpiredError(err) {
+		return c.loginWithRefreshedToken(ctx, err)
+	}
+	if _, isPassword := authenticator.(PasswordAuthenticator); isPassword && utils.IsSaaSHost(c.Config.Host) {
+		return errors.NewSaaSPasswordLoginFailed(c.Config.Host, err)
+	}
+	return err
+}
+
+func (c *Connection) login(ctx context.Context, authenticator Authenticator) error {
 	hasCompression := c.Config.Compression
 	c.Config.Compression = false
 
-	authRequest, err := c.preLogin(ctx, hasCompression)
+	authRequest, err := c.preLogin(ctx, authenticator, hasCompression)
 	if err != nil {
 		return err
 	}
+	// Encrypting the password doesn't observe the context
+	if err = ctx.Err(); err != nil {
+		c.Config.Compression = hasCompression
+		return err
+	}
 
 	if osUser, err := user.Current(); err == nil && osUser != nil {
 		authRequest.ClientOsUsername = osUser.Username
@@ -313,11 +599,18 @@ func (c *Connection) Login(ctx context.Context) error {
 		return fmt.Errorf("failed to login: %w", err)
 	}
 	c.IsClosed = false
+	c.session = SessionInfo{
+		SessionID:       authResponse.SessionID,
+		ProtocolVersion: authResponse.ProtocolVersion,
+		DatabaseVersion: authResponse.ReleaseVersion,
+		DatabaseName:    authResponse.DatabaseName,
+	}
+	c.Stats.inc(logins)
 
 	return nil
 }
 
-func (c *Connection) preLogin(ctx context.Context, compression bool) (*types.AuthCommand, error) {
+func (c *Connection) preLogin(ctx context.Context, authenticator Authenticator, compression bool) (*types.AuthCommand, error) {
 	authRequest := &types.AuthCommand{
 		UseCompression: false,
 		ClientName:     c.Config.ClientName,
@@ -332,68 +625,8 @@ func (c *Connection) preLogin(ctx context.Context, compression bool) (*types.Aut
 			QueryTimeout:       c.Config.QueryTimeout,
 		},
 	}
-	if c.Config.AccessToken != "" {
-		err := c.prepareLoginViaToken(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("access token login failed: %w", err)
-		}
-		authRequest.AccessToken = c.Config.AccessToken
-	} else if c.Config.RefreshToken != "" {
-		err := c.prepareLoginViaToken(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("refresh token login failed: %w", err)
-		}
-		authRequest.RefreshToken = c.Config.RefreshToken
-	} else {
-		password, err := c.prepareLoginViaPassword(ctx)
-		if err != nil {
-			return nil, err
-		}
-		authRequest.Username = c.Config.User
-		authRequest.Password = password
+	if err := authenticator.Authenticate(ctx, connectionAuthExchange{connection: c}, authRequest); err != nil {
+		return nil, err
 	}
 	return authRequest, nil
 }
-
-func (c *Connection) prepareLoginViaPassword(ctx context.Context) (string, error) {
-	loginCommand := &types.LoginCommand{
-		Command:         types.Command{Command: "login"},
-		ProtocolVersion: c.Config.ApiVersion,
-	}
-	loginResponse := &types.PublicKeyResponse{}
-	err := c.Send(ctx, loginCommand, loginResponse)
-	if err != nil {
-		return "", err
-	}
-
-	pubKeyMod, _ := hex.DecodeString(loginResponse.PublicKeyModulus)
-	var modulus big.Int
-	modulus.SetBytes(pubKeyMod)
-
-	pubKeyExp, _ := strconv.ParseUint(loginResponse.PublicKeyExponent, 16, 32)
-
-	pubKey := rsa.PublicKey{
-		N: &modulus,
-		E: int(pubKeyExp),
-	}
-	password := []byte(c.Config.Password)
-	encPass, err := rsa.EncryptPKCS1v15(rand.Reader, &pubKey, password)
-	if err != nil {
-		logger.ErrorLogger.Print(errors.NewPasswordEncryptionError(err))
-		return "", driver.ErrBadConn
-	}
-	return base64.StdEncoding.EncodeToString(encPass), nil
-}
-
-func (c *Connection) prepareLoginViaToken(ctx context.Context) error {
-	c.Config.Compression = false
-	loginCommand := &types.LoginTokenCommand{
-		Command:         types.Command{Command: "loginToken"},
-		ProtocolVersion: c.Config.ApiVersion,
-	}
-	err := c.Send(ctx, loginCommand, nil)
-	if err != nil {
-		return err
-	}
-	return nil
-}
@@ -5,11 +5,13 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/internal/config"
 	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
 	"github.com/exasol/exasol-driver-go/pkg/errors"
 	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -42,6 +44,17 @@ func (suite *ConnectionTestSuite) TestConnectFails() {
 	suite.ErrorContains(err, `failed to connect to URL "ws://invalid:12345": dial tcp`)
 }
 
+func (suite *ConnectionTestSuite) TestConnectFailsCountsStats() {
+	conn := &Connection{
+		Config:   &config.Config{Host: "invalid1,invalid2", Port: 12345},
+		Ctx:      context.Background(),
+		IsClosed: true,
+		Stats:    NewStatsCollector(nil),
+	}
+	suite.Error(conn.Connect())
+	suite.Equal(Stats{Reconnects: 1, FailedHandshakes: 2}, conn.Stats.Snapshot())
+}
+
 func (suite *ConnectionTestSuite) TestQueryContextNamedParametersNotSupported() {
 	rows, err := suite.createOpenConnection().QueryContext(context.Background(), "query", []driver.NamedValue{{Name: "arg", Ordinal: 1, Value: "value"}})
 	suite.EqualError(err, "E-EGOD-7: named parameters not supported")
@@ -154,7 +167,7 @@ func (suite *ConnectionTestSuite) TestPrepareContextFailsClosed() {
 	conn := suite.createOpenConnection()
 	conn.IsClosed = true
 	stmt, err := conn.PrepareContext(context.Background(), "query")
-	suite.EqualError(err, driver.ErrBadConn.Error())
+	suite.ErrorIs(err, driver.ErrBadConn)
 	suite.Nil(stmt)
 }
 
@@ -209,6 +222,15 @@ func (suite *ConnectionTestSuite) TestCloseSuccess() {
 	suite.True(conn.IsClosed)
 }
 
+func (suite *ConnectionTestSuite) TestCloseCountsStats() {
+	suite.websocketMock.SimulateOKResponse(types.Command{Command: "disconnect"}, nil)
+	suite.websocketMock.OnClose(nil)
+	conn := suite.createOpenConnection()
+	conn.Stats = NewStatsCollector(nil)
+	suite.NoError(conn.Close())
+	suite.Equal(Stats{ConnectionsClosed: 1, CommandsSent: 1}, conn.Stats.Snapshot())
+}
+
 func (suite *ConnectionTestSuite) TestCloseDisconnectFails() {
 	suite.websocketMock.SimulateErrorResponse(types.Command{Command: "disconnect"}, mockException)
 	suite.websocketMock.OnClose(nil)
@@ -223,6 +245,23 @@ func (suite *ConnectionTestSuite) TestCloseWebsocketCloseFails() {
 	suite.EqualError(err, "failed to close websocket: mock error")
 }
 
+func (suite *ConnectionTestSuite) TestCloseTimeoutClosesWebsocketForcibly() {
+	response := make(chan time.Time)
+	defer close(response)
+	suite.websocketMock.OnWriteTextMessage(wsconn.JsonMarshall(types.Command{Command: "disconnect"}), nil)
+	suite.websocketMock.On("ReadMessage").WaitUntil(response).Return(websocket.TextMessage, []byte(`{"status": "ok"}`), nil).Once()
+	suite.websocketMock.OnClose(nil)
+	conn := suite.createOpenConnection()
+	conn.Config.CloseTimeout = 10 * time.Millisecond
+	conn.Stats = NewStatsCollector(nil)
+
+	err := conn.Close()
+	suite.EqualError(err, "E-EGOD-42: disconnect did not complete within 10ms, closed websocket forcibly")
+	suite.True(conn.IsClosed)
+	suite.Equal(uint64(1), conn.Stats.Snapshot().ConnectionsClosed)
+	suite.websocketMock.AssertCalled(suite.T(), "Close")
+}
+
 func (suite *ConnectionTestSuite) TestBeginSuccess() {
 	tx, err := suite.createOpenConnection().Begin()
 	suite.NoError(err)
@@ -233,7 +272,7 @@ func (suite *ConnectionTestSuite) TestBeginFailsWithConnectionClosed() {
 	conn := suite.createOpenConnection()
 	conn.IsClosed = true
 	tx, err := conn.Begin()
-	suite.EqualError(err, driver.ErrBadConn.Error())
+	suite.ErrorIs(err, driver.ErrBadConn)
 	suite.Nil(tx)
 }
 
@@ -249,7 +288,9 @@ func (suite *ConnectionTestSuite) TestQueryFailsConnectionClosed() {
 	conn := suite.createOpenConnection()
 	conn.IsClosed = true
 	rows, err := conn.query(context.Background(), "query", nil)
-	suite.EqualError(err, driver.ErrBadConn.Error())
+	suite.ErrorIs(err, driver.ErrBadConn)
+	suite.ErrorIs(err, errors.ErrClosed)
+	suite.EqualError(err, "driver: bad connection: E-EGOD-2: connection was closed")
 	suite.Nil(rows)
 }
 
@@ -262,6 +303,108 @@ func (suite *ConnectionTestSuite) TestQueryNoArgs() {
 	suite.NotNil(rows)
 }
 
+func (suite *ConnectionTestSuite) TestImplementsQueryerAndExecer() {
+	suite.Implements((*driver.QueryerContext)(nil), &Connection{})
+	suite.Implements((*driver.ExecerContext)(nil), &Connection{})
+}
+
+func (suite *ConnectionTestSuite) TestQueryWithInterpolatedParams() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "SELECT * FROM T WHERE A = 42 AND B = 'it''s'", Attributes: types.Attributes{}},
+		types.SqlQueryResponseResultSet{ResultType: "resultType", ResultSet: types.SqlQueryResponseResultSetData{}})
+	conn := suite.createOpenConnection()
+	conn.Config.InterpolateParams = true
+	rows, err := conn.QueryContext(context.Background(), "SELECT * FROM T WHERE A = ? AND B = ?", []driver.NamedValue{{Ordinal: 1, Value: int64(42)}, {Ordinal: 2, Value: "it's"}})
+	suite.NoError(err)
+	suite.NotNil(rows)
+}
+
+func (suite *ConnectionTestSuite) TestExecWithInterpolatedParams() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "INSERT INTO T VALUES (1.5, NULL)", Attributes: types.Attributes{}},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 1})
+	conn := suite.createOpenConnection()
+	conn.Config.InterpolateParams = true
+	result, err := conn.ExecContext(context.Background(), "INSERT INTO T VALUES (?, ?)", []driver.NamedValue{{Ordinal: 1, Value: 1.5}, {Ordinal: 2, Value: nil}})
+	suite.NoError(err)
+	rowsAffected, err := result.RowsAffected()
+	suite.NoError(err)
+	suite.Equal(int64(1), rowsAffected)
+}
+
+func (suite *ConnectionTestSuite) TestQueryWithColonPlaceholders() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "SELECT * FROM T WHERE A = 42 AND B = 'x' AND C = 42", Attributes: types.Attributes{}},
+		types.SqlQueryResponseResultSet{ResultType: "resultType", ResultSet: types.SqlQueryResponseResultSetData{}})
+	conn := suite.createOpenConnection()
+	conn.Config.InterpolateParams = true
+	conn.Config.PlaceholderStyle = "colon"
+	rows, err := conn.QueryContext(context.Background(), "SELECT * FROM T WHERE A = :id AND B = :1 AND C = :id",
+		[]driver.NamedValue{{Ordinal: 1, Value: "x"}, {Ordinal: 2, Name: "id", Value: int64(42)}})
+	suite.NoError(err)
+	suite.NotNil(rows)
+}
+
+func (suite *ConnectionTestSuite) TestExecWithDollarPlaceholders() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "INSERT INTO T VALUES (2, 1)", Attributes: types.Attributes{}},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 1})
+	conn := suite.createOpenConnection()
+	conn.Config.InterpolateParams = true
+	conn.Config.PlaceholderStyle = "dollar"
+	_, err := conn.ExecContext(context.Background(), "INSERT INTO T VALUES ($2, $1)", []driver.NamedValue{{Ordinal: 1, Value: int64(1)}, {Ordinal: 2, Value: int64(2)}})
+	suite.NoError(err)
+}
+
+func (suite *ConnectionTestSuite) TestQueryWithMissingPlaceholderArgumentFails() {
+	conn := suite.createOpenConnection()
+	conn.Config.PlaceholderStyle = "colon"
+	_, err := conn.QueryContext(context.Background(), "SELECT :name", []driver.NamedValue{{Ordinal: 1, Value: int64(1)}})
+	suite.EqualError(err, "E-EGOD-52: no argument given for placeholder ':name'")
+}
+
+func (suite *ConnectionTestSuite) TestPrepareContextWithColonPlaceholders() {
+	suite.websocketMock.SimulateOKResponse(
+		types.SqlCommand{
+			Command:    types.Command{Command: "createPreparedStatement"},
+			SQLText:    "SELECT * FROM T WHERE A = ? OR B = ?",
+			Attributes: types.Attributes{},
+		},
+		types.CreatePreparedStatementResponse{
+			ParameterData: types.ParameterData{NumColumns: 2, Columns: []types.SqlQueryColumn{{Name: "A"}, {Name: "B"}}}})
+	conn := suite.createOpenConnection()
+	conn.Config.PlaceholderStyle = "colon"
+	stmt, err := conn.PrepareContext(context.Background(), "SELECT * FROM T WHERE A = :name OR B = :name")
+	suite.NoError(err)
+	suite.Equal(-1, stmt.NumInput())
+	values, err := stmt.(*Statement).bindArgs([]driver.NamedValue{{Ordinal: 1, Name: "name", Value: "a"}})
+	suite.NoError(err)
+	suite.Equal([]driver.Value{"a", "a"}, values)
+}
+
+func (suite *ConnectionTestSuite) TestReadOnlyAllowsSelect() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "SELECT 1", Attributes: types.Attributes{}},
+		types.SqlQueryResponseResultSet{ResultType: "resultType", ResultSet: types.SqlQueryResponseResultSetData{}})
+	conn := suite.createOpenConnection()
+	conn.Config.ReadOnly = true
+	rows, err := conn.query(context.Background(), "SELECT 1", nil)
+	suite.NoError(err)
+	suite.NotNil(rows)
+}
+
+func (suite *ConnectionTestSuite) TestReadOnlyRejectsWrites() {
+	conn := suite.createOpenConnection()
+	conn.Config.ReadOnly = true
+	_, err := conn.query(context.Background(), "DELETE FROM T", nil)
+	suite.ErrorIs(err, errors.ErrReadOnly)
+	_, err = conn.exec(context.Background(), "INSERT INTO T VALUES (1)", nil)
+	suite.ErrorIs(err, errors.ErrReadOnly)
+	_, err = conn.PrepareContext(context.Background(), "UPDATE T SET A = ?")
+	suite.ErrorIs(err, errors.ErrReadOnly)
+	suite.websocketMock.AssertNotCalled(suite.T(), "WriteMessage")
+}
+
 func (suite *ConnectionTestSuite) TestQueryNoArgsFails() {
 	suite.websocketMock.SimulateErrorResponse(
 		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "query", Attributes: types.Attributes{}},
@@ -343,7 +486,7 @@ func (suite *ConnectionTestSuite) TestPasswordLoginFailsEncryptingPasswordReques
 		types.PublicKeyResponse{PublicKeyPem: "", PublicKeyModulus: "", PublicKeyExponent: ""})
 	conn := suite.createOpenConnection()
 	err := conn.Login(context.Background())
-	suite.EqualError(err, driver.ErrBadConn.Error())
+	suite.ErrorIs(err, driver.ErrBadConn)
 }
 
 func (suite *ConnectionTestSuite) TestPasswordLoginSuccess() {
@@ -357,6 +500,26 @@ func (suite *ConnectionTestSuite) TestPasswordLoginSuccess() {
 	suite.NoError(err)
 }
 
+func (suite *ConnectionTestSuite) TestLoginStoresSessionInfo() {
+	suite.simulatePasswordLoginSuccessWithResponse(types.AuthResponse{SessionID: 1234, ProtocolVersion: 3, ReleaseVersion: "7.1.0", DatabaseName: "DB"})
+	conn := suite.createOpenConnection()
+	suite.Equal(SessionInfo{}, conn.SessionInfo())
+
+	err := conn.Login(context.Background())
+	suite.NoError(err)
+	suite.Equal(SessionInfo{SessionID: 1234, ProtocolVersion: 3, DatabaseVersion: "7.1.0", DatabaseName: "DB"}, conn.SessionInfo())
+}
+
+func (suite *ConnectionTestSuite) TestPasswordLoginCountsStats() {
+	suite.simulatePasswordLoginSuccess()
+	conn := suite.createOpenConnection()
+	conn.Stats = NewStatsCollector(nil)
+
+	err := conn.Login(context.Background())
+	suite.NoError(err)
+	suite.Equal(Stats{Logins: 1, CommandsSent: 2}, conn.Stats.Snapshot())
+}
+
 func (suite *ConnectionTestSuite) TestAccessTokenLoginSuccess() {
 	suite.simulateTokenLoginSuccess()
 	conn := suite.createOpenConnection()
@@ -453,6 +616,10 @@ func (suite *ConnectionTestSuite) TestLoginFailureRestoresCompressionToFalse() {
 }
 
 func (suite *ConnectionTestSuite) simulatePasswordLoginSuccess() {
+	suite.simulatePasswordLoginSuccessWithResponse(types.AuthResponse{})
+}
+
+func (suite *ConnectionTestSuite) simulatePasswordLoginSuccessWithResponse(authResponse types.AuthResponse) {
 	suite.websocketMock.SimulateOKResponse(types.LoginCommand{Command: types.Command{Command: "login"}, ProtocolVersion: 42},
 		types.PublicKeyResponse{
 			PublicKeyPem: `-----BEGIN RSA PUBLIC KEY-----
@@ -462,7 +629,7 @@ uYIhswioGpmyPXr/wqz1NFkt5wMzm6sU3lFfCjD5SxU6arQ1zVY3AgMBAAE=
 -----END RSA PUBLIC KEY-----`,
 			PublicKeyModulus:  `AE27141B47E4404E170FB2AA06B55D2D46FDE0A45520580C3C4C5D5107B1432A01CC87D4CDA484A157659AB2A8FCF253E1A6F479F42BD62EA2D797DA5FD1B9FE00B2F31F9BD26E8C1D756E86E4F62B082EEB4A31F749ECF9AEB98221B308A81A99B23D7AFFC2ACF534592DE703339BAB14DE515F0A30F94B153A6AB435CD5637`,
 			PublicKeyExponent: "010001"})
-	suite.websocketMock.SimulateOKResponseOnAnyMessage(types.AuthResponse{})
+	suite.websocketMock.SimulateOKResponseOnAnyMessage(authResponse)
 }
 
 func (suite *ConnectionTestSuite) simulatePasswordLoginFailure(exception *types.Exception) {
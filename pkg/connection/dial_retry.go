@@ -0,0 +1,56 @@
+package connection
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+)
+
+// Delays between dial retries used if the configuration doesn't set them.
+const (
+	defaultDialRetryDelay    = 100 * time.Millisecond
+	defaultDialRetryMaxDelay = 5 * time.Second
+)
+
+// dialWithRetries dials the configured hosts and retries with exponential backoff as configured if none of them is
+// reachable, e.g. during a short network interruption or a rolling restart of the cluster.
+// Each retry resolves the hosts again, so that it picks up changed DNS records.
+func (c *Connection) dialWithRetries() error {
+	err := c.dialConfiguredHosts()
+	delay, maxDelay := c.dialRetryDelays()
+	for retry := 1; err != nil && retry <= c.Config.DialRetries; retry++ {
+		wait := delay
+		if c.Config.DialRetryJitter {
+			wait = time.Duration(rand.Int63n(int64(delay) + 1)) //nolint:gosec
+		}
+		logger.TraceLogger.Printf("no host reachable, dial retry %d of %d in %s: %v", retry, c.Config.DialRetries, wait, err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-c.Ctx.Done():
+			timer.Stop()
+			return c.Ctx.Err()
+		case <-timer.C:
+		}
+		err = c.dialConfiguredHosts()
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+	return err
+}
+
+func (c *Connection) dialRetryDelays() (time.Duration, time.Duration) {
+	delay, maxDelay := c.Config.DialRetryDelay, c.Config.DialRetryMaxDelay
+	if delay <= 0 {
+		delay = defaultDialRetryDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultDialRetryMaxDelay
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay, maxDelay
+}
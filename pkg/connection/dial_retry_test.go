@@ -0,0 +1,89 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/stretchr/testify/suite"
+)
+
+type DialRetryTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+	dialedHosts   []string
+}
+
+func TestDialRetrySuite(t *testing.T) {
+	suite.Run(t, new(DialRetryTestSuite))
+}
+
+func (suite *DialRetryTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+	suite.dialedHosts = nil
+}
+
+func (suite *DialRetryTestSuite) TestDialWithoutRetries() {
+	conn := suite.createConnection(config.Config{Host: "host1,host2"}, 10)
+	suite.EqualError(conn.Connect(), "dial failed")
+	suite.Len(suite.dialedHosts, 2)
+}
+
+func (suite *DialRetryTestSuite) TestRetryAllHostsUntilOneIsReachable() {
+	conn := suite.createConnection(config.Config{Host: "host1,host2", DialRetries: 3, DialRetryDelay: time.Millisecond}, 4)
+	suite.NoError(conn.Connect())
+	suite.Len(suite.dialedHosts, 5)
+	suite.ElementsMatch([]string{"host1:8563", "host2:8563"}, suite.dialedHosts[:2])
+	suite.Same(suite.websocketMock, conn.websocket)
+}
+
+func (suite *DialRetryTestSuite) TestFailAfterLastRetry() {
+	conn := suite.createConnection(config.Config{Host: "host", DialRetries: 2, DialRetryDelay: time.Millisecond, DialRetryJitter: true}, 10)
+	suite.EqualError(conn.Connect(), "dial failed")
+	suite.Len(suite.dialedHosts, 3)
+}
+
+func (suite *DialRetryTestSuite) TestStopRetryingWhenContextIsCancelled() {
+	conn := suite.createConnection(config.Config{Host: "host", DialRetries: 5, DialRetryDelay: time.Minute}, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	conn.Ctx = ctx
+	suite.ErrorIs(conn.Connect(), context.Canceled)
+	suite.Len(suite.dialedHosts, 1)
+}
+
+func (suite *DialRetryTestSuite) TestDialRetryDelays() {
+	for _, test := range []struct {
+		delay, maxDelay                 time.Duration
+		expectedDelay, expectedMaxDelay time.Duration
+	}{
+		{0, 0, 100 * time.Millisecond, 5 * time.Second},
+		{time.Second, 0, time.Second, 5 * time.Second},
+		{time.Minute, 10 * time.Second, 10 * time.Second, 10 * time.Second},
+	} {
+		conn := &Connection{Config: &config.Config{DialRetryDelay: test.delay, DialRetryMaxDelay: test.maxDelay}}
+		delay, maxDelay := conn.dialRetryDelays()
+		suite.Equal(test.expectedDelay, delay)
+		suite.Equal(test.expectedMaxDelay, maxDelay)
+	}
+}
+
+// createConnection returns a connection whose first failedDials dial attempts fail.
+func (suite *DialRetryTestSuite) createConnection(cfg config.Config, failedDials int) *Connection {
+	cfg.Port = 8563
+	return &Connection{
+		Config: &cfg,
+		Ctx:    context.Background(),
+		DialFunc: func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+			suite.dialedHosts = append(suite.dialedHosts, url.Host)
+			if len(suite.dialedHosts) <= failedDials {
+				return nil, fmt.Errorf("dial failed")
+			}
+			return suite.websocketMock, nil
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package connection
+
+import (
+	"context"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// StatementDescription describes a statement that was compiled by the database without executing it.
+type StatementDescription struct {
+	Parameters []types.SqlQueryColumn // Parameters of the statement, empty if the statement has no placeholders
+	Columns    []types.SqlQueryColumn // Columns of the result set, empty if the statement does not return a result set
+}
+
+// DryRun lets the database compile the given statement without executing it.
+// This validates syntax, referenced objects and privileges and returns the parameter and result set metadata.
+func (c *Connection) DryRun(ctx context.Context, query string) (description *StatementDescription, err error) {
+	if c.IsClosed {
+		logger.ErrorLogger.Print(errors.ErrClosed)
+		return nil, errors.NewBadConnError(errors.ErrClosed)
+	}
+	response, err := c.createPreparedStatement(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := c.closePreparedStatement(ctx, response); err == nil && closeErr != nil {
+			description, err = nil, closeErr
+		}
+	}()
+	description = &StatementDescription{Parameters: response.ParameterData.Columns}
+	if response.NumResults > 0 {
+		resultSet := &types.SqlQueryResponseResultSet{}
+		if err = c.jsonCodec().Unmarshal(response.Results[0], resultSet); err != nil {
+			return nil, err
+		}
+		description.Columns = resultSet.ResultSet.Columns
+	}
+	return description, nil
+}
@@ -0,0 +1,93 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type DryRunTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestDryRunSuite(t *testing.T) {
+	suite.Run(t, new(DryRunTestSuite))
+}
+
+func (suite *DryRunTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+func (suite *DryRunTestSuite) TestDryRunFailsClosed() {
+	conn := suite.createOpenConnection()
+	conn.IsClosed = true
+	description, err := conn.DryRun(context.Background(), "query")
+	suite.ErrorIs(err, driver.ErrBadConn)
+	suite.Nil(description)
+}
+
+func (suite *DryRunTestSuite) TestDryRunFailsCompiling() {
+	suite.websocketMock.SimulateErrorResponse(types.CreatePreparedStatementCommand{
+		Command: types.Command{Command: "createPreparedStatement"}, SQLText: "query"}, mockException)
+	description, err := suite.createOpenConnection().DryRun(context.Background(), "query")
+	suite.EqualError(err, mockExceptionError(mockException))
+	suite.Nil(description)
+}
+
+func (suite *DryRunTestSuite) TestDryRunWithResultSet() {
+	parameter := types.SqlQueryColumn{Name: "param", DataType: types.SqlQueryColumnType{Type: "DECIMAL"}}
+	column := types.SqlQueryColumn{Name: "COL", DataType: types.SqlQueryColumnType{Type: "VARCHAR"}}
+	suite.websocketMock.SimulateOKResponse(types.CreatePreparedStatementCommand{
+		Command: types.Command{Command: "createPreparedStatement"}, SQLText: "query"},
+		types.CreatePreparedStatementResponse{
+			StatementHandle: 17,
+			ParameterData:   types.ParameterData{NumColumns: 1, Columns: []types.SqlQueryColumn{parameter}},
+			SqlQueriesResponse: types.SqlQueriesResponse{NumResults: 1, Results: []json.RawMessage{wsconn.JsonMarshall(
+				types.SqlQueryResponseResultSet{ResultType: "resultSet", ResultSet: types.SqlQueryResponseResultSetData{
+					NumColumns: 1, Columns: []types.SqlQueryColumn{column}}})}}})
+	suite.websocketMock.SimulateOKResponse(types.ClosePreparedStatementCommand{Command: types.Command{Command: "closePreparedStatement"}, StatementHandle: 17}, nil)
+
+	description, err := suite.createOpenConnection().DryRun(context.Background(), "query")
+	suite.NoError(err)
+	suite.Equal(&StatementDescription{Parameters: []types.SqlQueryColumn{parameter}, Columns: []types.SqlQueryColumn{column}}, description)
+}
+
+func (suite *DryRunTestSuite) TestDryRunWithoutResultSet() {
+	suite.websocketMock.SimulateOKResponse(types.CreatePreparedStatementCommand{
+		Command: types.Command{Command: "createPreparedStatement"}, SQLText: "query"},
+		types.CreatePreparedStatementResponse{StatementHandle: 17})
+	suite.websocketMock.SimulateOKResponse(types.ClosePreparedStatementCommand{Command: types.Command{Command: "closePreparedStatement"}, StatementHandle: 17}, nil)
+
+	description, err := suite.createOpenConnection().DryRun(context.Background(), "query")
+	suite.NoError(err)
+	suite.Equal(&StatementDescription{}, description)
+}
+
+func (suite *DryRunTestSuite) TestDryRunClosesStatementWhenResultSetIsInvalid() {
+	suite.websocketMock.SimulateOKResponse(types.CreatePreparedStatementCommand{
+		Command: types.Command{Command: "createPreparedStatement"}, SQLText: "query"},
+		types.CreatePreparedStatementResponse{StatementHandle: 17,
+			SqlQueriesResponse: types.SqlQueriesResponse{NumResults: 1, Results: []json.RawMessage{json.RawMessage(`"invalid"`)}}})
+	suite.websocketMock.SimulateOKResponse(types.ClosePreparedStatementCommand{Command: types.Command{Command: "closePreparedStatement"}, StatementHandle: 17}, nil)
+
+	description, err := suite.createOpenConnection().DryRun(context.Background(), "query")
+	suite.ErrorContains(err, "cannot unmarshal string")
+	suite.Nil(description)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *DryRunTestSuite) createOpenConnection() *Connection {
+	return &Connection{
+		Config:    &config.Config{Host: "invalid", Port: 12345},
+		Ctx:       context.Background(),
+		IsClosed:  false,
+		websocket: suite.websocketMock,
+	}
+}
@@ -0,0 +1,106 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/proxy"
+	"golang.org/x/sync/errgroup"
+)
+
+// ExportStatement writes the result of an EXPORT INTO LOCAL CSV statement to local files.
+// Each file gets its own proxy, so the database splits the exported data between all files.
+type ExportStatement struct {
+	query   string
+	paths   []string
+	proxies []*proxy.Proxy
+	writer  io.Writer                  // Receives the data instead of the file if not nil
+	render  func(target string) string // Creates the statement for the database, the query is rewritten if nil
+}
+
+func NewExportStatement(query string, host string, port int) (*ExportStatement, error) {
+	paths, err := utils.GetFilePaths(query)
+	if err != nil {
+		return nil, errors.ErrInvalidExportQuery
+	}
+	statement := &ExportStatement{query: query, paths: paths}
+	return statement, statement.startProxies(host, port)
+}
+
+// newLocalExportStatement creates a new export whose statement is rendered by the given local export instead of parsed.
+func newLocalExportStatement(query string, localExport LocalExport, host string, port int) (*ExportStatement, error) {
+	statement := &ExportStatement{query: query, paths: localExport.Files, writer: localExport.Writer, render: localExport.Statement}
+	if statement.writer != nil {
+		statement.paths = []string{"data.csv"}
+	}
+	return statement, statement.startProxies(host, port)
+}
+
+// startProxies starts one proxy per file. All proxies are closed if one fails to start.
+func (e *ExportStatement) startProxies(host string, port int) error {
+	for range e.paths {
+		p, err := createProxy(host, port)
+		if err != nil {
+			e.Close()
+			return err
+		}
+		e.proxies = append(e.proxies, p)
+		err = p.StartProxy()
+		if err != nil {
+			e.Close()
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *ExportStatement) GetUpdatedQuery() string {
+	var proxyURLs []string
+	for _, p := range e.proxies {
+		proxyURLs = append(proxyURLs, fmt.Sprintf("http://%s:%d", p.Host, p.Port))
+	}
+	if e.render != nil {
+		return e.render("CSV " + utils.ExportTargets(e.paths, proxyURLs))
+	}
+	return utils.UpdateExportQuery(e.query, proxyURLs)
+}
+
+func (e *ExportStatement) Close() {
+	for _, p := range e.proxies {
+		p.Close()
+	}
+}
+
+// DownloadFiles receives all exported files in parallel and writes them to the local paths.
+func (e *ExportStatement) DownloadFiles(ctx context.Context) error {
+	if e.writer != nil {
+		return e.proxies[0].Read(ctx, e.writer)
+	}
+	errs, errctx := errgroup.WithContext(ctx)
+	for i, path := range e.paths {
+		p := e.proxies[i]
+		path := path
+		errs.Go(func() error { return downloadFile(errctx, p, path) })
+	}
+	return errs.Wait()
+}
+
+func (e *ExportStatement) transfer(ctx context.Context) error {
+	return e.DownloadFiles(ctx)
+}
+
+func downloadFile(ctx context.Context, p *proxy.Proxy, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = p.Read(ctx, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package connection
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// Version is a release version of the database, e.g. 7.1.0.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a release version like 7.1.0 or 8.24.0-rc1. Missing minor and patch numbers are zero and
+// suffixes are ignored. It returns false if the text does not start with a major version number.
+func ParseVersion(text string) (Version, bool) {
+	var numbers [3]int
+	parts := strings.SplitN(text, ".", 3)
+	for i, part := range parts {
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+		number, err := strconv.Atoi(part[:end])
+		if err != nil {
+			if i == 0 {
+				return Version{}, false
+			}
+			break
+		}
+		numbers[i] = number
+		if end < len(part) {
+			break
+		}
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, true
+}
+
+// AtLeast checks if the version is equal to or newer than the given version.
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Feature is a capability that is only available from a certain database or protocol version on.
+type Feature string
+
+const (
+	// FeatureConsumerGroups are the consumer groups managing the resources of sessions, which replaced priority groups
+	// in Exasol 7.0, e.g. ALTER SESSION SET CONSUMER_GROUP and the CONSUMER_GROUP columns of the system tables.
+	FeatureConsumerGroups Feature = "consumer groups"
+	// FeatureSnapshotMode is the SNAPSHOT_MODE parameter of Exasol 7.1 for reading system tables without
+	// transaction conflicts.
+	FeatureSnapshotMode Feature = "snapshot mode"
+	// FeatureTokenLogin is the login with OpenID access and refresh tokens by the loginToken command of
+	// protocol version 3.
+	FeatureTokenLogin Feature = "token login"
+)
+
+// featureRequirement is the minimum database and protocol version of a feature.
+type featureRequirement struct {
+	version         Version
+	protocolVersion int
+}
+
+var featureRequirements = map[Feature]featureRequirement{
+	FeatureConsumerGroups: {version: Version{Major: 7}},
+	FeatureSnapshotMode:   {version: Version{Major: 7, Minor: 1}},
+	FeatureTokenLogin:     {protocolVersion: 3},
+}
+
+// FeatureProvider is implemented by connections of this driver.
+// Use it with [database/sql.Conn.Raw] to check the capabilities of the database before using them.
+type FeatureProvider interface {
+	ServerVersion() Version
+	Supports(feature Feature) bool
+	CheckSupports(feature Feature) error
+}
+
+// ServerVersion returns the release version the database reported at login.
+// It is the zero version before login and if the version can't be parsed.
+func (c *Connection) ServerVersion() Version {
+	version, _ := ParseVersion(c.session.DatabaseVersion)
+	return version
+}
+
+// Supports checks if the database version and the negotiated protocol version support the given feature.
+// Features are assumed to be supported if the versions are unknown, e.g. before login, so that the database decides.
+// Unknown features are not supported.
+func (c *Connection) Supports(feature Feature) bool {
+	requirement, ok := featureRequirements[feature]
+	if !ok {
+		return false
+	}
+	if version := c.ServerVersion(); version != (Version{}) && !version.AtLeast(requirement.version) {
+		return false
+	}
+	return c.session.ProtocolVersion == 0 || c.session.ProtocolVersion >= requirement.protocolVersion
+}
+
+// CheckSupports returns an error naming the feature and the versions of the database if the database does not
+// support the given feature, so that callers fail with a clear message instead of an error of the database.
+func (c *Connection) CheckSupports(feature Feature) error {
+	if c.Supports(feature) {
+		return nil
+	}
+	return errors.NewUnsupportedFeature(string(feature), c.session.DatabaseVersion, c.session.ProtocolVersion)
+}
@@ -0,0 +1,76 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FeaturesTestSuite struct {
+	suite.Suite
+}
+
+func TestFeaturesSuite(t *testing.T) {
+	suite.Run(t, new(FeaturesTestSuite))
+}
+
+func (suite *FeaturesTestSuite) TestParseVersion() {
+	tests := []struct {
+		text     string
+		expected Version
+		ok       bool
+	}{
+		{"7.1.0", Version{7, 1, 0}, true},
+		{"8.24.0-rc1", Version{8, 24, 0}, true},
+		{"6.2", Version{6, 2, 0}, true},
+		{"7", Version{7, 0, 0}, true},
+		{"7.1beta", Version{7, 1, 0}, true},
+		{"", Version{}, false},
+		{"unknown", Version{}, false},
+	}
+	for _, test := range tests {
+		version, ok := ParseVersion(test.text)
+		suite.Equal(test.ok, ok, test.text)
+		suite.Equal(test.expected, version, test.text)
+	}
+}
+
+func (suite *FeaturesTestSuite) TestAtLeast() {
+	suite.True(Version{7, 1, 0}.AtLeast(Version{7, 1, 0}))
+	suite.True(Version{7, 1, 2}.AtLeast(Version{7, 1, 0}))
+	suite.True(Version{8, 0, 0}.AtLeast(Version{7, 1, 5}))
+	suite.False(Version{7, 0, 20}.AtLeast(Version{7, 1, 0}))
+	suite.False(Version{6, 2, 15}.AtLeast(Version{7, 0, 0}))
+}
+
+func (suite *FeaturesTestSuite) TestVersionString() {
+	suite.Equal("7.1.0", Version{7, 1, 0}.String())
+}
+
+func (suite *FeaturesTestSuite) TestSupports() {
+	conn := &Connection{session: SessionInfo{DatabaseVersion: "7.0.18", ProtocolVersion: 3}}
+	suite.Equal(Version{7, 0, 18}, conn.ServerVersion())
+	suite.True(conn.Supports(FeatureConsumerGroups))
+	suite.False(conn.Supports(FeatureSnapshotMode))
+	suite.True(conn.Supports(FeatureTokenLogin))
+	suite.False(conn.Supports(Feature("time travel")))
+}
+
+func (suite *FeaturesTestSuite) TestSupportsChecksProtocolVersion() {
+	conn := &Connection{session: SessionInfo{DatabaseVersion: "7.1.0", ProtocolVersion: 1}}
+	suite.True(conn.Supports(FeatureSnapshotMode))
+	suite.False(conn.Supports(FeatureTokenLogin))
+}
+
+func (suite *FeaturesTestSuite) TestSupportsUnknownVersions() {
+	conn := &Connection{}
+	suite.Equal(Version{}, conn.ServerVersion())
+	suite.True(conn.Supports(FeatureConsumerGroups))
+	suite.True(conn.Supports(FeatureTokenLogin))
+}
+
+func (suite *FeaturesTestSuite) TestCheckSupports() {
+	conn := &Connection{session: SessionInfo{DatabaseVersion: "6.2.15", ProtocolVersion: 1}}
+	suite.EqualError(conn.CheckSupports(FeatureConsumerGroups), "E-EGOD-75: feature 'consumer groups' is not supported by Exasol 6.2.15 with protocol version 1 Check with connection.Supports before using the feature or upgrade the database.")
+	suite.NoError((&Connection{}).CheckSupports(FeatureConsumerGroups))
+}
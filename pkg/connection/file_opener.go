@@ -0,0 +1,60 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+)
+
+// FileOpener opens the files of local CSV imports with a path starting with the scheme it was registered for,
+// e.g. s3://bucket/key.csv. This allows streaming objects from cloud storage through the import without temporary files.
+type FileOpener interface {
+	Open(ctx context.Context, path string) (io.ReadCloser, error)
+}
+
+// FileOpenerFunc is an adapter to use an ordinary function as FileOpener.
+type FileOpenerFunc func(ctx context.Context, path string) (io.ReadCloser, error)
+
+// Open calls f(ctx, path).
+func (f FileOpenerFunc) Open(ctx context.Context, path string) (io.ReadCloser, error) {
+	return f(ctx, path)
+}
+
+var (
+	fileOpenersMutex sync.RWMutex
+	fileOpeners      = make(map[string]FileOpener)
+)
+
+// RegisterFileOpener makes a file opener available for import file paths with the given URL scheme, e.g. "s3".
+// If RegisterFileOpener is called twice with the same scheme or if opener is nil, it panics.
+func RegisterFileOpener(scheme string, opener FileOpener) {
+	fileOpenersMutex.Lock()
+	defer fileOpenersMutex.Unlock()
+	if opener == nil {
+		panic("exasol: RegisterFileOpener opener is nil")
+	}
+	scheme = strings.ToLower(scheme)
+	if _, duplicate := fileOpeners[scheme]; duplicate {
+		panic(fmt.Sprintf("exasol: RegisterFileOpener called twice for scheme %s", scheme))
+	}
+	fileOpeners[scheme] = opener
+}
+
+// openFile opens the given import file with the opener registered for its scheme or from the local file system.
+func openFile(ctx context.Context, path string) (io.ReadCloser, error) {
+	fileOpenersMutex.RLock()
+	opener, ok := fileOpeners[utils.GetURLScheme(path)]
+	fileOpenersMutex.RUnlock()
+	if ok {
+		return opener.Open(ctx, path)
+	}
+	file, err := utils.OpenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
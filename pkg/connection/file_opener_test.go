@@ -0,0 +1,130 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type FileOpenerTestSuite struct {
+	suite.Suite
+}
+
+func TestFileOpenerSuite(t *testing.T) {
+	suite.Run(t, new(FileOpenerTestSuite))
+}
+
+func (suite *FileOpenerTestSuite) TestOpenRegisteredScheme() {
+	var openedPath string
+	suite.register("mem", FileOpenerFunc(func(ctx context.Context, path string) (io.ReadCloser, error) {
+		openedPath = path
+		return io.NopCloser(strings.NewReader("1;a\n")), nil
+	}))
+
+	file, err := openFile(context.Background(), "MEM://bucket/data.csv")
+	suite.NoError(err)
+	content, _ := io.ReadAll(file)
+	suite.Equal("1;a\n", string(content))
+	suite.Equal("MEM://bucket/data.csv", openedPath)
+}
+
+func (suite *FileOpenerTestSuite) TestOpenRegisteredSchemeFails() {
+	suite.register("failing", FileOpenerFunc(func(ctx context.Context, path string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("access denied")
+	}))
+
+	file, err := openFile(context.Background(), "failing://bucket/data.csv")
+	suite.EqualError(err, "access denied")
+	suite.Nil(file)
+}
+
+func (suite *FileOpenerTestSuite) TestOpenLocalFile() {
+	file, err := openFile(context.Background(), "../../testData/data.csv")
+	suite.NoError(err)
+	suite.NoError(file.Close())
+}
+
+func (suite *FileOpenerTestSuite) TestOpenLocalFileNotFound() {
+	file, err := openFile(context.Background(), "unknown://bucket/data.csv")
+	suite.EqualError(err, "E-EGOD-28: file 'unknown://bucket/data.csv' not found")
+	suite.Nil(file)
+}
+
+// trackedFile records how many files are open at the same time.
+type trackedFile struct {
+	io.Reader
+	open *int
+}
+
+func (f *trackedFile) Close() error {
+	*f.open--
+	return nil
+}
+
+func (suite *FileOpenerTestSuite) TestFileReaderOpensOneFileAtATime() {
+	open, maxOpen := 0, 0
+	suite.register("tracked", FileOpenerFunc(func(ctx context.Context, path string) (io.ReadCloser, error) {
+		open++
+		if open > maxOpen {
+			maxOpen = open
+		}
+		return &trackedFile{Reader: strings.NewReader(path + "\n"), open: &open}, nil
+	}))
+
+	first := &fileReader{ctx: context.Background(), path: "tracked://1"}
+	second := &fileReader{ctx: context.Background(), path: "tracked://2"}
+	suite.Equal(0, open, "files are opened on the first read")
+	content, err := io.ReadAll(io.MultiReader(first, second))
+	suite.NoError(err)
+	suite.Equal("tracked://1\ntracked://2\n", string(content))
+	suite.Equal(1, maxOpen)
+	suite.Equal(0, open)
+	suite.NoError(first.Close(), "closing again")
+}
+
+func (suite *FileOpenerTestSuite) TestFileReaderClosesAbortedFile() {
+	open := 0
+	suite.register("tracked", FileOpenerFunc(func(ctx context.Context, path string) (io.ReadCloser, error) {
+		open++
+		return &trackedFile{Reader: strings.NewReader("1;a\n2;b\n"), open: &open}, nil
+	}))
+
+	file := &fileReader{ctx: context.Background(), path: "tracked://data.csv"}
+	_, err := file.Read(make([]byte, 2))
+	suite.NoError(err)
+	suite.Equal(1, open)
+	suite.NoError(file.Close())
+	suite.Equal(0, open)
+	n, err := file.Read(make([]byte, 2))
+	suite.Equal(0, n)
+	suite.Equal(io.EOF, err)
+}
+
+func (suite *FileOpenerTestSuite) TestFileReaderReturnsOpenError() {
+	file := &fileReader{ctx: context.Background(), path: "unknown://bucket/data.csv"}
+	_, err := file.Read(make([]byte, 2))
+	suite.EqualError(err, "E-EGOD-28: file 'unknown://bucket/data.csv' not found")
+}
+
+func (suite *FileOpenerTestSuite) TestRegisterNilPanics() {
+	suite.PanicsWithValue("exasol: RegisterFileOpener opener is nil", func() { RegisterFileOpener("nil", nil) })
+}
+
+func (suite *FileOpenerTestSuite) TestRegisterTwicePanics() {
+	opener := FileOpenerFunc(func(ctx context.Context, path string) (io.ReadCloser, error) { return nil, nil })
+	suite.register("twice", opener)
+	suite.PanicsWithValue("exasol: RegisterFileOpener called twice for scheme twice", func() { RegisterFileOpener("TWICE", opener) })
+}
+
+func (suite *FileOpenerTestSuite) register(scheme string, opener FileOpener) {
+	RegisterFileOpener(scheme, opener)
+	suite.T().Cleanup(func() {
+		fileOpenersMutex.Lock()
+		defer fileOpenersMutex.Unlock()
+		delete(fileOpeners, scheme)
+	})
+}
@@ -0,0 +1,105 @@
+package connection
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+)
+
+// probeHostFunc checks if a host accepts connections. Variable to replace the network in tests.
+var probeHostFunc = probeHost
+
+// probeHosts checks all hosts in parallel and moves the first healthy host to the front,
+// so that the login does not wait for the connection timeout of hosts that are down.
+// The order is unchanged if no host is healthy.
+func (c *Connection) probeHosts(hosts []string) []string {
+	ctx, cancel := context.WithTimeout(c.Ctx, c.Config.HostProbeTimeout)
+	defer cancel()
+	encryption := c.getURIScheme() == "wss"
+	// Probes of slow hosts keep running after the first healthy host is returned
+	probe := probeHostFunc
+	healthy := make(chan int, len(hosts))
+	for i, host := range hosts {
+		go func(index int, host string) {
+			name, port, err := utils.SplitHostPort(host, c.Config.Port)
+			if err == nil {
+				err = probe(ctx, fmt.Sprintf("%s:%d", name, port), encryption, c.Config.TLSServerName)
+			}
+			if err != nil {
+				logger.TraceLogger.Printf("host %s failed probe: %v", host, err)
+				healthy <- -1
+				return
+			}
+			healthy <- index
+		}(i, host)
+	}
+	for range hosts {
+		index := <-healthy
+		if index < 0 {
+			continue
+		}
+		ordered := append([]string{hosts[index]}, hosts[:index]...)
+		return append(ordered, hosts[index+1:]...)
+	}
+	return hosts
+}
+
+// HostProbe is the result of probing a host with ProbeHosts.
+type HostProbe struct {
+	Host    string
+	Latency time.Duration
+	Err     error
+}
+
+// ProbeHosts resolves the configured hosts and checks in parallel if they accept connections until the context is done,
+// like the probing before connecting with a host probe timeout. The results have the order of the resolved hosts.
+func ProbeHosts(ctx context.Context, cfg *config.Config) ([]HostProbe, error) {
+	c := &Connection{Config: cfg, Ctx: ctx}
+	hosts, err := c.resolveHosts(cfg.Host, nil)
+	if err != nil {
+		return nil, err
+	}
+	encryption := c.getURIScheme() == "wss"
+	probes := make([]HostProbe, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		probes[i].Host = host
+		wg.Add(1)
+		go func(probe *HostProbe, host string) {
+			defer wg.Done()
+			start := time.Now()
+			name, port, err := utils.SplitHostPort(host, cfg.Port)
+			if err == nil {
+				err = probeHostFunc(ctx, fmt.Sprintf("%s:%d", name, port), encryption, cfg.TLSServerName)
+			}
+			probe.Latency = time.Since(start)
+			probe.Err = err
+		}(&probes[i], host)
+	}
+	wg.Wait()
+	return probes, nil
+}
+
+// probeHost opens a TCP connection to the given address and completes the TLS handshake if encryption is enabled.
+// A non-empty serverName is sent in the handshake instead of the host of the address.
+func probeHost(ctx context.Context, address string, encryption bool, serverName string) error {
+	var dialer interface {
+		DialContext(ctx context.Context, network, address string) (net.Conn, error)
+	} = &net.Dialer{}
+	if encryption {
+		// The certificate is verified when opening the websocket
+		dialer = &tls.Dialer{Config: &tls.Config{InsecureSkipVerify: true, ServerName: serverName}} //nolint:gosec
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
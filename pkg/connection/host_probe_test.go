@@ -0,0 +1,125 @@
+package connection
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/stretchr/testify/suite"
+)
+
+type HostProbeTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+	healthyHosts  map[string]bool
+}
+
+func TestHostProbeSuite(t *testing.T) {
+	suite.Run(t, new(HostProbeTestSuite))
+}
+
+func (suite *HostProbeTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+	// Probes of a test may still run while the next test starts, so they use the map of their own test
+	healthyHosts := map[string]bool{}
+	suite.healthyHosts = healthyHosts
+	probe := probeHostFunc
+	probeHostFunc = func(ctx context.Context, address string, encryption bool, serverName string) error {
+		if healthyHosts[address] {
+			return nil
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	suite.T().Cleanup(func() { probeHostFunc = probe })
+}
+
+func (suite *HostProbeTestSuite) TestConnectsToHealthyHost() {
+	suite.healthyHosts["host3:8563"] = true
+	conn, dialedHosts := suite.createConnection("host1,host2,host3,host4", time.Second)
+	suite.NoError(conn.Connect())
+	suite.Equal([]string{"host3:8563"}, *dialedHosts)
+}
+
+func (suite *HostProbeTestSuite) TestProbesPerHostPorts() {
+	suite.healthyHosts["host2:9000"] = true
+	conn, dialedHosts := suite.createConnection("host1:9000,host2:9000", time.Second)
+	suite.NoError(conn.Connect())
+	suite.Equal([]string{"host2:9000"}, *dialedHosts)
+}
+
+func (suite *HostProbeTestSuite) TestTriesAllHostsIfNoHostIsHealthy() {
+	conn, dialedHosts := suite.createConnection("host1,host2", 10*time.Millisecond)
+	suite.NoError(conn.Connect())
+	suite.Len(*dialedHosts, 1)
+}
+
+func (suite *HostProbeTestSuite) TestProbingDisabled() {
+	probeHostFunc = func(ctx context.Context, address string, encryption bool, serverName string) error {
+		suite.Fail("unexpected probe")
+		return nil
+	}
+	conn, dialedHosts := suite.createConnection("host1,host2", 0)
+	suite.NoError(conn.Connect())
+	suite.Len(*dialedHosts, 1)
+}
+
+func (suite *HostProbeTestSuite) TestProbeHostsReportsEachHost() {
+	suite.healthyHosts["host2:8563"] = true
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	probes, err := ProbeHosts(ctx, &config.Config{Host: "host1..2,host3:abc", Port: 8563})
+	suite.NoError(err)
+	suite.Len(probes, 3)
+	suite.Equal("host1", probes[0].Host)
+	suite.ErrorIs(probes[0].Err, context.DeadlineExceeded)
+	suite.Equal("host2", probes[1].Host)
+	suite.NoError(probes[1].Err)
+	suite.ErrorContains(probes[2].Err, "E-EGOD-23")
+}
+
+func (suite *HostProbeTestSuite) TestProbeHostsFailsIfAllHostsAreExcluded() {
+	_, err := ProbeHosts(context.Background(), &config.Config{Host: "host1", ExcludeHosts: "host1"})
+	suite.ErrorContains(err, "E-EGOD-60")
+}
+
+func (suite *HostProbeTestSuite) TestProbeHost() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	defer listener.Close()
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			conn.Close()
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	suite.NoError(probeHost(ctx, listener.Addr().String(), false, ""))
+}
+
+func (suite *HostProbeTestSuite) TestProbeHostFailsForClosedPort() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	address := listener.Addr().String()
+	listener.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	suite.Error(probeHost(ctx, address, false, ""))
+}
+
+func (suite *HostProbeTestSuite) createConnection(hosts string, probeTimeout time.Duration) (*Connection, *[]string) {
+	var dialedHosts []string
+	conn := &Connection{
+		Config: &config.Config{Host: hosts, Port: 8563, HostProbeTimeout: probeTimeout},
+		Ctx:    context.Background(),
+		DialFunc: func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+			dialedHosts = append(dialedHosts, url.Host)
+			return suite.websocketMock, nil
+		},
+	}
+	return conn, &dialedHosts
+}
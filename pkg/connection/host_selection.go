@@ -0,0 +1,111 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// lookupSRVFunc looks up the SRV records of a service name. Variable to replace the DNS in tests.
+var lookupSRVFunc = func(ctx context.Context, name string) ([]*net.SRV, error) {
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+	return records, err
+}
+
+// resolveHosts expands the given comma-separated hosts and removes the hosts excluded by the configuration
+// and by the given list, e.g. a node under maintenance.
+// Services are looked up again on each call, so that every connect and failover uses the current DNS records.
+func (c *Connection) resolveHosts(hosts string, excluded []string) ([]string, error) {
+	resolved, err := utils.ResolveHosts(hosts)
+	if err != nil {
+		return nil, err
+	}
+	if resolved, err = c.lookupServices(resolved); err != nil {
+		return nil, err
+	}
+	if c.Config.ExcludeHosts != "" {
+		excluded = append([]string{c.Config.ExcludeHosts}, excluded...)
+	}
+	if len(excluded) > 0 {
+		excludedHosts, err := utils.ResolveHosts(strings.Join(excluded, ","))
+		if err != nil {
+			return nil, err
+		}
+		resolved = utils.ExcludeHosts(resolved, excludedHosts)
+	}
+	if len(resolved) == 0 {
+		return nil, errors.NewNoHostsAvailable(hosts)
+	}
+	return resolved, nil
+}
+
+// lookupServices replaces service names like _exasol._tcp.example.com with the targets and ports of their SRV records.
+// Other hosts are returned unchanged, their names are resolved when dialing.
+func (c *Connection) lookupServices(hosts []string) ([]string, error) {
+	var expanded []string
+	for _, host := range hosts {
+		if !isServiceName(host) {
+			expanded = append(expanded, host)
+			continue
+		}
+		records, err := lookupSRVFunc(c.Ctx, host)
+		if err != nil {
+			return nil, errors.NewServiceLookupFailed(host, err)
+		}
+		for _, record := range records {
+			expanded = append(expanded, fmt.Sprintf("%s:%d", strings.TrimSuffix(record.Target, "."), record.Port))
+		}
+	}
+	return expanded, nil
+}
+
+// isServiceName returns true for names of SRV records, which start with an underscore like _exasol._tcp.example.com.
+func isServiceName(host string) bool {
+	return strings.HasPrefix(host, "_") && !strings.Contains(host, ":")
+}
+
+// transferHosts returns the comma-separated hosts the proxies of an import or export connect to.
+// Pinned hosts take precedence over the configured transfer hosts, which default to the hosts of the connection.
+func (c *Connection) transferHosts(pinned []string, excluded []string) (string, error) {
+	hosts := c.Config.Host
+	if len(pinned) > 0 {
+		hosts = strings.Join(pinned, ",")
+	} else if c.Config.TransferHosts != "" {
+		hosts = c.Config.TransferHosts
+	}
+	resolved, err := c.resolveHosts(hosts, excluded)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(resolved, ","), nil
+}
+
+type preferredHostKey struct{}
+
+// WithPreferredHost returns a context for opening connections that try the host with the given index of the configured
+// hosts first instead of a random host, e.g. for spreading connections evenly across the nodes of a cluster.
+// The index wraps around the number of hosts. The other hosts are still tried in random order if the host is unavailable.
+func WithPreferredHost(ctx context.Context, index int) context.Context {
+	return context.WithValue(ctx, preferredHostKey{}, index)
+}
+
+// preferHost moves the host preferred by the given context to the front of the shuffled hosts.
+// It returns false if the context does not prefer a host.
+func preferHost(ctx context.Context, resolved []string, shuffled []string) bool {
+	index, ok := ctx.Value(preferredHostKey{}).(int)
+	if !ok {
+		return false
+	}
+	preferred := resolved[(index%len(resolved)+len(resolved))%len(resolved)]
+	for i, host := range shuffled {
+		if host == preferred {
+			shuffled[0], shuffled[i] = shuffled[i], shuffled[0]
+			break
+		}
+	}
+	return true
+}
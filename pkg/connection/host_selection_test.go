@@ -0,0 +1,157 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/stretchr/testify/suite"
+)
+
+type HostSelectionTestSuite struct {
+	suite.Suite
+}
+
+func TestHostSelectionSuite(t *testing.T) {
+	suite.Run(t, new(HostSelectionTestSuite))
+}
+
+func (suite *HostSelectionTestSuite) TestResolveHostsWithoutExclusions() {
+	hosts, err := suite.connection("", "").resolveHosts("exasol1..3", nil)
+	suite.NoError(err)
+	suite.Equal([]string{"exasol1", "exasol2", "exasol3"}, hosts)
+}
+
+func (suite *HostSelectionTestSuite) TestResolveHostsExcludesConfiguredAndGivenHosts() {
+	hosts, err := suite.connection("exasol2", "").resolveHosts("exasol1..4:8564", []string{"exasol3..4"})
+	suite.NoError(err)
+	suite.Equal([]string{"exasol1:8564"}, hosts)
+}
+
+func (suite *HostSelectionTestSuite) TestResolveHostsFailsIfAllHostsAreExcluded() {
+	_, err := suite.connection("exasol1..2", "").resolveHosts("exasol1,exasol2", nil)
+	suite.ErrorContains(err, "E-EGOD-60: no hosts available, all hosts of 'exasol1,exasol2' are excluded")
+}
+
+func (suite *HostSelectionTestSuite) TestTransferHostsDefaultToConnectionHosts() {
+	hosts, err := suite.connection("exasol3", "").transferHosts(nil, nil)
+	suite.NoError(err)
+	suite.Equal("exasol1,exasol2,exasol4", hosts)
+}
+
+func (suite *HostSelectionTestSuite) TestConfiguredTransferHosts() {
+	hosts, err := suite.connection("", "exasol3..4").transferHosts(nil, []string{"exasol4"})
+	suite.NoError(err)
+	suite.Equal("exasol3", hosts)
+}
+
+func (suite *HostSelectionTestSuite) TestPinnedTransferHosts() {
+	hosts, err := suite.connection("exasol2", "exasol3..4").transferHosts([]string{"exasol1..2"}, nil)
+	suite.NoError(err)
+	suite.Equal("exasol1", hosts)
+}
+
+func (suite *HostSelectionTestSuite) TestConnectSkipsExcludedHosts() {
+	conn := suite.connection("exasol1..3", "")
+	var dialedHosts []string
+	conn.DialFunc = func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+		dialedHosts = append(dialedHosts, url.Host)
+		return wsconn.CreateWebsocketConnectionMock(), nil
+	}
+	suite.NoError(conn.Connect())
+	suite.Equal([]string{"exasol4:8563"}, dialedHosts)
+}
+
+func (suite *HostSelectionTestSuite) TestConnectToPreferredHost() {
+	conn := suite.connection("exasol2", "")
+	var dialedHosts []string
+	conn.DialFunc = func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+		dialedHosts = append(dialedHosts, url.Host)
+		return wsconn.CreateWebsocketConnectionMock(), nil
+	}
+	for index := 0; index < 4; index++ {
+		conn.Ctx = WithPreferredHost(context.Background(), index)
+		suite.NoError(conn.Connect())
+	}
+	suite.Equal([]string{"exasol1:8563", "exasol3:8563", "exasol4:8563", "exasol1:8563"}, dialedHosts)
+}
+
+func (suite *HostSelectionTestSuite) TestPreferredHostIsTriedFirst() {
+	hosts := []string{"exasol3", "exasol1", "exasol2"}
+	suite.True(preferHost(WithPreferredHost(context.Background(), -1), []string{"exasol1", "exasol2", "exasol3"}, hosts))
+	suite.Equal("exasol3", hosts[0])
+	suite.False(preferHost(context.Background(), []string{"exasol1"}, hosts))
+}
+
+func (suite *HostSelectionTestSuite) TestResolveHostsLooksUpServices() {
+	suite.replaceDNS(map[string][]*net.SRV{"_exasol._tcp.example.com": {{Target: "exasol1.example.com.", Port: 8563}, {Target: "exasol2.example.com.", Port: 8564}}})
+	hosts, err := suite.connection("exasol2.example.com", "").resolveHosts("_exasol._tcp.example.com,exasol3", nil)
+	suite.NoError(err)
+	suite.Equal([]string{"exasol1.example.com:8563", "exasol3"}, hosts)
+}
+
+func (suite *HostSelectionTestSuite) TestResolveHostsFailsIfServiceLookupFails() {
+	suite.replaceDNS(map[string][]*net.SRV{})
+	_, err := suite.connection("", "").resolveHosts("_exasol._tcp.example.com", nil)
+	suite.ErrorContains(err, "E-EGOD-70: could not look up the SRV records of service '_exasol._tcp.example.com': 'no such host'")
+}
+
+func (suite *HostSelectionTestSuite) TestConnectLooksUpServiceAgain() {
+	records := map[string][]*net.SRV{"_exasol._tcp.example.com": {{Target: "exasol1.example.com.", Port: 8563}}}
+	suite.replaceDNS(records)
+	conn := suite.connection("", "")
+	conn.Config.Host = "_exasol._tcp.example.com"
+	var dialedHosts []string
+	conn.DialFunc = func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+		dialedHosts = append(dialedHosts, url.Host)
+		return wsconn.CreateWebsocketConnectionMock(), nil
+	}
+	suite.NoError(conn.Connect())
+	records["_exasol._tcp.example.com"] = []*net.SRV{{Target: "exasol2.example.com.", Port: 8563}}
+	suite.NoError(conn.Connect())
+	suite.Equal([]string{"exasol1.example.com:8563", "exasol2.example.com:8563"}, dialedHosts)
+}
+
+func (suite *HostSelectionTestSuite) TestDialRetryLooksUpServiceAgain() {
+	records := map[string][]*net.SRV{"_exasol._tcp.example.com": {{Target: "exasol1.example.com.", Port: 8563}}}
+	suite.replaceDNS(records)
+	conn := suite.connection("", "")
+	conn.Config.Host = "_exasol._tcp.example.com"
+	conn.Config.DialRetries = 1
+	conn.Config.DialRetryDelay = time.Millisecond
+	var dialedHosts []string
+	conn.DialFunc = func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+		dialedHosts = append(dialedHosts, url.Host)
+		if url.Host == "exasol1.example.com:8563" {
+			records["_exasol._tcp.example.com"] = []*net.SRV{{Target: "exasol2.example.com.", Port: 8563}}
+			return nil, fmt.Errorf("connection refused")
+		}
+		return wsconn.CreateWebsocketConnectionMock(), nil
+	}
+	suite.NoError(conn.Connect())
+	suite.Equal([]string{"exasol1.example.com:8563", "exasol2.example.com:8563"}, dialedHosts)
+}
+
+// replaceDNS answers SRV lookups with the given records for the duration of the test.
+func (suite *HostSelectionTestSuite) replaceDNS(records map[string][]*net.SRV) {
+	lookup := lookupSRVFunc
+	lookupSRVFunc = func(ctx context.Context, name string) ([]*net.SRV, error) {
+		if serviceRecords, ok := records[name]; ok {
+			return serviceRecords, nil
+		}
+		return nil, fmt.Errorf("no such host")
+	}
+	suite.T().Cleanup(func() { lookupSRVFunc = lookup })
+}
+
+func (suite *HostSelectionTestSuite) connection(excludeHosts string, transferHosts string) *Connection {
+	return &Connection{
+		Config: &config.Config{Host: "exasol1..4", Port: 8563, ExcludeHosts: excludeHosts, TransferHosts: transferHosts},
+		Ctx:    context.Background(),
+	}
+}
@@ -2,20 +2,30 @@ package connection
 
 import (
 	"context"
-	"os"
+	"fmt"
+	"io"
 
 	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
 	"github.com/exasol/exasol-driver-go/pkg/proxy"
 )
 
 type ImportStatement struct {
-	query string
-	host  string
-	port  int
-	proxy *proxy.Proxy
+	query    string
+	host     string
+	port     int
+	proxy    *proxy.Proxy
+	encoding string // Source encoding converted to UTF-8 during upload, empty if the files are sent unchanged
+	counters []*utils.RowCounter
+	source   io.Reader // Uploaded instead of the files of the query if not nil
+	dialect  utils.CSVDialect
+	paths    []string                   // Files to upload, taken from the query if nil
+	render   func(source string) string // Creates the statement for the database, the query is rewritten if nil
 }
 
-func NewImportStatement(query string, host string, port int) (*ImportStatement, error) {
+// NewImportStatement creates a new import of local files. The source encoding is taken from the ENCODING clause
+// of the query or the given default encoding if the query has none.
+func NewImportStatement(query string, host string, port int, defaultEncoding string) (*ImportStatement, error) {
 	p, err := createProxy(host, port)
 	if err != nil {
 		return nil, err
@@ -24,7 +34,26 @@ func NewImportStatement(query string, host string, port int) (*ImportStatement,
 	if err != nil {
 		return nil, err
 	}
-	return &ImportStatement{query: query, host: host, port: port, proxy: p}, nil
+	encoding := utils.GetEncoding(query)
+	if encoding == "" {
+		encoding = defaultEncoding
+	}
+	return &ImportStatement{query: query, host: host, port: port, proxy: p, encoding: utils.NormalizeEncoding(encoding),
+		dialect: utils.GetCSVDialect(query)}, nil
+}
+
+// newLocalImportStatement creates a new import whose statement is rendered by the given local import instead of parsed.
+func newLocalImportStatement(query string, localImport LocalImport, host string, port int) (*ImportStatement, error) {
+	p, err := createProxy(host, port)
+	if err != nil {
+		return nil, err
+	}
+	err = p.StartProxy()
+	if err != nil {
+		return nil, err
+	}
+	return &ImportStatement{query: query, host: host, port: port, proxy: p, encoding: utils.NormalizeEncoding(localImport.Encoding),
+		source: localImport.Reader, dialect: localImport.Dialect, paths: localImport.Files, render: localImport.Statement}, nil
 }
 
 func createProxy(host string, port int) (*proxy.Proxy, error) {
@@ -37,7 +66,14 @@ func createProxy(host string, port int) (*proxy.Proxy, error) {
 }
 
 func (i *ImportStatement) GetUpdatedQuery() string {
-	return utils.UpdateImportQuery(i.query, i.proxy.Host, i.proxy.Port)
+	if i.render != nil {
+		return i.render(fmt.Sprintf("CSV AT 'http://%s:%d' FILE 'data.csv'", i.proxy.Host, i.proxy.Port))
+	}
+	query := utils.UpdateImportQuery(i.query, i.proxy.Host, i.proxy.Port)
+	if i.encoding != "" {
+		query = utils.UpdateImportEncoding(query)
+	}
+	return query
 }
 
 func (i *ImportStatement) Close() {
@@ -45,24 +81,91 @@ func (i *ImportStatement) Close() {
 }
 
 func (i *ImportStatement) UploadFiles(ctx context.Context) error {
-	paths, err := utils.GetFilePaths(i.query)
+	dialect := i.dialect
+	if i.source != nil {
+		counter := dialect.NewRowCounter()
+		i.counters = append(i.counters, counter)
+		return i.proxy.Write(ctx, []io.Reader{io.TeeReader(utils.NewTranscodingReader(i.source, i.encoding), counter)}, dialect.RowSeparator)
+	}
+
+	paths := i.paths
+	if paths == nil {
+		var err error
+		if paths, err = utils.GetFilePaths(i.query); err != nil {
+			return err
+		}
+	}
+	paths, err := utils.ExpandFilePaths(paths)
 	if err != nil {
 		return err
 	}
 
-	var files []*os.File
+	var files []io.Reader
 	for _, path := range paths {
-		f, ferr := utils.OpenFile(path)
-		if ferr != nil {
-			return ferr
-		}
-		files = append(files, f)
+		file := &fileReader{ctx: ctx, path: path}
+		defer file.Close()
+		counter := dialect.NewRowCounter()
+		i.counters = append(i.counters, counter)
+		files = append(files, io.TeeReader(utils.NewTranscodingReader(file, i.encoding), counter))
 	}
 
-	err = i.proxy.Write(ctx, files, utils.GetRowSeparator(i.query))
+	err = i.proxy.Write(ctx, files, dialect.RowSeparator)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func (i *ImportStatement) transfer(ctx context.Context) error {
+	return i.UploadFiles(ctx)
+}
+
+// RowsSent returns the number of CSV rows uploaded by UploadFiles.
+func (i *ImportStatement) RowsSent() int64 {
+	var rows int64
+	for _, counter := range i.counters {
+		rows += counter.Rows()
+	}
+	return rows
+}
+
+// fileReader opens the file on the first read and closes it at its end, so that only the file currently uploaded
+// is open. It adds the path of the file to read errors.
+type fileReader struct {
+	ctx    context.Context
+	path   string
+	reader io.ReadCloser
+	done   bool
+}
+
+func (r *fileReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+	if r.reader == nil {
+		reader, err := openFile(r.ctx, r.path)
+		if err != nil {
+			return 0, err
+		}
+		r.reader = reader
+	}
+	n, err := r.reader.Read(p)
+	if err == io.EOF {
+		_ = r.Close()
+	} else if err != nil {
+		err = errors.NewFileReadError(r.path, err)
+	}
+	return n, err
+}
+
+// Close closes the file if it is still open, e.g. after the upload was aborted.
+func (r *fileReader) Close() error {
+	r.done = true
+	if r.reader == nil {
+		return nil
+	}
+	err := r.reader.Close()
+	r.reader = nil
+	return err
+}
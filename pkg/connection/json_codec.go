@@ -0,0 +1,106 @@
+package connection
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// JSONCodec encodes the commands sent to the database and decodes its responses.
+// Set [Connection.JSONCodec] to replace encoding/json with a faster implementation like jsoniter or go-json.
+//
+// Implementations must be safe for concurrent use and must decode numbers in interface{} values as json.Number,
+// so that large DECIMAL values keep their precision.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	NewDecoder(reader io.Reader) JSONDecoder
+}
+
+// JSONDecoder decodes a single JSON value from a stream.
+type JSONDecoder interface {
+	Decode(v interface{}) error
+}
+
+// StandardJSONCodec is the default [JSONCodec] based on encoding/json.
+var StandardJSONCodec JSONCodec = standardJSONCodec{}
+
+type standardJSONCodec struct{}
+
+func (standardJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes the data with json.Unmarshal. Values containing interface{} values are decoded with a decoder
+// using json.Number instead, as json.Unmarshal would decode their numbers as float64.
+func (standardJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	if !containsInterface(reflect.TypeOf(v)) {
+		return json.Unmarshal(data, v)
+	}
+	decoder := standardJSONCodec{}.NewDecoder(bytes.NewReader(data)).(*json.Decoder)
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if end := decoder.InputOffset(); !isEOF(decoder) {
+		return fmt.Errorf("invalid data after top-level value at offset %d", end)
+	}
+	return nil
+}
+
+func (standardJSONCodec) NewDecoder(reader io.Reader) JSONDecoder {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	return decoder
+}
+
+func isEOF(decoder *json.Decoder) bool {
+	_, err := decoder.Token()
+	return err == io.EOF
+}
+
+// interfaceTypes caches for each type whether it contains interface{} values.
+var interfaceTypes sync.Map
+
+// containsInterface checks if values of the given type can contain interface{} values.
+func containsInterface(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	if contains, ok := interfaceTypes.Load(t); ok {
+		return contains.(bool)
+	}
+	contains := typeContainsInterface(t, map[reflect.Type]bool{})
+	interfaceTypes.Store(t, contains)
+	return contains
+}
+
+func typeContainsInterface(t reflect.Type, visited map[reflect.Type]bool) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return typeContainsInterface(t.Elem(), visited)
+	case reflect.Struct:
+		if visited[t] {
+			return false
+		}
+		visited[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			if typeContainsInterface(t.Field(i).Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonCodec returns the configured codec or the [StandardJSONCodec].
+func (c *Connection) jsonCodec() JSONCodec {
+	if c == nil || c.JSONCodec == nil {
+		return StandardJSONCodec
+	}
+	return c.JSONCodec
+}
@@ -0,0 +1,107 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONCodecTestSuite struct {
+	suite.Suite
+}
+
+func TestJSONCodecSuite(t *testing.T) {
+	suite.Run(t, new(JSONCodecTestSuite))
+}
+
+func (suite *JSONCodecTestSuite) TestStandardCodecPreservesNumbers() {
+	var value interface{}
+	suite.NoError(StandardJSONCodec.Unmarshal([]byte(`[9007199254740993]`), &value))
+	suite.Equal([]interface{}{json.Number("9007199254740993")}, value)
+}
+
+func (suite *JSONCodecTestSuite) TestStandardCodecPreservesNumbersOfResultSets() {
+	resultSet := &types.SqlQueryResponseResultSet{}
+	suite.NoError(StandardJSONCodec.Unmarshal([]byte(`{"resultSet": {"data": [[9007199254740993]]}}`), resultSet))
+	suite.Equal([][]interface{}{{json.Number("9007199254740993")}}, resultSet.ResultSet.Data)
+}
+
+func (suite *JSONCodecTestSuite) TestStandardCodecRejectsTrailingData() {
+	var value interface{}
+	suite.EqualError(StandardJSONCodec.Unmarshal([]byte(`[1] [2]`), &value), "invalid data after top-level value at offset 3")
+	rowCount := &types.SqlQueryResponseRowCount{}
+	suite.EqualError(StandardJSONCodec.Unmarshal([]byte(`{"rowCount": 1} x`), rowCount), "invalid character 'x' after top-level value")
+}
+
+func (suite *JSONCodecTestSuite) TestContainsInterface() {
+	suite.True(containsInterface(reflect.TypeOf(&types.SqlQueryResponseResultSet{})))
+	suite.True(containsInterface(reflect.TypeOf(map[string]interface{}{})))
+	suite.False(containsInterface(reflect.TypeOf(&types.SqlQueryResponseRowCount{})))
+	suite.False(containsInterface(reflect.TypeOf(&types.BaseResponse{})))
+	suite.False(containsInterface(nil))
+}
+
+func (suite *JSONCodecTestSuite) TestStandardCodecMarshal() {
+	message, err := StandardJSONCodec.Marshal(types.Command{Command: "fetch"})
+	suite.NoError(err)
+	suite.JSONEq(`{"command": "fetch"}`, string(message))
+}
+
+func (suite *JSONCodecTestSuite) TestConnectionUsesConfiguredCodec() {
+	codec := &countingCodec{}
+	conn := &Connection{
+		Config:    &config.Config{},
+		Ctx:       context.Background(),
+		JSONCodec: codec,
+		websocket: &benchmarkConnection{messageType: websocket.TextMessage, response: []byte(`{"status": "ok", "responseData": {"numRows": 1}}`)},
+	}
+	result := &types.SqlQueryResponseResultSetData{}
+	suite.NoError(conn.Send(context.Background(), &types.Command{Command: "fetch"}, result))
+	suite.Equal(1, result.NumRows)
+	suite.Equal(countingCodec{marshalled: 1, unmarshalled: 1, decoded: 1}, *codec)
+}
+
+func (suite *JSONCodecTestSuite) TestResultsAreDecodedWithConfiguredCodec() {
+	codec := &countingCodec{}
+	conn := &Connection{Config: &config.Config{}, Ctx: context.Background(), JSONCodec: codec}
+	response := &types.SqlQueriesResponse{NumResults: 1, Results: []json.RawMessage{json.RawMessage(`{"resultType": "rowCount", "rowCount": 3}`)}}
+	result, err := toResult(response, conn.jsonCodec())
+	suite.NoError(err)
+	rows, _ := result.RowsAffected()
+	suite.Equal(int64(3), rows)
+	suite.Equal(int64(3), conn.resultRowCount(response))
+	suite.Equal(countingCodec{unmarshalled: 2}, *codec)
+}
+
+func (suite *JSONCodecTestSuite) TestNilConnectionUsesStandardCodec() {
+	var conn *Connection
+	suite.Equal(StandardJSONCodec, conn.jsonCodec())
+}
+
+type countingCodec struct {
+	marshalled   int
+	unmarshalled int
+	decoded      int
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshalled++
+	return StandardJSONCodec.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshalled++
+	return StandardJSONCodec.Unmarshal(data, v)
+}
+
+func (c *countingCodec) NewDecoder(reader io.Reader) JSONDecoder {
+	c.decoded++
+	return StandardJSONCodec.NewDecoder(reader)
+}
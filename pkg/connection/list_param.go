@@ -0,0 +1,68 @@
+package connection
+
+import (
+	"database/sql/driver"
+	"strings"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// ListParam is a parameter that is expanded into one placeholder per value, e.g. for IN (?) clauses.
+// An empty list is replaced with NULL, so that IN (?) matches no rows.
+// It is only supported by Query and Exec of the connection, not by prepared statements.
+type ListParam []driver.Value
+
+// CheckNamedValue converts the values of a [ListParam] and leaves all other values to the default conversion.
+func (c *Connection) CheckNamedValue(value *driver.NamedValue) error {
+	list, ok := value.Value.(ListParam)
+	if !ok {
+		return driver.ErrSkip
+	}
+	converted := make(ListParam, len(list))
+	for i, element := range list {
+		convertedElement, err := driver.DefaultParameterConverter.ConvertValue(element)
+		if err != nil {
+			return err
+		}
+		converted[i] = convertedElement
+	}
+	value.Value = converted
+	return nil
+}
+
+// expandListParams replaces the placeholder of each [ListParam] with one placeholder per value and flattens the values.
+func expandListParams(query string, args []driver.Value) (string, []driver.Value, error) {
+	if !hasListParam(args) {
+		return query, args, nil
+	}
+	expandedArgs := make([]driver.Value, 0, len(args))
+	expanded, placeholders, ok := utils.ReplacePlaceholders(query, func(index int) (string, bool) {
+		if index >= len(args) {
+			return "", false
+		}
+		list, isList := args[index].(ListParam)
+		if !isList {
+			expandedArgs = append(expandedArgs, args[index])
+			return "?", true
+		}
+		if len(list) == 0 {
+			return "NULL", true
+		}
+		expandedArgs = append(expandedArgs, list...)
+		return strings.Repeat("?, ", len(list)-1) + "?", true
+	})
+	if !ok || placeholders != len(args) {
+		return "", nil, errors.ErrInvalidListParam
+	}
+	return expanded, expandedArgs, nil
+}
+
+func hasListParam(args []driver.Value) bool {
+	for _, arg := range args {
+		if _, ok := arg.(ListParam); ok {
+			return true
+		}
+	}
+	return false
+}
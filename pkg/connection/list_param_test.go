@@ -0,0 +1,69 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListParamTestSuite struct {
+	suite.Suite
+}
+
+func TestListParamSuite(t *testing.T) {
+	suite.Run(t, new(ListParamTestSuite))
+}
+
+func (suite *ListParamTestSuite) TestCheckNamedValueConvertsListValues() {
+	value := &driver.NamedValue{Ordinal: 1, Value: ListParam{1, int32(2), "a"}}
+	suite.NoError((&Connection{}).CheckNamedValue(value))
+	suite.Equal(ListParam{int64(1), int64(2), "a"}, value.Value)
+}
+
+func (suite *ListParamTestSuite) TestCheckNamedValueFailsForUnsupportedListValue() {
+	value := &driver.NamedValue{Ordinal: 1, Value: ListParam{struct{}{}}}
+	suite.Error((&Connection{}).CheckNamedValue(value))
+}
+
+func (suite *ListParamTestSuite) TestCheckNamedValueSkipsOtherValues() {
+	suite.ErrorIs((&Connection{}).CheckNamedValue(&driver.NamedValue{Ordinal: 1, Value: 42}), driver.ErrSkip)
+}
+
+func (suite *ListParamTestSuite) TestExpandListParams() {
+	timestamp := time.Now()
+	query, args, err := expandListParams("SELECT * FROM T WHERE A IN (?) AND B = ? AND C IN (?)",
+		[]driver.Value{ListParam{int64(1), int64(2), int64(3)}, "b", ListParam{timestamp}})
+	suite.NoError(err)
+	suite.Equal("SELECT * FROM T WHERE A IN (?, ?, ?) AND B = ? AND C IN (?)", query)
+	suite.Equal([]driver.Value{int64(1), int64(2), int64(3), "b", timestamp}, args)
+}
+
+func (suite *ListParamTestSuite) TestExpandEmptyListParam() {
+	query, args, err := expandListParams("SELECT * FROM T WHERE A IN (?)", []driver.Value{ListParam{}})
+	suite.NoError(err)
+	suite.Equal("SELECT * FROM T WHERE A IN (NULL)", query)
+	suite.Empty(args)
+}
+
+func (suite *ListParamTestSuite) TestExpandWithoutListParamsKeepsQuery() {
+	args := []driver.Value{int64(1), int64(2)}
+	query, expandedArgs, err := expandListParams("INSERT INTO T VALUES (?)", args)
+	suite.NoError(err)
+	suite.Equal("INSERT INTO T VALUES (?)", query)
+	suite.Equal(args, expandedArgs)
+}
+
+func (suite *ListParamTestSuite) TestExpandFailsForBatch() {
+	_, _, err := expandListParams("SELECT * FROM T WHERE A IN (?)", []driver.Value{ListParam{int64(1)}, ListParam{int64(2)}})
+	suite.ErrorIs(err, errors.ErrInvalidListParam)
+}
+
+func (suite *ListParamTestSuite) TestPreparedStatementRejectsListParam() {
+	statement := &Statement{connection: &Connection{}}
+	_, err := statement.executePreparedStatement(context.Background(), []driver.Value{ListParam{int64(1)}})
+	suite.ErrorIs(err, errors.ErrInvalidListParam)
+}
@@ -0,0 +1,40 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+)
+
+// LocalExport describes an export into local CSV files whose statement is generated instead of parsed from the SQL text.
+type LocalExport struct {
+	// Query is the statement as written by the user with LOCAL CSV FILE clauses.
+	// It is passed to query interceptors, statement hooks and logs.
+	Query string
+	// Statement returns the EXPORT statement sent to the database. The given target clause sends the data to the driver,
+	// e.g. CSV AT 'http://10.0.0.1:4711' FILE 'data_1.csv'.
+	Statement func(target string) string
+	// Files are the local files to write. The database splits the exported data between them.
+	Files []string
+	// Writer receives the exported data instead of files if it is not nil.
+	Writer io.Writer
+	// Hosts are the nodes the download connects to instead of the transferhosts or hosts of the connection.
+	Hosts []string
+	// ExcludeHosts are nodes the download does not connect to in addition to the excludehosts of the connection.
+	ExcludeHosts []string
+}
+
+// ExecLocalExport executes the given export into local CSV files. If a query interceptor rewrites the query,
+// the rewritten query is executed like a statement passed to Exec.
+func (c *Connection) ExecLocalExport(ctx context.Context, localExport LocalExport) (driver.Result, error) {
+	return c.execWithTransfer(ctx, localExport.Query, nil, func(query string) (localTransfer, error) {
+		if query != localExport.Query {
+			return c.newTransfer(query, nil)
+		}
+		hosts, err := c.transferHosts(localExport.Hosts, localExport.ExcludeHosts)
+		if err != nil {
+			return nil, err
+		}
+		return newLocalExportStatement(query, localExport, hosts, c.Config.Port)
+	})
+}
@@ -0,0 +1,46 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+)
+
+// LocalImport describes an import of local CSV files whose statement is generated instead of parsed from the SQL text.
+type LocalImport struct {
+	// Query is the statement as written by the user with LOCAL CSV FILE clauses.
+	// It is passed to query interceptors, statement hooks and logs.
+	Query string
+	// Statement returns the IMPORT statement sent to the database. The given source clause reads the uploaded data,
+	// e.g. CSV AT 'http://10.0.0.1:4711' FILE 'data.csv'.
+	Statement func(source string) string
+	// Files are the local files, glob patterns, directories or URLs of registered file openers to upload.
+	Files []string
+	// Reader is uploaded instead of the files if it is not nil.
+	Reader io.Reader
+	// Encoding is the source encoding converted to UTF-8 during upload, empty if the data is sent unchanged.
+	Encoding string
+	// Dialect is the CSV format of the data, used for counting the uploaded rows.
+	Dialect utils.CSVDialect
+	// Hosts are the nodes the upload connects to instead of the transferhosts or hosts of the connection.
+	Hosts []string
+	// ExcludeHosts are nodes the upload does not connect to in addition to the excludehosts of the connection.
+	ExcludeHosts []string
+}
+
+// ExecLocalImport executes the given import of local CSV files. If a query interceptor rewrites the query,
+// the rewritten query is executed like a statement passed to Exec.
+func (c *Connection) ExecLocalImport(ctx context.Context, localImport LocalImport) (driver.Result, error) {
+	return c.execWithTransfer(ctx, localImport.Query, nil, func(query string) (localTransfer, error) {
+		if query != localImport.Query {
+			return c.newTransfer(query, localImport.Reader)
+		}
+		hosts, err := c.transferHosts(localImport.Hosts, localImport.ExcludeHosts)
+		if err != nil {
+			return nil, err
+		}
+		return newLocalImportStatement(query, localImport, hosts, c.Config.Port)
+	})
+}
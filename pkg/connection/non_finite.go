@@ -0,0 +1,66 @@
+package connection
+
+import (
+	"database/sql/driver"
+	"math"
+	"strconv"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// convertNonFiniteParams replaces NaN and infinite parameters with NULL if enabled with the nanasnull option.
+// Otherwise it returns an error, as DOUBLE columns do not support these values and JSON cannot represent them.
+// The given slice is not modified.
+func (c *Connection) convertNonFiniteParams(args []driver.Value) ([]driver.Value, error) {
+	var converted []driver.Value
+	for i, arg := range args {
+		f, ok := arg.(float64)
+		if !ok || !isNonFinite(f) {
+			continue
+		}
+		if !c.Config.NaNAsNull {
+			return nil, errors.NewNonFiniteParameter(i+1, f)
+		}
+		if converted == nil {
+			converted = append([]driver.Value(nil), args...)
+		}
+		converted[i] = nil
+	}
+	if converted == nil {
+		return args, nil
+	}
+	return converted, nil
+}
+
+// checkNonFiniteValues replaces NaN and infinite values of DOUBLE columns with NULL if enabled with the nanasnull option.
+// Otherwise it returns an error instead of returning a value that silently scans as NaN or fails to scan.
+func (results *QueryResults) checkNonFiniteValues(dest []driver.Value) error {
+	for i := range dest {
+		if results.data.Columns[i].DataType.Type != "DOUBLE" || !isNonFiniteValue(dest[i]) {
+			continue
+		}
+		if results.con == nil || !results.con.Config.NaNAsNull {
+			return errors.NewNonFiniteValue(results.data.Columns[i].Name, dest[i])
+		}
+		dest[i] = nil
+	}
+	return nil
+}
+
+// isNonFiniteValue checks if a value of a DOUBLE column is NaN, infinite or a number exceeding the range of float64.
+// Numbers are returned as strings if they cannot be converted to float64 exactly.
+func isNonFiniteValue(value driver.Value) bool {
+	switch v := value.(type) {
+	case float64:
+		return isNonFinite(v)
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return isNonFinite(f) || err != nil
+	default:
+		return false
+	}
+}
+
+func isNonFinite(f float64) bool {
+	return math.IsNaN(f) || math.IsInf(f, 0)
+}
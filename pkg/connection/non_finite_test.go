@@ -0,0 +1,79 @@
+package connection
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type NonFiniteTestSuite struct {
+	suite.Suite
+}
+
+func TestNonFiniteSuite(t *testing.T) {
+	suite.Run(t, new(NonFiniteTestSuite))
+}
+
+func (suite *NonFiniteTestSuite) TestConvertParamsFailsForNonFiniteValues() {
+	for _, value := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := suite.connection(false).convertNonFiniteParams([]driver.Value{1.5, value})
+		suite.ErrorContains(err, "E-EGOD-54: parameter 2 has value")
+	}
+}
+
+func (suite *NonFiniteTestSuite) TestConvertParamsToNull() {
+	args := []driver.Value{math.NaN(), 1.5, "a", math.Inf(-1)}
+	converted, err := suite.connection(true).convertNonFiniteParams(args)
+	suite.NoError(err)
+	suite.Equal([]driver.Value{nil, 1.5, "a", nil}, converted)
+	suite.True(math.IsNaN(args[0].(float64)), "arguments must not be modified")
+}
+
+func (suite *NonFiniteTestSuite) TestConvertParamsKeepsFiniteValues() {
+	args := []driver.Value{1.5, int64(1), nil}
+	converted, err := suite.connection(false).convertNonFiniteParams(args)
+	suite.NoError(err)
+	suite.Equal(args, converted)
+}
+
+func (suite *NonFiniteTestSuite) TestNextFailsForNonFiniteDouble() {
+	for _, value := range []interface{}{"NaN", "Infinity", "-Infinity", json.Number("1e400")} {
+		queryResults := suite.doubleResults(false, value)
+		err := queryResults.Next(make([]driver.Value, 2))
+		suite.ErrorContains(err, "E-EGOD-55: column 'D' contains value")
+	}
+}
+
+func (suite *NonFiniteTestSuite) TestNextReturnsNonFiniteDoubleAsNull() {
+	queryResults := suite.doubleResults(true, "NaN")
+	dest := make([]driver.Value, 2)
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{nil, "NaN"}, dest)
+}
+
+func (suite *NonFiniteTestSuite) TestNextReturnsFiniteDouble() {
+	queryResults := suite.doubleResults(false, json.Number("1.5"))
+	dest := make([]driver.Value, 2)
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{1.5, "NaN"}, dest)
+}
+
+func (suite *NonFiniteTestSuite) connection(nanAsNull bool) *Connection {
+	return &Connection{Config: &config.Config{NaNAsNull: nanAsNull}}
+}
+
+func (suite *NonFiniteTestSuite) doubleResults(nanAsNull bool, value interface{}) *QueryResults {
+	data := types.SqlQueryResponseResultSetData{NumRows: 1, NumRowsInMessage: 1,
+		Columns: []types.SqlQueryColumn{
+			{Name: "D", DataType: types.SqlQueryColumnType{Type: "DOUBLE"}},
+			{Name: "V", DataType: types.SqlQueryColumnType{Type: "VARCHAR"}},
+		},
+		Data: [][]interface{}{{value}, {"NaN"}},
+	}
+	return &QueryResults{data: &data, con: suite.connection(nanAsNull)}
+}
@@ -0,0 +1,83 @@
+package connection
+
+import (
+	"encoding/json"
+
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// Warning is a warning returned by the database together with the response to a command.
+type Warning struct {
+	Text    string          // Warning message
+	SQLCode string          // SQL code of the warning, empty if the database did not send one
+	Raw     json.RawMessage // Warning as sent by the database
+}
+
+// Notice contains the warnings and changed session attributes of a single response.
+type Notice struct {
+	Warnings []Warning
+	// Attributes contains the session attributes changed by the command, e.g. autocommit after an implicit rollback.
+	// It is nil if no attributes changed.
+	Attributes *types.Attributes
+}
+
+// NoticeCallback is called for each response that contains warnings or changed session attributes.
+type NoticeCallback func(notice Notice)
+
+// WarningsProvider is implemented by connections of this driver.
+// Use it with [database/sql.Conn.Raw] to get the warnings of the last statement.
+type WarningsProvider interface {
+	Warnings() []Warning
+}
+
+// Warnings returns the warnings of the last statement executed by the connection. They include the warnings of the
+// commands following the execution, e.g. closing the prepared statement and fetching the rows of the result set.
+func (c *Connection) Warnings() []Warning {
+	return append([]Warning(nil), c.warnings...)
+}
+
+// startStatement discards the warnings of the previous statement.
+func (c *Connection) startStatement() {
+	c.warnings = c.warnings[:0]
+}
+
+// recordNotices adds the warnings of the response to the warnings of the statement and reports them together with
+// changed attributes to the notice callback.
+func (c *Connection) recordNotices(result *types.BaseResponse) error {
+	var warnings []Warning
+	for _, raw := range result.Warnings {
+		warnings = append(warnings, c.parseWarning(raw))
+	}
+	c.warnings = append(c.warnings, warnings...)
+	if c.NoticeCallback == nil || (len(warnings) == 0 && len(result.Attributes) == 0) {
+		return nil
+	}
+	notice := Notice{Warnings: warnings}
+	if len(result.Attributes) > 0 {
+		notice.Attributes = &types.Attributes{}
+		if err := c.jsonCodec().Unmarshal(result.Attributes, notice.Attributes); err != nil {
+			return err
+		}
+	}
+	c.NoticeCallback(notice)
+	return nil
+}
+
+// parseWarning accepts warnings sent as plain message or as object with text and sqlCode.
+func (c *Connection) parseWarning(raw json.RawMessage) Warning {
+	warning := Warning{Raw: append(json.RawMessage(nil), raw...)}
+	if err := c.jsonCodec().Unmarshal(raw, &warning.Text); err == nil {
+		return warning
+	}
+	var object struct {
+		Text    string `json:"text"`
+		SQLCode string `json:"sqlCode"`
+	}
+	if err := c.jsonCodec().Unmarshal(raw, &object); err == nil {
+		warning.Text = object.Text
+		warning.SQLCode = object.SQLCode
+	} else {
+		warning.Text = string(raw)
+	}
+	return warning
+}
@@ -0,0 +1,116 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type NoticesTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestNoticesSuite(t *testing.T) {
+	suite.Run(t, new(NoticesTestSuite))
+}
+
+func (suite *NoticesTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+func (suite *NoticesTestSuite) TestWarningsOfLastStatement() {
+	suite.simulateResponse(`{"status":"ok","warnings":["plain warning",{"text":"object warning","sqlCode":"01000"}]}`)
+	suite.simulateResponse(`{"status":"ok"}`)
+	suite.simulateResponse(`{"status":"ok"}`)
+	conn := suite.createConnection()
+	expected := []Warning{
+		{Text: "plain warning", Raw: json.RawMessage(`"plain warning"`)},
+		{Text: "object warning", SQLCode: "01000", Raw: json.RawMessage(`{"text":"object warning","sqlCode":"01000"}`)},
+	}
+
+	suite.NoError(conn.Send(context.Background(), &types.Command{Command: "execute"}, nil))
+	suite.Equal(expected, conn.Warnings())
+
+	suite.NoError(conn.Send(context.Background(), &types.Command{Command: "fetch"}, nil))
+	suite.Equal(expected, conn.Warnings())
+
+	conn.startStatement()
+	suite.NoError(conn.Send(context.Background(), &types.Command{Command: "execute"}, nil))
+	suite.Empty(conn.Warnings())
+}
+
+func (suite *NoticesTestSuite) TestWarningsOfPreparedStatementAreKeptAfterClosingIt() {
+	column := types.SqlQueryColumn{Name: "col", DataType: types.SqlQueryColumnType{Type: "DECIMAL"}}
+	suite.simulateResponse(`{"status":"ok","warnings":["previous statement"]}`)
+	suite.websocketMock.SimulateOKResponse(types.SqlCommand{Command: types.Command{Command: "createPreparedStatement"}, SQLText: "INSERT INTO T VALUES (?)"},
+		types.CreatePreparedStatementResponse{StatementHandle: 1, ParameterData: types.ParameterData{NumColumns: 1, Columns: []types.SqlQueryColumn{column}}})
+	suite.websocketMock.OnWriteAnyMessage(nil)
+	suite.websocketMock.OnReadTextMessage([]byte(`{"status":"ok","responseData":{"numResults":1,"results":[{"resultType":"rowCount","rowCount":1}]},"warnings":["value truncated"]}`), nil)
+	suite.websocketMock.SimulateOKResponse(types.ClosePreparedStatementCommand{Command: types.Command{Command: "closePreparedStatement"}, StatementHandle: 1}, nil)
+	conn := suite.createConnection()
+	suite.NoError(conn.Send(context.Background(), &types.Command{Command: "execute"}, nil))
+
+	_, err := conn.ExecContext(context.Background(), "INSERT INTO T VALUES (?)", []driver.NamedValue{{Ordinal: 1, Value: int64(1)}})
+	suite.NoError(err)
+	suite.Equal([]Warning{{Text: "value truncated", Raw: json.RawMessage(`"value truncated"`)}}, conn.Warnings())
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *NoticesTestSuite) TestNoticeCallbackReceivesWarningsOfEachResponse() {
+	suite.simulateResponse(`{"status":"ok","warnings":["first"]}`)
+	suite.simulateResponse(`{"status":"ok","warnings":["second"]}`)
+	conn := suite.createConnection()
+	var notices []Notice
+	conn.NoticeCallback = func(notice Notice) { notices = append(notices, notice) }
+
+	suite.NoError(conn.Send(context.Background(), &types.Command{Command: "execute"}, nil))
+	suite.NoError(conn.Send(context.Background(), &types.Command{Command: "fetch"}, nil))
+	suite.Require().Len(notices, 2)
+	suite.Equal([]Warning{{Text: "second", Raw: json.RawMessage(`"second"`)}}, notices[1].Warnings)
+	suite.Len(conn.Warnings(), 2)
+}
+
+func (suite *NoticesTestSuite) TestUnknownWarningFormat() {
+	suite.Equal(Warning{Text: "42", Raw: json.RawMessage(`42`)}, suite.createConnection().parseWarning(json.RawMessage(`42`)))
+}
+
+func (suite *NoticesTestSuite) TestNoticeCallbackReceivesWarningsAndChangedAttributes() {
+	suite.simulateResponse(`{"status":"ok","attributes":{"autocommit":true},"warnings":["implicit rollback"]}`)
+	conn := suite.createConnection()
+	var notices []Notice
+	conn.NoticeCallback = func(notice Notice) { notices = append(notices, notice) }
+
+	suite.NoError(conn.Send(context.Background(), &types.Command{Command: "execute"}, nil))
+	suite.Require().Len(notices, 1)
+	suite.Equal("implicit rollback", notices[0].Warnings[0].Text)
+	suite.Equal(true, *notices[0].Attributes.Autocommit)
+	suite.Empty(notices[0].Attributes.CurrentSchema)
+}
+
+func (suite *NoticesTestSuite) TestNoticeCallbackNotCalledWithoutNotices() {
+	suite.simulateResponse(`{"status":"ok"}`)
+	conn := suite.createConnection()
+	conn.NoticeCallback = func(notice Notice) { suite.Fail("unexpected notice", notice) }
+
+	suite.NoError(conn.Send(context.Background(), &types.Command{Command: "execute"}, nil))
+}
+
+func (suite *NoticesTestSuite) simulateResponse(response string) {
+	suite.websocketMock.OnWriteAnyMessage(nil)
+	suite.websocketMock.OnReadTextMessage([]byte(response), nil)
+}
+
+func (suite *NoticesTestSuite) createConnection() *Connection {
+	return &Connection{
+		Config:    &config.Config{},
+		Ctx:       context.Background(),
+		websocket: suite.websocketMock,
+	}
+}
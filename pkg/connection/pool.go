@@ -0,0 +1,112 @@
+package connection
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"io"
+	"sync"
+
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// Buffers and compressors are reused between messages, so that the garbage collection load
+// does not grow with the number of bytes sent and received.
+var (
+	messageBufferPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+	zlibWriterPool    = sync.Pool{New: func() any { return zlib.NewWriter(nil) }}
+	zlibReaderPool    sync.Pool
+	readBufferPool    = sync.Pool{New: func() any { return bufio.NewReaderSize(nil, readBufferSize) }}
+	prefixBufferPool  = sync.Pool{New: func() any { return &prefixBuffer{limit: maxLoggedMessageLength} }}
+	baseResponsePool  = sync.Pool{New: func() any { return &types.BaseResponse{} }}
+)
+
+// readBufferSize is the size of the buffer between the network and the decompressor.
+const readBufferSize = 32 * 1024
+
+// maxPooledBufferSize is the largest capacity of buffers that are returned to their pools.
+// Larger buffers of exceptionally large messages are left to the garbage collector instead of being kept forever.
+const maxPooledBufferSize = 64 * 1024
+
+// compress returns the zlib compressed message in a pooled buffer that must be released with releaseMessageBuffer.
+func compress(message []byte) (*bytes.Buffer, error) {
+	buffer := messageBufferPool.Get().(*bytes.Buffer)
+	buffer.Reset()
+	writer := zlibWriterPool.Get().(*zlib.Writer)
+	defer zlibWriterPool.Put(writer)
+	writer.Reset(buffer)
+	if _, err := writer.Write(message); err != nil {
+		releaseMessageBuffer(buffer)
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		releaseMessageBuffer(buffer)
+		return nil, err
+	}
+	return buffer, nil
+}
+
+func releaseMessageBuffer(buffer *bytes.Buffer) {
+	if buffer.Cap() <= maxPooledBufferSize {
+		messageBufferPool.Put(buffer)
+	}
+}
+
+// pooledDecompressor reads zlib compressed data using a pooled buffer and decompressor.
+type pooledDecompressor struct {
+	buffer       *bufio.Reader
+	decompressor io.ReadCloser
+}
+
+func newPooledDecompressor(reader io.Reader) (*pooledDecompressor, error) {
+	buffer := readBufferPool.Get().(*bufio.Reader)
+	buffer.Reset(reader)
+	var err error
+	decompressor, ok := zlibReaderPool.Get().(io.ReadCloser)
+	if ok {
+		err = decompressor.(zlib.Resetter).Reset(buffer, nil)
+	} else {
+		decompressor, err = zlib.NewReader(buffer)
+	}
+	if err != nil {
+		buffer.Reset(nil)
+		readBufferPool.Put(buffer)
+		return nil, err
+	}
+	return &pooledDecompressor{buffer: buffer, decompressor: decompressor}, nil
+}
+
+func (d *pooledDecompressor) Read(p []byte) (int, error) {
+	return d.decompressor.Read(p)
+}
+
+// release returns the buffer and decompressor to their pools. The decompressor must not be used afterwards.
+func (d *pooledDecompressor) release() {
+	_ = d.decompressor.Close()
+	zlibReaderPool.Put(d.decompressor)
+	d.buffer.Reset(nil)
+	readBufferPool.Put(d.buffer)
+}
+
+func getPrefixBuffer() *prefixBuffer {
+	buffer := prefixBufferPool.Get().(*prefixBuffer)
+	buffer.Reset()
+	return buffer
+}
+
+func releasePrefixBuffer(buffer *prefixBuffer) {
+	prefixBufferPool.Put(buffer)
+}
+
+// getBaseResponse returns an empty response that reuses the memory of the response data of an earlier response.
+func getBaseResponse() *types.BaseResponse {
+	response := baseResponsePool.Get().(*types.BaseResponse)
+	*response = types.BaseResponse{ResponseData: response.ResponseData[:0]}
+	return response
+}
+
+func releaseBaseResponse(response *types.BaseResponse) {
+	if cap(response.ResponseData) <= maxPooledBufferSize {
+		baseResponsePool.Put(response)
+	}
+}
@@ -0,0 +1,155 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type PoolTestSuite struct {
+	suite.Suite
+}
+
+func TestPoolSuite(t *testing.T) {
+	suite.Run(t, new(PoolTestSuite))
+}
+
+func (suite *PoolTestSuite) TestCompressAndDecompress() {
+	for i := 0; i < 3; i++ {
+		message := []byte(fmt.Sprintf(`{"status": "ok", "responseData": %d}`, i))
+		buffer, err := compress(message)
+		suite.NoError(err)
+		decompressor, err := newPooledDecompressor(bytes.NewReader(buffer.Bytes()))
+		suite.NoError(err)
+		releaseMessageBuffer(buffer)
+		decompressed, err := io.ReadAll(decompressor)
+		suite.NoError(err)
+		decompressor.release()
+		suite.Equal(message, decompressed)
+	}
+}
+
+func (suite *PoolTestSuite) TestDecompressInvalidData() {
+	decompressor, err := newPooledDecompressor(bytes.NewReader([]byte("invalid")))
+	suite.EqualError(err, "zlib: invalid header")
+	suite.Nil(decompressor)
+}
+
+func (suite *PoolTestSuite) TestBaseResponseIsReset() {
+	response := getBaseResponse()
+	suite.NoError(json.Unmarshal([]byte(`{"status": "error", "responseData": {"a": 1}, "exception": {"text": "failed"}}`), response))
+	releaseBaseResponse(response)
+
+	response = getBaseResponse()
+	defer releaseBaseResponse(response)
+	suite.NoError(json.Unmarshal([]byte(`{"status": "ok"}`), response))
+	suite.Equal("ok", response.Status)
+	suite.Empty(response.ResponseData)
+	suite.Nil(response.Exception)
+}
+
+func (suite *PoolTestSuite) TestOversizedMessageBufferIsNotReused() {
+	buffer, err := compress([]byte("message"))
+	suite.NoError(err)
+	buffer.Grow(2 * maxPooledBufferSize)
+	releaseMessageBuffer(buffer)
+
+	reused := messageBufferPool.Get().(*bytes.Buffer)
+	defer releaseMessageBuffer(reused)
+	suite.NotSame(buffer, reused)
+	suite.LessOrEqual(reused.Cap(), maxPooledBufferSize)
+}
+
+func (suite *PoolTestSuite) TestOversizedResponseDataIsNotReused() {
+	response := getBaseResponse()
+	suite.NoError(json.Unmarshal([]byte(fmt.Sprintf(`{"status": "ok", "responseData": "%s"}`,
+		bytes.Repeat([]byte("a"), 2*maxPooledBufferSize))), response))
+	releaseBaseResponse(response)
+
+	reused := getBaseResponse()
+	defer releaseBaseResponse(reused)
+	suite.NotSame(response, reused)
+	suite.LessOrEqual(cap(reused.ResponseData), maxPooledBufferSize)
+}
+
+func (suite *PoolTestSuite) TestPrefixBufferIsReset() {
+	buffer := getPrefixBuffer()
+	_, _ = buffer.Write([]byte("message"))
+	releasePrefixBuffer(buffer)
+
+	buffer = getPrefixBuffer()
+	defer releasePrefixBuffer(buffer)
+	suite.Equal(0, buffer.Len())
+}
+
+// benchmarkConnection returns the same response for each request.
+type benchmarkConnection struct {
+	messageType int
+	response    []byte
+}
+
+func (c *benchmarkConnection) WriteMessage(messageType int, data []byte) error {
+	return nil
+}
+
+func (c *benchmarkConnection) ReadMessage() (int, []byte, error) {
+	return c.messageType, c.response, nil
+}
+
+func (c *benchmarkConnection) NextReader() (int, io.Reader, error) {
+	return c.messageType, bytes.NewReader(c.response), nil
+}
+
+func (c *benchmarkConnection) Close() error {
+	return nil
+}
+
+func BenchmarkSendUncompressed(b *testing.B) {
+	benchmarkSend(b, false)
+}
+
+func BenchmarkSendCompressed(b *testing.B) {
+	benchmarkSend(b, true)
+}
+
+func benchmarkSend(b *testing.B, compression bool) {
+	data := make([][]interface{}, 2)
+	for row := 0; row < 1000; row++ {
+		data[0] = append(data[0], float64(row))
+		data[1] = append(data[1], fmt.Sprintf("value %d", row))
+	}
+	response, _ := json.Marshal(map[string]interface{}{"status": "ok", "responseData": types.SqlQueryResponseResultSetData{
+		NumRows: 1000, NumRowsInMessage: 1000, Data: data,
+	}})
+	messageType := websocket.TextMessage
+	if compression {
+		buffer, _ := compress(response)
+		response = bytes.Clone(buffer.Bytes())
+		releaseMessageBuffer(buffer)
+		messageType = websocket.BinaryMessage
+	}
+	conn := &Connection{
+		Config:    &config.Config{Compression: compression},
+		Ctx:       context.Background(),
+		websocket: &benchmarkConnection{messageType: messageType, response: response},
+	}
+	request := &types.FetchCommand{Command: types.Command{Command: "fetch"}, ResultSetHandle: 1, NumBytes: 1024}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(response)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result := &types.SqlQueryResponseResultSetData{}
+		if err := conn.Send(context.Background(), request, result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package connection
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+)
+
+// maxTracedPayloadLength is the maximum length of a request payload in the protocol trace, longer payloads are truncated.
+const maxTracedPayloadLength = 1024
+
+// messageTrace collects the sizes and the latency of a command and its response for the protocol trace.
+// Methods of a nil trace do nothing, so that callers don't need to check if the trace is enabled.
+type messageTrace struct {
+	command              string
+	payload              []byte
+	sent                 int
+	sentCompressed       int
+	start                time.Time
+	received             countingReader
+	receivedUncompressed countingReader
+	compressed           bool
+}
+
+// newMessageTrace returns a trace of the given request message if the protocol trace is enabled, otherwise nil.
+func (c *Connection) newMessageTrace(message []byte) *messageTrace {
+	if !c.Config.DebugProtocol {
+		return nil
+	}
+	var command struct {
+		Command string `json:"command"`
+	}
+	_ = c.jsonCodec().Unmarshal(message, &command)
+	trace := &messageTrace{command: command.Command, sent: len(message)}
+	if c.Config.DebugPayloads {
+		trace.payload = utils.RedactFrame(message)
+	}
+	return trace
+}
+
+// sending records the size of the compressed message and starts measuring the latency.
+func (t *messageTrace) sending(message []byte, compressed bool) {
+	if t == nil {
+		return
+	}
+	if compressed {
+		t.sentCompressed = len(message)
+	}
+	t.compressed = compressed
+	t.start = time.Now()
+}
+
+// countReceived counts the bytes of the response read from the network.
+func (t *messageTrace) countReceived(reader io.Reader) io.Reader {
+	if t == nil {
+		return reader
+	}
+	t.received.reader = reader
+	return &t.received
+}
+
+// countUncompressed counts the bytes of the decompressed response.
+func (t *messageTrace) countUncompressed(reader io.Reader) io.Reader {
+	if t == nil {
+		return reader
+	}
+	t.receivedUncompressed.reader = reader
+	return &t.receivedUncompressed
+}
+
+// log writes the trace of the completed command to the trace logger.
+func (t *messageTrace) log(err error) {
+	if t == nil {
+		return
+	}
+	var line strings.Builder
+	fmt.Fprintf(&line, "protocol: command=%s latency=%s sent=%dB", t.command, time.Since(t.start).Round(time.Microsecond), t.sent)
+	if t.compressed {
+		fmt.Fprintf(&line, " (compressed %dB, %s)", t.sentCompressed, compressionRatio(t.sent, t.sentCompressed))
+	}
+	fmt.Fprintf(&line, " received=%dB", t.received.count)
+	if t.compressed {
+		fmt.Fprintf(&line, " (uncompressed %dB, %s)", t.receivedUncompressed.count, compressionRatio(t.receivedUncompressed.count, t.received.count))
+	}
+	if err != nil {
+		fmt.Fprintf(&line, " error=%q", err.Error())
+	}
+	if t.payload != nil {
+		fmt.Fprintf(&line, " payload=%s", truncatePayload(t.payload))
+	}
+	logger.TraceLogger.Print(line.String())
+}
+
+// compressionRatio returns the size of the compressed data in percent of the uncompressed size.
+func compressionRatio(uncompressed, compressed int) string {
+	if uncompressed == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f%%", float64(compressed)*100/float64(uncompressed))
+}
+
+func truncatePayload(payload []byte) string {
+	if len(payload) > maxTracedPayloadLength {
+		return fmt.Sprintf("%s... (%d bytes truncated)", payload[:maxTracedPayloadLength], len(payload)-maxTracedPayloadLength)
+	}
+	return string(payload)
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += n
+	return n, err
+}
@@ -0,0 +1,100 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProtocolTraceTestSuite struct {
+	suite.Suite
+	websocketMock  *wsconn.WebsocketConnectionMock
+	logBuffer      *bytes.Buffer
+	previousLogger logger.Logger
+}
+
+func TestProtocolTraceSuite(t *testing.T) {
+	suite.Run(t, new(ProtocolTraceTestSuite))
+}
+
+func (suite *ProtocolTraceTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+	suite.logBuffer = &bytes.Buffer{}
+	suite.previousLogger = logger.TraceLogger
+	logger.TraceLogger = log.New(suite.logBuffer, "", 0)
+}
+
+func (suite *ProtocolTraceTestSuite) TearDownTest() {
+	logger.TraceLogger = suite.previousLogger
+}
+
+func (suite *ProtocolTraceTestSuite) TestTraceDisabled() {
+	suite.websocketMock.OnWriteTextMessage([]byte(`{"command":"login","protocolVersion":0,"attributes":{}}`), nil)
+	suite.websocketMock.OnReadTextMessage([]byte(`{"status":"ok","responseData":{"publicKeyPem":"pem"}}`), nil)
+	conn := suite.createOpenConnection(config.Config{})
+	suite.NoError(conn.Send(context.Background(), types.LoginCommand{Command: types.Command{Command: "login"}}, &types.PublicKeyResponse{}))
+	suite.Empty(suite.logBuffer.String())
+}
+
+func (suite *ProtocolTraceTestSuite) TestTraceCommand() {
+	suite.websocketMock.OnWriteTextMessage([]byte(`{"command":"login","protocolVersion":0,"attributes":{}}`), nil)
+	suite.websocketMock.OnReadTextMessage([]byte(`{"status":"ok","responseData":{"publicKeyPem":"pem"}}`), nil)
+	conn := suite.createOpenConnection(config.Config{DebugProtocol: true})
+	suite.NoError(conn.Send(context.Background(), types.LoginCommand{Command: types.Command{Command: "login"}}, &types.PublicKeyResponse{}))
+	suite.Regexp(`^protocol: command=login latency=\S+ sent=55B received=53B\n$`, suite.logBuffer.String())
+}
+
+func (suite *ProtocolTraceTestSuite) TestTraceCompressedCommand() {
+	suite.websocketMock.OnWriteCompressedMessage([]byte(`{"command":"login","protocolVersion":0,"attributes":{}}`), nil)
+	suite.websocketMock.OnReadCompressedMessage([]byte(`{"status":"ok","responseData":{"publicKeyPem":"pem"}}`), nil)
+	conn := suite.createOpenConnection(config.Config{DebugProtocol: true, Compression: true})
+	suite.NoError(conn.Send(context.Background(), types.LoginCommand{Command: types.Command{Command: "login"}}, &types.PublicKeyResponse{}))
+	suite.Regexp(`^protocol: command=login latency=\S+ sent=55B \(compressed \d+B, \d+%\) received=\d+B \(uncompressed 53B, \d+%\)\n$`, suite.logBuffer.String())
+}
+
+func (suite *ProtocolTraceTestSuite) TestTraceFailedCommand() {
+	suite.websocketMock.OnWriteAnyMessage(nil)
+	suite.websocketMock.OnReadTextMessage([]byte(`{"status":"error","exception":{"text":"mock error","sqlCode":"42000"}}`), nil)
+	conn := suite.createOpenConnection(config.Config{DebugProtocol: true})
+	suite.Error(conn.Send(context.Background(), types.Command{Command: "execute"}, nil))
+	suite.Contains(suite.logBuffer.String(), `protocol: command=execute`)
+	suite.Contains(suite.logBuffer.String(), `error="E-EGOD-11: execution failed with SQL error code '42000' and message 'mock error'"`)
+}
+
+func (suite *ProtocolTraceTestSuite) TestTraceFailedWrite() {
+	suite.websocketMock.OnWriteAnyMessage(fmt.Errorf("mock error"))
+	conn := suite.createOpenConnection(config.Config{DebugProtocol: true})
+	suite.Error(conn.Send(context.Background(), types.Command{Command: "execute"}, nil))
+	suite.Contains(suite.logBuffer.String(), `received=0B error="mock error"`)
+}
+
+func (suite *ProtocolTraceTestSuite) TestTracePayloadRedacted() {
+	suite.websocketMock.OnWriteAnyMessage(nil)
+	suite.websocketMock.OnReadTextMessage([]byte(`{"status":"ok","responseData":{}}`), nil)
+	conn := suite.createOpenConnection(config.Config{DebugProtocol: true, DebugPayloads: true})
+	suite.NoError(conn.Send(context.Background(), types.AuthCommand{Username: "sys", Password: "secret"}, nil))
+	suite.Contains(suite.logBuffer.String(), `"password":"***"`)
+	suite.NotContains(suite.logBuffer.String(), "secret")
+}
+
+func (suite *ProtocolTraceTestSuite) TestTruncatePayload() {
+	suite.Equal("abc", truncatePayload([]byte("abc")))
+	suite.Equal(fmt.Sprintf("%s... (1 bytes truncated)", bytes.Repeat([]byte("a"), maxTracedPayloadLength)), truncatePayload(bytes.Repeat([]byte("a"), maxTracedPayloadLength+1)))
+}
+
+func (suite *ProtocolTraceTestSuite) createOpenConnection(cfg config.Config) *Connection {
+	return &Connection{
+		Config:    &cfg,
+		Ctx:       context.Background(),
+		IsClosed:  false,
+		websocket: suite.websocketMock,
+	}
+}
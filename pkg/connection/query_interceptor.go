@@ -0,0 +1,20 @@
+package connection
+
+import "context"
+
+// QueryInterceptor can rewrite the SQL text of a statement before it is sent to the database,
+// e.g. for adding query hints or tenant filters. If it returns an error, the statement is not executed
+// and the error is returned to the caller.
+type QueryInterceptor func(ctx context.Context, query string) (string, error)
+
+// interceptQuery passes the query through all interceptors in the order they were added.
+func (c *Connection) interceptQuery(ctx context.Context, query string) (string, error) {
+	for _, interceptor := range c.QueryInterceptors {
+		var err error
+		query, err = interceptor(ctx, query)
+		if err != nil {
+			return "", err
+		}
+	}
+	return query, nil
+}
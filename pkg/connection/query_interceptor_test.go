@@ -0,0 +1,75 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryInterceptorTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+	conn          *Connection
+}
+
+func TestQueryInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(QueryInterceptorTestSuite))
+}
+
+func (suite *QueryInterceptorTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+	suite.conn = &Connection{Config: &config.Config{}, Ctx: context.Background(), websocket: suite.websocketMock}
+}
+
+func (suite *QueryInterceptorTestSuite) TestInterceptorsRewriteQueryInOrder() {
+	suite.conn.QueryInterceptors = []QueryInterceptor{
+		func(ctx context.Context, query string) (string, error) { return query + " WHERE TENANT = 1", nil },
+		func(ctx context.Context, query string) (string, error) { return query + " LIMIT 10", nil },
+	}
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "SELECT * FROM T WHERE TENANT = 1 LIMIT 10"},
+		types.SqlQueryResponseResultSet{ResultType: "resultSet"})
+	rows, err := suite.conn.query(context.Background(), "SELECT * FROM T", nil)
+	suite.NoError(err)
+	suite.NotNil(rows)
+}
+
+func (suite *QueryInterceptorTestSuite) TestInterceptorRejectsQuery() {
+	var called bool
+	suite.conn.QueryInterceptors = []QueryInterceptor{
+		func(ctx context.Context, query string) (string, error) { return "", fmt.Errorf("rejected %q", query) },
+		func(ctx context.Context, query string) (string, error) { called = true; return query, nil },
+	}
+	_, err := suite.conn.exec(context.Background(), "DROP TABLE T", nil)
+	suite.EqualError(err, `rejected "DROP TABLE T"`)
+	_, err = suite.conn.PrepareContext(context.Background(), "DROP TABLE T")
+	suite.EqualError(err, `rejected "DROP TABLE T"`)
+	suite.False(called)
+	suite.websocketMock.AssertNotCalled(suite.T(), "WriteMessage")
+}
+
+func (suite *QueryInterceptorTestSuite) TestReadOnlyChecksRewrittenQuery() {
+	suite.conn.Config.ReadOnly = true
+	suite.conn.QueryInterceptors = []QueryInterceptor{
+		func(ctx context.Context, query string) (string, error) { return "DELETE FROM T", nil },
+	}
+	_, err := suite.conn.query(context.Background(), "SELECT * FROM T", nil)
+	suite.EqualError(err, "E-EGOD-44: only SELECT statements are allowed on a read-only connection")
+}
+
+func (suite *QueryInterceptorTestSuite) TestInterceptorReceivesContext() {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "tenant")
+	suite.conn.QueryInterceptors = []QueryInterceptor{
+		func(ctx context.Context, query string) (string, error) {
+			return "", fmt.Errorf("%v", ctx.Value(key{}))
+		},
+	}
+	_, err := suite.conn.exec(ctx, "SELECT 1", nil)
+	suite.EqualError(err, "tenant")
+}
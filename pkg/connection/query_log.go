@@ -0,0 +1,54 @@
+package connection
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// logQuery writes an executed statement to the trace logger if the query log is enabled.
+// Credentials in the SQL text are redacted, parameter values are only logged if explicitly enabled.
+func (c *Connection) logQuery(query string, args []driver.Value, duration time.Duration, result *types.SqlQueriesResponse, err error) {
+	if !c.Config.QueryLog {
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("query: session=%d duration=%s rows=%d", c.session.SessionID, duration, c.resultRowCount(result)))
+	if len(args) > 0 {
+		if c.Config.QueryLogParameters {
+			sb.WriteString(fmt.Sprintf(" params=%v", args))
+		} else {
+			sb.WriteString(fmt.Sprintf(" params=<%d redacted>", len(args)))
+		}
+	}
+	sb.WriteString(fmt.Sprintf(" sql=%q", utils.RedactSQL(query)))
+	if err != nil {
+		sb.WriteString(fmt.Sprintf(" error=%q", err.Error()))
+	}
+	logger.TraceLogger.Print(sb.String())
+}
+
+// resultRowCount returns the number of affected rows or the number of rows in the result set of the first result.
+func (c *Connection) resultRowCount(result *types.SqlQueriesResponse) int64 {
+	if result == nil || len(result.Results) == 0 {
+		return 0
+	}
+	var firstResult struct {
+		RowCount  int64 `json:"rowCount"`
+		ResultSet *struct {
+			NumRows int64 `json:"numRows"`
+		} `json:"resultSet"`
+	}
+	if err := c.jsonCodec().Unmarshal(result.Results[0], &firstResult); err != nil {
+		return 0
+	}
+	if firstResult.ResultSet != nil {
+		return firstResult.ResultSet.NumRows
+	}
+	return firstResult.RowCount
+}
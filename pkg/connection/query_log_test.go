@@ -0,0 +1,101 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"log"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryLogTestSuite struct {
+	suite.Suite
+	websocketMock  *wsconn.WebsocketConnectionMock
+	logBuffer      *bytes.Buffer
+	previousLogger logger.Logger
+}
+
+func TestQueryLogSuite(t *testing.T) {
+	suite.Run(t, new(QueryLogTestSuite))
+}
+
+func (suite *QueryLogTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+	suite.logBuffer = &bytes.Buffer{}
+	suite.previousLogger = logger.TraceLogger
+	logger.TraceLogger = log.New(suite.logBuffer, "", 0)
+}
+
+func (suite *QueryLogTestSuite) TearDownTest() {
+	logger.TraceLogger = suite.previousLogger
+}
+
+func (suite *QueryLogTestSuite) TestQueryLogDisabled() {
+	conn := suite.createOpenConnection(false, false)
+	conn.logQuery("SELECT 1", nil, 0, nil, nil)
+	suite.Empty(suite.logBuffer.String())
+}
+
+func (suite *QueryLogTestSuite) TestSimpleExecLogged() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "CREATE USER u IDENTIFIED BY 'secret'", Attributes: types.Attributes{}},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 0})
+	conn := suite.createOpenConnection(true, false)
+	_, err := conn.SimpleExec(context.Background(), "CREATE USER u IDENTIFIED BY 'secret'")
+	suite.NoError(err)
+	suite.Regexp(`^query: session=1234 duration=\S+ rows=0 sql="CREATE USER u IDENTIFIED BY '\*\*\*'"\n$`, suite.logBuffer.String())
+}
+
+func (suite *QueryLogTestSuite) TestFailedStatementLogged() {
+	suite.websocketMock.SimulateErrorResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "SELECT X", Attributes: types.Attributes{}},
+		mockException)
+	conn := suite.createOpenConnection(true, false)
+	_, err := conn.SimpleExec(context.Background(), "SELECT X")
+	suite.Error(err)
+	suite.Contains(suite.logBuffer.String(), `sql="SELECT X" error="E-EGOD-11: execution failed with SQL error code 'mock sql code' and message 'mock error'"`)
+}
+
+func (suite *QueryLogTestSuite) TestParametersRedacted() {
+	conn := suite.createOpenConnection(true, false)
+	conn.logQuery("INSERT INTO T VALUES (?, ?)", []driver.Value{"a", int64(1)}, 0, rowCountResponse(2), nil)
+	suite.Equal("query: session=1234 duration=0s rows=2 params=<2 redacted> sql=\"INSERT INTO T VALUES (?, ?)\"\n", suite.logBuffer.String())
+}
+
+func (suite *QueryLogTestSuite) TestParametersLoggedIfEnabled() {
+	conn := suite.createOpenConnection(true, true)
+	conn.logQuery("INSERT INTO T VALUES (?, ?)", []driver.Value{"a", int64(1)}, 0, rowCountResponse(2), nil)
+	suite.Equal("query: session=1234 duration=0s rows=2 params=[a 1] sql=\"INSERT INTO T VALUES (?, ?)\"\n", suite.logBuffer.String())
+}
+
+func (suite *QueryLogTestSuite) TestResultRowCount() {
+	resultSet := &types.SqlQueriesResponse{NumResults: 1, Results: []json.RawMessage{
+		wsconn.JsonMarshall(types.SqlQueryResponseResultSet{ResultType: "resultSet", ResultSet: types.SqlQueryResponseResultSetData{NumRows: 17}})}}
+	conn := suite.createOpenConnection(true, false)
+	suite.Equal(int64(17), conn.resultRowCount(resultSet))
+	suite.Equal(int64(3), conn.resultRowCount(rowCountResponse(3)))
+	suite.Equal(int64(0), conn.resultRowCount(nil))
+	suite.Equal(int64(0), conn.resultRowCount(&types.SqlQueriesResponse{}))
+}
+
+func rowCountResponse(rowCount int) *types.SqlQueriesResponse {
+	return &types.SqlQueriesResponse{NumResults: 1, Results: []json.RawMessage{
+		wsconn.JsonMarshall(types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: rowCount})}}
+}
+
+func (suite *QueryLogTestSuite) createOpenConnection(queryLog, queryLogParameters bool) *Connection {
+	return &Connection{
+		Config:    &config.Config{Host: "invalid", Port: 12345, QueryLog: queryLog, QueryLogParameters: queryLogParameters},
+		Ctx:       context.Background(),
+		IsClosed:  false,
+		websocket: suite.websocketMock,
+		session:   SessionInfo{SessionID: 1234},
+	}
+}
@@ -0,0 +1,26 @@
+package connection
+
+import (
+	"context"
+	"strings"
+)
+
+type queryTagKey struct{}
+
+// WithQueryTag returns a context whose statements are sent to the database with the tag as leading comment,
+// e.g. /* job=nightly-etl */ SELECT ..., so that their SQL text in EXA_ALL_SESSIONS and the auditing views
+// attributes the load to the application job. Prepared statements keep the tag of the context they were prepared with.
+// An empty tag removes the tag of the parent context.
+func WithQueryTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, queryTagKey{}, tag)
+}
+
+// tagQuery adds the tag of the context as leading comment to the query. The end of a comment in the tag is broken up,
+// so that the tag can't end the comment early.
+func tagQuery(ctx context.Context, query string) string {
+	tag, _ := ctx.Value(queryTagKey{}).(string)
+	if tag == "" {
+		return query
+	}
+	return "/* " + strings.ReplaceAll(tag, "*/", "* /") + " */ " + query
+}
@@ -0,0 +1,23 @@
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagQuery(t *testing.T) {
+	tests := []struct {
+		ctx      context.Context
+		expected string
+	}{
+		{context.Background(), "SELECT 1"},
+		{WithQueryTag(context.Background(), "job=nightly-etl"), "/* job=nightly-etl */ SELECT 1"},
+		{WithQueryTag(context.Background(), "job=*/ DROP TABLE T; /*"), "/* job=* / DROP TABLE T; /* */ SELECT 1"},
+		{WithQueryTag(WithQueryTag(context.Background(), "job=nightly-etl"), ""), "SELECT 1"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, tagQuery(test.ctx, "SELECT 1"))
+	}
+}
@@ -0,0 +1,72 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+)
+
+var (
+	frameRecordersMutex sync.Mutex
+	frameRecorders      = map[string]*sharedFrameRecorder{}
+)
+
+// sharedFrameRecorder is the recorder of a file shared by all websockets recording to it.
+type sharedFrameRecorder struct {
+	*wsconn.FrameRecorder
+	file       *os.File
+	references int
+}
+
+// recordFrames wraps the websocket so that its frames are appended to the given file.
+// The file is opened for the first websocket recording to it and closed when the last of them is closed.
+func recordFrames(ws wsconn.WebsocketConnection, path string) (wsconn.WebsocketConnection, error) {
+	frameRecordersMutex.Lock()
+	defer frameRecordersMutex.Unlock()
+	recorder, ok := frameRecorders[path]
+	if !ok {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open frame recording file %q: %w", path, err)
+		}
+		recorder = &sharedFrameRecorder{FrameRecorder: wsconn.NewFrameRecorder(file), file: file}
+		frameRecorders[path] = recorder
+	}
+	recorder.references++
+	return recorder.WrapWithCloseHook(ws, func() { releaseFrameRecorder(path, recorder) }), nil
+}
+
+func releaseFrameRecorder(path string, recorder *sharedFrameRecorder) {
+	frameRecordersMutex.Lock()
+	defer frameRecordersMutex.Unlock()
+	recorder.references--
+	if recorder.references == 0 {
+		delete(frameRecorders, path)
+		_ = recorder.file.Close()
+	}
+}
+
+// NewReplayDialFunc creates a dial function that replays the connections recorded with option recordframes
+// in the order they were opened, e.g. for reproducing protocol errors in unit tests.
+func NewReplayDialFunc(recording io.Reader) (DialFunc, error) {
+	connections, err := wsconn.ReadFrames(recording)
+	if err != nil {
+		return nil, err
+	}
+	var mutex sync.Mutex
+	return func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if len(connections) == 0 {
+			return nil, fmt.Errorf("no more recorded connections to replay for %q", url.Host)
+		}
+		frames := connections[0]
+		connections = connections[1:]
+		return wsconn.NewReplayConnection(frames), nil
+	}, nil
+}
@@ -0,0 +1,83 @@
+package connection
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecordingTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestRecordingSuite(t *testing.T) {
+	suite.Run(t, new(RecordingTestSuite))
+}
+
+func (suite *RecordingTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+func (suite *RecordingTestSuite) TestRecordAndReplay() {
+	path := filepath.Join(suite.T().TempDir(), "frames.jsonl")
+	conn := &Connection{Config: &config.Config{Host: "host", Port: 1234, RecordFrames: path}, Ctx: context.Background(),
+		DialFunc: func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+			return suite.websocketMock, nil
+		}}
+	suite.NoError(conn.Connect())
+	request := types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "SELECT 1"}
+	suite.websocketMock.SimulateSQLQueriesResponse(request, types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 42})
+	result := &types.SqlQueriesResponse{}
+	suite.NoError(conn.Send(context.Background(), request, result))
+
+	recording, err := os.ReadFile(path)
+	suite.NoError(err)
+	dialFunc, err := NewReplayDialFunc(strings.NewReader(string(recording)))
+	suite.NoError(err)
+	replayConn := &Connection{Config: &config.Config{Host: "host", Port: 1234}, Ctx: context.Background(), DialFunc: dialFunc}
+	suite.NoError(replayConn.Connect())
+	replayedResult := &types.SqlQueriesResponse{}
+	suite.NoError(replayConn.Send(context.Background(), request, replayedResult))
+	suite.Equal(result, replayedResult)
+}
+
+func (suite *RecordingTestSuite) TestReplayNoMoreConnections() {
+	dialFunc, err := NewReplayDialFunc(strings.NewReader(""))
+	suite.NoError(err)
+	conn := &Connection{Config: &config.Config{Host: "host", Port: 1234}, Ctx: context.Background(), DialFunc: dialFunc}
+	suite.EqualError(conn.Connect(), `no more recorded connections to replay for "host:1234"`)
+}
+
+func (suite *RecordingTestSuite) TestRecordingFileCannotBeOpened() {
+	path := filepath.Join(suite.T().TempDir(), "missing", "frames.jsonl")
+	conn := &Connection{Config: &config.Config{Host: "host", Port: 1234, RecordFrames: path}, Ctx: context.Background(),
+		DialFunc: func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+			return suite.websocketMock, nil
+		}}
+	suite.ErrorContains(conn.Connect(), "failed to open frame recording file")
+}
+
+func (suite *RecordingTestSuite) TestRecordingFileIsClosedWithLastWebsocket() {
+	path := filepath.Join(suite.T().TempDir(), "frames.jsonl")
+	suite.websocketMock.OnClose(nil)
+	first, err := recordFrames(suite.websocketMock, path)
+	suite.NoError(err)
+	second, err := recordFrames(suite.websocketMock, path)
+	suite.NoError(err)
+	recorder := frameRecorders[path]
+
+	suite.NoError(first.Close())
+	suite.Same(recorder, frameRecorders[path])
+	suite.NoError(second.Close())
+	suite.NotContains(frameRecorders, path)
+	suite.ErrorIs(recorder.file.Close(), os.ErrClosed)
+}
@@ -0,0 +1,65 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// ResetSession is called by database/sql before a pooled connection is reused.
+// It rolls back changes that were neither committed nor rolled back, e.g. because the caller forgot to call Rollback,
+// and restores the configured autocommit mode, so that the next user does not inherit locks and uncommitted changes.
+// Connections that cannot be reset are discarded.
+func (c *Connection) ResetSession(ctx context.Context) error {
+	if !c.IsValid() {
+		return driver.ErrBadConn
+	}
+	if c.uncommittedChanges {
+		logger.TraceLogger.Print("rolling back uncommitted changes of connection returned to the pool")
+		if _, err := c.SimpleExec(ctx, "ROLLBACK"); err != nil {
+			return errors.NewBadConnError(err)
+		}
+		c.uncommittedChanges = false
+	}
+	if c.transaction != nil {
+		// The abandoned transaction must not commit changes of the next user
+		c.transaction.connection = nil
+		c.transaction = nil
+	}
+	if c.autocommit() != c.Config.Autocommit {
+		err := c.SetSessionAttributes(ctx, &types.Attributes{Autocommit: utils.BoolToPtr(c.Config.Autocommit)})
+		if err != nil {
+			return errors.NewBadConnError(err)
+		}
+		c.attributes.Autocommit = utils.BoolToPtr(c.Config.Autocommit)
+	}
+	return nil
+}
+
+// statementExecuted remembers that the session may contain uncommitted changes if autocommit is disabled.
+func (c *Connection) statementExecuted() {
+	if !c.autocommit() {
+		c.uncommittedChanges = true
+	}
+}
+
+// transactionEnded finishes the transaction started with Begin.
+// The session contains no uncommitted changes if the commit or rollback succeeded.
+func (c *Connection) transactionEnded(succeeded bool) {
+	c.transaction = nil
+	if succeeded {
+		c.uncommittedChanges = false
+	}
+}
+
+// autocommit returns the autocommit mode of the session, which may differ from the configuration after changing the session attributes.
+func (c *Connection) autocommit() bool {
+	if c.attributes.Autocommit != nil {
+		return *c.attributes.Autocommit
+	}
+	return c.Config.Autocommit
+}
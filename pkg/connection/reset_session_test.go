@@ -0,0 +1,114 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResetSessionTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestResetSessionSuite(t *testing.T) {
+	suite.Run(t, new(ResetSessionTestSuite))
+}
+
+func (suite *ResetSessionTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+func (suite *ResetSessionTestSuite) TestResetSessionWithoutChanges() {
+	conn := suite.createOpenConnection(false)
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.websocketMock.AssertNotCalled(suite.T(), "WriteMessage")
+}
+
+func (suite *ResetSessionTestSuite) TestResetSessionRollsBackUncommittedChanges() {
+	conn := suite.createOpenConnection(false)
+	suite.simulateExec("INSERT INTO T VALUES (1)")
+	suite.simulateExec("ROLLBACK")
+	_, err := conn.SimpleExec(context.Background(), "INSERT INTO T VALUES (1)")
+	suite.NoError(err)
+	suite.True(conn.uncommittedChanges)
+
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.False(conn.uncommittedChanges)
+	suite.websocketMock.AssertNumberOfCalls(suite.T(), "WriteMessage", 2)
+}
+
+func (suite *ResetSessionTestSuite) TestResetSessionAfterCommit() {
+	conn := suite.createOpenConnection(false)
+	suite.simulateExec("INSERT INTO T VALUES (1)")
+	suite.simulateExec("COMMIT")
+	_, err := conn.SimpleExec(context.Background(), "INSERT INTO T VALUES (1)")
+	suite.NoError(err)
+	tx, err := conn.Begin()
+	suite.NoError(err)
+	suite.NoError(tx.Commit())
+
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.websocketMock.AssertNumberOfCalls(suite.T(), "WriteMessage", 2)
+}
+
+func (suite *ResetSessionTestSuite) TestResetSessionIgnoresStatementsWithAutocommit() {
+	conn := suite.createOpenConnection(true)
+	suite.simulateExec("INSERT INTO T VALUES (1)")
+	_, err := conn.SimpleExec(context.Background(), "INSERT INTO T VALUES (1)")
+	suite.NoError(err)
+
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.websocketMock.AssertNumberOfCalls(suite.T(), "WriteMessage", 1)
+}
+
+func (suite *ResetSessionTestSuite) TestResetSessionRestoresAutocommit() {
+	conn := suite.createOpenConnection(true)
+	conn.attributes.Autocommit = utils.BoolToPtr(false)
+	conn.uncommittedChanges = true
+	suite.simulateExec("ROLLBACK")
+	suite.websocketMock.SimulateOKResponse(types.SetAttributesCommand{Command: types.Command{Command: "setAttributes"},
+		Attributes: types.Attributes{Autocommit: utils.BoolToPtr(true)}}, nil)
+
+	suite.NoError(conn.ResetSession(context.Background()))
+	suite.True(*conn.attributes.Autocommit)
+	suite.websocketMock.AssertNumberOfCalls(suite.T(), "WriteMessage", 2)
+}
+
+func (suite *ResetSessionTestSuite) TestResetSessionFailsForInvalidConnection() {
+	conn := suite.createOpenConnection(false)
+	conn.closedByDatabase = true
+	suite.ErrorIs(conn.ResetSession(context.Background()), driver.ErrBadConn)
+}
+
+func (suite *ResetSessionTestSuite) TestResetSessionFailsIfRollbackFails() {
+	conn := suite.createOpenConnection(false)
+	conn.uncommittedChanges = true
+	suite.websocketMock.SimulateErrorResponse(suite.execCommand("ROLLBACK"), mockException)
+	err := conn.ResetSession(context.Background())
+	suite.ErrorIs(err, driver.ErrBadConn)
+	suite.ErrorContains(err, mockExceptionError(mockException))
+}
+
+func (suite *ResetSessionTestSuite) simulateExec(query string) {
+	suite.websocketMock.SimulateSQLQueriesResponse(suite.execCommand(query), types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 1})
+}
+
+func (suite *ResetSessionTestSuite) execCommand(query string) types.SqlCommand {
+	return types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: query, Attributes: types.Attributes{}}
+}
+
+func (suite *ResetSessionTestSuite) createOpenConnection(autocommit bool) *Connection {
+	return &Connection{
+		Config:    &config.Config{Host: "invalid", Port: 12345, User: "user", Password: "password", ApiVersion: 42, Autocommit: autocommit},
+		Ctx:       context.Background(),
+		IsClosed:  false,
+		websocket: suite.websocketMock,
+	}
+}
@@ -1,12 +1,15 @@
 package connection
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"database/sql/driver"
 	"io"
 	"reflect"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/pkg/types"
 )
@@ -18,6 +21,11 @@ type QueryResults struct {
 	fetchedRows     int
 	totalRowPointer int
 	rowPointer      int
+	tracker         *slowQueryTracker
+	fetch           fetchResponse    // reused for all fetch responses
+	fetchedColumns  [][]driver.Value // column-major values of the last fetch, reused across fetches
+	endOperation    func()           // ends the in-flight operation of the query when the result set is closed, may be nil
+	numberTypes     []numberType     // types of the numbers per column, see columnNumberType
 }
 
 func (results *QueryResults) ColumnTypeDatabaseTypeName(index int) string {
@@ -36,18 +44,50 @@ func (results *QueryResults) ColumnTypeNullable(index int) (nullable, ok bool) {
 }
 
 func (results *QueryResults) ColumnTypeScanType(index int) reflect.Type {
-	switch results.ColumnTypeDatabaseTypeName(index) {
-	case "VARCHAR", "CHAR", "GEOMETRY", "HASHTYPE", "INTERVAL DAY TO SECOND", "INTERVAL YEAR TO MONTH":
+	columnType := results.ColumnTypeDatabaseTypeName(index)
+	if rawBytesColumn(columnType) {
 		return reflect.TypeOf(sql.RawBytes{})
+	}
+	switch columnType {
 	case "BOOLEAN":
 		return reflect.TypeOf(sql.NullBool{})
 	case "DOUBLE":
 		return reflect.TypeOf(sql.NullFloat64{})
+	case "DECIMAL":
+		switch columnNumberType(results.data.Columns[index].DataType) {
+		case integerNumber:
+			return reflect.TypeOf(sql.NullInt64{})
+		case floatNumber:
+			return reflect.TypeOf(sql.NullFloat64{})
+		default:
+			return reflect.TypeOf(sql.NullString{})
+		}
 	default:
 		return reflect.TypeOf(new(interface{}))
 	}
 }
 
+// columnNumberTypes returns the types of the numbers of all columns, determined on the first call of Next.
+func (results *QueryResults) columnNumberTypes() []numberType {
+	if results.numberTypes == nil {
+		results.numberTypes = make([]numberType, len(results.data.Columns))
+		for i, column := range results.data.Columns {
+			results.numberTypes[i] = columnNumberType(column.DataType)
+		}
+	}
+	return results.numberTypes
+}
+
+// rawBytesColumn returns true for the types of columns whose values are strings scanned as sql.RawBytes.
+func rawBytesColumn(columnType string) bool {
+	switch columnType {
+	case "VARCHAR", "CHAR", "GEOMETRY", "HASHTYPE", "INTERVAL DAY TO SECOND", "INTERVAL YEAR TO MONTH":
+		return true
+	default:
+		return false
+	}
+}
+
 func (results *QueryResults) ColumnTypeLength(index int) (length int64, ok bool) {
 	if results.data.Columns[index].DataType.Size != nil {
 		return *results.data.Columns[index].DataType.Size, true
@@ -64,6 +104,11 @@ func (results *QueryResults) Columns() []string {
 }
 
 func (results *QueryResults) Close() error {
+	results.tracker.finish()
+	results.tracker = nil
+	if results.endOperation != nil {
+		defer results.endOperation()
+	}
 	if results.data.ResultSetHandle == 0 {
 		return nil
 	}
@@ -83,29 +128,72 @@ func (results *QueryResults) Next(dest []driver.Value) error {
 	}
 
 	if results.data.NumRowsInMessage < results.data.NumRows && results.totalRowPointer == results.fetchedRows {
-		result := &types.SqlQueryResponseResultSetData{}
+		results.con.Stats.inc(fetchCalls)
+		fetchStart := time.Now()
 		err := results.con.Send(context.Background(), &types.FetchCommand{
 			Command:         types.Command{Command: "fetch"},
 			ResultSetHandle: results.data.ResultSetHandle,
 			StartPosition:   results.totalRowPointer,
 			NumBytes:        results.con.Config.FetchSize * 1024,
-		}, result)
+		}, &results.fetch)
+		results.tracker.fetched(time.Since(fetchStart))
 		if err != nil {
 			return err
 		}
+		// Overwrite old data, user needs to collect the whole data if needed
+		results.fetchedColumns, err = decodeColumns(results.fetch.Data, results.data.Columns, results.fetchedColumns, results.rawBytes())
+		if err != nil {
+			return err
+		}
+		results.data.Data = nil
 		results.rowPointer = 0
-		results.fetchedRows = results.fetchedRows + result.NumRows
+		results.fetchedRows = results.fetchedRows + results.fetch.NumRows
+	}
 
-		// Overwrite old data, user needs to collect the whole data if needed
-		results.data.Data = result.Data
+	if results.fetchedColumns != nil {
+		for i := range dest {
+			dest[i] = results.fetchedColumns[i][results.rowPointer]
+		}
+	} else {
+		for i := range dest {
+			dest[i] = convertNumber(results.data.Data[i][results.rowPointer], results.columnNumberTypes()[i])
+			if value, ok := dest[i].(string); ok && results.rawBytes() && rawBytesColumn(results.data.Columns[i].DataType.Type) {
+				dest[i] = []byte(value)
+			}
+		}
 	}
 
-	for i := range dest {
-		dest[i] = results.data.Data[i][results.rowPointer]
+	results.trimCharValues(dest)
+	if err := results.checkNonFiniteValues(dest); err != nil {
+		return err
 	}
 
 	results.rowPointer = results.rowPointer + 1
 	results.totalRowPointer = results.totalRowPointer + 1
+	results.tracker.rowRead()
 
 	return nil
 }
+
+// rawBytes returns true if string values are returned as []byte as enabled with the rawbytes option.
+func (results *QueryResults) rawBytes() bool {
+	return results.con != nil && results.con.Config.RawBytes
+}
+
+// trimCharValues removes the space padding from values of CHAR columns if enabled with the trimchar option.
+func (results *QueryResults) trimCharValues(dest []driver.Value) {
+	if results.con == nil || !results.con.Config.TrimChar {
+		return
+	}
+	for i := range dest {
+		if results.data.Columns[i].DataType.Type != "CHAR" {
+			continue
+		}
+		switch value := dest[i].(type) {
+		case string:
+			dest[i] = strings.TrimRight(value, " ")
+		case []byte:
+			dest[i] = bytes.TrimRight(value, " ")
+		}
+	}
+}
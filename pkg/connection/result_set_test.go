@@ -1,11 +1,17 @@
 package connection
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 
+	"github.com/exasol/exasol-driver-go/internal/config"
 	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/gorilla/websocket"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -114,6 +120,20 @@ func (suite *ResultSetTestSuite) TestColumnTypeScanTypeDouble() {
 	suite.assertColumnType("DOUBLE", sql.NullFloat64{})
 }
 
+func (suite *ResultSetTestSuite) TestColumnTypeScanTypeDecimal() {
+	for _, test := range []struct {
+		column   types.SqlQueryColumn
+		expected interface{}
+	}{
+		{decimalColumn(18, 0), sql.NullInt64{}},
+		{decimalColumn(10, 2), sql.NullFloat64{}},
+		{decimalColumn(36, 0), sql.NullString{}},
+	} {
+		queryResults := QueryResults{data: &types.SqlQueryResponseResultSetData{Columns: []types.SqlQueryColumn{test.column}}}
+		suite.Equal(reflect.TypeOf(test.expected), queryResults.ColumnTypeScanType(0))
+	}
+}
+
 func (suite *ResultSetTestSuite) TestColumnTypeScanTypeDefault() {
 	suite.assertColumnType("UNKNOWN", new(interface{}))
 }
@@ -160,3 +180,176 @@ func (suite *ResultSetTestSuite) TestNextPointerDoesNotMatch() {
 	queryResults := QueryResults{data: &data, totalRowPointer: 2}
 	suite.EqualError(queryResults.Next(nil), "EOF")
 }
+
+func (suite *ResultSetTestSuite) TestNextPreservesNumberPrecision() {
+	resultSet := &types.SqlQueryResponseResultSet{}
+	suite.NoError(StandardJSONCodec.Unmarshal([]byte(`{"resultSet": {"numColumns": 3, "numRows": 1, "numRowsInMessage": 1,
+		"columns": [{"name": "A", "dataType": {"type": "DECIMAL"}}, {"name": "B", "dataType": {"type": "DECIMAL"}}, {"name": "C", "dataType": {"type": "DOUBLE"}}],
+		"data": [[9007199254740993], [123456789012345678.12], [0.1]]}}`), resultSet))
+	queryResults := QueryResults{data: &resultSet.ResultSet}
+	dest := make([]driver.Value, 3)
+
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{int64(9007199254740993), "123456789012345678.12", 0.1}, dest)
+}
+
+func (suite *ResultSetTestSuite) TestNextTrimsCharValues() {
+	queryResults := suite.charResults(true)
+	dest := make([]driver.Value, 3)
+
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{"a", "b  ", nil}, dest)
+}
+
+func (suite *ResultSetTestSuite) TestNextKeepsCharPaddingByDefault() {
+	queryResults := suite.charResults(false)
+	dest := make([]driver.Value, 3)
+
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{"a  ", "b  ", nil}, dest)
+}
+
+func (suite *ResultSetTestSuite) TestNextReturnsRawBytes() {
+	queryResults := suite.charResults(true)
+	queryResults.con.Config.RawBytes = true
+	dest := make([]driver.Value, 3)
+
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{[]byte("a"), []byte("b  "), nil}, dest)
+}
+
+func (suite *ResultSetTestSuite) charResults(trimChar bool) *QueryResults {
+	data := types.SqlQueryResponseResultSetData{NumRows: 1, NumRowsInMessage: 1,
+		Columns: []types.SqlQueryColumn{
+			{DataType: types.SqlQueryColumnType{Type: "CHAR"}},
+			{DataType: types.SqlQueryColumnType{Type: "VARCHAR"}},
+			{DataType: types.SqlQueryColumnType{Type: "CHAR"}},
+		},
+		Data: [][]interface{}{{"a  "}, {"b  "}, {nil}},
+	}
+	return &QueryResults{data: &data, con: &Connection{Config: &config.Config{TrimChar: trimChar}}}
+}
+
+func (suite *ResultSetTestSuite) TestNextReadsFetchedColumns() {
+	response := []byte(`{"status": "ok", "responseData": {"numRows": 2, "data": [[9007199254740993, 2], ["a", null]]}}`)
+	conn := &Connection{
+		Config:    &config.Config{FetchSize: 2000},
+		Ctx:       context.Background(),
+		websocket: &benchmarkConnection{messageType: websocket.TextMessage, response: response},
+	}
+	queryResults := QueryResults{con: conn, data: &types.SqlQueryResponseResultSetData{
+		ResultSetHandle: 1, NumColumns: 2, NumRows: 4,
+		Columns: []types.SqlQueryColumn{{DataType: types.SqlQueryColumnType{Type: "DECIMAL"}}, {DataType: types.SqlQueryColumnType{Type: "VARCHAR"}}},
+	}}
+	dest := make([]driver.Value, 2)
+
+	var rows [][]driver.Value
+	for queryResults.Next(dest) == nil {
+		rows = append(rows, append([]driver.Value{}, dest...))
+	}
+	suite.Equal([][]driver.Value{{int64(9007199254740993), "a"}, {int64(2), nil}, {int64(9007199254740993), "a"}, {int64(2), nil}}, rows)
+}
+
+func (suite *ResultSetTestSuite) TestNextReadsFetchedColumnsAsRawBytes() {
+	response := []byte(`{"status": "ok", "responseData": {"numRows": 1, "data": [["a"]]}}`)
+	conn := &Connection{
+		Config:    &config.Config{FetchSize: 2000, RawBytes: true},
+		Ctx:       context.Background(),
+		websocket: &benchmarkConnection{messageType: websocket.TextMessage, response: response},
+	}
+	queryResults := QueryResults{con: conn, data: &types.SqlQueryResponseResultSetData{
+		ResultSetHandle: 1, NumColumns: 1, NumRows: 1,
+		Columns: []types.SqlQueryColumn{{DataType: types.SqlQueryColumnType{Type: "VARCHAR"}}},
+	}}
+	dest := make([]driver.Value, 1)
+
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{[]byte("a")}, dest)
+}
+
+func (suite *ResultSetTestSuite) TestColumnNumberType() {
+	for _, test := range []struct {
+		column   types.SqlQueryColumn
+		expected numberType
+	}{
+		{decimalColumn(18, 0), integerNumber},
+		{decimalColumn(1, 0), integerNumber},
+		{decimalColumn(19, 0), decimalNumber},
+		{decimalColumn(15, 2), floatNumber},
+		{decimalColumn(16, 2), decimalNumber},
+		{decimalColumn(36, 10), decimalNumber},
+		{columnsOfType("DECIMAL")[0], integerNumber},
+		{columnsOfType("DOUBLE")[0], floatNumber},
+		{columnsOfType("VARCHAR")[0], floatNumber},
+	} {
+		suite.Equal(test.expected, columnNumberType(test.column.DataType), "%+v", test.column.DataType)
+	}
+}
+
+func (suite *ResultSetTestSuite) TestConvertNumber() {
+	for _, test := range []struct {
+		value    interface{}
+		numbers  numberType
+		expected interface{}
+	}{
+		{json.Number("42"), integerNumber, int64(42)},
+		{json.Number("9007199254740993"), integerNumber, int64(9007199254740993)},
+		{json.Number("1.5"), integerNumber, "1.5"},
+		{json.Number("123456789012345678901234567890"), decimalNumber, "123456789012345678901234567890"},
+		{json.Number("1234567890.123456789"), decimalNumber, "1234567890.123456789"},
+		{json.Number("1.50"), floatNumber, 1.5},
+		{json.Number("1.5E+3"), floatNumber, float64(1500)},
+		{json.Number("0.10000000000000001"), floatNumber, 0.1},
+		{"text", floatNumber, "text"},
+		{nil, integerNumber, nil},
+	} {
+		suite.Equal(test.expected, convertNumber(test.value, test.numbers), "value %v of type %d", test.value, test.numbers)
+	}
+}
+
+func (suite *ResultSetTestSuite) TestNextReturnsSameTypeForAllRowsOfDecimalColumn() {
+	data := types.SqlQueryResponseResultSetData{
+		Columns:          []types.SqlQueryColumn{decimalColumn(18, 0), decimalColumn(36, 2)},
+		Data:             [][]interface{}{{json.Number("1"), json.Number("9007199254740993")}, {json.Number("1.5"), json.Number("2")}},
+		NumRows:          2,
+		NumRowsInMessage: 2,
+	}
+	queryResults := QueryResults{data: &data, con: &Connection{Config: &config.Config{}}}
+	dest := make([]driver.Value, 2)
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{int64(1), "1.5"}, dest)
+	suite.NoError(queryResults.Next(dest))
+	suite.Equal([]driver.Value{int64(9007199254740993), "2"}, dest)
+}
+
+func BenchmarkNextWithFetch(b *testing.B) {
+	const rowsPerFetch = 1000
+	data := make([][]interface{}, 3)
+	for row := 0; row < rowsPerFetch; row++ {
+		data[0] = append(data[0], row)
+		data[1] = append(data[1], fmt.Sprintf("value %d", row))
+		data[2] = append(data[2], float64(row)/3)
+	}
+	response, _ := json.Marshal(map[string]interface{}{"status": "ok", "responseData": map[string]interface{}{"numRows": rowsPerFetch, "data": data}})
+	conn := &Connection{
+		Config:    &config.Config{FetchSize: 2000},
+		Ctx:       context.Background(),
+		websocket: &benchmarkConnection{messageType: websocket.TextMessage, response: response},
+	}
+	columns := []types.SqlQueryColumn{
+		{Name: "A", DataType: types.SqlQueryColumnType{Type: "DECIMAL"}},
+		{Name: "B", DataType: types.SqlQueryColumnType{Type: "VARCHAR"}},
+		{Name: "C", DataType: types.SqlQueryColumnType{Type: "DOUBLE"}},
+	}
+	dest := make([]driver.Value, len(columns))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		results := &QueryResults{con: conn, data: &types.SqlQueryResponseResultSetData{
+			ResultSetHandle: 1, NumColumns: len(columns), NumRows: 10 * rowsPerFetch, Columns: columns,
+		}}
+		for results.Next(dest) == nil {
+		}
+	}
+}
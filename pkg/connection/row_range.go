@@ -0,0 +1,84 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// NumRows returns the total number of rows of the result set.
+func (results *QueryResults) NumRows() int {
+	return results.data.NumRows
+}
+
+// FetchRows returns up to count rows of the result set starting at the zero-based offset, e.g. for a page of a paginated result.
+// Rows are fetched from the open result set on the database without executing the query again.
+// The position of Next is not changed. Fewer rows are returned at the end of the result set.
+func (results *QueryResults) FetchRows(ctx context.Context, offset, count int) ([][]driver.Value, error) {
+	if offset < 0 || count < 0 {
+		return nil, errors.NewInvalidRowRange(offset, count)
+	}
+	end := offset + count
+	if end > results.data.NumRows {
+		end = results.data.NumRows
+	}
+	var rows [][]driver.Value
+	for position := offset; position < end; {
+		columns, first, numRows, err := results.rowsAt(ctx, position)
+		if err != nil {
+			return nil, err
+		}
+		if numRows == 0 {
+			break
+		}
+		for ; position < first+numRows && position < end; position++ {
+			row := make([]driver.Value, len(columns))
+			for column := range columns {
+				row[column] = columns[column][position-first]
+			}
+			results.trimCharValues(row)
+			if err := results.checkNonFiniteValues(row); err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// rowsAt returns the column-major values of a chunk starting at or before the given position,
+// the position of its first row and its number of rows. Rows of the first response are used as long as Next has not replaced them.
+func (results *QueryResults) rowsAt(ctx context.Context, position int) ([][]driver.Value, int, int, error) {
+	if results.data.Data != nil && position < results.data.NumRowsInMessage {
+		columns := make([][]driver.Value, len(results.data.Columns))
+		for i := range columns {
+			columns[i] = make([]driver.Value, len(results.data.Data[i]))
+			numbers := columnNumberType(results.data.Columns[i].DataType)
+			for row, value := range results.data.Data[i] {
+				columns[i][row] = convertNumber(value, numbers)
+			}
+		}
+		return columns, 0, results.data.NumRowsInMessage, nil
+	}
+	results.con.Stats.inc(fetchCalls)
+	var fetch fetchResponse
+	fetchStart := time.Now()
+	err := results.con.Send(ctx, &types.FetchCommand{
+		Command:         types.Command{Command: "fetch"},
+		ResultSetHandle: results.data.ResultSetHandle,
+		StartPosition:   position,
+		NumBytes:        results.con.Config.FetchSize * 1024,
+	}, &fetch)
+	results.tracker.fetched(time.Since(fetchStart))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	columns, err := decodeColumns(fetch.Data, results.data.Columns, nil, false)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return columns, position, fetch.NumRows, nil
+}
@@ -0,0 +1,90 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type RowRangeTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestRowRangeSuite(t *testing.T) {
+	suite.Run(t, new(RowRangeTestSuite))
+}
+
+func (suite *RowRangeTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+func (suite *RowRangeTestSuite) TestFetchRowsFromFirstResponse() {
+	results := suite.results(3, 3, [][]interface{}{{json.Number("1"), json.Number("2"), json.Number("3")}, {"a", "b", "c"}})
+	rows, err := results.FetchRows(context.Background(), 1, 5)
+	suite.NoError(err)
+	suite.Equal([][]driver.Value{{int64(2), "b"}, {int64(3), "c"}}, rows)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *RowRangeTestSuite) TestFetchRowsFromOffset() {
+	suite.simulateFetch(5, map[string]interface{}{"numRows": 2, "data": [][]interface{}{{6, 7}, {"f", "g"}}})
+	results := suite.results(10, 2, [][]interface{}{{json.Number("1"), json.Number("2")}, {"a", "b"}})
+	rows, err := results.FetchRows(context.Background(), 5, 2)
+	suite.NoError(err)
+	suite.Equal([][]driver.Value{{int64(6), "f"}, {int64(7), "g"}}, rows)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *RowRangeTestSuite) TestFetchRowsAcrossChunks() {
+	suite.simulateFetch(2, map[string]interface{}{"numRows": 1, "data": [][]interface{}{{3}, {"c"}}})
+	suite.simulateFetch(3, map[string]interface{}{"numRows": 1, "data": [][]interface{}{{4}, {"d"}}})
+	results := suite.results(4, 2, [][]interface{}{{json.Number("1"), json.Number("2")}, {"a", "b"}})
+	rows, err := results.FetchRows(context.Background(), 1, 10)
+	suite.NoError(err)
+	suite.Equal([][]driver.Value{{int64(2), "b"}, {int64(3), "c"}, {int64(4), "d"}}, rows)
+	suite.websocketMock.AssertExpectations(suite.T())
+}
+
+func (suite *RowRangeTestSuite) TestFetchRowsBeyondEnd() {
+	results := suite.results(2, 2, [][]interface{}{{json.Number("1"), json.Number("2")}, {"a", "b"}})
+	rows, err := results.FetchRows(context.Background(), 5, 10)
+	suite.NoError(err)
+	suite.Empty(rows)
+}
+
+func (suite *RowRangeTestSuite) TestFetchRowsInvalidRange() {
+	results := suite.results(2, 2, [][]interface{}{{json.Number("1"), json.Number("2")}, {"a", "b"}})
+	_, err := results.FetchRows(context.Background(), -1, 10)
+	suite.EqualError(err, "E-EGOD-63: invalid row range with offset -1 and count 10, both must not be negative")
+}
+
+func (suite *RowRangeTestSuite) TestFetchRowsDoesNotMoveNext() {
+	results := suite.results(2, 2, [][]interface{}{{json.Number("1"), json.Number("2")}, {"a", "b"}})
+	_, err := results.FetchRows(context.Background(), 1, 1)
+	suite.NoError(err)
+	dest := make([]driver.Value, 2)
+	suite.NoError(results.Next(dest))
+	suite.Equal([]driver.Value{int64(1), "a"}, dest)
+}
+
+func (suite *RowRangeTestSuite) results(numRows, numRowsInMessage int, data [][]interface{}) *QueryResults {
+	connection := &Connection{Config: &config.Config{FetchSize: 2000}, Ctx: context.Background(), websocket: suite.websocketMock}
+	return &QueryResults{con: connection, data: &types.SqlQueryResponseResultSetData{
+		ResultSetHandle: 1, NumColumns: 2, NumRows: numRows, NumRowsInMessage: numRowsInMessage,
+		Columns: []types.SqlQueryColumn{{DataType: types.SqlQueryColumnType{Type: "DECIMAL"}}, {DataType: types.SqlQueryColumnType{Type: "VARCHAR"}}},
+		Data:    data,
+	}}
+}
+
+func (suite *RowRangeTestSuite) simulateFetch(position int, response interface{}) {
+	suite.websocketMock.SimulateOKResponse(types.FetchCommand{
+		Command: types.Command{Command: "fetch"}, ResultSetHandle: 1, StartPosition: position, NumBytes: 2000 * 1024,
+	}, response)
+}
@@ -0,0 +1,45 @@
+package connection
+
+import (
+	"context"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// SessionAttributes returns the current schema, autocommit, timezone and format attributes of the session.
+func (c *Connection) SessionAttributes(ctx context.Context) (*types.Attributes, error) {
+	if c.IsClosed {
+		logger.ErrorLogger.Print(errors.ErrClosed)
+		return nil, errors.NewBadConnError(errors.ErrClosed)
+	}
+	err := c.Send(ctx, &types.Command{Command: "getAttributes"}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Attributes{
+		Autocommit:                 c.attributes.Autocommit,
+		CurrentSchema:              c.attributes.CurrentSchema,
+		DateFormat:                 c.attributes.DateFormat,
+		DateLanguage:               c.attributes.DateLanguage,
+		DatetimeFormat:             c.attributes.DatetimeFormat,
+		DefaultLikeEscapeCharacter: c.attributes.DefaultLikeEscapeCharacter,
+		NumericCharacters:          c.attributes.NumericCharacters,
+		TimestampUtcEnabled:        c.attributes.TimestampUtcEnabled,
+		Timezone:                   c.attributes.Timezone,
+		TimeZoneBehavior:           c.attributes.TimeZoneBehavior,
+	}, nil
+}
+
+// SetSessionAttributes sets the given attributes for the session. Empty attributes are not changed.
+func (c *Connection) SetSessionAttributes(ctx context.Context, attributes *types.Attributes) error {
+	if c.IsClosed {
+		logger.ErrorLogger.Print(errors.ErrClosed)
+		return errors.NewBadConnError(errors.ErrClosed)
+	}
+	return c.Send(ctx, &types.SetAttributesCommand{
+		Command:    types.Command{Command: "setAttributes"},
+		Attributes: *attributes,
+	}, nil)
+}
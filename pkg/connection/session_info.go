@@ -0,0 +1,20 @@
+package connection
+
+// SessionInfo describes the database session of a connection as returned by the database after login.
+type SessionInfo struct {
+	SessionID       int    // ID of the session, matches SESSION_ID in the system tables, e.g. EXA_ALL_SESSIONS
+	ProtocolVersion int    // Websocket protocol version negotiated with the database
+	DatabaseVersion string // Release version of the database, e.g. "7.1.0"
+	DatabaseName    string // Name of the database
+}
+
+// SessionInfoProvider is implemented by connections of this driver.
+// Use it with [database/sql.Conn.Raw] to get information about the session of a connection.
+type SessionInfoProvider interface {
+	SessionInfo() SessionInfo
+}
+
+// SessionInfo returns information about the database session. It is empty before login.
+func (c *Connection) SessionInfo() SessionInfo {
+	return c.session
+}
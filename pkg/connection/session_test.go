@@ -0,0 +1,81 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type SessionTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+}
+
+func TestSessionSuite(t *testing.T) {
+	suite.Run(t, new(SessionTestSuite))
+}
+
+func (suite *SessionTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+}
+
+func (suite *SessionTestSuite) TestSessionAttributesFailsClosed() {
+	conn := suite.createOpenConnection()
+	conn.IsClosed = true
+	attributes, err := conn.SessionAttributes(context.Background())
+	suite.ErrorIs(err, driver.ErrBadConn)
+	suite.Nil(attributes)
+}
+
+func (suite *SessionTestSuite) TestSessionAttributesFails() {
+	suite.websocketMock.SimulateErrorResponse(types.Command{Command: "getAttributes"}, mockException)
+	attributes, err := suite.createOpenConnection().SessionAttributes(context.Background())
+	suite.EqualError(err, mockExceptionError(mockException))
+	suite.Nil(attributes)
+}
+
+func (suite *SessionTestSuite) TestSessionAttributes() {
+	suite.websocketMock.SimulateResponse(types.Command{Command: "getAttributes"}, types.BaseResponse{Status: "ok",
+		Attributes: wsconn.JsonMarshall(types.Attributes{Autocommit: utils.BoolToPtr(false), CurrentSchema: "SCHEMA",
+			Timezone: "EUROPE/BERLIN", DateFormat: "DD.MM.YYYY", CompressionEnabled: utils.BoolToPtr(true), QueryTimeout: 10})})
+	attributes, err := suite.createOpenConnection().SessionAttributes(context.Background())
+	suite.NoError(err)
+	suite.Equal(&types.Attributes{Autocommit: utils.BoolToPtr(false), CurrentSchema: "SCHEMA",
+		Timezone: "EUROPE/BERLIN", DateFormat: "DD.MM.YYYY"}, attributes)
+}
+
+func (suite *SessionTestSuite) TestResponseUpdatesAttributes() {
+	conn := suite.createOpenConnection()
+	conn.attributes = types.Attributes{CurrentSchema: "OLD", Timezone: "UTC"}
+	suite.websocketMock.SimulateResponse(types.Command{Command: "getAttributes"}, types.BaseResponse{Status: "ok",
+		Attributes: wsconn.JsonMarshall(map[string]any{"currentSchema": "NEW"})})
+	suite.NoError(conn.Send(context.Background(), &types.Command{Command: "getAttributes"}, nil))
+	suite.Equal(types.Attributes{CurrentSchema: "NEW", Timezone: "UTC"}, conn.attributes)
+}
+
+func (suite *SessionTestSuite) TestSetSessionAttributesFailsClosed() {
+	conn := suite.createOpenConnection()
+	conn.IsClosed = true
+	suite.ErrorIs(conn.SetSessionAttributes(context.Background(), &types.Attributes{}), driver.ErrBadConn)
+}
+
+func (suite *SessionTestSuite) TestSetSessionAttributes() {
+	attributes := types.Attributes{CurrentSchema: "SCHEMA", Timezone: "UTC"}
+	suite.websocketMock.SimulateOKResponse(types.SetAttributesCommand{Command: types.Command{Command: "setAttributes"}, Attributes: attributes}, nil)
+	suite.NoError(suite.createOpenConnection().SetSessionAttributes(context.Background(), &attributes))
+}
+
+func (suite *SessionTestSuite) createOpenConnection() *Connection {
+	return &Connection{
+		Config:    &config.Config{Host: "invalid", Port: 12345},
+		Ctx:       context.Background(),
+		IsClosed:  false,
+		websocket: suite.websocketMock,
+	}
+}
@@ -0,0 +1,171 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// ShutdownGroup tracks open connections and their in-flight operations for a graceful shutdown.
+// Connections and operations are also tracked by the parent group, if one is set.
+// A nil group ignores all events.
+type ShutdownGroup struct {
+	parent      *ShutdownGroup
+	mutex       sync.Mutex
+	shutdown    bool
+	connections map[*Connection]int // number of in-flight operations per connection
+	operations  int
+	drained     chan struct{} // closed when all operations finished after starting the shutdown
+}
+
+// NewShutdownGroup creates a new group that also tracks all connections in the given parent (may be nil).
+func NewShutdownGroup(parent *ShutdownGroup) *ShutdownGroup {
+	return &ShutdownGroup{parent: parent, connections: make(map[*Connection]int)}
+}
+
+// Shutdown stops accepting new connections and operations and waits until all in-flight operations
+// like queries, result sets and IMPORT/EXPORT transfers are finished or the context is done.
+// The connections are owned by the database/sql pool, so Shutdown doesn't close them itself. Instead they are no longer
+// valid and the pool closes them when they are returned or used next and when the database is closed.
+// Connections that still have in-flight operations when the context is done are closed forcibly and ctx.Err() is returned.
+func (g *ShutdownGroup) Shutdown(ctx context.Context) error {
+	if g == nil {
+		return nil
+	}
+	g.mutex.Lock()
+	if !g.shutdown {
+		g.shutdown = true
+		g.drained = make(chan struct{})
+		if g.operations == 0 {
+			g.closeDrained()
+		}
+	}
+	drained := g.drained
+	g.mutex.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+	}
+
+	g.mutex.Lock()
+	var busy []*Connection
+	for connection, operations := range g.connections {
+		if operations > 0 {
+			busy = append(busy, connection)
+		}
+	}
+	g.mutex.Unlock()
+
+	for _, connection := range busy {
+		connection.forceClose()
+	}
+	return ctx.Err()
+}
+
+func (g *ShutdownGroup) isShutdown() bool {
+	for group := g; group != nil; group = group.parent {
+		group.mutex.Lock()
+		shutdown := group.shutdown
+		group.mutex.Unlock()
+		if shutdown {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *ShutdownGroup) register(c *Connection) error {
+	if g.isShutdown() {
+		return errors.ErrShutdown
+	}
+	for group := g; group != nil; group = group.parent {
+		group.mutex.Lock()
+		group.connections[c] = 0
+		group.mutex.Unlock()
+	}
+	return nil
+}
+
+func (g *ShutdownGroup) unregister(c *Connection) {
+	for group := g; group != nil; group = group.parent {
+		group.mutex.Lock()
+		delete(group.connections, c)
+		group.mutex.Unlock()
+	}
+}
+
+func (g *ShutdownGroup) begin(c *Connection) error {
+	if g.isShutdown() {
+		return errors.ErrShutdown
+	}
+	for group := g; group != nil; group = group.parent {
+		group.mutex.Lock()
+		group.operations++
+		if _, ok := group.connections[c]; ok {
+			group.connections[c]++
+		}
+		group.mutex.Unlock()
+	}
+	return nil
+}
+
+func (g *ShutdownGroup) end(c *Connection) {
+	for group := g; group != nil; group = group.parent {
+		group.mutex.Lock()
+		group.operations--
+		if operations, ok := group.connections[c]; ok && operations > 0 {
+			group.connections[c]--
+		}
+		if group.shutdown && group.operations == 0 {
+			group.closeDrained()
+		}
+		group.mutex.Unlock()
+	}
+}
+
+// closeDrained signals that all operations are finished. The caller must hold the mutex.
+func (g *ShutdownGroup) closeDrained() {
+	select {
+	case <-g.drained:
+	default:
+		close(g.drained)
+	}
+}
+
+// beginOperation registers an in-flight operation of the connection.
+// The returned function must be called when the operation is finished.
+// Each operation executes a new statement, so the warnings of the previous statement are discarded.
+func (c *Connection) beginOperation() (func(), error) {
+	if err := c.ShutdownGroup.begin(c); err != nil {
+		return nil, err
+	}
+	c.startStatement()
+	var once sync.Once
+	return func() { once.Do(func() { c.ShutdownGroup.end(c) }) }, nil
+}
+
+// withOperation keeps the operation in-flight until the returned rows are closed.
+func withOperation(endOperation func()) func(driver.Rows, error) (driver.Rows, error) {
+	return func(rows driver.Rows, err error) (driver.Rows, error) {
+		if err != nil {
+			endOperation()
+			return nil, err
+		}
+		rows.(*QueryResults).endOperation = endOperation
+		return rows, nil
+	}
+}
+
+// forceClose closes the websocket without sending the disconnect command, e.g. to abort in-flight operations during shutdown.
+// It doesn't modify the connection, as it is still used concurrently. The pool closes the connection after the failed operation.
+func (c *Connection) forceClose() {
+	c.websocketMutex.Lock()
+	defer c.websocketMutex.Unlock()
+	if c.websocket != nil {
+		_ = c.websocket.Close()
+	}
+}
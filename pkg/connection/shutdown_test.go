@@ -0,0 +1,124 @@
+package connection
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShutdownTestSuite struct {
+	suite.Suite
+	group *ShutdownGroup
+}
+
+func TestShutdownSuite(t *testing.T) {
+	suite.Run(t, new(ShutdownTestSuite))
+}
+
+func (suite *ShutdownTestSuite) SetupTest() {
+	suite.group = NewShutdownGroup(nil)
+}
+
+// closeRecordingConnection answers all requests with an OK response and records if it was closed.
+type closeRecordingConnection struct {
+	benchmarkConnection
+	closed bool
+}
+
+func (c *closeRecordingConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (suite *ShutdownTestSuite) openConnection(group *ShutdownGroup) (*Connection, *closeRecordingConnection) {
+	ws := &closeRecordingConnection{benchmarkConnection: benchmarkConnection{messageType: websocket.TextMessage, response: []byte(`{"status": "ok"}`)}}
+	conn := &Connection{Config: &config.Config{}, Ctx: context.Background(), websocket: ws, ShutdownGroup: group}
+	suite.NoError(group.register(conn))
+	return conn, ws
+}
+
+func (suite *ShutdownTestSuite) TestShutdownInvalidatesIdleConnections() {
+	conn, ws := suite.openConnection(suite.group)
+
+	suite.NoError(suite.group.Shutdown(context.Background()))
+	suite.False(conn.IsValid())
+	suite.ErrorIs(conn.ResetSession(context.Background()), driver.ErrBadConn)
+	suite.False(ws.closed, "the pool closes the connection")
+	suite.NoError(conn.Close())
+	suite.True(ws.closed)
+}
+
+func (suite *ShutdownTestSuite) TestShutdownWaitsForInFlightOperations() {
+	conn, ws := suite.openConnection(suite.group)
+	endOperation, err := conn.beginOperation()
+	suite.NoError(err)
+
+	done := make(chan error, 1)
+	go func() { done <- suite.group.Shutdown(context.Background()) }()
+	select {
+	case <-done:
+		suite.Fail("shutdown did not wait for the operation")
+	case <-time.After(20 * time.Millisecond):
+	}
+	endOperation()
+	suite.NoError(<-done)
+	suite.False(conn.IsValid())
+	suite.False(ws.closed)
+}
+
+func (suite *ShutdownTestSuite) TestShutdownClosesBusyConnectionsForciblyAfterTimeout() {
+	conn, ws := suite.openConnection(suite.group)
+	_, err := conn.beginOperation()
+	suite.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	suite.ErrorIs(suite.group.Shutdown(ctx), context.DeadlineExceeded)
+	suite.True(ws.closed)
+	suite.False(conn.IsClosed, "connection is not modified as it is still in use")
+	suite.False(conn.IsValid())
+}
+
+func (suite *ShutdownTestSuite) TestShutdownRejectsNewWork() {
+	conn, _ := suite.openConnection(suite.group)
+	suite.NoError(suite.group.Shutdown(context.Background()))
+
+	_, err := conn.beginOperation()
+	suite.ErrorIs(err, errors.ErrShutdown)
+	suite.ErrorIs(suite.group.register(&Connection{}), errors.ErrShutdown)
+	suite.ErrorIs((&Connection{ShutdownGroup: suite.group}).Connect(), errors.ErrShutdown)
+}
+
+func (suite *ShutdownTestSuite) TestEndOperationIsIdempotent() {
+	conn, _ := suite.openConnection(suite.group)
+	endOperation, err := conn.beginOperation()
+	suite.NoError(err)
+	endOperation()
+	endOperation()
+	suite.Equal(0, suite.group.operations)
+}
+
+func (suite *ShutdownTestSuite) TestParentShutdownInvalidatesConnectionsOfChildren() {
+	child := NewShutdownGroup(suite.group)
+	conn, _ := suite.openConnection(child)
+
+	suite.NoError(suite.group.Shutdown(context.Background()))
+	suite.False(conn.IsValid())
+	_, err := conn.beginOperation()
+	suite.ErrorIs(err, errors.ErrShutdown)
+}
+
+func (suite *ShutdownTestSuite) TestNilGroup() {
+	conn := &Connection{}
+	endOperation, err := conn.beginOperation()
+	suite.NoError(err)
+	endOperation()
+	var group *ShutdownGroup
+	suite.NoError(group.Shutdown(context.Background()))
+}
@@ -0,0 +1,99 @@
+package connection
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+)
+
+// SlowQuery describes a statement that took longer than the configured slow query threshold.
+type SlowQuery struct {
+	SessionID     int           // ID of the database session that executed the statement
+	SQLHash       string        // Hex encoded SHA-256 hash of the SQL text
+	Duration      time.Duration // Total duration including fetching the result set
+	ExecutionTime time.Duration // Duration until the database returned the first response
+	FetchCalls    int           // Number of fetch commands for reading the result set
+	FetchTime     time.Duration // Total duration of all fetch commands
+	RowsFetched   int64         // Number of rows read from the result set
+}
+
+// SlowQueryCallback is called for each statement that took longer than the slow query threshold.
+type SlowQueryCallback func(query SlowQuery)
+
+// slowQueryTracker measures the duration of a single statement including all fetches of its result set.
+// A nil tracker ignores all events.
+type slowQueryTracker struct {
+	connection    *Connection
+	query         string
+	start         time.Time
+	executionTime time.Duration
+	fetchCalls    int
+	fetchTime     time.Duration
+	rowsFetched   int64
+}
+
+// startSlowQueryTracker returns a new tracker or nil if slow query reporting is disabled.
+func (c *Connection) startSlowQueryTracker(query string) *slowQueryTracker {
+	if c.Config.SlowQueryThreshold <= 0 {
+		return nil
+	}
+	return &slowQueryTracker{connection: c, query: query, start: time.Now()}
+}
+
+func (t *slowQueryTracker) executed() {
+	if t == nil {
+		return
+	}
+	t.executionTime = time.Since(t.start)
+}
+
+func (t *slowQueryTracker) fetched(duration time.Duration) {
+	if t == nil {
+		return
+	}
+	t.fetchCalls++
+	t.fetchTime += duration
+}
+
+func (t *slowQueryTracker) rowRead() {
+	if t == nil {
+		return
+	}
+	t.rowsFetched++
+}
+
+// finish reports the statement if it exceeded the threshold.
+func (t *slowQueryTracker) finish() {
+	if t == nil {
+		return
+	}
+	duration := time.Since(t.start)
+	if duration < t.connection.Config.SlowQueryThreshold {
+		return
+	}
+	if t.executionTime == 0 {
+		t.executionTime = duration
+	}
+	slowQuery := SlowQuery{
+		SessionID:     t.connection.session.SessionID,
+		SQLHash:       sqlHash(t.query),
+		Duration:      duration,
+		ExecutionTime: t.executionTime,
+		FetchCalls:    t.fetchCalls,
+		FetchTime:     t.fetchTime,
+		RowsFetched:   t.rowsFetched,
+	}
+	if t.connection.SlowQueryCallback != nil {
+		t.connection.SlowQueryCallback(slowQuery)
+		return
+	}
+	logger.TraceLogger.Printf("slow query: session=%d hash=%s duration=%s execution=%s fetches=%d fetchTime=%s rows=%d",
+		slowQuery.SessionID, slowQuery.SQLHash, slowQuery.Duration, slowQuery.ExecutionTime, slowQuery.FetchCalls, slowQuery.FetchTime, slowQuery.RowsFetched)
+}
+
+func sqlHash(query string) string {
+	hash := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,106 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type SlowQueryTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+	slowQueries   []SlowQuery
+}
+
+func TestSlowQuerySuite(t *testing.T) {
+	suite.Run(t, new(SlowQueryTestSuite))
+}
+
+func (suite *SlowQueryTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+	suite.slowQueries = nil
+}
+
+func (suite *SlowQueryTestSuite) TestTrackerDisabled() {
+	conn := suite.createOpenConnection(0)
+	suite.Nil(conn.startSlowQueryTracker("query"))
+}
+
+func (suite *SlowQueryTestSuite) TestFastQueryNotReported() {
+	conn := suite.createOpenConnection(time.Hour)
+	conn.startSlowQueryTracker("query").finish()
+	suite.Empty(suite.slowQueries)
+}
+
+func (suite *SlowQueryTestSuite) TestSlowQueryReported() {
+	conn := suite.createOpenConnection(time.Nanosecond)
+	tracker := conn.startSlowQueryTracker("query")
+	tracker.executed()
+	tracker.fetched(time.Second)
+	tracker.rowRead()
+	tracker.rowRead()
+	tracker.finish()
+	suite.Len(suite.slowQueries, 1)
+	slowQuery := suite.slowQueries[0]
+	suite.Equal(1234, slowQuery.SessionID)
+	suite.Equal("a8b771920b8319e47251d1360f5e880bc18e8d329b0f0d003ea3c7e615558947", slowQuery.SQLHash)
+	suite.Equal(1, slowQuery.FetchCalls)
+	suite.Equal(time.Second, slowQuery.FetchTime)
+	suite.Equal(int64(2), slowQuery.RowsFetched)
+	suite.GreaterOrEqual(slowQuery.Duration, slowQuery.ExecutionTime)
+}
+
+func (suite *SlowQueryTestSuite) TestSlowQueryLoggedWithoutCallback() {
+	buffer := &bytes.Buffer{}
+	previousLogger := logger.TraceLogger
+	logger.TraceLogger = log.New(buffer, "", 0)
+	defer func() { logger.TraceLogger = previousLogger }()
+
+	conn := suite.createOpenConnection(time.Nanosecond)
+	conn.SlowQueryCallback = nil
+	conn.startSlowQueryTracker("query").finish()
+	suite.Regexp(`^slow query: session=1234 hash=[0-9a-f]{64} duration=\S+ execution=\S+ fetches=0 fetchTime=0s rows=0\n$`, buffer.String())
+}
+
+func (suite *SlowQueryTestSuite) TestSlowQueryReportedOnRowsClose() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "query", Attributes: types.Attributes{}},
+		types.SqlQueryResponseResultSet{ResultType: "resultSet", ResultSet: types.SqlQueryResponseResultSetData{}})
+	conn := suite.createOpenConnection(time.Nanosecond)
+	rows, err := conn.query(context.Background(), "query", nil)
+	suite.NoError(err)
+	suite.Empty(suite.slowQueries)
+	suite.NoError(rows.Close())
+	suite.NoError(rows.Close())
+	suite.Len(suite.slowQueries, 1)
+}
+
+func (suite *SlowQueryTestSuite) TestSlowExecReported() {
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "query", Attributes: types.Attributes{}},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 1})
+	conn := suite.createOpenConnection(time.Nanosecond)
+	_, err := conn.exec(context.Background(), "query", nil)
+	suite.NoError(err)
+	suite.Len(suite.slowQueries, 1)
+	suite.Equal(sqlHash("query"), suite.slowQueries[0].SQLHash)
+}
+
+func (suite *SlowQueryTestSuite) createOpenConnection(threshold time.Duration) *Connection {
+	return &Connection{
+		Config:            &config.Config{Host: "invalid", Port: 12345, SlowQueryThreshold: threshold},
+		Ctx:               context.Background(),
+		IsClosed:          false,
+		websocket:         suite.websocketMock,
+		session:           SessionInfo{SessionID: 1234},
+		SlowQueryCallback: func(query SlowQuery) { suite.slowQueries = append(suite.slowQueries, query) },
+	}
+}
@@ -3,6 +3,7 @@ package connection
 import (
 	"context"
 	"database/sql/driver"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/internal/utils"
 	"github.com/exasol/exasol-driver-go/pkg/errors"
@@ -12,9 +13,12 @@ import (
 
 type Statement struct {
 	connection      *Connection
+	query           string
 	statementHandle int
 	columns         []types.SqlQueryColumn
 	numInput        int
+	// placeholders references the arguments of the placeholders translated from the configured placeholder style.
+	placeholders []utils.Placeholder
 }
 
 func NewStatement(connection *Connection, response *types.CreatePreparedStatementResponse) *Statement {
@@ -22,48 +26,66 @@ func NewStatement(connection *Connection, response *types.CreatePreparedStatemen
 }
 
 func (s *Statement) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
-	values, err := utils.NamedValuesToValues(args)
+	values, err := s.bindArgs(args)
 	if err != nil {
 		return nil, err
 	}
-	result, err := s.executePreparedStatement(ctx, values)
-	if err != nil {
-		return nil, err
-	}
-	return ToRow(result, s.connection)
+	return s.queryRows(ctx, values)
 }
 
 func (s *Statement) Query(args []driver.Value) (driver.Rows, error) {
-	result, err := s.executePreparedStatement(context.Background(), args)
+	return s.queryRows(context.Background(), args)
+}
+
+func (s *Statement) queryRows(ctx context.Context, args []driver.Value) (driver.Rows, error) {
+	endOperation, err := s.connection.beginOperation()
 	if err != nil {
 		return nil, err
 	}
-	return ToRow(result, s.connection)
+	return withOperation(endOperation)(s.executeQuery(ctx, args))
 }
 
-func (s *Statement) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
-	values, err := utils.NamedValuesToValues(args)
+func (s *Statement) executeQuery(ctx context.Context, args []driver.Value) (driver.Rows, error) {
+	tracker := s.connection.startSlowQueryTracker(s.query)
+	result, err := s.executePreparedStatement(ctx, args)
 	if err != nil {
+		tracker.finish()
 		return nil, err
 	}
-	result, err := s.executePreparedStatement(ctx, values)
+	tracker.executed()
+	return toTrackedRows(result, s.connection, tracker)
+}
+
+func (s *Statement) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	values, err := s.bindArgs(args)
 	if err != nil {
 		return nil, err
 	}
-	return ToResult(result)
+	return s.execResult(ctx, values)
 }
 
 func (s *Statement) Exec(args []driver.Value) (driver.Result, error) {
-	result, err := s.executePreparedStatement(context.Background(), args)
+	return s.execResult(context.Background(), args)
+}
+
+func (s *Statement) execResult(ctx context.Context, args []driver.Value) (driver.Result, error) {
+	endOperation, err := s.connection.beginOperation()
 	if err != nil {
 		return nil, err
 	}
-	return ToResult(result)
+	defer endOperation()
+	tracker := s.connection.startSlowQueryTracker(s.query)
+	defer tracker.finish()
+	result, err := s.executePreparedStatement(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return toResult(result, s.connection.jsonCodec())
 }
 
 func (s *Statement) Close() error {
 	if s.connection.IsClosed {
-		return driver.ErrBadConn
+		return errors.NewBadConnError(errors.ErrClosed)
 	}
 	return s.connection.Send(context.Background(), &types.ClosePreparedStatementCommand{
 		Command:         types.Command{Command: "closePreparedStatement"},
@@ -71,15 +93,33 @@ func (s *Statement) Close() error {
 	}, nil)
 }
 
+// NumInput returns -1 for statements with translated placeholders, as a placeholder may be referenced multiple times.
 func (s *Statement) NumInput() int {
+	if s.placeholders != nil {
+		return -1
+	}
 	return s.numInput
 }
 
+func (s *Statement) bindArgs(args []driver.NamedValue) ([]driver.Value, error) {
+	if s.placeholders == nil {
+		return utils.NamedValuesToValues(args)
+	}
+	return utils.BindPlaceholders(s.placeholders, s.connection.Config.PlaceholderStyle, args)
+}
+
 func (s *Statement) executePreparedStatement(ctx context.Context, args []driver.Value) (*types.SqlQueriesResponse, error) {
+	if hasListParam(args) {
+		return nil, errors.ErrInvalidListParam
+	}
 	columns := s.columns
 	if len(args)%len(columns) != 0 {
 		return nil, errors.ErrInvalidValuesCount
 	}
+	args, err := s.connection.convertNonFiniteParams(args)
+	if err != nil {
+		return nil, err
+	}
 
 	data := make([][]interface{}, len(columns))
 	for i, arg := range args {
@@ -101,7 +141,13 @@ func (s *Statement) executePreparedStatement(ctx context.Context, args []driver.
 		},
 	}
 	result := &types.SqlQueriesResponse{}
-	err := s.connection.Send(ctx, command, result)
+	s.connection.beforeExecute(ctx, s.query)
+	start := time.Now()
+	err = s.connection.Send(ctx, command, result)
+	duration := time.Since(start)
+	s.connection.logQuery(s.query, args, duration, result, err)
+	s.connection.afterExecute(ctx, s.query, duration, result, err)
+	s.connection.statementExecuted()
 	if err != nil {
 		return nil, err
 	}
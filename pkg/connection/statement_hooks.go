@@ -0,0 +1,59 @@
+package connection
+
+import (
+	"context"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// StatementEvent describes a statement passed to [StatementHooks].
+type StatementEvent struct {
+	SessionID int           // ID of the database session that executes the statement
+	SQLHash   string        // Hex encoded SHA-256 hash of the SQL text
+	SQL       string        // SQL text with credentials redacted
+	Duration  time.Duration // Duration of the execution, only set after execution
+	Rows      int64         // Number of affected rows or rows of the result set, only set after execution
+	Err       error         // Error of the execution, only set after execution
+}
+
+// StatementHooks observe each statement executed by a connection, e.g. for APM or auditing.
+// Hooks are called synchronously, so they should return quickly. Both functions may be nil.
+type StatementHooks struct {
+	BeforeExecute func(ctx context.Context, event StatementEvent)
+	AfterExecute  func(ctx context.Context, event StatementEvent)
+}
+
+func (c *Connection) newStatementEvent(query string) StatementEvent {
+	return StatementEvent{SessionID: c.session.SessionID, SQLHash: sqlHash(query), SQL: utils.RedactSQL(query)}
+}
+
+// beforeExecute calls the BeforeExecute hooks for the given statement.
+func (c *Connection) beforeExecute(ctx context.Context, query string) {
+	if len(c.StatementHooks) == 0 {
+		return
+	}
+	event := c.newStatementEvent(query)
+	for _, hooks := range c.StatementHooks {
+		if hooks.BeforeExecute != nil {
+			hooks.BeforeExecute(ctx, event)
+		}
+	}
+}
+
+// afterExecute calls the AfterExecute hooks for the given statement.
+func (c *Connection) afterExecute(ctx context.Context, query string, duration time.Duration, result *types.SqlQueriesResponse, err error) {
+	if len(c.StatementHooks) == 0 {
+		return
+	}
+	event := c.newStatementEvent(query)
+	event.Duration = duration
+	event.Rows = c.resultRowCount(result)
+	event.Err = err
+	for _, hooks := range c.StatementHooks {
+		if hooks.AfterExecute != nil {
+			hooks.AfterExecute(ctx, event)
+		}
+	}
+}
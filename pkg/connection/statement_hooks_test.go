@@ -0,0 +1,82 @@
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatementHooksTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+	conn          *Connection
+	events        []string
+}
+
+func TestStatementHooksSuite(t *testing.T) {
+	suite.Run(t, new(StatementHooksTestSuite))
+}
+
+func (suite *StatementHooksTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+	suite.conn = &Connection{Config: &config.Config{}, Ctx: context.Background(), websocket: suite.websocketMock,
+		session: SessionInfo{SessionID: 1234}}
+	suite.events = nil
+}
+
+func (suite *StatementHooksTestSuite) recordingHooks(name string, after *StatementEvent) StatementHooks {
+	return StatementHooks{
+		BeforeExecute: func(ctx context.Context, event StatementEvent) {
+			suite.events = append(suite.events, name+" before "+event.SQL)
+		},
+		AfterExecute: func(ctx context.Context, event StatementEvent) {
+			suite.events = append(suite.events, name+" after "+event.SQL)
+			*after = event
+		},
+	}
+}
+
+func (suite *StatementHooksTestSuite) TestHooksObserveStatement() {
+	var after StatementEvent
+	suite.conn.StatementHooks = []StatementHooks{suite.recordingHooks("first", &after), suite.recordingHooks("second", &after)}
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "DELETE FROM T"},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: 3})
+
+	_, err := suite.conn.exec(context.Background(), "DELETE FROM T", nil)
+	suite.NoError(err)
+	suite.Equal([]string{"first before DELETE FROM T", "second before DELETE FROM T", "first after DELETE FROM T", "second after DELETE FROM T"}, suite.events)
+	suite.Equal(1234, after.SessionID)
+	suite.Equal(sqlHash("DELETE FROM T"), after.SQLHash)
+	suite.Equal(int64(3), after.Rows)
+	suite.NoError(after.Err)
+	suite.Positive(after.Duration)
+}
+
+func (suite *StatementHooksTestSuite) TestAfterExecuteReceivesError() {
+	var after StatementEvent
+	suite.conn.StatementHooks = []StatementHooks{{AfterExecute: func(ctx context.Context, event StatementEvent) { after = event }}}
+	suite.websocketMock.SimulateErrorResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "SELECT 1"}, mockException)
+
+	_, err := suite.conn.query(context.Background(), "SELECT 1", nil)
+	suite.EqualError(err, mockExceptionError(mockException))
+	suite.EqualError(after.Err, mockExceptionError(mockException))
+}
+
+func (suite *StatementHooksTestSuite) TestHooksReceiveRedactedSQL() {
+	var after StatementEvent
+	suite.conn.StatementHooks = []StatementHooks{suite.recordingHooks("hooks", &after)}
+	suite.websocketMock.SimulateSQLQueriesResponse(
+		types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "CREATE USER U IDENTIFIED BY 'secret'"},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount"})
+
+	_, err := suite.conn.exec(context.Background(), "CREATE USER U IDENTIFIED BY 'secret'", nil)
+	suite.NoError(err)
+	suite.Equal("CREATE USER U IDENTIFIED BY '***'", after.SQL)
+	suite.Equal(sqlHash("CREATE USER U IDENTIFIED BY 'secret'"), after.SQLHash)
+}
@@ -0,0 +1,69 @@
+package connection
+
+import "sync/atomic"
+
+// Stats is a snapshot of the counters collected by a [StatsCollector].
+type Stats struct {
+	ConnectionsOpened uint64 // Number of successfully opened websocket connections
+	ConnectionsClosed uint64 // Number of closed connections
+	Logins            uint64 // Number of successful logins
+	CommandsSent      uint64 // Number of commands sent to the database
+	FetchCalls        uint64 // Number of fetch commands sent for reading result sets
+	BytesCompressed   uint64 // Number of uncompressed message bytes passed through compression
+	Reconnects        uint64 // Number of connection attempts to another host after a failed attempt
+	FailedHandshakes  uint64 // Number of failed websocket handshakes
+}
+
+type statsCounter int
+
+const (
+	connectionsOpened statsCounter = iota
+	connectionsClosed
+	logins
+	commandsSent
+	fetchCalls
+	bytesCompressed
+	reconnects
+	failedHandshakes
+	numStatsCounters
+)
+
+// StatsCollector counts events of connections. It is safe for concurrent use.
+// Events are also forwarded to the parent collector, if one is set.
+// A nil collector ignores all events.
+type StatsCollector struct {
+	parent   *StatsCollector
+	counters [numStatsCounters]atomic.Uint64
+}
+
+// NewStatsCollector creates a new collector that forwards all events to the given parent (may be nil).
+func NewStatsCollector(parent *StatsCollector) *StatsCollector {
+	return &StatsCollector{parent: parent}
+}
+
+// Snapshot returns the current values of all counters.
+func (s *StatsCollector) Snapshot() Stats {
+	if s == nil {
+		return Stats{}
+	}
+	return Stats{
+		ConnectionsOpened: s.counters[connectionsOpened].Load(),
+		ConnectionsClosed: s.counters[connectionsClosed].Load(),
+		Logins:            s.counters[logins].Load(),
+		CommandsSent:      s.counters[commandsSent].Load(),
+		FetchCalls:        s.counters[fetchCalls].Load(),
+		BytesCompressed:   s.counters[bytesCompressed].Load(),
+		Reconnects:        s.counters[reconnects].Load(),
+		FailedHandshakes:  s.counters[failedHandshakes].Load(),
+	}
+}
+
+func (s *StatsCollector) add(counter statsCounter, delta uint64) {
+	for collector := s; collector != nil; collector = collector.parent {
+		collector.counters[counter].Add(delta)
+	}
+}
+
+func (s *StatsCollector) inc(counter statsCounter) {
+	s.add(counter, 1)
+}
@@ -0,0 +1,51 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatsTestSuite struct {
+	suite.Suite
+}
+
+func TestStatsSuite(t *testing.T) {
+	suite.Run(t, new(StatsTestSuite))
+}
+
+func (suite *StatsTestSuite) TestSnapshotEmpty() {
+	suite.Equal(Stats{}, NewStatsCollector(nil).Snapshot())
+}
+
+func (suite *StatsTestSuite) TestSnapshotNilCollector() {
+	var collector *StatsCollector
+	collector.inc(logins)
+	suite.Equal(Stats{}, collector.Snapshot())
+}
+
+func (suite *StatsTestSuite) TestSnapshotCountsAllCounters() {
+	collector := NewStatsCollector(nil)
+	collector.inc(connectionsOpened)
+	collector.inc(connectionsClosed)
+	collector.inc(logins)
+	collector.inc(commandsSent)
+	collector.inc(commandsSent)
+	collector.inc(fetchCalls)
+	collector.add(bytesCompressed, 42)
+	collector.inc(reconnects)
+	collector.inc(failedHandshakes)
+	suite.Equal(Stats{ConnectionsOpened: 1, ConnectionsClosed: 1, Logins: 1, CommandsSent: 2, FetchCalls: 1,
+		BytesCompressed: 42, Reconnects: 1, FailedHandshakes: 1}, collector.Snapshot())
+}
+
+func (suite *StatsTestSuite) TestEventsForwardedToParent() {
+	parent := NewStatsCollector(nil)
+	child1 := NewStatsCollector(parent)
+	child2 := NewStatsCollector(parent)
+	child1.inc(logins)
+	child2.inc(logins)
+	suite.Equal(uint64(1), child1.Snapshot().Logins)
+	suite.Equal(uint64(1), child2.Snapshot().Logins)
+	suite.Equal(uint64(2), parent.Snapshot().Logins)
+}
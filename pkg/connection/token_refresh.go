@@ -0,0 +1,97 @@
+package connection
+
+import (
+	"context"
+	goerrors "errors"
+	"sync"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+)
+
+// TokenProvider returns a new OpenID access token, e.g. requested from the identity provider with stored credentials.
+// The driver calls it when a login fails because the access token expired.
+type TokenProvider func(ctx context.Context) (string, error)
+
+// tokenRejectedSQLCode is the SQL code of the exception the database sends when it rejects the credentials of a login.
+const tokenRejectedSQLCode = "08004"
+
+// isTokenExpiredError returns true if the database rejected a login with the credentials.
+// The database uses the same SQL code for expired and invalid tokens, so a token is refreshed in both cases.
+func isTokenExpiredError(err error) bool {
+	var sqlError *errors.SQLError
+	return goerrors.As(err, &sqlError) && sqlError.SQLCode == tokenRejectedSQLCode
+}
+
+// TokenCache shares the access token obtained from the [TokenProvider] by one connection with the connections
+// opened later, so that they log in with it instead of failing with the expired token first.
+type TokenCache struct {
+	mutex sync.Mutex
+	token string
+}
+
+func (t *TokenCache) get() string {
+	if t == nil {
+		return ""
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.token
+}
+
+func (t *TokenCache) set(token string) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.token = token
+}
+
+// loginWithRefreshedToken reconnects and logs in again with a new access token from the token provider
+// or with the refresh token of the configuration.
+// The login error is returned unchanged if no new token can be obtained.
+func (c *Connection) loginWithRefreshedToken(ctx context.Context, loginErr error) error {
+	authenticator, err := c.refreshedAuthenticator(ctx)
+	if err != nil {
+		return err
+	}
+	if authenticator == nil {
+		return loginErr
+	}
+	logger.TraceLogger.Print("access token expired, logging in again with a new token")
+	if c.websocket != nil {
+		_ = c.websocket.Close()
+		c.setWebsocket(nil)
+	}
+	if err := c.connect(); err != nil {
+		return err
+	}
+	if err := c.login(ctx, authenticator); err != nil {
+		return err
+	}
+	if tokenAuthenticator, ok := authenticator.(AccessTokenAuthenticator); ok {
+		c.TokenCache.set(tokenAuthenticator.Token)
+	}
+	return nil
+}
+
+func (c *Connection) refreshedAuthenticator(ctx context.Context) (Authenticator, error) {
+	if c.TokenProvider != nil {
+		token, err := c.TokenProvider(ctx)
+		if err != nil {
+			return nil, errors.NewTokenRefreshFailed(err)
+		}
+		return AccessTokenAuthenticator{Token: token}, nil
+	}
+	if c.Config.RefreshToken != "" {
+		return RefreshTokenAuthenticator{Token: c.Config.RefreshToken}, nil
+	}
+	return nil, nil
+}
+
+// usesAccessToken checks if the configuration logs in with an access token that can be refreshed.
+func usesAccessToken(config *config.Config) bool {
+	return config.AccessToken != "" || config.TokenFile != ""
+}
@@ -2,7 +2,6 @@ package connection
 
 import (
 	"context"
-	"database/sql/driver"
 
 	"github.com/exasol/exasol-driver-go/pkg/errors"
 	"github.com/exasol/exasol-driver-go/pkg/logger"
@@ -16,28 +15,42 @@ func NewTransaction(connection *Connection) *Transaction {
 	return &Transaction{connection: connection}
 }
 
+// Commit commits the transaction. It waits at most for the configured commit timeout.
 func (t *Transaction) Commit() error {
-	if t.connection == nil {
-		return errors.ErrInvalidConn
-	}
-	if t.connection.IsClosed {
-		logger.ErrorLogger.Print(errors.ErrClosed)
-		return driver.ErrBadConn
-	}
-	_, err := t.connection.SimpleExec(context.Background(), "COMMIT")
-	t.connection = nil
-	return err
+	return t.CommitContext(context.Background())
 }
 
+// CommitContext commits the transaction and aborts waiting for the database when the context is done.
+func (t *Transaction) CommitContext(ctx context.Context) error {
+	return t.end(ctx, "COMMIT")
+}
+
+// Rollback rolls back the transaction. It waits at most for the configured commit timeout.
 func (t *Transaction) Rollback() error {
+	return t.RollbackContext(context.Background())
+}
+
+// RollbackContext rolls back the transaction and aborts waiting for the database when the context is done.
+func (t *Transaction) RollbackContext(ctx context.Context) error {
+	return t.end(ctx, "ROLLBACK")
+}
+
+func (t *Transaction) end(ctx context.Context, command string) error {
 	if t.connection == nil {
 		return errors.ErrInvalidConn
 	}
 	if t.connection.IsClosed {
 		logger.ErrorLogger.Print(errors.ErrClosed)
-		return driver.ErrBadConn
+		return errors.NewBadConnError(errors.ErrClosed)
+	}
+	if timeout := t.connection.Config.CommitTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
-	_, err := t.connection.SimpleExec(context.Background(), "ROLLBACK")
+	t.connection.startStatement()
+	_, err := t.connection.SimpleExec(ctx, command)
+	t.connection.transactionEnded(err == nil)
 	t.connection = nil
 	return err
 }
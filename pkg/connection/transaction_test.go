@@ -1,9 +1,16 @@
 package connection
 
 import (
+	"context"
 	"database/sql/driver"
 	"testing"
+	"time"
 
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -28,11 +35,72 @@ func (suite *TransactionTestSuite) TestRollbackWithEmptyConnection() {
 func (suite *TransactionTestSuite) TestCommitWithClosedConnection() {
 	connection := Connection{IsClosed: true}
 	transaction := Transaction{connection: &connection}
-	suite.EqualError(transaction.Commit(), driver.ErrBadConn.Error())
+	suite.ErrorIs(transaction.Commit(), driver.ErrBadConn)
 }
 
 func (suite *TransactionTestSuite) TestRollbackWithClosedConnection() {
 	connection := Connection{IsClosed: true}
 	transaction := Transaction{connection: &connection}
-	suite.EqualError(transaction.Rollback(), driver.ErrBadConn.Error())
+	suite.ErrorIs(transaction.Rollback(), driver.ErrBadConn)
+}
+
+func (suite *TransactionTestSuite) TestNestedBeginFails() {
+	connection := Connection{Config: &config.Config{Autocommit: false}}
+	_, err := connection.Begin()
+	suite.NoError(err)
+	_, err = connection.Begin()
+	suite.ErrorIs(err, errors.ErrTransactionInProgress)
+}
+
+func (suite *TransactionTestSuite) TestBeginAfterCommit() {
+	websocketMock := wsconn.CreateWebsocketConnectionMock()
+	websocketMock.SimulateSQLQueriesResponse(types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "COMMIT"},
+		types.SqlQueryResponseRowCount{ResultType: "rowCount"})
+	connection := Connection{Config: &config.Config{Autocommit: false}, Ctx: context.Background(), websocket: websocketMock}
+	transaction, err := connection.Begin()
+	suite.NoError(err)
+	suite.NoError(transaction.Commit())
+	_, err = connection.Begin()
+	suite.NoError(err)
+}
+
+func (suite *TransactionTestSuite) TestBeginAfterFailedRollback() {
+	websocketMock := wsconn.CreateWebsocketConnectionMock()
+	websocketMock.SimulateErrorResponse(types.SqlCommand{Command: types.Command{Command: "execute"}, SQLText: "ROLLBACK"}, mockException)
+	connection := Connection{Config: &config.Config{Autocommit: false}, Ctx: context.Background(), websocket: websocketMock}
+	transaction, err := connection.Begin()
+	suite.NoError(err)
+	suite.Error(transaction.Rollback())
+	_, err = connection.Begin()
+	suite.NoError(err)
+}
+
+func (suite *TransactionTestSuite) TestCommitTimeout() {
+	websocketMock := suite.createBlockingWebsocketMock()
+	connection := Connection{Config: &config.Config{Autocommit: false, CommitTimeout: 10 * time.Millisecond}, Ctx: context.Background(), websocket: websocketMock}
+	transaction, err := connection.Begin()
+	suite.NoError(err)
+	suite.ErrorIs(transaction.Commit(), context.DeadlineExceeded)
+	_, err = connection.Begin()
+	suite.NoError(err)
+}
+
+func (suite *TransactionTestSuite) TestRollbackContextCancelled() {
+	websocketMock := suite.createBlockingWebsocketMock()
+	connection := Connection{Config: &config.Config{Autocommit: false}, Ctx: context.Background(), websocket: websocketMock}
+	transaction, err := connection.Begin()
+	suite.NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	suite.ErrorIs(transaction.(*Transaction).RollbackContext(ctx), context.Canceled)
+}
+
+// createBlockingWebsocketMock accepts the command and the abortQuery request but answers only after the test has given up waiting.
+func (suite *TransactionTestSuite) createBlockingWebsocketMock() *wsconn.WebsocketConnectionMock {
+	websocketMock := wsconn.CreateWebsocketConnectionMock()
+	websocketMock.OnWriteAnyMessage(nil)
+	websocketMock.OnWriteAnyMessage(nil)
+	response := wsconn.JsonMarshall(types.BaseResponse{Status: "ok", ResponseData: wsconn.JsonMarshall(types.SqlQueriesResponse{})})
+	websocketMock.On("ReadMessage").After(200*time.Millisecond).Return(websocket.TextMessage, []byte(response), nil).Maybe()
+	return websocketMock
 }
@@ -3,26 +3,101 @@ package connection
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"strconv"
 
 	"github.com/exasol/exasol-driver-go/pkg/types"
 )
 
 func ToRow(result *types.SqlQueriesResponse, con *Connection) (driver.Rows, error) {
+	return toTrackedRows(result, con, nil)
+}
+
+func toTrackedRows(result *types.SqlQueriesResponse, con *Connection, tracker *slowQueryTracker) (driver.Rows, error) {
 	resultSet := &types.SqlQueryResponseResultSet{}
-	err := json.Unmarshal(result.Results[0], resultSet)
+	err := con.jsonCodec().Unmarshal(result.Results[0], resultSet)
 	if err != nil {
+		tracker.finish()
 		return nil, err
 	}
 
-	return &QueryResults{data: &resultSet.ResultSet, con: con}, nil
+	return &QueryResults{data: &resultSet.ResultSet, con: con, tracker: tracker}, nil
 }
 
 func ToResult(result *types.SqlQueriesResponse) (driver.Result, error) {
+	return toResult(result, StandardJSONCodec)
+}
+
+// toResult decodes the number of affected rows of the first result with the given codec.
+func toResult(result *types.SqlQueriesResponse, codec JSONCodec) (driver.Result, error) {
 	rowCountResult := &types.SqlQueryResponseRowCount{}
-	err := json.Unmarshal(result.Results[0], rowCountResult)
+	err := codec.Unmarshal(result.Results[0], rowCountResult)
 	if err != nil {
 		return nil, err
 	}
 
 	return &RowCount{affectedRows: int64(rowCountResult.RowCount)}, nil
 }
+
+// numberType is the Go type of the numbers of a column. It is chosen once per column from its data type,
+// so that the values of all rows of a column have the same type.
+type numberType int
+
+const (
+	floatNumber   numberType = iota // float64
+	integerNumber                   // int64
+	decimalNumber                   // string with the exact decimal
+)
+
+const (
+	// maxInt64Precision is the largest precision of DECIMAL integers that fit into int64.
+	maxInt64Precision = 18
+	// maxFloatPrecision is the largest precision of decimals that float64 represents without losing digits.
+	maxFloatPrecision = 15
+)
+
+// columnNumberType returns the type of the numbers of a column with the given data type.
+// DECIMAL columns without precision are DECIMAL(18,0), the default of the database.
+func columnNumberType(dataType types.SqlQueryColumnType) numberType {
+	if dataType.Type != "DECIMAL" {
+		return floatNumber
+	}
+	precision, scale := int64(maxInt64Precision), int64(0)
+	if dataType.Precision != nil {
+		precision = *dataType.Precision
+	}
+	if dataType.Scale != nil {
+		scale = *dataType.Scale
+	}
+	switch {
+	case scale == 0 && precision <= maxInt64Precision:
+		return integerNumber
+	case precision <= maxFloatPrecision:
+		return floatNumber
+	default:
+		return decimalNumber
+	}
+}
+
+// convertNumber converts a number decoded as json.Number to the given type.
+// Numbers that can't be parsed as the type, e.g. in columns with a wrong data type, are returned as string.
+func convertNumber(value interface{}, numbers numberType) interface{} {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	return parseNumber(string(number), numbers)
+}
+
+func parseNumber(text string, numbers numberType) driver.Value {
+	switch numbers {
+	case integerNumber:
+		if integer, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return integer
+		}
+	case floatNumber:
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return f
+		}
+	}
+	return text
+}
@@ -0,0 +1,51 @@
+package connection
+
+import (
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+)
+
+// Backoff between connection attempts while waiting for the database. Variables to allow shorter waits in tests.
+var (
+	waitForDatabaseInitialBackoff = time.Second
+	waitForDatabaseMaxBackoff     = 15 * time.Second
+)
+
+// connectWaitingForDatabase retries connecting with exponential backoff until the configured wait duration elapsed,
+// e.g. while an auto-stopped SaaS cluster is starting. Without wait duration it connects only once.
+func (c *Connection) connectWaitingForDatabase() error {
+	err := c.connect()
+	if err == nil || c.Config.WaitForDatabase <= 0 {
+		return err
+	}
+	deadline := time.Now().Add(c.Config.WaitForDatabase)
+	backoff := waitForDatabaseInitialBackoff
+	for attempt := 2; ; attempt++ {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return errors.NewDatabaseWaitTimeout(c.Config.WaitForDatabase, err)
+		}
+		if backoff > remaining {
+			backoff = remaining
+		}
+		logger.TraceLogger.Printf("database not available, connection attempt %d in %s (waiting at most %s longer): %v", attempt, backoff, remaining.Round(time.Second), err)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-c.Ctx.Done():
+			timer.Stop()
+			return c.Ctx.Err()
+		case <-timer.C:
+		}
+		err = c.connect()
+		if err == nil {
+			logger.TraceLogger.Printf("database available after %d connection attempts", attempt)
+			return nil
+		}
+		backoff *= 2
+		if backoff > waitForDatabaseMaxBackoff {
+			backoff = waitForDatabaseMaxBackoff
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/config"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/stretchr/testify/suite"
+)
+
+type WaitForDatabaseTestSuite struct {
+	suite.Suite
+	websocketMock *wsconn.WebsocketConnectionMock
+	dialAttempts  int
+}
+
+func TestWaitForDatabaseSuite(t *testing.T) {
+	suite.Run(t, new(WaitForDatabaseTestSuite))
+}
+
+func (suite *WaitForDatabaseTestSuite) SetupTest() {
+	suite.websocketMock = wsconn.CreateWebsocketConnectionMock()
+	suite.dialAttempts = 0
+	initialBackoff, maxBackoff := waitForDatabaseInitialBackoff, waitForDatabaseMaxBackoff
+	waitForDatabaseInitialBackoff, waitForDatabaseMaxBackoff = time.Millisecond, 4*time.Millisecond
+	suite.T().Cleanup(func() {
+		waitForDatabaseInitialBackoff, waitForDatabaseMaxBackoff = initialBackoff, maxBackoff
+	})
+}
+
+func (suite *WaitForDatabaseTestSuite) TestConnectOnceWithoutWait() {
+	conn := suite.createConnection(0, 3)
+	suite.EqualError(conn.Connect(), "dial failed")
+	suite.Equal(1, suite.dialAttempts)
+}
+
+func (suite *WaitForDatabaseTestSuite) TestConnectRetriesUntilDatabaseIsAvailable() {
+	conn := suite.createConnection(time.Minute, 3)
+	suite.NoError(conn.Connect())
+	suite.Equal(4, suite.dialAttempts)
+	suite.Same(suite.websocketMock, conn.websocket)
+}
+
+func (suite *WaitForDatabaseTestSuite) TestConnectFailsAfterWait() {
+	conn := suite.createConnection(20*time.Millisecond, 1000)
+	suite.EqualError(conn.Connect(), "E-EGOD-48: database not available after waiting 20ms: dial failed")
+	suite.Greater(suite.dialAttempts, 1)
+}
+
+func (suite *WaitForDatabaseTestSuite) TestConnectStopsWaitingWhenContextIsCancelled() {
+	conn := suite.createConnection(time.Minute, 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	conn.Ctx = ctx
+	suite.ErrorIs(conn.Connect(), context.Canceled)
+	suite.Equal(1, suite.dialAttempts)
+}
+
+// createConnection returns a connection whose first failedAttempts dial attempts fail.
+func (suite *WaitForDatabaseTestSuite) createConnection(wait time.Duration, failedAttempts int) *Connection {
+	return &Connection{
+		Config: &config.Config{Host: "host", Port: 8563, WaitForDatabase: wait},
+		Ctx:    context.Background(),
+		DialFunc: func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+			suite.dialAttempts++
+			if suite.dialAttempts <= failedAttempts {
+				return nil, fmt.Errorf("dial failed")
+			}
+			return suite.websocketMock, nil
+		},
+	}
+}
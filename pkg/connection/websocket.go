@@ -2,13 +2,11 @@ package connection
 
 import (
 	"bytes"
-	"compress/zlib"
 	"context"
-	"database/sql/driver"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/internal/utils"
 	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
@@ -20,6 +18,9 @@ import (
 )
 
 func (c *Connection) getURIScheme() string {
+	if c.Config.WebsocketScheme != "" {
+		return c.Config.WebsocketScheme
+	}
 	if c.Config.Encryption {
 		return "wss"
 	} else {
@@ -28,29 +29,109 @@ func (c *Connection) getURIScheme() string {
 }
 
 func (c *Connection) Connect() error {
-	hosts, err := utils.ResolveHosts(c.Config.Host)
+	if err := c.ShutdownGroup.register(c); err != nil {
+		return err
+	}
+	err := c.connectWaitingForDatabase()
 	if err != nil {
+		c.ShutdownGroup.unregister(c)
+	}
+	return err
+}
+
+func (c *Connection) connect() error {
+	if err := c.dialWithRetries(); err != nil {
 		return err
 	}
+	return c.wrapWebsocket()
+}
 
+// dialConfiguredHosts resolves the configured hosts and dials them in random order or starting with the preferred host.
+func (c *Connection) dialConfiguredHosts() error {
+	hosts, err := c.resolveHosts(c.Config.Host, nil)
+	if err != nil {
+		return err
+	}
+
+	resolved := append([]string{}, hosts...)
 	utils.ShuffleHosts(hosts)
+	// Probing would replace the preferred host with the fastest one
+	if !preferHost(c.Ctx, resolved, hosts) && c.Config.HostProbeTimeout > 0 && len(hosts) > 1 {
+		hosts = c.probeHosts(hosts)
+	}
+	return c.dialHosts(hosts)
+}
 
-	for _, host := range hosts {
+// dialHosts opens the websocket connection to the first reachable of the given hosts.
+func (c *Connection) dialHosts(hosts []string) error {
+	var err error
+	for i, host := range hosts {
+		if i > 0 {
+			c.Stats.inc(reconnects)
+		}
+		var name string
+		var port int
+		if name, port, err = utils.SplitHostPort(host, c.Config.Port); err != nil {
+			return err
+		}
 		url := url.URL{
 			Scheme: c.getURIScheme(),
-			Host:   fmt.Sprintf("%s:%d", host, c.Config.Port),
+			Host:   fmt.Sprintf("%s:%d", name, port),
+			Path:   c.Config.WebsocketPath,
 		}
-		c.websocket, err = c.connectToHost(url)
+		var ws wsconn.WebsocketConnection
+		ws, err = c.connectToHost(url)
+		c.setWebsocket(ws)
 		if err == nil {
+			c.Stats.inc(connectionsOpened)
 			return nil
 		}
+		c.Stats.inc(failedHandshakes)
 	}
 	return err
 }
 
+// wrapWebsocket enables the debug options for logging and recording websocket frames.
+func (c *Connection) wrapWebsocket() error {
+	if c.Config.DebugFrames {
+		c.setWebsocket(wsconn.NewFrameLogger(c.websocket))
+	}
+	if c.Config.RecordFrames == "" {
+		return nil
+	}
+	ws, err := recordFrames(c.websocket, c.Config.RecordFrames)
+	if err != nil {
+		return err
+	}
+	c.setWebsocket(ws)
+	return nil
+}
+
+// setWebsocket replaces the websocket of the connection. Only the goroutine using the connection replaces the websocket,
+// so it reads the field without locking.
+func (c *Connection) setWebsocket(ws wsconn.WebsocketConnection) {
+	c.websocketMutex.Lock()
+	defer c.websocketMutex.Unlock()
+	c.websocket = ws
+}
+
+// DialFunc opens a websocket connection to the given URL, e.g. to replace the database with a fake in unit tests.
+type DialFunc func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error)
+
 func (c *Connection) connectToHost(url url.URL) (wsconn.WebsocketConnection, error) {
-	skipVerify := !c.Config.ValidateServerCertificate || c.Config.CertificateFingerprint != ""
-	ws, err := wsconn.CreateConnection(c.Ctx, skipVerify, c.Config.CertificateFingerprint, url)
+	var ws wsconn.WebsocketConnection
+	var err error
+	if c.DialFunc != nil {
+		ws, err = c.DialFunc(c.Ctx, url)
+	} else {
+		ws, err = wsconn.CreateConnectionWithOptions(c.Ctx, url, wsconn.Options{
+			DialOptions:            c.DialOptions,
+			SkipVerify:             !c.Config.ValidateServerCertificate || c.Config.CertificateFingerprint != "",
+			CertificateFingerprint: c.Config.CertificateFingerprint,
+			ServerName:             c.Config.TLSServerName,
+			PermessageDeflate:      c.usePermessageDeflate(),
+		})
+	}
 	if err != nil {
 		logger.ErrorLogger.Print(errors.NewConnectionFailedError(url, err))
 		return nil, err
@@ -58,100 +139,177 @@ func (c *Connection) connectToHost(url url.URL) (wsconn.WebsocketConnection, err
 	return ws, nil
 }
 
+// usePermessageDeflate returns true if the permessage-deflate extension is enabled.
+// Application level compression takes precedence, compressing the messages twice would only cost CPU time.
+func (c *Connection) usePermessageDeflate() bool {
+	return c.Config.PermessageDeflate && !c.Config.Compression
+}
+
 func (c *Connection) Send(ctx context.Context, request, response interface{}) error {
+	release, err := c.commands.acquire(ctx, c.Config.MaxQueuedCommands, c.Config.CommandWaitTimeout)
+	if err != nil {
+		return err
+	}
 	receiver, err := c.asyncSend(request)
 	if err != nil {
+		release()
 		return err
 	}
 	channel := make(chan error, 1)
-	go func() { channel <- receiver(response) }()
-	select {
-	case <-ctx.Done():
-		_, err := c.asyncSend(&types.Command{Command: "abortQuery"})
-		if err != nil {
-			return errors.NewErrCouldNotAbort(ctx.Err())
+	go func() {
+		// The next command must not be sent before the response was read, even if the caller stopped waiting for it
+		err := receiver(response)
+		release()
+		channel <- err
+	}()
+	var keepalive <-chan time.Time
+	if c.Config.KeepaliveInterval > 0 {
+		ticker := time.NewTicker(c.Config.KeepaliveInterval)
+		defer ticker.Stop()
+		keepalive = ticker.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			_, err := c.asyncSend(&types.Command{Command: "abortQuery"})
+			if err != nil {
+				return errors.NewErrCouldNotAbort(ctx.Err())
+			}
+			return ctx.Err()
+		case err := <-channel:
+			return err
+		case <-keepalive:
+			c.sendKeepalive()
 		}
-		return ctx.Err()
-	case err := <-channel:
-		return err
+	}
+}
+
+// sendKeepalive sends a websocket ping so that proxies don't close the connection while waiting for a long-running statement.
+func (c *Connection) sendKeepalive() {
+	ws := c.websocket
+	if ws == nil {
+		return
+	}
+	if err := wsconn.Ping(ws); err != nil {
+		logger.ErrorLogger.Print(errors.NewKeepaliveError(err))
 	}
 }
 
 func (c *Connection) asyncSend(request interface{}) (func(interface{}) error, error) {
-	message, err := json.Marshal(request)
+	message, err := c.jsonCodec().Marshal(request)
 	if err != nil {
 		logger.ErrorLogger.Print(errors.NewMarshallingError(request, err))
-		return nil, driver.ErrBadConn
+		return nil, errors.NewBadConnError(err)
 	}
 
+	trace := c.newMessageTrace(message)
 	messageType := websocket.TextMessage
-	if c.Config.Compression {
-		var b bytes.Buffer
-		w := zlib.NewWriter(&b)
-		_, err = w.Write(message)
+	if c.Config.Compression && len(message) >= c.Config.CompressionThreshold {
+		c.Stats.add(bytesCompressed, uint64(len(message)))
+		buffer, err := compress(message)
 		if err != nil {
 			return nil, err
 		}
-		w.Close()
-		message = b.Bytes()
+		defer releaseMessageBuffer(buffer)
+		message = buffer.Bytes()
 		messageType = websocket.BinaryMessage
 	}
 
 	if c.websocket == nil {
 		return nil, errors.NewWebsocketNotConnected(string(message))
 	}
+	trace.sending(message, messageType == websocket.BinaryMessage)
 	err = c.websocket.WriteMessage(messageType, message)
 	if err != nil {
 		logger.ErrorLogger.Print(errors.NewRequestSendingError(err))
-		return nil, driver.ErrBadConn
+		trace.log(err)
+		return nil, c.brokenConnectionError(err)
 	}
+	c.Stats.inc(commandsSent)
 
-	return c.callback(), nil
+	return c.callback(trace), nil
 }
 
-func (c *Connection) callback() func(response interface{}) error {
-	return func(response interface{}) error {
-		_, message, err := c.websocket.ReadMessage()
+func (c *Connection) callback(trace *messageTrace) func(response interface{}) error {
+	return func(response interface{}) (err error) {
+		defer func() { trace.log(err) }()
+		// Decode the message from the reader instead of reading it into an intermediate buffer first.
+		// The JSON decoder still buffers the complete message.
+		_, messageReader, err := wsconn.NextReader(c.websocket)
 		if err != nil {
 			logger.ErrorLogger.Print(errors.NewReceivingError(err))
-			return driver.ErrBadConn
+			return c.brokenConnectionError(err)
 		}
+		messageReader = trace.countReceived(messageReader)
 
-		result := &types.BaseResponse{}
-
-		var reader io.Reader
-		reader = bytes.NewReader(message)
-
+		result := getBaseResponse()
+		defer releaseBaseResponse(result)
+		reader := messageReader
 		if c.Config.Compression {
-			reader, err = zlib.NewReader(bytes.NewReader(message))
+			decompressor, err := newPooledDecompressor(reader)
 			if err != nil {
 				logger.ErrorLogger.Print(errors.NewUncompressingError(err))
-				return driver.ErrBadConn
+				return errors.NewBadConnError(err)
 			}
+			defer decompressor.release()
+			reader = trace.countUncompressed(decompressor)
 		}
+		messageStart := getPrefixBuffer()
+		defer releasePrefixBuffer(messageStart)
+		reader = io.TeeReader(reader, messageStart)
 
-		err = json.NewDecoder(reader).Decode(result)
+		err = c.jsonCodec().NewDecoder(reader).Decode(result)
 		if err != nil {
-			logger.ErrorLogger.Print(errors.NewJsonDecodingError(err, message))
-			return driver.ErrBadConn
+			logger.ErrorLogger.Print(errors.NewJsonDecodingError(err, messageStart.Bytes()))
+			return c.brokenConnectionError(err)
 		}
 
 		if result.Status != "ok" {
 			if result.Exception != nil {
-				return errors.NewSqlErr(result.Exception.SQLCode, result.Exception.Text)
+				return errors.NewSQLError(result.Exception.SQLCode, result.Exception.Text, result.Exception.Details)
 			} else {
-				return fmt.Errorf("result status is not 'ok': %q, expected exception in response %v", result.Status, result)
+				return fmt.Errorf("result status is not 'ok': %q, expected exception in response %s", result.Status, messageStart.Bytes())
 			}
 		}
 
+		if len(result.Attributes) > 0 {
+			err = c.jsonCodec().Unmarshal(result.Attributes, &c.attributes)
+			if err != nil {
+				return fmt.Errorf("failed to parse attributes %q: %w", result.Attributes, err)
+			}
+		}
+		if err = c.recordNotices(result); err != nil {
+			return fmt.Errorf("failed to parse attributes %q: %w", result.Attributes, err)
+		}
+
 		if response == nil {
 			return nil
 		}
 
-		err = json.Unmarshal(result.ResponseData, response)
+		err = c.jsonCodec().Unmarshal(result.ResponseData, response)
 		if err != nil {
 			return fmt.Errorf("failed to parse response data %q: %w", result.ResponseData, err)
 		}
 		return nil
 	}
 }
+
+// maxLoggedMessageLength is the number of bytes of a message included in decoding errors.
+const maxLoggedMessageLength = 4096
+
+// prefixBuffer keeps the first bytes written to it and discards the rest.
+type prefixBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *prefixBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); remaining > 0 {
+		if len(p) > remaining {
+			b.Buffer.Write(p[:remaining])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,31 @@
+package connection
+
+import (
+	goerrors "errors"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/gorilla/websocket"
+)
+
+// brokenConnectionError returns the error for a failure reading or writing the websocket.
+// If the database closed the websocket, the error contains the close code and reason
+// and only matches driver.ErrBadConn if a new connection may succeed, so that database/sql retries the operation.
+// Other failures always match driver.ErrBadConn.
+func (c *Connection) brokenConnectionError(err error) error {
+	var closeError *websocket.CloseError
+	if !goerrors.As(err, &closeError) {
+		return errors.NewBadConnError(err)
+	}
+	c.closedByDatabase = true
+	websocketCloseError := errors.NewWebsocketCloseError(closeError.Code, closeError.Text)
+	if websocketCloseError.Retryable() {
+		return errors.NewBadConnError(websocketCloseError)
+	}
+	return websocketCloseError
+}
+
+// IsValid returns false if the connection is closed, the database closed the websocket or the connection was shut down,
+// so that database/sql discards the connection instead of returning it to the pool.
+func (c *Connection) IsValid() bool {
+	return !c.IsClosed && !c.closedByDatabase && !c.ShutdownGroup.isShutdown()
+}
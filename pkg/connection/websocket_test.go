@@ -3,12 +3,22 @@ package connection
 import (
 	"context"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/internal/config"
 	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
 	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -48,6 +58,32 @@ func (suite *WebsocketTestSuite) TestSendSuccessWithCompression() {
 	suite.Equal("pem", response.PublicKeyPem)
 }
 
+func (suite *WebsocketTestSuite) TestSendSmallMessageUncompressedBelowThreshold() {
+	request := types.LoginCommand{Command: types.Command{Command: "login"}}
+	response := &types.PublicKeyResponse{}
+	suite.websocketMock.OnWriteTextMessage([]byte(`{"command":"login","protocolVersion":0,"attributes":{}}`), nil)
+	suite.websocketMock.OnReadCompressedMessage([]byte(`{"status":"ok","responseData":{"publicKeyPem":"pem"}}`), nil)
+
+	conn := suite.createOpenConnection()
+	conn.Config.Compression = true
+	conn.Config.CompressionThreshold = 1024
+	err := conn.Send(context.Background(), request, response)
+	suite.NoError(err)
+	suite.Equal("pem", response.PublicKeyPem)
+}
+
+func (suite *WebsocketTestSuite) TestSendMessageCompressedAtThreshold() {
+	request := types.LoginCommand{Command: types.Command{Command: "login"}}
+	message := []byte(`{"command":"login","protocolVersion":0,"attributes":{}}`)
+	suite.websocketMock.OnWriteCompressedMessage(message, nil)
+	suite.websocketMock.OnReadCompressedMessage([]byte(`{"status":"ok","responseData":{}}`), nil)
+
+	conn := suite.createOpenConnection()
+	conn.Config.Compression = true
+	conn.Config.CompressionThreshold = len(message)
+	suite.NoError(conn.Send(context.Background(), request, nil))
+}
+
 func (suite *WebsocketTestSuite) TestSendWithCompressionFailsDuringUncompress() {
 	request := types.LoginCommand{Command: types.Command{Command: "login"}}
 	response := &types.PublicKeyResponse{}
@@ -57,7 +93,7 @@ func (suite *WebsocketTestSuite) TestSendWithCompressionFailsDuringUncompress()
 	conn := suite.createOpenConnection()
 	conn.Config.Compression = true
 	err := conn.Send(context.Background(), request, response)
-	suite.EqualError(err, driver.ErrBadConn.Error())
+	suite.ErrorIs(err, driver.ErrBadConn)
 }
 
 func (suite *WebsocketTestSuite) TestSendSuccessNoResponse() {
@@ -83,7 +119,8 @@ func (suite *WebsocketTestSuite) TestSendFailsAtWriteMessage() {
 	response := &types.PublicKeyResponse{}
 	suite.websocketMock.OnWriteAnyMessage(fmt.Errorf("mock error"))
 	err := suite.createOpenConnection().Send(context.Background(), request, response)
-	suite.EqualError(err, driver.ErrBadConn.Error())
+	suite.ErrorIs(err, driver.ErrBadConn)
+	suite.EqualError(err, "driver: bad connection: mock error")
 }
 
 func (suite *WebsocketTestSuite) TestSendFailsAtReadMessage() {
@@ -93,7 +130,53 @@ func (suite *WebsocketTestSuite) TestSendFailsAtReadMessage() {
 	suite.websocketMock.OnReadTextMessage(nil, fmt.Errorf("mock error"))
 
 	err := suite.createOpenConnection().Send(context.Background(), request, response)
-	suite.EqualError(err, driver.ErrBadConn.Error())
+	suite.ErrorIs(err, driver.ErrBadConn)
+	suite.EqualError(err, "driver: bad connection: mock error")
+}
+
+func (suite *WebsocketTestSuite) TestSendFailsAtRetryableCloseCode() {
+	request := types.LoginCommand{Command: types.Command{Command: "login"}}
+	suite.websocketMock.OnWriteAnyMessage(nil)
+	suite.websocketMock.OnReadTextMessage(nil, &websocket.CloseError{Code: websocket.CloseGoingAway, Text: "database shutdown"})
+
+	conn := suite.createOpenConnection()
+	err := conn.Send(context.Background(), request, nil)
+	suite.ErrorIs(err, driver.ErrBadConn)
+	suite.EqualError(err, "driver: bad connection: E-EGOD-61: database closed the connection with code 1001 (going away): 'database shutdown'")
+	var closeError *errors.WebsocketCloseError
+	suite.ErrorAs(err, &closeError)
+	suite.Equal(websocket.CloseGoingAway, closeError.Code)
+	suite.False(conn.IsValid())
+}
+
+func (suite *WebsocketTestSuite) TestSendFailsAtNonRetryableCloseCode() {
+	request := types.LoginCommand{Command: types.Command{Command: "login"}}
+	suite.websocketMock.OnWriteAnyMessage(nil)
+	suite.websocketMock.OnReadTextMessage(nil, &websocket.CloseError{Code: websocket.ClosePolicyViolation, Text: "too many sessions"})
+
+	conn := suite.createOpenConnection()
+	err := conn.Send(context.Background(), request, nil)
+	suite.NotErrorIs(err, driver.ErrBadConn)
+	suite.EqualError(err, "E-EGOD-61: database closed the connection with code 1008 (policy violation): 'too many sessions'")
+	suite.False(conn.IsValid())
+}
+
+func (suite *WebsocketTestSuite) TestCloseSkipsDisconnectAfterDatabaseClosedConnection() {
+	suite.websocketMock.OnWriteAnyMessage(nil)
+	suite.websocketMock.OnReadTextMessage(nil, &websocket.CloseError{Code: websocket.CloseProtocolError})
+	suite.websocketMock.OnClose(nil)
+
+	conn := suite.createOpenConnection()
+	suite.Error(conn.Send(context.Background(), types.Command{Command: "getAttributes"}, nil))
+	suite.NoError(conn.Close())
+	suite.websocketMock.AssertNumberOfCalls(suite.T(), "WriteMessage", 1)
+}
+
+func (suite *WebsocketTestSuite) TestIsValid() {
+	conn := suite.createOpenConnection()
+	suite.True(conn.IsValid())
+	conn.IsClosed = true
+	suite.False(conn.IsValid())
 }
 
 func (suite *WebsocketTestSuite) TestSendFailsAtDecodingResponse() {
@@ -103,7 +186,9 @@ func (suite *WebsocketTestSuite) TestSendFailsAtDecodingResponse() {
 	suite.websocketMock.OnReadTextMessage([]byte("invalid json"), nil)
 
 	err := suite.createOpenConnection().Send(context.Background(), request, response)
-	suite.EqualError(err, driver.ErrBadConn.Error())
+	suite.ErrorIs(err, driver.ErrBadConn)
+	var syntaxError *json.SyntaxError
+	suite.ErrorAs(err, &syntaxError)
 }
 
 func (suite *WebsocketTestSuite) TestSendFailsAtNonOKStatusException() {
@@ -115,6 +200,20 @@ func (suite *WebsocketTestSuite) TestSendFailsAtNonOKStatusException() {
 	suite.EqualError(err, "E-EGOD-11: execution failed with SQL error code 'mock sql code' and message 'mock error'")
 }
 
+func (suite *WebsocketTestSuite) TestSendFailsWithExceptionDetails() {
+	request := types.LoginCommand{Command: types.Command{Command: "login"}}
+	suite.websocketMock.OnWriteTextMessage(wsconn.JsonMarshall(request), nil)
+	suite.websocketMock.OnReadTextMessage([]byte(`{"status":"error","exception":{"text":"syntax error","sqlCode":"42000","position":{"line":120,"column":7}}}`), nil)
+
+	err := suite.createOpenConnection().Send(context.Background(), request, nil)
+	var sqlError *errors.SQLError
+	suite.Require().ErrorAs(err, &sqlError)
+	suite.Equal("42000", sqlError.SQLCode)
+	suite.Equal("syntax error", sqlError.Text)
+	suite.JSONEq(`{"line":120,"column":7}`, string(sqlError.Details["position"]))
+	suite.EqualError(err, `E-EGOD-11: execution failed with SQL error code '42000' and message 'syntax error' (position={"line":120,"column":7})`)
+}
+
 func (suite *WebsocketTestSuite) TestSendFailsAtNonOKStatusMissingException() {
 	request := types.LoginCommand{Command: types.Command{Command: "login"}}
 	response := &types.PublicKeyResponse{}
@@ -122,7 +221,7 @@ func (suite *WebsocketTestSuite) TestSendFailsAtNonOKStatusMissingException() {
 	suite.websocketMock.OnReadTextMessage([]byte(`{"status": "notok"}`), nil)
 
 	err := suite.createOpenConnection().Send(context.Background(), request, response)
-	suite.EqualError(err, `result status is not 'ok': "notok", expected exception in response &{notok [] <nil>}`)
+	suite.EqualError(err, `result status is not 'ok': "notok", expected exception in response {"status": "notok"}`)
 }
 
 func (suite *WebsocketTestSuite) TestSendFailsAtParsingResponseData() {
@@ -135,6 +234,181 @@ func (suite *WebsocketTestSuite) TestSendFailsAtParsingResponseData() {
 	suite.EqualError(err, `failed to parse response data "\"invalid\"": json: cannot unmarshal string into Go value of type types.PublicKeyResponse`)
 }
 
+type streamingWebsocketMock struct {
+	*wsconn.WebsocketConnectionMock
+	message string
+}
+
+func (mock *streamingWebsocketMock) NextReader() (int, io.Reader, error) {
+	return websocket.TextMessage, strings.NewReader(mock.message), nil
+}
+
+func (suite *WebsocketTestSuite) TestSendDecodesStreamedMessage() {
+	request := types.LoginCommand{Command: types.Command{Command: "login"}}
+	response := &types.PublicKeyResponse{}
+	suite.websocketMock.OnWriteTextMessage(wsconn.JsonMarshall(request), nil)
+	conn := suite.createOpenConnection()
+	conn.websocket = &streamingWebsocketMock{WebsocketConnectionMock: suite.websocketMock,
+		message: `{"status": "ok", "responseData": {"publicKeyPem": "pem"}}`}
+
+	suite.NoError(conn.Send(context.Background(), request, response))
+	suite.Equal("pem", response.PublicKeyPem)
+	suite.websocketMock.AssertNotCalled(suite.T(), "ReadMessage")
+}
+
+func (suite *WebsocketTestSuite) TestPrefixBufferKeepsStartOfMessage() {
+	buffer := &prefixBuffer{limit: 5}
+	n, err := buffer.Write([]byte("abc"))
+	suite.Equal(3, n)
+	suite.NoError(err)
+	n, _ = buffer.Write([]byte("defgh"))
+	suite.Equal(5, n)
+	_, _ = buffer.Write([]byte("ijk"))
+	suite.Equal("abcde", buffer.String())
+}
+
+type pingingWebsocketMock struct {
+	*wsconn.WebsocketConnectionMock
+	pings   atomic.Int32
+	pingErr error
+}
+
+func (mock *pingingWebsocketMock) Ping() error {
+	mock.pings.Add(1)
+	return mock.pingErr
+}
+
+func (suite *WebsocketTestSuite) TestSendSendsKeepalivePings() {
+	pingingMock := &pingingWebsocketMock{WebsocketConnectionMock: suite.websocketMock}
+	request := types.LoginCommand{Command: types.Command{Command: "login"}}
+	suite.websocketMock.OnWriteTextMessage(wsconn.JsonMarshall(request), nil)
+	suite.websocketMock.On("ReadMessage").Return(websocket.TextMessage, []byte(`{"status": "ok"}`), nil).After(100 * time.Millisecond).Once()
+	conn := suite.createOpenConnection()
+	conn.websocket = pingingMock
+	conn.Config.KeepaliveInterval = 10 * time.Millisecond
+
+	suite.NoError(conn.Send(context.Background(), request, nil))
+	suite.Greater(pingingMock.pings.Load(), int32(2))
+}
+
+func (suite *WebsocketTestSuite) TestSendIgnoresFailedKeepalivePing() {
+	pingingMock := &pingingWebsocketMock{WebsocketConnectionMock: suite.websocketMock, pingErr: fmt.Errorf("mock error")}
+	request := types.LoginCommand{Command: types.Command{Command: "login"}}
+	suite.websocketMock.OnWriteTextMessage(wsconn.JsonMarshall(request), nil)
+	suite.websocketMock.On("ReadMessage").Return(websocket.TextMessage, []byte(`{"status": "ok"}`), nil).After(50 * time.Millisecond).Once()
+	conn := suite.createOpenConnection()
+	conn.websocket = pingingMock
+	conn.Config.KeepaliveInterval = 10 * time.Millisecond
+
+	suite.NoError(conn.Send(context.Background(), request, nil))
+	suite.Greater(pingingMock.pings.Load(), int32(0))
+}
+
+func (suite *WebsocketTestSuite) TestSendWithoutKeepaliveDoesNotPing() {
+	pingingMock := &pingingWebsocketMock{WebsocketConnectionMock: suite.websocketMock}
+	request := types.LoginCommand{Command: types.Command{Command: "login"}}
+	suite.websocketMock.OnWriteTextMessage(wsconn.JsonMarshall(request), nil)
+	suite.websocketMock.On("ReadMessage").Return(websocket.TextMessage, []byte(`{"status": "ok"}`), nil).After(30 * time.Millisecond).Once()
+	conn := suite.createOpenConnection()
+	conn.websocket = pingingMock
+
+	suite.NoError(conn.Send(context.Background(), request, nil))
+	suite.Equal(int32(0), pingingMock.pings.Load())
+}
+
+func (suite *WebsocketTestSuite) TestPermessageDeflateIgnoredWithCompression() {
+	for _, test := range []struct {
+		compression, permessageDeflate, expected bool
+	}{
+		{false, false, false},
+		{false, true, true},
+		{true, true, false},
+		{true, false, false},
+	} {
+		conn := &Connection{Config: &config.Config{Compression: test.compression, PermessageDeflate: test.permessageDeflate}}
+		suite.Equal(test.expected, conn.usePermessageDeflate(), test)
+	}
+}
+
+func (suite *WebsocketTestSuite) TestConnectUsesConfiguredSchemeAndPath() {
+	for _, test := range []struct {
+		encryption   bool
+		scheme, path string
+		expectedURL  string
+	}{
+		{true, "", "", "wss://host:12345"},
+		{false, "", "", "ws://host:12345"},
+		{true, "ws", "", "ws://host:12345"},
+		{false, "wss", "/exasol/ws", "wss://host:12345/exasol/ws"},
+		{true, "", "exasol", "wss://host:12345/exasol"},
+	} {
+		conn := &Connection{Config: &config.Config{Host: "host", Port: 12345, Encryption: test.encryption, WebsocketScheme: test.scheme, WebsocketPath: test.path}, Ctx: context.Background()}
+		var dialedURL string
+		conn.DialFunc = func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+			dialedURL = url.String()
+			return suite.websocketMock, nil
+		}
+		suite.NoError(conn.Connect())
+		suite.Equal(test.expectedURL, dialedURL)
+	}
+}
+
+func (suite *WebsocketTestSuite) TestConnectUsesDialFunc() {
+	conn := &Connection{Config: &config.Config{Host: "host1,host2", Port: 12345}, Ctx: context.Background()}
+	var dialedHosts []string
+	conn.DialFunc = func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+		dialedHosts = append(dialedHosts, url.Host)
+		return suite.websocketMock, nil
+	}
+	suite.NoError(conn.Connect())
+	suite.Len(dialedHosts, 1)
+	suite.Same(suite.websocketMock, conn.websocket)
+}
+
+func (suite *WebsocketTestSuite) TestConnectUsesPerHostPorts() {
+	conn := &Connection{Config: &config.Config{Host: "host1:8564,host2", Port: 12345}, Ctx: context.Background()}
+	var dialedHosts []string
+	conn.DialFunc = func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+		dialedHosts = append(dialedHosts, url.Host)
+		return nil, fmt.Errorf("mock error")
+	}
+	suite.EqualError(conn.Connect(), "mock error")
+	suite.ElementsMatch([]string{"host1:8564", "host2:12345"}, dialedHosts)
+}
+
+func (suite *WebsocketTestSuite) TestConnectFailsWithInvalidPerHostPort() {
+	conn := &Connection{Config: &config.Config{Host: "host1:abc", Port: 12345}, Ctx: context.Background()}
+	suite.EqualError(conn.Connect(), "E-EGOD-23: invalid `port` value 'abc', numeric port expected")
+}
+
+func (suite *WebsocketTestSuite) TestConnectFailsWithDialFunc() {
+	conn := &Connection{Config: &config.Config{Host: "host", Port: 12345}, Ctx: context.Background()}
+	conn.DialFunc = func(ctx context.Context, url url.URL) (wsconn.WebsocketConnection, error) {
+		return nil, fmt.Errorf("mock error")
+	}
+	suite.EqualError(conn.Connect(), "mock error")
+}
+
+func (suite *WebsocketTestSuite) TestConnectUsesDialOptions() {
+	headers := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header
+		upgrader := websocket.Upgrader{}
+		if ws, err := upgrader.Upgrade(w, r, nil); err == nil {
+			ws.Close()
+		}
+	}))
+	defer server.Close()
+	conn := &Connection{
+		Config:      &config.Config{Host: strings.TrimPrefix(server.URL, "http://"), Port: 12345},
+		Ctx:         context.Background(),
+		DialOptions: wsconn.DialOptions{Dialer: &websocket.Dialer{HandshakeTimeout: time.Second}, Header: http.Header{"X-Route": []string{"node1"}}},
+	}
+	suite.NoError(conn.Connect())
+	defer conn.websocket.Close()
+	suite.Equal("node1", (<-headers).Get("X-Route"))
+}
+
 func (suite *WebsocketTestSuite) createOpenConnection() *Connection {
 	conn := &Connection{
 		Config:    &config.Config{Host: "invalid", Port: 12345, User: "user", Password: "password", ApiVersion: 42},
@@ -0,0 +1,71 @@
+package wsconn
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/gorilla/websocket"
+)
+
+// maxLoggedFrameLength is the maximum length of a logged frame, longer frames are truncated.
+const maxLoggedFrameLength = 4096
+
+// NewFrameLogger returns a connection that logs all frames sent and received via the given connection
+// with the trace logger. Frames are pretty-printed, credentials are redacted and long frames are truncated.
+func NewFrameLogger(conn WebsocketConnection) WebsocketConnection {
+	return &frameLoggingConnection{delegate: conn}
+}
+
+type frameLoggingConnection struct {
+	delegate WebsocketConnection
+}
+
+func (c *frameLoggingConnection) WriteMessage(messageType int, data []byte) error {
+	logFrame(DirectionSend, messageType, data)
+	return c.delegate.WriteMessage(messageType, data)
+}
+
+func (c *frameLoggingConnection) ReadMessage() (int, []byte, error) {
+	messageType, data, err := c.delegate.ReadMessage()
+	if err == nil {
+		logFrame(DirectionReceive, messageType, data)
+	}
+	return messageType, data, err
+}
+
+func (c *frameLoggingConnection) Ping() error {
+	return Ping(c.delegate)
+}
+
+func (c *frameLoggingConnection) Close() error {
+	return c.delegate.Close()
+}
+
+func logFrame(direction string, messageType int, data []byte) {
+	compressed := messageType == websocket.BinaryMessage
+	if compressed {
+		uncompressed, err := decompress(data)
+		if err != nil {
+			logger.TraceLogger.Printf("frame %s: %d compressed bytes, %v", direction, len(data), err)
+			return
+		}
+		data = uncompressed
+	}
+	logger.TraceLogger.Printf("frame %s (compressed=%t):\n%s", direction, compressed, formatFrame(data))
+}
+
+// formatFrame returns the pretty-printed frame with credentials redacted, truncated to maxLoggedFrameLength.
+func formatFrame(data []byte) string {
+	data = utils.RedactFrame(data)
+	var buffer bytes.Buffer
+	if err := json.Indent(&buffer, data, "", "  "); err == nil {
+		data = buffer.Bytes()
+	}
+	if len(data) > maxLoggedFrameLength {
+		return fmt.Sprintf("%s... (%d bytes truncated)", data[:maxLoggedFrameLength], len(data)-maxLoggedFrameLength)
+	}
+	return string(data)
+}
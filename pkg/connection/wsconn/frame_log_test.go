@@ -0,0 +1,57 @@
+package wsconn
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/pkg/logger"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type FrameLogTestSuite struct {
+	suite.Suite
+	websocketMock  *WebsocketConnectionMock
+	output         *bytes.Buffer
+	previousLogger logger.Logger
+}
+
+func TestFrameLogSuite(t *testing.T) {
+	suite.Run(t, new(FrameLogTestSuite))
+}
+
+func (suite *FrameLogTestSuite) SetupTest() {
+	suite.websocketMock = CreateWebsocketConnectionMock()
+	suite.output = &bytes.Buffer{}
+	suite.previousLogger = logger.TraceLogger
+	logger.TraceLogger = log.New(suite.output, "", 0)
+}
+
+func (suite *FrameLogTestSuite) TearDownTest() {
+	logger.TraceLogger = suite.previousLogger
+}
+
+func (suite *FrameLogTestSuite) TestLogsSentFrame() {
+	suite.websocketMock.OnWriteTextMessage([]byte(`{"command":"login","password":"secret"}`), nil)
+	err := NewFrameLogger(suite.websocketMock).WriteMessage(websocket.TextMessage, []byte(`{"command":"login","password":"secret"}`))
+	suite.NoError(err)
+	suite.Equal("frame send (compressed=false):\n{\n  \"command\": \"login\",\n  \"password\": \"***\"\n}\n", suite.output.String())
+}
+
+func (suite *FrameLogTestSuite) TestLogsReceivedCompressedFrame() {
+	suite.websocketMock.OnReadCompressedMessage([]byte(`{"status":"ok"}`), nil)
+	_, _, err := NewFrameLogger(suite.websocketMock).ReadMessage()
+	suite.NoError(err)
+	suite.Equal("frame receive (compressed=true):\n{\n  \"status\": \"ok\"\n}\n", suite.output.String())
+}
+
+func (suite *FrameLogTestSuite) TestTruncatesLongFrame() {
+	formatted := formatFrame([]byte(`"` + strings.Repeat("a", maxLoggedFrameLength+8) + `"`))
+	suite.Equal(`"`+strings.Repeat("a", maxLoggedFrameLength-1)+"... (10 bytes truncated)", formatted)
+}
+
+func (suite *FrameLogTestSuite) TestFormatsInvalidJson() {
+	suite.Equal("hello", formatFrame([]byte("hello")))
+}
@@ -0,0 +1,116 @@
+package wsconn
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/gorilla/websocket"
+)
+
+// Directions of recorded frames.
+const (
+	DirectionSend    = "send"
+	DirectionReceive = "receive"
+)
+
+// Frame is a recorded websocket message. Compressed messages are recorded uncompressed.
+type Frame struct {
+	Connection int             `json:"connection"`           // Number of the recorded connection, starting at 1
+	Direction  string          `json:"direction"`            // DirectionSend or DirectionReceive
+	Compressed bool            `json:"compressed,omitempty"` // Whether the message was compressed
+	Data       json.RawMessage `json:"data"`                 // Message with credentials redacted
+}
+
+// FrameRecorder writes the frames of websocket connections as JSON lines with credentials redacted.
+// It is safe for concurrent use.
+type FrameRecorder struct {
+	mutex           sync.Mutex
+	writer          io.Writer
+	connectionCount int
+}
+
+// NewFrameRecorder creates a new recorder writing to the given writer.
+func NewFrameRecorder(writer io.Writer) *FrameRecorder {
+	return &FrameRecorder{writer: writer}
+}
+
+// Wrap returns a connection that records all frames sent and received via the given connection.
+func (r *FrameRecorder) Wrap(conn WebsocketConnection) WebsocketConnection {
+	return r.WrapWithCloseHook(conn, func() {})
+}
+
+// WrapWithCloseHook returns a connection like [FrameRecorder.Wrap] that calls onClose once when it is closed,
+// e.g. for closing the file of the recorder after the last recorded connection.
+func (r *FrameRecorder) WrapWithCloseHook(conn WebsocketConnection, onClose func()) WebsocketConnection {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.connectionCount++
+	return &recordingConnection{delegate: conn, recorder: r, connection: r.connectionCount, onClose: onClose}
+}
+
+func (r *FrameRecorder) record(connection int, direction string, messageType int, data []byte) {
+	compressed := messageType == websocket.BinaryMessage
+	if compressed {
+		uncompressed, err := decompress(data)
+		if err != nil {
+			return
+		}
+		data = uncompressed
+	}
+	data = utils.RedactFrame(data)
+	if !json.Valid(data) {
+		data, _ = json.Marshal(string(data))
+	}
+	line, err := json.Marshal(Frame{Connection: connection, Direction: direction, Compressed: compressed, Data: data})
+	if err != nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	_, _ = r.writer.Write(append(line, '\n'))
+}
+
+type recordingConnection struct {
+	delegate   WebsocketConnection
+	recorder   *FrameRecorder
+	connection int
+	onClose    func()
+	closeOnce  sync.Once
+}
+
+func (c *recordingConnection) WriteMessage(messageType int, data []byte) error {
+	c.recorder.record(c.connection, DirectionSend, messageType, data)
+	return c.delegate.WriteMessage(messageType, data)
+}
+
+func (c *recordingConnection) ReadMessage() (int, []byte, error) {
+	messageType, data, err := c.delegate.ReadMessage()
+	if err == nil {
+		c.recorder.record(c.connection, DirectionReceive, messageType, data)
+	}
+	return messageType, data, err
+}
+
+func (c *recordingConnection) Ping() error {
+	return Ping(c.delegate)
+}
+
+func (c *recordingConnection) Close() error {
+	err := c.delegate.Close()
+	c.closeOnce.Do(c.onClose)
+	return err
+}
+
+func decompress(data []byte) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress frame: %w", err)
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,69 @@
+package wsconn
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecordingTestSuite struct {
+	suite.Suite
+	websocketMock *WebsocketConnectionMock
+	output        *bytes.Buffer
+	recorder      *FrameRecorder
+}
+
+func TestRecordingSuite(t *testing.T) {
+	suite.Run(t, new(RecordingTestSuite))
+}
+
+func (suite *RecordingTestSuite) SetupTest() {
+	suite.websocketMock = CreateWebsocketConnectionMock()
+	suite.output = &bytes.Buffer{}
+	suite.recorder = NewFrameRecorder(suite.output)
+}
+
+func (suite *RecordingTestSuite) TestRecordsSentFrameRedacted() {
+	suite.websocketMock.OnWriteTextMessage([]byte(`{"password":"secret"}`), nil)
+	suite.NoError(suite.recorder.Wrap(suite.websocketMock).WriteMessage(websocket.TextMessage, []byte(`{"password":"secret"}`)))
+	suite.Equal(`{"connection":1,"direction":"send","data":{"password":"***"}}`+"\n", suite.output.String())
+}
+
+func (suite *RecordingTestSuite) TestRecordsReceivedCompressedFrame() {
+	suite.websocketMock.OnReadCompressedMessage([]byte(`{"status":"ok"}`), nil)
+	_, _, err := suite.recorder.Wrap(suite.websocketMock).ReadMessage()
+	suite.NoError(err)
+	suite.Equal(`{"connection":1,"direction":"receive","compressed":true,"data":{"status":"ok"}}`+"\n", suite.output.String())
+}
+
+func (suite *RecordingTestSuite) TestRecordsInvalidJsonAsString() {
+	suite.websocketMock.OnWriteTextMessage([]byte(`hello`), nil)
+	suite.NoError(suite.recorder.Wrap(suite.websocketMock).WriteMessage(websocket.TextMessage, []byte(`hello`)))
+	suite.Equal(`{"connection":1,"direction":"send","data":"hello"}`+"\n", suite.output.String())
+}
+
+func (suite *RecordingTestSuite) TestNumbersConnections() {
+	suite.recorder.Wrap(suite.websocketMock)
+	suite.websocketMock.OnReadTextMessage([]byte(`{}`), nil)
+	_, _, err := suite.recorder.Wrap(suite.websocketMock).ReadMessage()
+	suite.NoError(err)
+	frame := Frame{}
+	suite.NoError(json.Unmarshal(suite.output.Bytes(), &frame))
+	suite.Equal(2, frame.Connection)
+}
+
+func (suite *RecordingTestSuite) TestPingWithoutPingerIsIgnored() {
+	suite.NoError(Ping(suite.recorder.Wrap(suite.websocketMock)))
+}
+
+func (suite *RecordingTestSuite) TestCloseHookIsCalledOnce() {
+	suite.websocketMock.OnClose(nil)
+	calls := 0
+	conn := suite.recorder.WrapWithCloseHook(suite.websocketMock, func() { calls++ })
+	suite.NoError(conn.Close())
+	suite.NoError(conn.Close())
+	suite.Equal(1, calls)
+}
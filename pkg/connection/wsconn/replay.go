@@ -0,0 +1,109 @@
+package wsconn
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// ReadFrames reads frames recorded by a [FrameRecorder] and groups them by connection in the order of their first frame.
+func ReadFrames(reader io.Reader) ([][]Frame, error) {
+	var connections [][]Frame
+	indexes := map[int]int{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		frame := Frame{}
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, fmt.Errorf("invalid frame in line %d: %w", lineNumber, err)
+		}
+		index, ok := indexes[frame.Connection]
+		if !ok {
+			index = len(connections)
+			indexes[frame.Connection] = index
+			connections = append(connections, nil)
+		}
+		connections[index] = append(connections[index], frame)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
+// ReplayConnection is a websocket connection that replays recorded frames.
+// Sent messages are checked against the recorded commands, received messages are taken from the recording.
+type ReplayConnection struct {
+	mutex  sync.Mutex
+	frames []Frame
+	next   int
+}
+
+// NewReplayConnection creates a new connection replaying the given frames of a single connection.
+func NewReplayConnection(frames []Frame) *ReplayConnection {
+	return &ReplayConnection{frames: frames}
+}
+
+func (c *ReplayConnection) WriteMessage(messageType int, data []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	frame, err := c.nextFrame(DirectionSend)
+	if err != nil {
+		return err
+	}
+	if messageType == websocket.BinaryMessage {
+		if data, err = decompress(data); err != nil {
+			return err
+		}
+	}
+	expectedCommand := commandName(frame.Data)
+	actualCommand := commandName(data)
+	if expectedCommand != actualCommand {
+		return fmt.Errorf("replay diverged at frame %d: expected command %q but got %q", c.next, expectedCommand, actualCommand)
+	}
+	return nil
+}
+
+func (c *ReplayConnection) ReadMessage() (int, []byte, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	frame, err := c.nextFrame(DirectionReceive)
+	if err != nil {
+		return 0, nil, err
+	}
+	if frame.Compressed {
+		return websocket.BinaryMessage, compress(frame.Data), nil
+	}
+	return websocket.TextMessage, frame.Data, nil
+}
+
+func (c *ReplayConnection) Close() error {
+	return nil
+}
+
+func (c *ReplayConnection) nextFrame(direction string) (*Frame, error) {
+	if c.next >= len(c.frames) {
+		return nil, fmt.Errorf("replay diverged: no more recorded frames, expected %s", direction)
+	}
+	frame := &c.frames[c.next]
+	c.next++
+	if frame.Direction != direction {
+		return nil, fmt.Errorf("replay diverged at frame %d: expected %s but recording contains %s", c.next, direction, frame.Direction)
+	}
+	return frame, nil
+}
+
+func commandName(message []byte) string {
+	command := struct {
+		Command string `json:"command"`
+	}{}
+	_ = json.Unmarshal(message, &command)
+	return command.Command
+}
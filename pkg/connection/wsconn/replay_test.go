@@ -0,0 +1,69 @@
+package wsconn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReplayTestSuite struct {
+	suite.Suite
+}
+
+func TestReplaySuite(t *testing.T) {
+	suite.Run(t, new(ReplayTestSuite))
+}
+
+const recording = `{"connection":1,"direction":"send","data":{"command":"login"}}
+{"connection":2,"direction":"send","data":{"command":"login"}}
+{"connection":1,"direction":"receive","compressed":true,"data":{"status":"ok"}}
+`
+
+func (suite *ReplayTestSuite) TestReadFrames() {
+	connections, err := ReadFrames(strings.NewReader(recording))
+	suite.NoError(err)
+	suite.Len(connections, 2)
+	suite.Len(connections[0], 2)
+	suite.Len(connections[1], 1)
+}
+
+func (suite *ReplayTestSuite) TestReadFramesInvalid() {
+	connections, err := ReadFrames(strings.NewReader("invalid"))
+	suite.ErrorContains(err, "invalid frame in line 1")
+	suite.Nil(connections)
+}
+
+func (suite *ReplayTestSuite) TestReplay() {
+	conn := suite.replayFirstConnection()
+	suite.NoError(conn.WriteMessage(websocket.TextMessage, []byte(`{"command":"login","protocolVersion":3}`)))
+	messageType, data, err := conn.ReadMessage()
+	suite.NoError(err)
+	suite.Equal(websocket.BinaryMessage, messageType)
+	uncompressed, err := decompress(data)
+	suite.NoError(err)
+	suite.Equal(`{"status":"ok"}`, string(uncompressed))
+}
+
+func (suite *ReplayTestSuite) TestReplayDivergingCommand() {
+	conn := suite.replayFirstConnection()
+	suite.EqualError(conn.WriteMessage(websocket.TextMessage, []byte(`{"command":"execute"}`)), `replay diverged at frame 1: expected command "login" but got "execute"`)
+}
+
+func (suite *ReplayTestSuite) TestReplayDivergingDirection() {
+	conn := suite.replayFirstConnection()
+	_, _, err := conn.ReadMessage()
+	suite.EqualError(err, "replay diverged at frame 1: expected receive but recording contains send")
+}
+
+func (suite *ReplayTestSuite) TestReplayNoMoreFrames() {
+	conn := NewReplayConnection(nil)
+	suite.EqualError(conn.WriteMessage(websocket.TextMessage, []byte(`{}`)), "replay diverged: no more recorded frames, expected send")
+}
+
+func (suite *ReplayTestSuite) replayFirstConnection() *ReplayConnection {
+	connections, err := ReadFrames(strings.NewReader(recording))
+	suite.NoError(err)
+	return NewReplayConnection(connections[0])
+}
@@ -1,14 +1,18 @@
 package wsconn
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/hex"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/pkg/errors"
 	"github.com/gorilla/websocket"
@@ -27,6 +31,43 @@ type WebsocketConnection interface {
 	Close() error
 }
 
+// Pinger is implemented by websocket connections that can send ping control frames.
+type Pinger interface {
+	// Ping sends a ping control frame. It may be called concurrently with ReadMessage and WriteMessage.
+	Ping() error
+}
+
+// Ping sends a ping via the given connection if it implements [Pinger].
+func Ping(conn WebsocketConnection) error {
+	if pinger, ok := conn.(Pinger); ok {
+		return pinger.Ping()
+	}
+	return nil
+}
+
+// MessageReader is implemented by websocket connections that can read messages as a stream.
+type MessageReader interface {
+	// NextReader returns a reader for the next message. The reader is invalid after the next call.
+	NextReader() (messageType int, r io.Reader, err error)
+}
+
+// NextReader returns a reader for the next message of the given connection.
+// Messages are streamed from the network if the connection implements [MessageReader],
+// otherwise the whole message is read into memory.
+func NextReader(conn WebsocketConnection) (int, io.Reader, error) {
+	if messageReader, ok := conn.(MessageReader); ok {
+		return messageReader.NextReader()
+	}
+	messageType, message, err := conn.ReadMessage()
+	if err != nil {
+		return messageType, nil, err
+	}
+	return messageType, bytes.NewReader(message), nil
+}
+
+// pingWriteTimeout is the maximum duration for sending a ping.
+const pingWriteTimeout = 10 * time.Second
+
 type wsConnImpl struct {
 	socket *websocket.Conn
 }
@@ -56,23 +97,70 @@ var cipherSuites = []uint16{
 	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
 }
 
+// DialOptions customize the websocket handshake of CreateConnectionWithOptions, see Options.
+type DialOptions struct {
+	// Dialer is used instead of websocket.DefaultDialer, e.g. with a handshake timeout, subprotocols, a proxy or
+	// a NetDialContext function. Its TLS configuration is cloned and extended with the certificate verification
+	// configured for the driver, so that client certificates and root CAs are kept. Compression is set by the driver.
+	Dialer *websocket.Dialer
+	// Header contains additional headers of the handshake request, e.g. for header-based routing.
+	Header http.Header
+}
+
+// Options configure the websocket connections created with CreateConnectionWithOptions.
+type Options struct {
+	DialOptions
+	// SkipVerify deactivates the verification of the server certificate.
+	SkipVerify bool
+	// CertificateFingerprint is the expected SHA-256 fingerprint of the server certificate, if not empty.
+	CertificateFingerprint string
+	// ServerName is sent in the TLS handshake (SNI) and verified against the certificate instead of the host of the URL,
+	// if not empty.
+	ServerName string
+	// PermessageDeflate negotiates the permessage-deflate extension and compresses written messages if the server
+	// supports the extension. Otherwise write compression is deactivated for the new connection.
+	PermessageDeflate bool
+}
+
 // CreateConnection creates a websocket connection to the given URL.
 // This deactivates write compression for the new connection.
 func CreateConnection(ctx context.Context, skipVerify bool, expectedFingerprint string, url url.URL) (WebsocketConnection, error) {
+	return CreateConnectionWithOptions(ctx, url, Options{SkipVerify: skipVerify, CertificateFingerprint: expectedFingerprint})
+}
+
+// CreateConnectionWithOptions creates a websocket connection to the given URL with the given options.
+func CreateConnectionWithOptions(ctx context.Context, url url.URL, options Options) (WebsocketConnection, error) {
 	dialer := *websocket.DefaultDialer
-	dialer.TLSClientConfig = &tls.Config{
-		InsecureSkipVerify:    skipVerify, //nolint:gosec
-		CipherSuites:          cipherSuites,
-		VerifyPeerCertificate: certificateVerifier(expectedFingerprint),
+	if options.Dialer != nil {
+		dialer = *options.Dialer
 	}
-	ws, _, err := dialer.DialContext(ctx, url.String(), nil)
+	dialer.EnableCompression = options.PermessageDeflate
+	dialer.TLSClientConfig = tlsConfig(dialer.TLSClientConfig, options.SkipVerify, options.CertificateFingerprint, options.ServerName)
+	ws, _, err := dialer.DialContext(ctx, url.String(), options.Header)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to URL %q: %w", url.String(), err)
 	}
-	ws.EnableWriteCompression(false)
+	ws.EnableWriteCompression(options.PermessageDeflate)
 	return &wsConnImpl{socket: ws}, nil
 }
 
+// tlsConfig returns a copy of the given TLS configuration with the certificate verification of the driver.
+func tlsConfig(base *tls.Config, skipVerify bool, expectedFingerprint string, serverName string) *tls.Config {
+	config := &tls.Config{}
+	if base != nil {
+		config = base.Clone()
+	}
+	config.InsecureSkipVerify = skipVerify //nolint:gosec
+	config.VerifyPeerCertificate = certificateVerifier(expectedFingerprint)
+	if serverName != "" {
+		config.ServerName = serverName
+	}
+	if config.CipherSuites == nil {
+		config.CipherSuites = cipherSuites
+	}
+	return config
+}
+
 func (ws *wsConnImpl) WriteMessage(messageType int, data []byte) error {
 	return ws.socket.WriteMessage(messageType, data)
 }
@@ -81,6 +169,14 @@ func (ws *wsConnImpl) ReadMessage() (messageType int, p []byte, err error) {
 	return ws.socket.ReadMessage()
 }
 
+func (ws *wsConnImpl) NextReader() (messageType int, r io.Reader, err error) {
+	return ws.socket.NextReader()
+}
+
+func (ws *wsConnImpl) Ping() error {
+	return ws.socket.WriteControl(websocket.PingMessage, nil, time.Now().Add(pingWriteTimeout))
+}
+
 func (ws *wsConnImpl) Close() error {
 	return ws.socket.Close()
 }
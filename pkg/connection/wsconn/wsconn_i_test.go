@@ -7,14 +7,14 @@ import (
 	"testing"
 
 	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
-	"github.com/exasol/exasol-driver-go/pkg/integrationTesting"
+	"github.com/exasol/exasol-driver-go/pkg/exasoltest"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/suite"
 )
 
 type WebsocketITestSuite struct {
 	suite.Suite
-	exasol *integrationTesting.DbTestSetup
+	exasol *exasoltest.Database
 }
 
 func TestIntegrationWebsocketSuite(t *testing.T) {
@@ -22,15 +22,15 @@ func TestIntegrationWebsocketSuite(t *testing.T) {
 }
 
 func (suite *WebsocketITestSuite) SetupSuite() {
-	suite.exasol = integrationTesting.StartDbSetup(&suite.Suite)
+	suite.exasol = exasoltest.Start(suite.T())
 }
 
 func (suite *WebsocketITestSuite) TearDownSuite() {
-	suite.exasol.StopDb()
+	suite.NoError(suite.exasol.Stop())
 }
 
 func (suite *WebsocketITestSuite) TestCreateConnectionSuccess() {
-	conn, err := wsconn.CreateConnection(context.Background(), true, "", suite.exasol.GetUrl())
+	conn, err := wsconn.CreateConnection(context.Background(), true, "", suite.exasol.URL())
 	suite.NoError(err)
 	suite.NotNil(conn)
 	conn.Close()
@@ -43,8 +43,8 @@ func (suite *WebsocketITestSuite) TestCreateConnectionFailed() {
 }
 
 func (suite *WebsocketITestSuite) TestCreateConnectionInvalidCertificate() {
-	conn, err := wsconn.CreateConnection(context.Background(), false, "invalid", suite.exasol.GetUrl())
-	suite.ErrorContains(err, fmt.Sprintf(`failed to connect to URL "wss://%s:%d": tls: failed to verify certificate`, suite.exasol.ConnectionInfo.Host, suite.exasol.ConnectionInfo.Port))
+	conn, err := wsconn.CreateConnection(context.Background(), false, "invalid", suite.exasol.URL())
+	suite.ErrorContains(err, fmt.Sprintf(`failed to connect to URL "wss://%s:%d": tls: failed to verify certificate`, suite.exasol.Host, suite.exasol.Port))
 	suite.Nil(conn)
 }
 
@@ -64,7 +64,7 @@ func (suite *WebsocketITestSuite) TestRead() {
 }
 
 func (suite *WebsocketITestSuite) createConnection() wsconn.WebsocketConnection {
-	conn, err := wsconn.CreateConnection(context.Background(), true, "", suite.exasol.GetUrl())
+	conn, err := wsconn.CreateConnection(context.Background(), true, "", suite.exasol.URL())
 	if err != nil {
 		suite.FailNowf("connection failed: %v", err.Error())
 	}
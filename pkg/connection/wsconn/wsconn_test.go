@@ -1,8 +1,18 @@
 package wsconn
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -69,3 +79,157 @@ func (suite *WebsocketTestSuite) TestBytesToHexString() {
 		})
 	}
 }
+
+type streamingConnection struct {
+	*WebsocketConnectionMock
+	message string
+}
+
+func (c *streamingConnection) NextReader() (int, io.Reader, error) {
+	return websocket.TextMessage, strings.NewReader(c.message), nil
+}
+
+func (suite *WebsocketTestSuite) TestNextReaderStreamsMessage() {
+	conn := &streamingConnection{WebsocketConnectionMock: CreateWebsocketConnectionMock(), message: "streamed"}
+	messageType, reader, err := NextReader(conn)
+	suite.NoError(err)
+	suite.Equal(websocket.TextMessage, messageType)
+	content, _ := io.ReadAll(reader)
+	suite.Equal("streamed", string(content))
+	conn.AssertNotCalled(suite.T(), "ReadMessage")
+}
+
+func (suite *WebsocketTestSuite) TestNextReaderReadsWholeMessage() {
+	mock := CreateWebsocketConnectionMock()
+	mock.OnReadTextMessage([]byte("message"), nil)
+	_, reader, err := NextReader(mock)
+	suite.NoError(err)
+	content, _ := io.ReadAll(reader)
+	suite.Equal("message", string(content))
+}
+
+func (suite *WebsocketTestSuite) TestNextReaderFails() {
+	mock := CreateWebsocketConnectionMock()
+	mock.OnReadTextMessage(nil, fmt.Errorf("mock error"))
+	_, reader, err := NextReader(mock)
+	suite.EqualError(err, "mock error")
+	suite.Nil(reader)
+}
+
+func (suite *WebsocketTestSuite) TestCreateConnectionSendsServerName() {
+	for _, testCase := range []struct {
+		serverName         string
+		expectedServerName string
+	}{
+		{"", "localhost"},
+		{"cluster.example.com", "cluster.example.com"},
+	} {
+		serverNames := make(chan string, 1)
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			upgrader := websocket.Upgrader{}
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err == nil {
+				conn.Close()
+			}
+		}))
+		server.TLS = &tls.Config{GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverNames <- hello.ServerName
+			return nil, nil
+		}}
+		server.StartTLS()
+		serverURL, _ := url.Parse(server.URL)
+		serverURL.Scheme = "wss"
+		serverURL.Host = strings.Replace(serverURL.Host, "127.0.0.1", "localhost", 1)
+
+		conn, err := CreateConnectionWithOptions(context.Background(), *serverURL, Options{SkipVerify: true, ServerName: testCase.serverName})
+		suite.NoError(err)
+		conn.Close()
+		server.Close()
+		suite.Equal(testCase.expectedServerName, <-serverNames)
+	}
+}
+
+func (suite *WebsocketTestSuite) TestCreateConnectionWithOptions() {
+	requests := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r
+		upgrader := websocket.Upgrader{Subprotocols: []string{"exasol"}}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	serverURL.Scheme = "ws"
+	options := Options{DialOptions: DialOptions{
+		Dialer: &websocket.Dialer{HandshakeTimeout: time.Second, Subprotocols: []string{"exasol"}},
+		Header: http.Header{"X-Cluster": []string{"cluster1"}},
+	}}
+
+	conn, err := CreateConnectionWithOptions(context.Background(), *serverURL, options)
+	suite.NoError(err)
+	conn.Close()
+	request := <-requests
+	suite.Equal("cluster1", request.Header.Get("X-Cluster"))
+	suite.Equal("exasol", request.Header.Get("Sec-Websocket-Protocol"))
+}
+
+func (suite *WebsocketTestSuite) TestCreateConnectionDeactivatesCompression() {
+	extensions := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		extensions <- r.Header.Get("Sec-Websocket-Extensions")
+		upgrader := websocket.Upgrader{EnableCompression: true}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	serverURL.Scheme = "ws"
+
+	conn, err := CreateConnection(context.Background(), true, "", *serverURL)
+	suite.NoError(err)
+	conn.Close()
+	suite.Empty(<-extensions)
+}
+
+func (suite *WebsocketTestSuite) TestTLSConfigKeepsClientCertificates() {
+	certificate := tls.Certificate{Certificate: [][]byte{{1, 2, 3}}}
+	base := &tls.Config{Certificates: []tls.Certificate{certificate}, ServerName: "proxy.example.com", MinVersion: tls.VersionTLS12}
+	config := tlsConfig(base, true, "", "")
+	suite.Equal([]tls.Certificate{certificate}, config.Certificates)
+	suite.Equal("proxy.example.com", config.ServerName)
+	suite.Equal(cipherSuites, config.CipherSuites)
+	suite.True(config.InsecureSkipVerify)
+	suite.False(base.InsecureSkipVerify)
+	suite.Equal("cluster.example.com", tlsConfig(base, false, "", "cluster.example.com").ServerName)
+	suite.Equal(cipherSuites, tlsConfig(nil, false, "", "").CipherSuites)
+}
+
+func (suite *WebsocketTestSuite) TestCreateConnectionNegotiatesPermessageDeflate() {
+	for _, enabled := range []bool{true, false} {
+		extensions := make(chan string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			extensions <- r.Header.Get("Sec-Websocket-Extensions")
+			upgrader := websocket.Upgrader{EnableCompression: true}
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err == nil {
+				conn.Close()
+			}
+		}))
+		serverURL, _ := url.Parse(server.URL)
+		serverURL.Scheme = "ws"
+
+		conn, err := CreateConnectionWithOptions(context.Background(), *serverURL, Options{SkipVerify: true, PermessageDeflate: enabled})
+		suite.NoError(err)
+		conn.Close()
+		server.Close()
+		if enabled {
+			suite.Contains(<-extensions, "permessage-deflate")
+		} else {
+			suite.Empty(<-extensions)
+		}
+	}
+}
@@ -4,7 +4,7 @@ import "github.com/exasol/exasol-driver-go/internal/config"
 
 func ToInternalConfig(dsnConfig *DSNConfig) *config.Config {
 	apiVersion := 2
-	if dsnConfig.AccessToken != "" || dsnConfig.RefreshToken != "" {
+	if dsnConfig.AccessToken != "" || dsnConfig.TokenFile != "" || dsnConfig.RefreshToken != "" {
 		apiVersion = 3
 	}
 	return &config.Config{
@@ -12,6 +12,8 @@ func ToInternalConfig(dsnConfig *DSNConfig) *config.Config {
 		Password:                  dsnConfig.Password,
 		AccessToken:               dsnConfig.AccessToken,
 		RefreshToken:              dsnConfig.RefreshToken,
+		PasswordFile:              dsnConfig.PasswordFile,
+		TokenFile:                 dsnConfig.TokenFile,
 		Host:                      dsnConfig.Host,
 		Port:                      dsnConfig.Port,
 		Params:                    dsnConfig.Params,
@@ -27,5 +29,44 @@ func ToInternalConfig(dsnConfig *DSNConfig) *config.Config {
 		Encryption:                *dsnConfig.Encryption,
 		ValidateServerCertificate: *dsnConfig.ValidateServerCertificate,
 		CertificateFingerprint:    dsnConfig.CertificateFingerprint,
+		QueryLog:                  dsnConfig.QueryLog,
+		QueryLogParameters:        dsnConfig.QueryLogParameters,
+		SlowQueryThreshold:        dsnConfig.SlowQueryThreshold,
+		RecordFrames:              dsnConfig.RecordFrames,
+		DebugFrames:               dsnConfig.hasDebugCategory(DebugFrames),
+		DebugProtocol:             dsnConfig.hasDebugCategory(DebugProtocol) || dsnConfig.hasDebugCategory(DebugPayloads),
+		DebugPayloads:             dsnConfig.hasDebugCategory(DebugPayloads),
+		KeepaliveInterval:         dsnConfig.KeepaliveInterval,
+		ImportEncoding:            dsnConfig.ImportEncoding,
+		TrimChar:                  dsnConfig.TrimChar,
+		CloseTimeout:              dsnConfig.CloseTimeout,
+		ReadOnly:                  dsnConfig.ReadOnly,
+		CompressionThreshold:      dsnConfig.CompressionThreshold,
+		PermessageDeflate:         dsnConfig.PermessageDeflate,
+		WebsocketScheme:           dsnConfig.WebsocketScheme,
+		WebsocketPath:             dsnConfig.WebsocketPath,
+		WaitForDatabase:           dsnConfig.WaitForDatabase,
+		InterpolateParams:         dsnConfig.InterpolateParams,
+		PlaceholderStyle:          dsnConfig.PlaceholderStyle,
+		Timezone:                  dsnConfig.Timezone,
+		DateFormat:                dsnConfig.DateFormat,
+		NumericCharacters:         dsnConfig.NumericCharacters,
+		NaNAsNull:                 dsnConfig.NaNAsNull,
+		HostProbeTimeout:          dsnConfig.HostProbeTimeout,
+		ExcludeHosts:              dsnConfig.ExcludeHosts,
+		TransferHosts:             dsnConfig.TransferHosts,
+		CommitTimeout:             dsnConfig.CommitTimeout,
+		MaxQueuedCommands:         dsnConfig.MaxQueuedCommands,
+		CommandWaitTimeout:        dsnConfig.CommandWaitTimeout,
+		LoginTimeout:              dsnConfig.LoginTimeout,
+		RawBytes:                  dsnConfig.RawBytes,
+		DialRetries:               dsnConfig.DialRetries,
+		DialRetryDelay:            dsnConfig.DialRetryDelay,
+		DialRetryMaxDelay:         dsnConfig.DialRetryMaxDelay,
+		DialRetryJitter:           dsnConfig.DialRetryJitter,
+		TLSServerName:             dsnConfig.TLSServerName,
+		ConsumerGroup:             dsnConfig.ConsumerGroup,
+		BatchImportRows:           dsnConfig.BatchImportRows,
+		BatchImportBytes:          dsnConfig.BatchImportBytes,
 	}
 }
@@ -2,6 +2,7 @@ package dsn_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/exasol/exasol-driver-go/internal/config"
 	"github.com/exasol/exasol-driver-go/pkg/dsn"
@@ -34,6 +35,17 @@ func (suite *ConverterTestSuite) TestConvertAccessToken() {
 	suite.Equal("", config.Password)
 }
 
+func (suite *ConverterTestSuite) TestConvertSecretFiles() {
+	config := suite.convert("exa:localhost:1234;user=sys;passwordfile=/run/secrets/password")
+	suite.Equal(2, config.ApiVersion)
+	suite.Equal("sys", config.User)
+	suite.Equal("/run/secrets/password", config.PasswordFile)
+
+	config = suite.convert("exa:localhost:1234;tokenfile=/run/secrets/token")
+	suite.Equal(3, config.ApiVersion)
+	suite.Equal("/run/secrets/token", config.TokenFile)
+}
+
 func (suite *ConverterTestSuite) TestConvertRefreshToken() {
 	config := suite.convert("exa:localhost:1234;refreshtoken=token")
 	suite.Equal(3, config.ApiVersion)
@@ -53,6 +65,153 @@ func (suite *ConverterTestSuite) TestConvertQueryTimeout() {
 	suite.Equal(42, config.QueryTimeout)
 }
 
+func (suite *ConverterTestSuite) TestConvertDebugFrames() {
+	config := suite.convert("exa:localhost:1234;debug=frames")
+	suite.True(config.DebugFrames)
+}
+
+func (suite *ConverterTestSuite) TestConvertDebugProtocol() {
+	config := suite.convert("exa:localhost:1234;debug=protocol")
+	suite.True(config.DebugProtocol)
+	suite.False(config.DebugPayloads)
+	suite.False(config.DebugFrames)
+}
+
+func (suite *ConverterTestSuite) TestConvertDebugPayloadsEnablesProtocol() {
+	config := suite.convert("exa:localhost:1234;debug=payloads")
+	suite.True(config.DebugProtocol)
+	suite.True(config.DebugPayloads)
+}
+
+func (suite *ConverterTestSuite) TestConvertDebugFramesDisabledByDefault() {
+	config := suite.convert("exa:localhost:1234")
+	suite.False(config.DebugFrames)
+}
+
+func (suite *ConverterTestSuite) TestConvertKeepaliveInterval() {
+	config := suite.convert("exa:localhost:1234;keepaliveinterval=1m")
+	suite.Equal(time.Minute, config.KeepaliveInterval)
+}
+
+func (suite *ConverterTestSuite) TestConvertImportEncoding() {
+	config := suite.convert("exa:localhost:1234;importencoding=UTF-16")
+	suite.Equal("UTF-16", config.ImportEncoding)
+}
+
+func (suite *ConverterTestSuite) TestConvertTrimChar() {
+	config := suite.convert("exa:localhost:1234;trimchar=1")
+	suite.True(config.TrimChar)
+}
+
+func (suite *ConverterTestSuite) TestConvertCloseTimeout() {
+	config := suite.convert("exa:localhost:1234;closetimeout=5s")
+	suite.Equal(5*time.Second, config.CloseTimeout)
+}
+
+func (suite *ConverterTestSuite) TestConvertReadOnly() {
+	config := suite.convert("exa:localhost:1234;readonly=1")
+	suite.True(config.ReadOnly)
+}
+
+func (suite *ConverterTestSuite) TestConvertCompressionThreshold() {
+	config := suite.convert("exa:localhost:1234;compressionthreshold=512")
+	suite.Equal(512, config.CompressionThreshold)
+}
+
+func (suite *ConverterTestSuite) TestConvertPermessageDeflate() {
+	config := suite.convert("exa:localhost:1234;permessagedeflate=1")
+	suite.True(config.PermessageDeflate)
+}
+
+func (suite *ConverterTestSuite) TestConvertWebsocketSchemeAndPath() {
+	config := suite.convert("exa:localhost:1234;websocketscheme=wss;websocketpath=/exasol")
+	suite.Equal("wss", config.WebsocketScheme)
+	suite.Equal("/exasol", config.WebsocketPath)
+}
+
+func (suite *ConverterTestSuite) TestConvertWaitForDatabase() {
+	config := suite.convert("exa:localhost:1234;waitfordatabase=90s")
+	suite.Equal(90*time.Second, config.WaitForDatabase)
+}
+
+func (suite *ConverterTestSuite) TestConvertInterpolateParams() {
+	config := suite.convert("exa:localhost:1234;interpolateparams=1")
+	suite.True(config.InterpolateParams)
+}
+
+func (suite *ConverterTestSuite) TestConvertPlaceholderStyle() {
+	config := suite.convert("exa:localhost:1234;placeholderstyle=dollar")
+	suite.Equal("dollar", config.PlaceholderStyle)
+}
+
+func (suite *ConverterTestSuite) TestConvertSessionFormatAttributes() {
+	config := suite.convert("exa:localhost:1234;timezone=UTC;dateformat=YYYY-MM-DD;numericcharacters=.,")
+	suite.Equal("UTC", config.Timezone)
+	suite.Equal("YYYY-MM-DD", config.DateFormat)
+	suite.Equal(".,", config.NumericCharacters)
+}
+
+func (suite *ConverterTestSuite) TestConvertNaNAsNull() {
+	config := suite.convert("exa:localhost:1234;nanasnull=1")
+	suite.True(config.NaNAsNull)
+}
+
+func (suite *ConverterTestSuite) TestConvertHostProbeTimeout() {
+	config := suite.convert("exa:localhost:1234;hostprobetimeout=1s")
+	suite.Equal(time.Second, config.HostProbeTimeout)
+}
+
+func (suite *ConverterTestSuite) TestConvertHostAffinity() {
+	config := suite.convert("exa:localhost:1234;excludehosts=exasol2;transferhosts=exasol3")
+	suite.Equal("exasol2", config.ExcludeHosts)
+	suite.Equal("exasol3", config.TransferHosts)
+}
+
+func (suite *ConverterTestSuite) TestConvertCommitTimeout() {
+	config := suite.convert("exa:localhost:1234;committimeout=10s")
+	suite.Equal(10*time.Second, config.CommitTimeout)
+}
+
+func (suite *ConverterTestSuite) TestConvertCommandQueueLimits() {
+	config := suite.convert("exa:localhost:1234;maxqueuedcommands=5;commandwaittimeout=2s")
+	suite.Equal(5, config.MaxQueuedCommands)
+	suite.Equal(2*time.Second, config.CommandWaitTimeout)
+}
+
+func (suite *ConverterTestSuite) TestConvertLoginTimeout() {
+	config := suite.convert("exa:localhost:1234;logintimeout=15s")
+	suite.Equal(15*time.Second, config.LoginTimeout)
+}
+
+func (suite *ConverterTestSuite) TestConvertRawBytes() {
+	config := suite.convert("exa:localhost:1234;rawbytes=1")
+	suite.True(config.RawBytes)
+}
+
+func (suite *ConverterTestSuite) TestConvertDialRetries() {
+	config := suite.convert("exa:localhost:1234;dialretries=3;dialretrydelay=1s;dialretrymaxdelay=4s;dialretryjitter=1")
+	suite.Equal(3, config.DialRetries)
+	suite.Equal(time.Second, config.DialRetryDelay)
+	suite.Equal(4*time.Second, config.DialRetryMaxDelay)
+	suite.True(config.DialRetryJitter)
+}
+
+func (suite *ConverterTestSuite) TestConvertTLSServerName() {
+	config := suite.convert("exa:localhost:1234;tlsservername=cluster.example.com")
+	suite.Equal("cluster.example.com", config.TLSServerName)
+}
+
+func (suite *ConverterTestSuite) TestConvertConsumerGroup() {
+	config := suite.convert("exa:localhost:1234;consumergroup=BATCH")
+	suite.Equal("BATCH", config.ConsumerGroup)
+}
+
+func (suite *ConverterTestSuite) TestConvertBatchImportThresholds() {
+	config := suite.convert("exa:localhost:1234;batchimportrows=10000;batchimportbytes=1048576")
+	suite.Equal(10000, config.BatchImportRows)
+	suite.Equal(1048576, config.BatchImportBytes)
+}
+
 func (suite *ConverterTestSuite) convert(dsnValue string) *config.Config {
 	config, err := dsn.ParseDSN(dsnValue)
 	suite.NoError(err)
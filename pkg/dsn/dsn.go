@@ -2,8 +2,13 @@ package dsn
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	// Embeds the time zone database for validating time zones on systems without one, e.g. in minimal containers
+	_ "time/tzdata"
+	"unicode"
 
 	"github.com/exasol/exasol-driver-go/internal/utils"
 	"github.com/exasol/exasol-driver-go/pkg/errors"
@@ -29,6 +34,46 @@ type DSNConfig struct {
 	Params                    map[string]string // Connection parameters
 	AccessToken               string            // Access token (alternative to username/password)
 	RefreshToken              string            // Refresh token (alternative to username/password)
+	PasswordFile              string            // Path of a file containing the password, read at each login (alternative to password)
+	TokenFile                 string            // Path of a file containing the access token, read at each login (alternative to accesstoken)
+	QueryLog                  bool              // If true, executed statements are logged with credentials redacted (default: false)
+	QueryLogParameters        bool              // If true, the query log also contains parameter values (default: false)
+	SlowQueryThreshold        time.Duration     // Statements running longer than this are reported as slow queries (default: 0, i.e. disabled)
+	RecordFrames              string            // Path of a file to which all websocket frames are appended with credentials redacted (default: "", i.e. disabled)
+	Debug                     []string          // Debug categories to log via the trace logger, e.g. DebugFrames (default: none)
+	KeepaliveInterval         time.Duration     // Interval of websocket pings while waiting for a response (default: 0, i.e. disabled)
+	ImportEncoding            string            // Source encoding of local import files converted to UTF-8, used if the IMPORT statement has no ENCODING clause (default: "", i.e. no conversion)
+	TrimChar                  bool              // If true, trailing spaces are removed from values of CHAR columns (default: false)
+	CloseTimeout              time.Duration     // Maximum duration of the graceful disconnect when closing a connection (default: 0, i.e. no limit)
+	ReadOnly                  bool              // If true, the driver rejects all statements except SELECT (default: false)
+	CompressionThreshold      int               // Messages smaller than this number of bytes are sent uncompressed if compression is enabled (default: 0, i.e. all messages are compressed)
+	PermessageDeflate         bool              // If true, the websocket permessage-deflate extension is negotiated, ignored if compression is enabled (default: false)
+	WebsocketScheme           string            // Scheme of the websocket URL, "ws" or "wss" (default: "", i.e. "wss" if encryption is enabled, else "ws")
+	WebsocketPath             string            // Path of the websocket URL, e.g. if a reverse proxy forwards a path to the database (default: "", i.e. the root path)
+	WaitForDatabase           time.Duration     // Maximum duration for retrying failed connection attempts, e.g. while a SaaS cluster is starting (default: 0, i.e. no retries)
+	InterpolateParams         bool              // If true, parameters are inserted into the statement as literals instead of creating a prepared statement (default: false)
+	PlaceholderStyle          string            // Placeholders translated to ? placeholders, "question", "colon" for :1 and :name or "dollar" for $1 (default: "", i.e. "question")
+	Timezone                  string            // Time zone of the session, e.g. "EUROPE/BERLIN" (default: "", i.e. the database default)
+	DateFormat                string            // Date format of the session, e.g. "YYYY-MM-DD" (default: "", i.e. the database default)
+	NumericCharacters         string            // Decimal and group separator of the session, e.g. ".," (default: "", i.e. the database default)
+	NaNAsNull                 bool              // If true, NaN and infinite doubles are bound and returned as NULL instead of failing (default: false)
+	HostProbeTimeout          time.Duration     // Timeout for probing all hosts in parallel before connecting to the first healthy one (default: 0, i.e. no probing)
+	ExcludeHosts              string            // Comma-separated hosts that are never connected to, e.g. nodes under maintenance (default: "")
+	TransferHosts             string            // Comma-separated hosts the proxies of local imports and exports connect to (default: "", i.e. the hosts of the connection)
+	CommitTimeout             time.Duration     // Maximum duration of commits and rollbacks of transactions (default: 0, i.e. no limit)
+	MaxQueuedCommands         int               // Maximum number of commands waiting while the connection executes another command (default: 0, i.e. no limit)
+	CommandWaitTimeout        time.Duration     // Maximum duration a command waits while the connection executes another command (default: 0, i.e. no limit)
+	LoginTimeout              time.Duration     // Maximum duration of the login after the websocket is connected (default: 0, i.e. no limit)
+	RawBytes                  bool              // If true, values of string columns are returned as []byte valid until the next row (default: false)
+	DialRetries               int               // Number of retries of the websocket dial across all hosts if no host is reachable (default: 0, i.e. no retries)
+	DialRetryDelay            time.Duration     // Delay before the first dial retry, doubled for each further retry (default: 0, i.e. 100ms)
+	DialRetryMaxDelay         time.Duration     // Maximum delay between dial retries (default: 0, i.e. 5s)
+	DialRetryJitter           bool              // If true, the delays between dial retries are randomized between 0 and the computed delay (default: false)
+	TLSServerName             string            // Server name sent in the TLS handshake (SNI) and verified against the server's certificate (default: "", i.e. the dialed host)
+	ConsumerGroup             string            // Consumer group of the session set after login (default: "", i.e. the consumer group of the user)
+	BatchImportRows           int               // Chunks of prepared batch inserts with more rows are uploaded with IMPORT (default: 0, i.e. never)
+	BatchImportBytes          int               // Chunks of prepared batch inserts with more bytes of values are uploaded with IMPORT (default: 0, i.e. never)
+	Strict                    *bool             // Reject unknown options and boolean options with values other than 0 and 1 (default: true)
 }
 
 // DSNConfigBuilder is a builder for DSNConfig objects.
@@ -96,13 +141,13 @@ func (c *DSNConfigBuilder) ClientVersion(version string) *DSNConfigBuilder {
 	return c
 }
 
-// Host sets the hostname.
+// Host sets the hostname or a comma-separated list of hosts. Each host may have its own port like host1:8564,host2:8565.
 func (c *DSNConfigBuilder) Host(host string) *DSNConfigBuilder {
 	c.Config.Host = host
 	return c
 }
 
-// Port sets the port number.
+// Port sets the port number of all hosts without their own port.
 func (c *DSNConfigBuilder) Port(port int) *DSNConfigBuilder {
 	c.Config.Port = port
 	return c
@@ -120,6 +165,268 @@ func (c *DSNConfigBuilder) Schema(schema string) *DSNConfigBuilder {
 	return c
 }
 
+// QueryLog enables logging of executed statements via the trace logger (default: false).
+// Credentials in the SQL text are redacted. Parameter values are only logged if enabled with QueryLogParameters.
+func (c *DSNConfigBuilder) QueryLog(enabled bool) *DSNConfigBuilder {
+	c.Config.QueryLog = enabled
+	return c
+}
+
+// QueryLogParameters defines if the query log contains the values of statement parameters (default: false).
+func (c *DSNConfigBuilder) QueryLogParameters(enabled bool) *DSNConfigBuilder {
+	c.Config.QueryLogParameters = enabled
+	return c
+}
+
+// SlowQueryThreshold sets the duration after which a statement is reported as slow query (default: 0, i.e. disabled).
+// Slow queries are logged via the trace logger or reported to the SlowQueryCallback of the connector.
+func (c *DSNConfigBuilder) SlowQueryThreshold(threshold time.Duration) *DSNConfigBuilder {
+	c.Config.SlowQueryThreshold = threshold
+	return c
+}
+
+// RecordFrames enables recording all websocket frames to the given file for debugging (default: "", i.e. disabled).
+// Credentials are redacted. Recordings can be replayed with connection.NewReplayDialFunc.
+func (c *DSNConfigBuilder) RecordFrames(path string) *DSNConfigBuilder {
+	c.Config.RecordFrames = path
+	return c
+}
+
+// Debug enables logging of the given debug categories via the trace logger, e.g. DebugFrames (default: none).
+func (c *DSNConfigBuilder) Debug(categories ...string) *DSNConfigBuilder {
+	c.Config.Debug = categories
+	return c
+}
+
+// KeepaliveInterval sets the interval of websocket pings sent while waiting for the response of a long-running statement
+// (default: 0, i.e. disabled). This prevents proxies from closing the idle connection.
+func (c *DSNConfigBuilder) KeepaliveInterval(interval time.Duration) *DSNConfigBuilder {
+	c.Config.KeepaliveInterval = interval
+	return c
+}
+
+// ImportEncoding sets the encoding of local import files that is converted to UTF-8 while uploading (default: "", i.e. no conversion).
+// Supported are ISO-8859-1, WINDOWS-1252, UTF-16, UTF-16LE and UTF-16BE. An ENCODING clause of the IMPORT statement takes precedence.
+func (c *DSNConfigBuilder) ImportEncoding(encoding string) *DSNConfigBuilder {
+	c.Config.ImportEncoding = encoding
+	return c
+}
+
+// TrimChar defines if trailing spaces are removed from values of CHAR columns (default: false).
+// Values of VARCHAR columns are returned unchanged.
+func (c *DSNConfigBuilder) TrimChar(enabled bool) *DSNConfigBuilder {
+	c.Config.TrimChar = enabled
+	return c
+}
+
+// CloseTimeout sets the maximum duration for waiting for the response to the disconnect command when closing a connection
+// (default: 0, i.e. no limit). After the timeout the websocket is closed forcibly, e.g. if the database does not respond anymore.
+func (c *DSNConfigBuilder) CloseTimeout(timeout time.Duration) *DSNConfigBuilder {
+	c.Config.CloseTimeout = timeout
+	return c
+}
+
+// ReadOnly defines if the driver rejects all statements except SELECT (default: false),
+// e.g. to protect reporting services from accidentally modifying data.
+func (c *DSNConfigBuilder) ReadOnly(enabled bool) *DSNConfigBuilder {
+	c.Config.ReadOnly = enabled
+	return c
+}
+
+// CompressionThreshold sets the size in bytes below which messages are sent uncompressed if compression is enabled
+// (default: 0, i.e. all messages are compressed). Small commands are not worth the CPU time for compressing them.
+func (c *DSNConfigBuilder) CompressionThreshold(bytes int) *DSNConfigBuilder {
+	c.Config.CompressionThreshold = bytes
+	return c
+}
+
+// PermessageDeflate defines if the websocket permessage-deflate extension is negotiated (default: false).
+// This compresses the messages in the websocket layer, e.g. for proxies that inspect the messages.
+// The option is ignored if Compression is enabled.
+func (c *DSNConfigBuilder) PermessageDeflate(enabled bool) *DSNConfigBuilder {
+	c.Config.PermessageDeflate = enabled
+	return c
+}
+
+// WebsocketScheme overrides the scheme of the websocket URL, "ws" or "wss" (default: "wss" if encryption is enabled, else "ws"),
+// e.g. if a reverse proxy terminates TLS.
+func (c *DSNConfigBuilder) WebsocketScheme(scheme string) *DSNConfigBuilder {
+	c.Config.WebsocketScheme = scheme
+	return c
+}
+
+// WebsocketPath sets the path of the websocket URL (default: the root path),
+// e.g. if a reverse proxy forwards only a specific path to the database.
+func (c *DSNConfigBuilder) WebsocketPath(path string) *DSNConfigBuilder {
+	c.Config.WebsocketPath = path
+	return c
+}
+
+// WaitForDatabase sets the maximum duration for retrying failed connection attempts with increasing backoff
+// (default: 0, i.e. no retries). Use it for Exasol SaaS clusters that are stopped automatically and start on the first connection.
+func (c *DSNConfigBuilder) WaitForDatabase(wait time.Duration) *DSNConfigBuilder {
+	c.Config.WaitForDatabase = wait
+	return c
+}
+
+// InterpolateParams defines if parameters of Query and Exec are inserted into the statement as SQL literals (default: false).
+// This saves the round-trips for creating and closing a prepared statement. Values without exact literal representation,
+// e.g. time.Time and []byte, and batches with more values than placeholders still use prepared statements.
+func (c *DSNConfigBuilder) InterpolateParams(enabled bool) *DSNConfigBuilder {
+	c.Config.InterpolateParams = enabled
+	return c
+}
+
+// PlaceholderStyle defines the placeholders in statements that the driver translates to the ? placeholders of the protocol:
+// "question" for ? only (default), "colon" for :1 and :name or "dollar" for $1.
+// Positions refer to the arguments in the order they are given, names to arguments given with sql.Named.
+func (c *DSNConfigBuilder) PlaceholderStyle(style string) *DSNConfigBuilder {
+	c.Config.PlaceholderStyle = style
+	return c
+}
+
+// Timezone sets the time zone of each new session after login, e.g. "EUROPE/BERLIN" (default: the database default).
+func (c *DSNConfigBuilder) Timezone(timezone string) *DSNConfigBuilder {
+	c.Config.Timezone = timezone
+	return c
+}
+
+// DateFormat sets the date format of each new session after login, e.g. "YYYY-MM-DD" (default: the database default).
+func (c *DSNConfigBuilder) DateFormat(format string) *DSNConfigBuilder {
+	c.Config.DateFormat = format
+	return c
+}
+
+// NumericCharacters sets the decimal and group separator of each new session after login, e.g. ".," (default: the database default).
+func (c *DSNConfigBuilder) NumericCharacters(characters string) *DSNConfigBuilder {
+	c.Config.NumericCharacters = characters
+	return c
+}
+
+// NaNAsNull defines if NaN and infinite float parameters and DOUBLE values are converted to NULL (default: false).
+// By default, statements with such parameters and reading such values fail, as Exasol does not support them.
+func (c *DSNConfigBuilder) NaNAsNull(enabled bool) *DSNConfigBuilder {
+	c.Config.NaNAsNull = enabled
+	return c
+}
+
+// HostProbeTimeout enables probing all hosts in parallel with a TCP connection and the TLS handshake before connecting
+// (default: 0, i.e. no probing). The driver connects to the first healthy host, so that hosts that are down do not delay the login.
+func (c *DSNConfigBuilder) HostProbeTimeout(timeout time.Duration) *DSNConfigBuilder {
+	c.Config.HostProbeTimeout = timeout
+	return c
+}
+
+// ExcludeHosts sets hosts that are never connected to, neither for the login nor for imports and exports (default: none).
+// Use it to skip a node under maintenance without changing the host list. Hosts may be ranges like exasol3..4.
+func (c *DSNConfigBuilder) ExcludeHosts(hosts ...string) *DSNConfigBuilder {
+	c.Config.ExcludeHosts = strings.Join(hosts, ",")
+	return c
+}
+
+// TransferHosts sets the hosts the proxies of local imports and exports connect to (default: the hosts of the connection).
+// Use it to pin file transfers to specific nodes. Hosts may be ranges like exasol3..4 and have their own port.
+func (c *DSNConfigBuilder) TransferHosts(hosts ...string) *DSNConfigBuilder {
+	c.Config.TransferHosts = strings.Join(hosts, ",")
+	return c
+}
+
+// CommitTimeout sets the maximum duration for waiting for the response to the commit or rollback of a transaction
+// (default: 0, i.e. no limit). Use it so that an unresponsive database does not block the caller or the shutdown of the connection pool.
+func (c *DSNConfigBuilder) CommitTimeout(timeout time.Duration) *DSNConfigBuilder {
+	c.Config.CommitTimeout = timeout
+	return c
+}
+
+// MaxQueuedCommands sets the maximum number of commands that wait while the connection executes another command
+// (default: 0, i.e. no limit). Further commands fail with errors.DriverSaturatedError.
+func (c *DSNConfigBuilder) MaxQueuedCommands(commands int) *DSNConfigBuilder {
+	c.Config.MaxQueuedCommands = commands
+	return c
+}
+
+// CommandWaitTimeout sets the maximum duration a command waits while the connection executes another command
+// (default: 0, i.e. no limit). Commands waiting longer fail with errors.DriverSaturatedError.
+func (c *DSNConfigBuilder) CommandWaitTimeout(timeout time.Duration) *DSNConfigBuilder {
+	c.Config.CommandWaitTimeout = timeout
+	return c
+}
+
+// LoginTimeout sets the maximum duration of the login including the retrieval of the public key, the password encryption
+// and the login command (default: 0, i.e. no limit). It is independent of the query timeout.
+func (c *DSNConfigBuilder) LoginTimeout(timeout time.Duration) *DSNConfigBuilder {
+	c.Config.LoginTimeout = timeout
+	return c
+}
+
+// RawBytes defines if values of string columns like VARCHAR and CHAR are returned as []byte referencing the fetched
+// result data instead of as string (default: false). This avoids an allocation per value, but the values are only
+// valid until the next row is read, like with sql.RawBytes, and must be copied to be retained.
+func (c *DSNConfigBuilder) RawBytes(enabled bool) *DSNConfigBuilder {
+	c.Config.RawBytes = enabled
+	return c
+}
+
+// DialRetries sets how often the driver retries dialing the websocket connection across all hosts if none of them
+// is reachable (default: 0, i.e. no retries), so that short network interruptions and rolling restarts of the cluster
+// don't fail new connections. Failed logins are not retried.
+func (c *DSNConfigBuilder) DialRetries(retries int) *DSNConfigBuilder {
+	c.Config.DialRetries = retries
+	return c
+}
+
+// DialRetryDelay sets the delay before the first dial retry, which is doubled for each further retry up to the maximum delay
+// (default: 100ms and 5s).
+func (c *DSNConfigBuilder) DialRetryDelay(baseDelay, maxDelay time.Duration) *DSNConfigBuilder {
+	c.Config.DialRetryDelay = baseDelay
+	c.Config.DialRetryMaxDelay = maxDelay
+	return c
+}
+
+// DialRetryJitter defines if the delays between dial retries are randomized between zero and the computed delay
+// (default: false). This spreads the reconnects of many clients after an outage of the database.
+func (c *DSNConfigBuilder) DialRetryJitter(enabled bool) *DSNConfigBuilder {
+	c.Config.DialRetryJitter = enabled
+	return c
+}
+
+// TLSServerName sets the server name sent in the TLS handshake (SNI) and verified against the server's certificate
+// (default: "", i.e. the dialed host). Use it when connecting via IP addresses or a tunnel while the certificate
+// carries the DNS name of the cluster.
+func (c *DSNConfigBuilder) TLSServerName(name string) *DSNConfigBuilder {
+	c.Config.TLSServerName = name
+	return c
+}
+
+// ConsumerGroup assigns each new session to the given consumer group after login (default: the consumer group of
+// the user), e.g. for managing the resources of batch and interactive connections of an application differently.
+// The user must be allowed to use the consumer group.
+func (c *DSNConfigBuilder) ConsumerGroup(name string) *DSNConfigBuilder {
+	c.Config.ConsumerGroup = name
+	return c
+}
+
+// BatchImportRows sets the number of rows above which a chunk of a prepared batch insert is uploaded with an IMPORT
+// statement instead of being sent in a single message (default: 0, i.e. never).
+func (c *DSNConfigBuilder) BatchImportRows(rows int) *DSNConfigBuilder {
+	c.Config.BatchImportRows = rows
+	return c
+}
+
+// BatchImportBytes sets the approximate size in bytes of the values above which a chunk of a prepared batch insert is
+// uploaded with an IMPORT statement instead of being sent in a single message (default: 0, i.e. never).
+func (c *DSNConfigBuilder) BatchImportBytes(bytes int) *DSNConfigBuilder {
+	c.Config.BatchImportBytes = bytes
+	return c
+}
+
+// Strict defines if parsing the DSN rejects unknown options, e.g. typos like comperssion=1, and boolean options
+// with values other than 0 and 1 (default: true). Without strict parsing unknown options are kept as custom parameters.
+func (c *DSNConfigBuilder) Strict(enabled bool) *DSNConfigBuilder {
+	c.Config.Strict = &enabled
+	return c
+}
+
 // String converts the configuration to a DSN (data source name) that can be used for connecting to an Exasol database.
 func (c *DSNConfigBuilder) String() string {
 	return c.Config.ToDSN()
@@ -132,8 +439,12 @@ func (c *DSNConfig) ToDSN() string {
 
 	if c.AccessToken != "" {
 		sb.WriteString(fmt.Sprintf("accesstoken=%s;", c.AccessToken))
+	} else if c.TokenFile != "" {
+		sb.WriteString(fmt.Sprintf("tokenfile=%s;", c.TokenFile))
 	} else if c.RefreshToken != "" {
 		sb.WriteString(fmt.Sprintf("refreshtoken=%s;", c.RefreshToken))
+	} else if c.Password == "" && c.PasswordFile != "" {
+		sb.WriteString(fmt.Sprintf("user=%s;passwordfile=%s;", c.User, c.PasswordFile))
 	} else {
 		sb.WriteString(fmt.Sprintf("user=%s;password=%s;", c.User, c.Password))
 	}
@@ -168,6 +479,120 @@ func (c *DSNConfig) ToDSN() string {
 	if c.Schema != "" {
 		sb.WriteString(fmt.Sprintf("schema=%s;", c.Schema))
 	}
+	if c.QueryLog {
+		sb.WriteString("querylog=1;")
+	}
+	if c.QueryLogParameters {
+		sb.WriteString("querylogparameters=1;")
+	}
+	if c.SlowQueryThreshold != 0 {
+		sb.WriteString(fmt.Sprintf("slowquerythreshold=%s;", c.SlowQueryThreshold))
+	}
+	if c.RecordFrames != "" {
+		sb.WriteString(fmt.Sprintf("recordframes=%s;", c.RecordFrames))
+	}
+	if c.KeepaliveInterval != 0 {
+		sb.WriteString(fmt.Sprintf("keepaliveinterval=%s;", c.KeepaliveInterval))
+	}
+	if len(c.Debug) > 0 {
+		sb.WriteString(fmt.Sprintf("debug=%s;", strings.Join(c.Debug, ",")))
+	}
+	if c.ImportEncoding != "" {
+		sb.WriteString(fmt.Sprintf("importencoding=%s;", c.ImportEncoding))
+	}
+	if c.TrimChar {
+		sb.WriteString("trimchar=1;")
+	}
+	if c.CloseTimeout != 0 {
+		sb.WriteString(fmt.Sprintf("closetimeout=%s;", c.CloseTimeout))
+	}
+	if c.ReadOnly {
+		sb.WriteString("readonly=1;")
+	}
+	if c.CompressionThreshold != 0 {
+		sb.WriteString(fmt.Sprintf("compressionthreshold=%d;", c.CompressionThreshold))
+	}
+	if c.PermessageDeflate {
+		sb.WriteString("permessagedeflate=1;")
+	}
+	if c.WebsocketScheme != "" {
+		sb.WriteString(fmt.Sprintf("websocketscheme=%s;", c.WebsocketScheme))
+	}
+	if c.WebsocketPath != "" {
+		sb.WriteString(fmt.Sprintf("websocketpath=%s;", c.WebsocketPath))
+	}
+	if c.WaitForDatabase != 0 {
+		sb.WriteString(fmt.Sprintf("waitfordatabase=%s;", c.WaitForDatabase))
+	}
+	if c.InterpolateParams {
+		sb.WriteString("interpolateparams=1;")
+	}
+	if c.PlaceholderStyle != "" {
+		sb.WriteString(fmt.Sprintf("placeholderstyle=%s;", c.PlaceholderStyle))
+	}
+	if c.Timezone != "" {
+		sb.WriteString(fmt.Sprintf("timezone=%s;", c.Timezone))
+	}
+	if c.DateFormat != "" {
+		sb.WriteString(fmt.Sprintf("dateformat=%s;", c.DateFormat))
+	}
+	if c.NumericCharacters != "" {
+		sb.WriteString(fmt.Sprintf("numericcharacters=%s;", c.NumericCharacters))
+	}
+	if c.NaNAsNull {
+		sb.WriteString("nanasnull=1;")
+	}
+	if c.HostProbeTimeout != 0 {
+		sb.WriteString(fmt.Sprintf("hostprobetimeout=%s;", c.HostProbeTimeout))
+	}
+	if c.ExcludeHosts != "" {
+		sb.WriteString(fmt.Sprintf("excludehosts=%s;", c.ExcludeHosts))
+	}
+	if c.TransferHosts != "" {
+		sb.WriteString(fmt.Sprintf("transferhosts=%s;", c.TransferHosts))
+	}
+	if c.CommitTimeout != 0 {
+		sb.WriteString(fmt.Sprintf("committimeout=%s;", c.CommitTimeout))
+	}
+	if c.MaxQueuedCommands != 0 {
+		sb.WriteString(fmt.Sprintf("maxqueuedcommands=%d;", c.MaxQueuedCommands))
+	}
+	if c.CommandWaitTimeout != 0 {
+		sb.WriteString(fmt.Sprintf("commandwaittimeout=%s;", c.CommandWaitTimeout))
+	}
+	if c.LoginTimeout != 0 {
+		sb.WriteString(fmt.Sprintf("logintimeout=%s;", c.LoginTimeout))
+	}
+	if c.RawBytes {
+		sb.WriteString("rawbytes=1;")
+	}
+	if c.DialRetries != 0 {
+		sb.WriteString(fmt.Sprintf("dialretries=%d;", c.DialRetries))
+	}
+	if c.DialRetryDelay != 0 {
+		sb.WriteString(fmt.Sprintf("dialretrydelay=%s;", c.DialRetryDelay))
+	}
+	if c.DialRetryMaxDelay != 0 {
+		sb.WriteString(fmt.Sprintf("dialretrymaxdelay=%s;", c.DialRetryMaxDelay))
+	}
+	if c.DialRetryJitter {
+		sb.WriteString("dialretryjitter=1;")
+	}
+	if c.TLSServerName != "" {
+		sb.WriteString(fmt.Sprintf("tlsservername=%s;", c.TLSServerName))
+	}
+	if c.ConsumerGroup != "" {
+		sb.WriteString(fmt.Sprintf("consumergroup=%s;", c.ConsumerGroup))
+	}
+	if c.BatchImportRows != 0 {
+		sb.WriteString(fmt.Sprintf("batchimportrows=%d;", c.BatchImportRows))
+	}
+	if c.BatchImportBytes != 0 {
+		sb.WriteString(fmt.Sprintf("batchimportbytes=%d;", c.BatchImportBytes))
+	}
+	if c.Strict != nil && !*c.Strict {
+		sb.WriteString("strict=0;")
+	}
 	return strings.TrimRight(sb.String(), ";")
 }
 
@@ -195,16 +620,24 @@ func splitIntoConnectionStringAndParameters(dsn string) []string {
 	return strings.SplitN(cleanDsn, ";", 2)
 }
 
+// extractHostAndPort splits a connection string like host1:8564,host2:8563 into the hosts and the port after the last colon.
+// The port applies to all hosts without their own port.
 func extractHostAndPort(connectionString string) (string, int, error) {
-	hostPort := strings.Split(connectionString, ":")
-	if len(hostPort) != 2 {
+	index := strings.LastIndex(connectionString, ":")
+	if index <= 0 {
 		return "", 0, errors.NewInvalidConnectionStringHostOrPort(connectionString)
 	}
-	port, err := strconv.Atoi(hostPort[1])
+	host := connectionString[:index]
+	port, err := strconv.Atoi(connectionString[index+1:])
 	if err != nil {
-		return "", 0, errors.NewInvalidConnectionStringInvalidPort(hostPort[1])
+		return "", 0, errors.NewInvalidConnectionStringInvalidPort(connectionString[index+1:])
+	}
+	for _, entry := range strings.Split(host, ",") {
+		if _, _, err := utils.SplitHostPort(entry, port); err != nil {
+			return "", 0, err
+		}
 	}
-	return hostPort[0], port, nil
+	return host, port, nil
 }
 
 func getDefaultConfig(host string, port int) *DSNConfig {
@@ -225,6 +658,10 @@ func getDefaultConfig(host string, port int) *DSNConfig {
 func getConfigWithParameters(host string, port int, parametersString string) (*DSNConfig, error) {
 	config := getDefaultConfig(host, port)
 	parameters := extractParameters(parametersString)
+	strict, err := isStrict(parameters)
+	if err != nil {
+		return nil, err
+	}
 	for _, parameter := range parameters {
 		keyValuePair := strings.SplitN(parameter, "=", 2)
 		if len(keyValuePair) != 2 {
@@ -232,6 +669,9 @@ func getConfigWithParameters(host string, port int, parametersString string) (*D
 		}
 		key := keyValuePair[0]
 		value := keyValuePair[1]
+		if strict && booleanParameters[key] && !isBoolValue(value) {
+			return nil, errors.NewInvalidConnectionStringInvalidBoolParam(key, value)
+		}
 
 		switch key {
 		case "password":
@@ -240,6 +680,10 @@ func getConfigWithParameters(host string, port int, parametersString string) (*D
 			config.AccessToken = unescape(value, ";")
 		case "refreshtoken":
 			config.RefreshToken = unescape(value, ";")
+		case "passwordfile":
+			config.PasswordFile = unescape(value, ";")
+		case "tokenfile":
+			config.TokenFile = unescape(value, ";")
 		case "user":
 			config.User = unescape(value, ";")
 		case "autocommit":
@@ -258,6 +702,161 @@ func getConfigWithParameters(host string, port int, parametersString string) (*D
 			config.ClientVersion = value
 		case "schema":
 			config.Schema = value
+		case "keepaliveinterval":
+			interval, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, errors.NewInvalidConnectionStringInvalidDurationParam("keepaliveinterval", value)
+			}
+			config.KeepaliveInterval = interval
+		case "closetimeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, errors.NewInvalidConnectionStringInvalidDurationParam("closetimeout", value)
+			}
+			config.CloseTimeout = timeout
+		case "waitfordatabase":
+			wait, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, errors.NewInvalidConnectionStringInvalidDurationParam("waitfordatabase", value)
+			}
+			config.WaitForDatabase = wait
+		case "debug":
+			debug, err := parseDebugCategories(value)
+			if err != nil {
+				return nil, err
+			}
+			config.Debug = debug
+		case "importencoding":
+			if utils.NormalizeEncoding(value) == "" {
+				return nil, errors.NewInvalidConnectionStringInvalidImportEncoding(value)
+			}
+			config.ImportEncoding = value
+		case "recordframes":
+			config.RecordFrames = unescape(value, ";")
+		case "querylog":
+			config.QueryLog = value == "1"
+		case "querylogparameters":
+			config.QueryLogParameters = value == "1"
+		case "trimchar":
+			config.TrimChar = value == "1"
+		case "permessagedeflate":
+			config.PermessageDeflate = value == "1"
+		case "websocketscheme":
+			scheme := strings.ToLower(value)
+			if scheme != "ws" && scheme != "wss" {
+				return nil, errors.NewInvalidConnectionStringInvalidWebsocketScheme(value)
+			}
+			config.WebsocketScheme = scheme
+		case "websocketpath":
+			config.WebsocketPath = value
+		case "interpolateparams":
+			config.InterpolateParams = value == "1"
+		case "placeholderstyle":
+			style := strings.ToLower(value)
+			if style != utils.PlaceholderStyleQuestion && style != utils.PlaceholderStyleColon && style != utils.PlaceholderStyleDollar {
+				return nil, errors.NewInvalidConnectionStringInvalidPlaceholderStyle(value)
+			}
+			config.PlaceholderStyle = style
+		case "timezone":
+			if !isValidTimezone(value) {
+				return nil, errors.NewInvalidConnectionStringInvalidTimezone(value)
+			}
+			config.Timezone = value
+		case "dateformat":
+			if !isValidDateFormat(value) {
+				return nil, errors.NewInvalidConnectionStringInvalidDateFormat(value)
+			}
+			config.DateFormat = value
+		case "numericcharacters":
+			if separators := []rune(value); len(separators) != 2 || separators[0] == separators[1] {
+				return nil, errors.NewInvalidConnectionStringInvalidNumericCharacters(value)
+			}
+			config.NumericCharacters = value
+		case "nanasnull":
+			config.NaNAsNull = value == "1"
+		case "hostprobetimeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, errors.NewInvalidConnectionStringInvalidDurationParam("hostprobetimeout", value)
+			}
+			config.HostProbeTimeout = timeout
+		case "excludehosts":
+			config.ExcludeHosts = value
+		case "transferhosts":
+			config.TransferHosts = value
+		case "committimeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, errors.NewInvalidConnectionStringInvalidDurationParam("committimeout", value)
+			}
+			config.CommitTimeout = timeout
+		case "maxqueuedcommands":
+			commands, err := strconv.Atoi(value)
+			if err != nil || commands < 0 {
+				return nil, errors.NewInvalidConnectionStringInvalidIntParam("maxqueuedcommands", value)
+			}
+			config.MaxQueuedCommands = commands
+		case "commandwaittimeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, errors.NewInvalidConnectionStringInvalidDurationParam("commandwaittimeout", value)
+			}
+			config.CommandWaitTimeout = timeout
+		case "logintimeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, errors.NewInvalidConnectionStringInvalidDurationParam("logintimeout", value)
+			}
+			config.LoginTimeout = timeout
+		case "rawbytes":
+			config.RawBytes = value == "1"
+		case "dialretries":
+			retries, err := strconv.Atoi(value)
+			if err != nil || retries < 0 {
+				return nil, errors.NewInvalidConnectionStringInvalidIntParam("dialretries", value)
+			}
+			config.DialRetries = retries
+		case "dialretrydelay":
+			delay, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, errors.NewInvalidConnectionStringInvalidDurationParam("dialretrydelay", value)
+			}
+			config.DialRetryDelay = delay
+		case "dialretrymaxdelay":
+			delay, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, errors.NewInvalidConnectionStringInvalidDurationParam("dialretrymaxdelay", value)
+			}
+			config.DialRetryMaxDelay = delay
+		case "dialretryjitter":
+			config.DialRetryJitter = value == "1"
+		case "tlsservername":
+			config.TLSServerName = value
+		case "consumergroup":
+			if !consumerGroupRegex.MatchString(value) {
+				return nil, errors.NewInvalidConnectionStringInvalidConsumerGroup(value)
+			}
+			config.ConsumerGroup = value
+		case "batchimportrows":
+			rows, err := strconv.Atoi(value)
+			if err != nil || rows < 0 {
+				return nil, errors.NewInvalidConnectionStringInvalidIntParam("batchimportrows", value)
+			}
+			config.BatchImportRows = rows
+		case "batchimportbytes":
+			bytes, err := strconv.Atoi(value)
+			if err != nil || bytes < 0 {
+				return nil, errors.NewInvalidConnectionStringInvalidIntParam("batchimportbytes", value)
+			}
+			config.BatchImportBytes = bytes
+		case "readonly":
+			config.ReadOnly = value == "1"
+		case "compressionthreshold":
+			threshold, err := strconv.Atoi(value)
+			if err != nil || threshold < 0 {
+				return nil, errors.NewInvalidConnectionStringInvalidIntParam("compressionthreshold", value)
+			}
+			config.CompressionThreshold = threshold
 		case "fetchsize":
 			fetchSizeValue, err := strconv.Atoi(value)
 			if err != nil {
@@ -276,13 +875,138 @@ func getConfigWithParameters(host string, port int, parametersString string) (*D
 				return nil, errors.NewInvalidConnectionStringInvalidIntParam("resultsetmaxrows", value)
 			}
 			config.ResultSetMaxRows = maxRowsValue
+		case "slowquerythreshold":
+			threshold, err := time.ParseDuration(value)
+			if err != nil {
+				return nil, errors.NewInvalidConnectionStringInvalidDurationParam("slowquerythreshold", value)
+			}
+			config.SlowQueryThreshold = threshold
+		case "strict":
+			config.Strict = utils.BoolToPtr(strict)
 		default:
+			if strict {
+				return nil, errors.NewInvalidConnectionStringUnknownParam(key, suggestParameter(key), parameterNames)
+			}
 			config.Params[key] = unescape(value, ";")
 		}
 	}
 	return config, nil
 }
 
+// parameterNames are the options accepted by ParseDSN in alphabetical order.
+var parameterNames = []string{"accesstoken", "autocommit", "batchimportbytes", "batchimportrows",
+	"certificatefingerprint", "clientname", "clientversion", "closetimeout", "commandwaittimeout", "committimeout",
+	"compression", "compressionthreshold", "consumergroup",
+	"dateformat", "debug", "dialretries", "dialretrydelay", "dialretryjitter", "dialretrymaxdelay", "encryption",
+	"excludehosts", "fetchsize", "hostprobetimeout", "importencoding", "interpolateparams", "keepaliveinterval",
+	"logintimeout", "maxqueuedcommands", "nanasnull", "numericcharacters", "password", "passwordfile",
+	"permessagedeflate", "placeholderstyle", "querylog", "querylogparameters", "querytimeout", "rawbytes", "readonly",
+	"recordframes", "refreshtoken", "resultsetmaxrows", "schema", "slowquerythreshold", "strict", "timezone",
+	"tlsservername", "tokenfile", "transferhosts", "trimchar", "user", "validateservercertificate", "waitfordatabase",
+	"websocketpath", "websocketscheme"}
+
+// booleanParameters are the options whose values are checked to be 0 or 1 by strict parsing.
+var booleanParameters = map[string]bool{"autocommit": true, "encryption": true, "validateservercertificate": true,
+	"compression": true, "querylog": true, "querylogparameters": true, "trimchar": true, "permessagedeflate": true,
+	"interpolateparams": true, "nanasnull": true, "rawbytes": true, "dialretryjitter": true, "readonly": true, "strict": true}
+
+func isBoolValue(value string) bool {
+	return value == "0" || value == "1"
+}
+
+// isStrict returns the value of option strict, which applies to all options regardless of their order.
+func isStrict(parameters []string) (bool, error) {
+	strict := true
+	for _, parameter := range parameters {
+		key, value, _ := strings.Cut(parameter, "=")
+		if key != "strict" {
+			continue
+		}
+		if !isBoolValue(value) {
+			return false, errors.NewInvalidConnectionStringInvalidBoolParam(key, value)
+		}
+		strict = value == "1"
+	}
+	return strict, nil
+}
+
+// suggestParameter returns the supported option closest to the given unknown option
+// or an empty string if no option differs by at most two edits.
+func suggestParameter(key string) string {
+	suggestion, bestDistance := "", 3
+	for _, name := range parameterNames {
+		if distance := editDistance(strings.ToLower(key), name); distance < bestDistance {
+			suggestion, bestDistance = name, distance
+		}
+	}
+	return suggestion
+}
+
+// editDistance returns the optimal string alignment distance, which counts insertions, deletions, substitutions and
+// transpositions of adjacent characters as one edit each, so that typos like comperssion are close to compression.
+func editDistance(a, b string) int {
+	previous2 := make([]int, len(b)+1)
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				current[j] = minInt(current[j], previous2[j-2]+1)
+			}
+		}
+		previous2, previous, current = previous, current, previous2
+	}
+	return previous[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// consumerGroupRegex matches regular identifiers, which are sent unquoted in ALTER SESSION SET CONSUMER_GROUP.
+var consumerGroupRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// Debug categories for logging protocol messages via the trace logger.
+const (
+	// DebugFrames logs all websocket frames.
+	DebugFrames = "frames"
+	// DebugProtocol logs the command name, the sizes, the compression and the latency of each command and response.
+	DebugProtocol = "protocol"
+	// DebugPayloads logs the protocol trace including the redacted request payloads.
+	DebugPayloads = "payloads"
+)
+
+func parseDebugCategories(value string) ([]string, error) {
+	categories := strings.Split(value, ",")
+	for _, category := range categories {
+		if category != DebugFrames && category != DebugProtocol && category != DebugPayloads {
+			return nil, errors.NewInvalidConnectionStringInvalidDebugParam(value)
+		}
+	}
+	return categories, nil
+}
+
+func (c *DSNConfig) hasDebugCategory(category string) bool {
+	for _, debugCategory := range c.Debug {
+		if debugCategory == category {
+			return true
+		}
+	}
+	return false
+}
+
 func extractParameters(parametersString string) []string {
 	// Replace escaped separator with placeholder to avoid wrong split
 	replaced := strings.ReplaceAll(parametersString, `\;`, "{{,}}")
@@ -294,6 +1018,55 @@ func extractParameters(parametersString string) []string {
 	return splitted
 }
 
+// isValidTimezone checks if the time zone can be loaded from the IANA time zone database.
+// The database embedded with time/tzdata is used if the system does not provide one.
+// Names like EUROPE/BERLIN are accepted as well, as the database does not distinguish upper and lower case.
+func isValidTimezone(name string) bool {
+	if name == "" {
+		return true
+	}
+	for _, candidate := range []string{name, capitalizeTimezone(name)} {
+		if _, err := time.LoadLocation(candidate); err == nil && candidate != "Local" {
+			return true
+		}
+	}
+	return false
+}
+
+var timezoneWordRegex = regexp.MustCompile(`[A-Za-z]+`)
+
+// capitalizeTimezone converts a name like AMERICA/PORT_OF_SPAIN to the capitalization of the time zone database.
+func capitalizeTimezone(name string) string {
+	return timezoneWordRegex.ReplaceAllStringFunc(name, func(word string) string {
+		switch word = strings.ToLower(word); word {
+		case "of", "es", "au":
+			return word
+		case "gmt", "utc", "uct":
+			return strings.ToUpper(word)
+		default:
+			return strings.ToUpper(word[:1]) + word[1:]
+		}
+	})
+}
+
+// isValidDateFormat checks that the date format only contains format elements, separators and quoted text.
+func isValidDateFormat(format string) bool {
+	if format == "" {
+		return false
+	}
+	quoted := false
+	for _, char := range format {
+		switch {
+		case char == '"':
+			quoted = !quoted
+		case quoted, unicode.IsLetter(char), unicode.IsDigit(char), strings.ContainsRune(" -/,.;:", char):
+		default:
+			return false
+		}
+	}
+	return !quoted
+}
+
 func unescape(s, char string) string {
 	return strings.ReplaceAll(s, `\`+char, char)
 }
@@ -2,6 +2,7 @@ package dsn
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -48,6 +49,7 @@ func (suite *DsnTestSuite) TestParseValidDsnWithParameters() {
 			"compression=1;" +
 			"resultsetmaxrows=100;" +
 			"certificatefingerprint=fingerprint;" +
+			"strict=0;" +
 			"mycustomparam=value")
 	suite.NoError(err)
 	suite.Equal("sys", dsn.User)
@@ -119,6 +121,34 @@ func (suite *DsnTestSuite) TestInvalidHostPortFormat() {
 	suite.EqualError(err, "E-EGOD-22: invalid host or port in 'localhost', expected format: <host>:<port>")
 }
 
+func (suite *DsnTestSuite) TestParsePerHostPorts() {
+	dsn, err := ParseDSN("exa:host1:8563,host2:8564,host3:8565;user=sys")
+	suite.NoError(err)
+	suite.Equal("host1:8563,host2:8564,host3", dsn.Host)
+	suite.Equal(8565, dsn.Port)
+}
+
+func (suite *DsnTestSuite) TestParsePerHostPortsWithDefaultPort() {
+	dsn, err := ParseDSN("exa:host1:9000,host2:8563")
+	suite.NoError(err)
+	suite.Equal("host1:9000,host2", dsn.Host)
+	suite.Equal(8563, dsn.Port)
+}
+
+func (suite *DsnTestSuite) TestInvalidPerHostPort() {
+	dsn, err := ParseDSN("exa:host1:abc,host2:8563")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-23: invalid `port` value 'abc', numeric port expected")
+}
+
+func (suite *DsnTestSuite) TestPerHostPortsToDSN() {
+	config := &DSNConfig{Host: "host1:8564,host2:8565", Port: 8563}
+	dsn, err := ParseDSN(config.ToDSN())
+	suite.NoError(err)
+	suite.Equal("host1:8564,host2:8565", dsn.Host)
+	suite.Equal(8563, dsn.Port)
+}
+
 func (suite *DsnTestSuite) TestInvalidParameter() {
 	dsn, err := ParseDSN("exa:localhost:1234;user")
 	suite.Nil(dsn)
@@ -137,8 +167,14 @@ func (suite *DsnTestSuite) TestInvalidQueryTimeout() {
 	suite.EqualError(err, "E-EGOD-25: invalid 'querytimeout' value 'timeout', numeric expected")
 }
 
-func (suite *DsnTestSuite) TestInvalidValidateservercertificateUsesDefaultValue() {
+func (suite *DsnTestSuite) TestInvalidValidateservercertificate() {
 	dsn, err := ParseDSN("exa:localhost:1234;validateservercertificate=false")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-74: invalid 'validateservercertificate' value 'false', 0 or 1 expected")
+}
+
+func (suite *DsnTestSuite) TestInvalidValidateservercertificateUsesDefaultValueWithoutStrict() {
+	dsn, err := ParseDSN("exa:localhost:1234;validateservercertificate=false;strict=0")
 	suite.NoError(err)
 	suite.Equal(true, *dsn.ValidateServerCertificate)
 }
@@ -175,6 +211,438 @@ func (suite *DsnTestSuite) TestToDsnWithUserPassword() {
 	suite.Equal(value, dsn.ToDSN())
 }
 
+func (suite *DsnTestSuite) TestParseQueryLog() {
+	dsn, err := ParseDSN("exa:localhost:1234;querylog=1;querylogparameters=1")
+	suite.NoError(err)
+	suite.True(dsn.QueryLog)
+	suite.True(dsn.QueryLogParameters)
+}
+
+func (suite *DsnTestSuite) TestToDsnWithQueryLog() {
+	const value = "exa:localhost:1234;user=sys;password=exasol;autocommit=1;compression=0;encryption=1;validateservercertificate=1;fetchsize=2000;clientname=Go client;querylog=1;querylogparameters=1"
+	dsn, err := ParseDSN(value)
+	suite.NoError(err)
+	suite.Equal(value, dsn.ToDSN())
+}
+
+func (suite *DsnTestSuite) TestParseSlowQueryThreshold() {
+	dsn, err := ParseDSN("exa:localhost:1234;slowquerythreshold=1m30s")
+	suite.NoError(err)
+	suite.Equal(90*time.Second, dsn.SlowQueryThreshold)
+	suite.Contains(dsn.ToDSN(), ";slowquerythreshold=1m30s")
+}
+
+func (suite *DsnTestSuite) TestInvalidSlowQueryThreshold() {
+	dsn, err := ParseDSN("exa:localhost:1234;slowquerythreshold=10")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-30: invalid 'slowquerythreshold' value '10', duration with unit expected, e.g. 500ms or 2s")
+}
+
+func (suite *DsnTestSuite) TestParseRecordFrames() {
+	dsn, err := ParseDSN("exa:localhost:1234;recordframes=/tmp/frames.jsonl")
+	suite.NoError(err)
+	suite.Equal("/tmp/frames.jsonl", dsn.RecordFrames)
+	suite.Contains(dsn.ToDSN(), ";recordframes=/tmp/frames.jsonl")
+}
+
+func (suite *DsnTestSuite) TestParseKeepaliveInterval() {
+	dsn, err := ParseDSN("exa:localhost:1234;keepaliveinterval=30s")
+	suite.NoError(err)
+	suite.Equal(30*time.Second, dsn.KeepaliveInterval)
+	suite.Contains(dsn.ToDSN(), ";keepaliveinterval=30s")
+}
+
+func (suite *DsnTestSuite) TestInvalidKeepaliveInterval() {
+	dsn, err := ParseDSN("exa:localhost:1234;keepaliveinterval=30")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-30: invalid 'keepaliveinterval' value '30', duration with unit expected, e.g. 500ms or 2s")
+}
+
+func (suite *DsnTestSuite) TestParseCloseTimeout() {
+	dsn, err := ParseDSN("exa:localhost:1234;closetimeout=5s")
+	suite.NoError(err)
+	suite.Equal(5*time.Second, dsn.CloseTimeout)
+	suite.Contains(dsn.ToDSN(), ";closetimeout=5s")
+}
+
+func (suite *DsnTestSuite) TestInvalidCloseTimeout() {
+	dsn, err := ParseDSN("exa:localhost:1234;closetimeout=5")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-30: invalid 'closetimeout' value '5', duration with unit expected, e.g. 500ms or 2s")
+}
+
+func (suite *DsnTestSuite) TestParseReadOnly() {
+	dsn, err := ParseDSN("exa:localhost:1234;readonly=1")
+	suite.NoError(err)
+	suite.True(dsn.ReadOnly)
+	suite.Contains(dsn.ToDSN(), ";readonly=1")
+}
+
+func (suite *DsnTestSuite) TestReadOnlyDisabledByDefault() {
+	dsn, err := ParseDSN("exa:localhost:1234;readonly=0")
+	suite.NoError(err)
+	suite.False(dsn.ReadOnly)
+	suite.NotContains(dsn.ToDSN(), "readonly")
+}
+
+func (suite *DsnTestSuite) TestParseCompressionThreshold() {
+	dsn, err := ParseDSN("exa:localhost:1234;compression=1;compressionthreshold=512")
+	suite.NoError(err)
+	suite.Equal(512, dsn.CompressionThreshold)
+	suite.Contains(dsn.ToDSN(), ";compressionthreshold=512")
+}
+
+func (suite *DsnTestSuite) TestInvalidCompressionThreshold() {
+	dsn, err := ParseDSN("exa:localhost:1234;compressionthreshold=small")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-25: invalid 'compressionthreshold' value 'small', numeric expected")
+	_, err = ParseDSN("exa:localhost:1234;compressionthreshold=-1")
+	suite.EqualError(err, "E-EGOD-25: invalid 'compressionthreshold' value '-1', numeric expected")
+}
+
+func (suite *DsnTestSuite) TestParsePermessageDeflate() {
+	dsn, err := ParseDSN("exa:localhost:1234;permessagedeflate=1")
+	suite.NoError(err)
+	suite.True(dsn.PermessageDeflate)
+	suite.Contains(dsn.ToDSN(), ";permessagedeflate=1")
+}
+
+func (suite *DsnTestSuite) TestParseWebsocketSchemeAndPath() {
+	dsn, err := ParseDSN("exa:localhost:1234;websocketscheme=WS;websocketpath=/exasol/ws")
+	suite.NoError(err)
+	suite.Equal("ws", dsn.WebsocketScheme)
+	suite.Equal("/exasol/ws", dsn.WebsocketPath)
+	suite.Contains(dsn.ToDSN(), ";websocketscheme=ws;websocketpath=/exasol/ws")
+}
+
+func (suite *DsnTestSuite) TestInvalidWebsocketScheme() {
+	dsn, err := ParseDSN("exa:localhost:1234;websocketscheme=https")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-46: invalid websocketscheme value 'https', expected 'ws' or 'wss'")
+}
+
+func (suite *DsnTestSuite) TestParseWaitForDatabase() {
+	dsn, err := ParseDSN("exa:localhost:1234;waitfordatabase=2m")
+	suite.NoError(err)
+	suite.Equal(2*time.Minute, dsn.WaitForDatabase)
+	suite.Contains(dsn.ToDSN(), ";waitfordatabase=2m0s")
+}
+
+func (suite *DsnTestSuite) TestInvalidWaitForDatabase() {
+	dsn, err := ParseDSN("exa:localhost:1234;waitfordatabase=120")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-30: invalid 'waitfordatabase' value '120', duration with unit expected, e.g. 500ms or 2s")
+}
+
+func (suite *DsnTestSuite) TestParseInterpolateParams() {
+	dsn, err := ParseDSN("exa:localhost:1234;interpolateparams=1")
+	suite.NoError(err)
+	suite.True(dsn.InterpolateParams)
+	suite.Contains(dsn.ToDSN(), ";interpolateparams=1")
+}
+
+func (suite *DsnTestSuite) TestParsePlaceholderStyle() {
+	dsn, err := ParseDSN("exa:localhost:1234;placeholderstyle=Colon")
+	suite.NoError(err)
+	suite.Equal("colon", dsn.PlaceholderStyle)
+	suite.Contains(dsn.ToDSN(), ";placeholderstyle=colon")
+}
+
+func (suite *DsnTestSuite) TestInvalidPlaceholderStyle() {
+	dsn, err := ParseDSN("exa:localhost:1234;placeholderstyle=at")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-50: invalid placeholderstyle value 'at', expected 'question', 'colon' or 'dollar'")
+}
+
+func (suite *DsnTestSuite) TestParseSessionFormatAttributes() {
+	dsn, err := ParseDSN("exa:localhost:1234;timezone=EUROPE/BERLIN;dateformat=DD.MM.YYYY;numericcharacters=,.")
+	suite.NoError(err)
+	suite.Equal("EUROPE/BERLIN", dsn.Timezone)
+	suite.Equal("DD.MM.YYYY", dsn.DateFormat)
+	suite.Equal(",.", dsn.NumericCharacters)
+	suite.Contains(dsn.ToDSN(), ";timezone=EUROPE/BERLIN;dateformat=DD.MM.YYYY;numericcharacters=,.")
+}
+
+func (suite *DsnTestSuite) TestInvalidNumericCharacters() {
+	dsn, err := ParseDSN("exa:localhost:1234;numericcharacters=.")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-53: invalid numericcharacters value '.', expected a decimal and a group separator, e.g. '.,'")
+}
+
+func (suite *DsnTestSuite) TestNumericCharactersMustDiffer() {
+	dsn, err := ParseDSN("exa:localhost:1234;numericcharacters=..")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-53: invalid numericcharacters value '..', expected a decimal and a group separator, e.g. '.,'")
+}
+
+func (suite *DsnTestSuite) TestParseTimezone() {
+	for _, timezone := range []string{"UTC", "Europe/Berlin", "EUROPE/BERLIN", "AMERICA/PORT_OF_SPAIN", "ETC/GMT+1"} {
+		dsn, err := ParseDSN("exa:localhost:1234;timezone=" + timezone)
+		suite.NoError(err, timezone)
+		suite.Equal(timezone, dsn.Timezone)
+	}
+}
+
+func (suite *DsnTestSuite) TestInvalidTimezone() {
+	for _, timezone := range []string{"MARS/OLYMPUS", "Local", "../etc/passwd"} {
+		dsn, err := ParseDSN("exa:localhost:1234;timezone=" + timezone)
+		suite.Nil(dsn)
+		suite.ErrorContains(err, "E-EGOD-77: invalid timezone value '"+timezone+"'", timezone)
+	}
+}
+
+func (suite *DsnTestSuite) TestParseDateFormat() {
+	for _, format := range []string{"YYYY-MM-DD", "DD.MM.YYYY", `DD "of" MONTH YYYY`} {
+		dsn, err := ParseDSN("exa:localhost:1234;dateformat=" + format)
+		suite.NoError(err, format)
+		suite.Equal(format, dsn.DateFormat)
+	}
+}
+
+func (suite *DsnTestSuite) TestInvalidDateFormat() {
+	for _, format := range []string{"", "DD'MM", `DD "MM`} {
+		dsn, err := ParseDSN("exa:localhost:1234;dateformat=" + format)
+		suite.Nil(dsn)
+		suite.ErrorContains(err, "E-EGOD-78: invalid dateformat value '"+format+"'", format)
+	}
+}
+
+func (suite *DsnTestSuite) TestParseNaNAsNull() {
+	dsn, err := ParseDSN("exa:localhost:1234;nanasnull=1")
+	suite.NoError(err)
+	suite.True(dsn.NaNAsNull)
+	suite.Contains(dsn.ToDSN(), ";nanasnull=1")
+}
+
+func (suite *DsnTestSuite) TestParseHostProbeTimeout() {
+	dsn, err := ParseDSN("exa:host1,host2:1234;hostprobetimeout=300ms")
+	suite.NoError(err)
+	suite.Equal(300*time.Millisecond, dsn.HostProbeTimeout)
+	suite.Contains(dsn.ToDSN(), ";hostprobetimeout=300ms")
+}
+
+func (suite *DsnTestSuite) TestInvalidHostProbeTimeout() {
+	dsn, err := ParseDSN("exa:localhost:1234;hostprobetimeout=300")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-30: invalid 'hostprobetimeout' value '300', duration with unit expected, e.g. 500ms or 2s")
+}
+
+func (suite *DsnTestSuite) TestParseHostAffinity() {
+	dsn, err := ParseDSN("exa:exasol1..4:8563;excludehosts=exasol2,exasol4;transferhosts=exasol3..4")
+	suite.NoError(err)
+	suite.Equal("exasol2,exasol4", dsn.ExcludeHosts)
+	suite.Equal("exasol3..4", dsn.TransferHosts)
+	suite.Contains(dsn.ToDSN(), ";excludehosts=exasol2,exasol4;transferhosts=exasol3..4")
+}
+
+func (suite *DsnTestSuite) TestParseCommitTimeout() {
+	dsn, err := ParseDSN("exa:localhost:1234;committimeout=10s")
+	suite.NoError(err)
+	suite.Equal(10*time.Second, dsn.CommitTimeout)
+	suite.Contains(dsn.ToDSN(), ";committimeout=10s")
+}
+
+func (suite *DsnTestSuite) TestInvalidCommitTimeout() {
+	dsn, err := ParseDSN("exa:localhost:1234;committimeout=10")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-30: invalid 'committimeout' value '10', duration with unit expected, e.g. 500ms or 2s")
+}
+
+func (suite *DsnTestSuite) TestParseCommandQueueLimits() {
+	dsn, err := ParseDSN("exa:localhost:1234;maxqueuedcommands=5;commandwaittimeout=2s")
+	suite.NoError(err)
+	suite.Equal(5, dsn.MaxQueuedCommands)
+	suite.Equal(2*time.Second, dsn.CommandWaitTimeout)
+	suite.Contains(dsn.ToDSN(), ";maxqueuedcommands=5;commandwaittimeout=2s")
+}
+
+func (suite *DsnTestSuite) TestInvalidMaxQueuedCommands() {
+	dsn, err := ParseDSN("exa:localhost:1234;maxqueuedcommands=-1")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-25: invalid 'maxqueuedcommands' value '-1', numeric expected")
+}
+
+func (suite *DsnTestSuite) TestInvalidCommandWaitTimeout() {
+	dsn, err := ParseDSN("exa:localhost:1234;commandwaittimeout=2")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-30: invalid 'commandwaittimeout' value '2', duration with unit expected, e.g. 500ms or 2s")
+}
+
+func (suite *DsnTestSuite) TestParseLoginTimeout() {
+	dsn, err := ParseDSN("exa:localhost:1234;logintimeout=15s")
+	suite.NoError(err)
+	suite.Equal(15*time.Second, dsn.LoginTimeout)
+	suite.Contains(dsn.ToDSN(), ";logintimeout=15s")
+}
+
+func (suite *DsnTestSuite) TestInvalidLoginTimeout() {
+	dsn, err := ParseDSN("exa:localhost:1234;logintimeout=15")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-30: invalid 'logintimeout' value '15', duration with unit expected, e.g. 500ms or 2s")
+}
+
+func (suite *DsnTestSuite) TestParseRawBytes() {
+	dsn, err := ParseDSN("exa:localhost:1234;rawbytes=1")
+	suite.NoError(err)
+	suite.True(dsn.RawBytes)
+	suite.Contains(dsn.ToDSN(), ";rawbytes=1")
+}
+
+func (suite *DsnTestSuite) TestRawBytesDisabledByDefault() {
+	dsn, err := ParseDSN("exa:localhost:1234")
+	suite.NoError(err)
+	suite.False(dsn.RawBytes)
+	suite.NotContains(dsn.ToDSN(), "rawbytes")
+}
+
+func (suite *DsnTestSuite) TestParseDialRetries() {
+	dsn, err := ParseDSN("exa:localhost:1234;dialretries=5;dialretrydelay=200ms;dialretrymaxdelay=10s;dialretryjitter=1")
+	suite.NoError(err)
+	suite.Equal(5, dsn.DialRetries)
+	suite.Equal(200*time.Millisecond, dsn.DialRetryDelay)
+	suite.Equal(10*time.Second, dsn.DialRetryMaxDelay)
+	suite.True(dsn.DialRetryJitter)
+	suite.Contains(dsn.ToDSN(), ";dialretries=5;dialretrydelay=200ms;dialretrymaxdelay=10s;dialretryjitter=1")
+}
+
+func (suite *DsnTestSuite) TestInvalidDialRetries() {
+	_, err := ParseDSN("exa:localhost:1234;dialretries=many")
+	suite.EqualError(err, "E-EGOD-25: invalid 'dialretries' value 'many', numeric expected")
+	_, err = ParseDSN("exa:localhost:1234;dialretries=-1")
+	suite.EqualError(err, "E-EGOD-25: invalid 'dialretries' value '-1', numeric expected")
+	_, err = ParseDSN("exa:localhost:1234;dialretrydelay=100")
+	suite.EqualError(err, "E-EGOD-30: invalid 'dialretrydelay' value '100', duration with unit expected, e.g. 500ms or 2s")
+}
+
+func (suite *DsnTestSuite) TestParseTLSServerName() {
+	dsn, err := ParseDSN("exa:10.0.0.11..13:8563;tlsservername=cluster.example.com")
+	suite.NoError(err)
+	suite.Equal("cluster.example.com", dsn.TLSServerName)
+	suite.Contains(dsn.ToDSN(), ";tlsservername=cluster.example.com")
+}
+
+func (suite *DsnTestSuite) TestParseConsumerGroup() {
+	dsn, err := ParseDSN("exa:localhost:1234;consumergroup=BATCH_LOW")
+	suite.NoError(err)
+	suite.Equal("BATCH_LOW", dsn.ConsumerGroup)
+	suite.Contains(dsn.ToDSN(), ";consumergroup=BATCH_LOW")
+}
+
+func (suite *DsnTestSuite) TestParseInvalidConsumerGroup() {
+	_, err := ParseDSN("exa:localhost:1234;consumergroup=BATCH\\; DROP TABLE T")
+	suite.ErrorContains(err, "E-EGOD-72: invalid consumergroup value")
+}
+
+func (suite *DsnTestSuite) TestParseUnknownOption() {
+	dsn, err := ParseDSN("exa:localhost:1234;comperssion=1")
+	suite.Nil(dsn)
+	suite.ErrorContains(err, "E-EGOD-73: unknown option 'comperssion' in connection string Did you mean 'compression'? Supported options are accesstoken, autocommit, ")
+	suite.ErrorContains(err, "websocketscheme. Set strict=0 to accept unknown options.")
+}
+
+func (suite *DsnTestSuite) TestParseUnknownOptionWithoutSuggestion() {
+	_, err := ParseDSN("exa:localhost:1234;mycustomparam=value")
+	suite.ErrorContains(err, "E-EGOD-73: unknown option 'mycustomparam' in connection string Supported options are ")
+}
+
+func (suite *DsnTestSuite) TestParseUnknownOptionWithoutStrict() {
+	dsn, err := ParseDSN("exa:localhost:1234;comperssion=1;strict=0")
+	suite.NoError(err)
+	suite.Equal(map[string]string{"comperssion": "1"}, dsn.Params)
+	suite.False(*dsn.Strict)
+	suite.Contains(dsn.ToDSN(), ";strict=0")
+}
+
+func (suite *DsnTestSuite) TestParseInvalidBooleanValues() {
+	for _, parameter := range []string{"compression=yes", "autocommit=true", "readonly=true", "readonly=on", "strict=false"} {
+		_, err := ParseDSN("exa:localhost:1234;" + parameter)
+		suite.ErrorContains(err, "E-EGOD-74: invalid ", parameter)
+	}
+}
+
+func (suite *DsnTestSuite) TestParameterNamesAreSupported() {
+	for _, name := range parameterNames {
+		_, err := ParseDSN("exa:localhost:1234;" + name + "=1")
+		if err != nil {
+			suite.NotContains(err.Error(), "E-EGOD-73", name)
+		}
+	}
+}
+
+func (suite *DsnTestSuite) TestSuggestParameter() {
+	suite.Equal("compression", suggestParameter("comperssion"))
+	suite.Equal("fetchsize", suggestParameter("FetchSize"))
+	suite.Equal("querytimeout", suggestParameter("querytimout"))
+	suite.Equal("", suggestParameter("mycustomparam"))
+}
+
+func (suite *DsnTestSuite) TestParseBatchImportThresholds() {
+	dsn, err := ParseDSN("exa:localhost:1234;batchimportrows=10000;batchimportbytes=1048576")
+	suite.NoError(err)
+	suite.Equal(10000, dsn.BatchImportRows)
+	suite.Equal(1048576, dsn.BatchImportBytes)
+	suite.Contains(dsn.ToDSN(), ";batchimportrows=10000;batchimportbytes=1048576")
+}
+
+func (suite *DsnTestSuite) TestInvalidBatchImportRows() {
+	dsn, err := ParseDSN("exa:localhost:1234;batchimportrows=-1")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-25: invalid 'batchimportrows' value '-1', numeric expected")
+}
+
+func (suite *DsnTestSuite) TestInvalidBatchImportBytes() {
+	dsn, err := ParseDSN("exa:localhost:1234;batchimportbytes=1MB")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-25: invalid 'batchimportbytes' value '1MB', numeric expected")
+}
+
+func (suite *DsnTestSuite) TestParseDebug() {
+	dsn, err := ParseDSN("exa:localhost:1234;debug=frames")
+	suite.NoError(err)
+	suite.Equal([]string{DebugFrames}, dsn.Debug)
+	suite.Contains(dsn.ToDSN(), ";debug=frames")
+}
+
+func (suite *DsnTestSuite) TestParseDebugProtocol() {
+	dsn, err := ParseDSN("exa:localhost:1234;debug=protocol,payloads")
+	suite.NoError(err)
+	suite.Equal([]string{DebugProtocol, DebugPayloads}, dsn.Debug)
+	suite.Contains(dsn.ToDSN(), ";debug=protocol,payloads")
+}
+
+func (suite *DsnTestSuite) TestInvalidDebug() {
+	dsn, err := ParseDSN("exa:localhost:1234;debug=frames,all")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-34: invalid debug value 'frames,all', expected comma-separated list of 'frames, protocol, payloads'")
+}
+
+func (suite *DsnTestSuite) TestParseImportEncoding() {
+	dsn, err := ParseDSN("exa:localhost:1234;importencoding=latin1")
+	suite.NoError(err)
+	suite.Equal("latin1", dsn.ImportEncoding)
+	suite.Contains(dsn.ToDSN(), ";importencoding=latin1")
+}
+
+func (suite *DsnTestSuite) TestParseTrimChar() {
+	dsn, err := ParseDSN("exa:localhost:1234;trimchar=1")
+	suite.NoError(err)
+	suite.True(dsn.TrimChar)
+	suite.Contains(dsn.ToDSN(), ";trimchar=1")
+}
+
+func (suite *DsnTestSuite) TestTrimCharDisabledByDefault() {
+	dsn, err := ParseDSN("exa:localhost:1234")
+	suite.NoError(err)
+	suite.False(dsn.TrimChar)
+	suite.NotContains(dsn.ToDSN(), "trimchar")
+}
+
+func (suite *DsnTestSuite) TestInvalidImportEncoding() {
+	dsn, err := ParseDSN("exa:localhost:1234;importencoding=EBCDIC")
+	suite.Nil(dsn)
+	suite.EqualError(err, "E-EGOD-37: invalid importencoding value 'EBCDIC', expected one of 'ISO-8859-1, WINDOWS-1252, UTF-16, UTF-16LE, UTF-16BE'")
+}
+
 func (suite *DsnTestSuite) TestToDsnWithAccessToken() {
 	const value = "exa:localhost:1234;accesstoken=token;autocommit=1;compression=0;encryption=1;validateservercertificate=1;fetchsize=2000;clientname=Go client"
 	dsn, err := ParseDSN(value)
@@ -189,6 +657,19 @@ func (suite *DsnTestSuite) TestToDsnWithRefreshToken() {
 	suite.Equal(value, dsn.ToDSN())
 }
 
+func (suite *DsnTestSuite) TestParseSecretFiles() {
+	dsn, err := ParseDSN(`exa:localhost:1234;user=sys;passwordfile=/run/secrets/pass\;word`)
+	suite.NoError(err)
+	suite.Equal("/run/secrets/pass;word", dsn.PasswordFile)
+	suite.Equal("", dsn.Password)
+	suite.Contains(dsn.ToDSN(), ";user=sys;passwordfile=/run/secrets/pass;word;")
+
+	dsn, err = ParseDSN("exa:localhost:1234;tokenfile=/run/secrets/token")
+	suite.NoError(err)
+	suite.Equal("/run/secrets/token", dsn.TokenFile)
+	suite.Contains(dsn.ToDSN(), ";tokenfile=/run/secrets/token;")
+}
+
 func (suite *DsnTestSuite) TestParseValidDsnWithAccessToken() {
 	dsn, err := ParseDSN(
 		`exa:localhost:1234;accesstoken=TOKEN.JWT.TEST;autocommit=1;encryption=1;compression=0`)
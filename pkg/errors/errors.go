@@ -1,7 +1,12 @@
 package errors
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"net/url"
+	"sort"
+	"strings"
+	"time"
 
 	exaerror "github.com/exasol/error-reporting-go"
 )
@@ -23,6 +28,23 @@ var (
 				Message("could not create proxy connection to import file"))
 	ErrInvalidImportQuery = NewDriverErr(exaerror.New("E-EGOD-27").
 				Message("could not parse import query"))
+	ErrUnsupportedConnection = NewDriverErr(exaerror.New("E-EGOD-31").
+					Message("connection is not an Exasol connection"))
+	ErrProfileNotFound = NewDriverErr(exaerror.New("E-EGOD-32").
+				Message("no profiling information found for statement"))
+	ErrInvalidExportQuery = NewDriverErr(exaerror.New("E-EGOD-36").
+				Message("could not parse export query"))
+	ErrShutdown = NewDriverErr(exaerror.New("E-EGOD-43").
+			Message("connector is shut down"))
+	ErrReadOnly = NewDriverErr(exaerror.New("E-EGOD-44").
+			Message("only SELECT statements are allowed on a read-only connection"))
+	ErrInvalidListParam = NewDriverErr(exaerror.New("E-EGOD-49").
+				Message("list parameters require exactly one value per placeholder and are not supported by prepared statements"))
+	ErrTransactionInProgress = NewDriverErr(exaerror.New("E-EGOD-62").
+					Message("transaction already in progress, commit or roll back the open transaction before beginning a new one"))
+	ErrExplainRequiresQuery = NewDriverErr(exaerror.New("E-EGOD-76").
+				Message("only SELECT statements can be explained").
+				Mitigation("Use DryRun to validate other statements without executing them."))
 )
 
 func NewErrCertificateFingerprintMismatch(actualFingerprint, expectedFingerprint string) DriverErr {
@@ -39,6 +61,37 @@ func NewSqlErr(sqlCode string, msg string) DriverErr {
 		Parameter("text", msg))
 }
 
+// SQLError is returned when the database rejects a command. It contains all fields of the exception sent by the database.
+type SQLError struct {
+	SQLCode string
+	Text    string
+	// Details contains all other fields of the exception, e.g. the position of the error in the statement, as raw JSON values.
+	Details map[string]json.RawMessage
+}
+
+// NewSQLError creates a new error for an exception sent by the database.
+func NewSQLError(sqlCode, text string, details map[string]json.RawMessage) *SQLError {
+	return &SQLError{SQLCode: sqlCode, Text: text, Details: details}
+}
+
+// Error returns the message of NewSqlErr followed by the details sorted by name.
+func (e *SQLError) Error() string {
+	message := NewSqlErr(e.SQLCode, e.Text).Error()
+	if len(e.Details) == 0 {
+		return message
+	}
+	names := make([]string, 0, len(e.Details))
+	for name := range e.Details {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	details := make([]string, len(names))
+	for i, name := range names {
+		details[i] = name + "=" + string(e.Details[name])
+	}
+	return message + " (" + strings.Join(details, ", ") + ")"
+}
+
 func NewErrCouldNotAbort(rootCause error) DriverErr {
 	return NewDriverErr(exaerror.New("E-EGOD-12").
 		Message("could not abort query: {{root cause}}").
@@ -147,6 +200,13 @@ func NewWebsocketNotConnected(request interface{}) DriverErr {
 		Parameter("request", request))
 }
 
+func NewInvalidConnectionStringInvalidDurationParam(paramName, value string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-30").
+		Message("invalid {{parameter name}} value {{value}}, duration with unit expected, e.g. 500ms or 2s").
+		Parameter("parameter name", paramName).
+		Parameter("value", value))
+}
+
 // DriverErr This type represents an error that can occur when working with a database connection.
 type DriverErr string
 
@@ -154,3 +214,413 @@ type DriverErr string
 func (e DriverErr) Error() string {
 	return string(e)
 }
+
+// BadConnError reports that the connection is broken and must not be used anymore.
+// It matches [driver.ErrBadConn] with errors.Is, so that database/sql discards the connection,
+// and unwraps to the root cause, e.g. a network or decoding error.
+type BadConnError struct {
+	Cause error
+}
+
+// NewBadConnError creates a new error for a broken connection caused by the given error.
+func NewBadConnError(cause error) error {
+	return &BadConnError{Cause: cause}
+}
+
+// Error returns the message of driver.ErrBadConn followed by the message of the cause.
+func (e *BadConnError) Error() string {
+	return driver.ErrBadConn.Error() + ": " + e.Cause.Error()
+}
+
+// Unwrap returns the cause.
+func (e *BadConnError) Unwrap() error {
+	return e.Cause
+}
+
+// Is returns true for driver.ErrBadConn.
+func (e *BadConnError) Is(target error) bool {
+	return target == driver.ErrBadConn
+}
+
+// WebsocketCloseError reports that the database closed the websocket connection with a close code and reason.
+type WebsocketCloseError struct {
+	Code   int    // Close code, e.g. 1001 for going away
+	Reason string // Close reason sent by the database, may be empty
+}
+
+// NewWebsocketCloseError creates a new error for a websocket connection closed with the given code and reason.
+func NewWebsocketCloseError(code int, reason string) *WebsocketCloseError {
+	return &WebsocketCloseError{Code: code, Reason: reason}
+}
+
+// Error returns the close code, its description and the reason.
+func (e *WebsocketCloseError) Error() string {
+	message := "database closed the connection with code {{code|uq}} ({{description|uq}})"
+	if e.Reason != "" {
+		message += ": {{reason}}"
+	}
+	return NewDriverErr(exaerror.New("E-EGOD-61").
+		Message(message).
+		Parameter("code", e.Code).
+		Parameter("description", closeCodeDescription(e.Code)).
+		Parameter("reason", e.Reason)).Error()
+}
+
+// Retryable returns true if a new connection may succeed, e.g. because the database restarts.
+// Protocol errors and policy violations would occur again on a new connection.
+func (e *WebsocketCloseError) Retryable() bool {
+	switch e.Code {
+	case 1000, 1001, 1006, 1012, 1013, 1014:
+		return true
+	default:
+		return false
+	}
+}
+
+// BatchError reports the failure of a statement or chunk of rows of a batch.
+// Statements and chunks before the failed one were executed and are committed if autocommit is enabled.
+type BatchError struct {
+	Index     int     // Zero-based index of the failed statement or chunk
+	RowCounts []int64 // Affected rows of the statements or chunks executed before the failed one
+	Cause     error
+}
+
+// NewBatchError creates a new error for the statement or chunk with the given index.
+func NewBatchError(index int, rowCounts []int64, cause error) *BatchError {
+	return &BatchError{Index: index, RowCounts: rowCounts, Cause: cause}
+}
+
+// Error returns the index of the failed statement or chunk and the message of the cause.
+func (e *BatchError) Error() string {
+	return NewDriverErr(exaerror.New("E-EGOD-68").
+		Message("batch failed at index {{index|uq}} after {{executed|uq}} successful executions: {{cause|uq}}").
+		Parameter("index", e.Index).
+		Parameter("executed", len(e.RowCounts)).
+		Parameter("cause", e.Cause)).Error()
+}
+
+// Unwrap returns the cause, e.g. the *SQLError of the database.
+func (e *BatchError) Unwrap() error {
+	return e.Cause
+}
+
+// DriverSaturatedError reports that a command was rejected because the connection is busy with other commands.
+// The connection stays usable, so the command can be retried later or on another connection.
+type DriverSaturatedError struct {
+	MaxQueuedCommands int           // Limit of waiting commands that was reached, 0 if the command timed out
+	WaitTimeout       time.Duration // Waiting time after which the command was rejected, 0 if the queue was full
+}
+
+// NewQueueFullError creates a new error for a command rejected because the given number of commands are already waiting.
+func NewQueueFullError(maxQueuedCommands int) *DriverSaturatedError {
+	return &DriverSaturatedError{MaxQueuedCommands: maxQueuedCommands}
+}
+
+// NewQueueTimeoutError creates a new error for a command that waited longer than the given timeout.
+func NewQueueTimeoutError(waitTimeout time.Duration) *DriverSaturatedError {
+	return &DriverSaturatedError{WaitTimeout: waitTimeout}
+}
+
+// Error returns the reason why the command was rejected.
+func (e *DriverSaturatedError) Error() string {
+	if e.WaitTimeout > 0 {
+		return NewDriverErr(exaerror.New("E-EGOD-79").
+			Message("connection is saturated, command waited longer than {{timeout|uq}} for other commands to finish").
+			Parameter("timeout", e.WaitTimeout.String()).
+			Mitigation("Use more connections or increase commandwaittimeout.")).Error()
+	}
+	return NewDriverErr(exaerror.New("E-EGOD-64").
+		Message("connection is saturated, {{commands|uq}} commands are already waiting for other commands to finish").
+		Parameter("commands", e.MaxQueuedCommands).
+		Mitigation("Use more connections or increase maxqueuedcommands.")).Error()
+}
+
+func closeCodeDescription(code int) string {
+	switch code {
+	case 1000:
+		return "normal closure"
+	case 1001:
+		return "going away"
+	case 1002:
+		return "protocol error"
+	case 1003:
+		return "unsupported data"
+	case 1006:
+		return "abnormal closure"
+	case 1007:
+		return "invalid payload data"
+	case 1008:
+		return "policy violation"
+	case 1009:
+		return "message too big"
+	case 1011:
+		return "internal server error"
+	case 1012:
+		return "service restart"
+	case 1013:
+		return "try again later"
+	case 1014:
+		return "bad gateway"
+	default:
+		return "unknown close code"
+	}
+}
+
+func NewErrTableNotFound(schema, table string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-33").
+		Message("table {{schema}}.{{table}} not found").
+		Parameter("schema", schema).
+		Parameter("table", table))
+}
+
+func NewInvalidConnectionStringInvalidDebugParam(value string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-34").
+		Message("invalid debug value {{value}}, expected comma-separated list of {{categories}}").
+		Parameter("value", value).
+		Parameter("categories", "frames, protocol, payloads"))
+}
+
+func NewInvalidConnectionStringInvalidImportEncoding(value string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-37").
+		Message("invalid importencoding value {{value}}, expected one of {{encodings}}").
+		Parameter("value", value).
+		Parameter("encodings", "ISO-8859-1, WINDOWS-1252, UTF-16, UTF-16LE, UTF-16BE"))
+}
+
+func NewInvalidConnectionStringInvalidWebsocketScheme(value string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-46").
+		Message("invalid websocketscheme value {{value}}, expected 'ws' or 'wss'").
+		Parameter("value", value))
+}
+
+func NewInvalidFilePattern(pattern string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-38").
+		Message("invalid file pattern {{pattern}}").
+		Parameter("pattern", pattern))
+}
+
+func NewNoCSVFilesInDirectory(directory string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-39").
+		Message("directory {{directory}} contains no CSV files").
+		Parameter("directory", directory))
+}
+
+func NewFileReadError(path string, err error) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-40").
+		Message("could not read file {{path}}: {{error}}").
+		Parameter("path", path).
+		Parameter("error", err))
+}
+
+func NewInvalidStreamRowLength(actual, expected int) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-41").
+		Message("row has {{actual|uq}} values but {{expected|uq}} columns are inserted").
+		Parameter("actual", actual).
+		Parameter("expected", expected))
+}
+
+func NewCloseTimeout(timeout time.Duration) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-42").
+		Message("disconnect did not complete within {{timeout|uq}}, closed websocket forcibly").
+		Parameter("timeout", timeout.String()))
+}
+
+func NewLoginTimeout(timeout time.Duration) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-65").
+		Message("login did not complete within {{timeout|uq}}").
+		Parameter("timeout", timeout.String()).
+		Mitigation("Check the connection to the database or increase logintimeout."))
+}
+
+func NewKeepaliveError(err error) DriverErr {
+	return NewDriverErr(exaerror.New("W-EGOD-35").
+		Message("could not send keepalive ping: {{error}}").
+		Parameter("error", err))
+}
+
+func NewSaaSPasswordLoginFailed(host string, err error) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-47").
+		Message("password login to Exasol SaaS host {{host}} failed: {{error|uq}}").
+		Parameter("host", host).
+		Parameter("error", err).
+		Mitigation("Exasol SaaS requires a personal access token, use exasol.NewConfigForSaaS(host, token) or set the accesstoken property."))
+}
+
+func NewDatabaseWaitTimeout(wait time.Duration, err error) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-48").
+		Message("database not available after waiting {{wait|uq}}: {{error|uq}}").
+		Parameter("wait", wait.String()).
+		Parameter("error", err))
+}
+
+func NewTokenRefreshFailed(err error) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-45").
+		Message("access token expired and a new token could not be obtained: {{error|uq}}").
+		Parameter("error", err))
+}
+
+func NewInvalidConnectionStringInvalidPlaceholderStyle(value string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-50").
+		Message("invalid placeholderstyle value {{value}}, expected 'question', 'colon' or 'dollar'").
+		Parameter("value", value))
+}
+
+func NewInvalidConnectionStringInvalidNumericCharacters(value string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-53").
+		Message("invalid numericcharacters value {{value}}, expected a decimal and a group separator, e.g. '.,'").
+		Parameter("value", value))
+}
+
+func NewNonFiniteParameter(index int, value float64) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-54").
+		Message("parameter {{index|uq}} has value {{value|uq}}, which is not supported by Exasol").
+		Parameter("index", index).
+		Parameter("value", value).
+		Mitigation("Use nil for missing values or set nanasnull=1 to bind NaN and infinite values as NULL."))
+}
+
+func NewNonFiniteValue(column string, value interface{}) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-55").
+		Message("column {{column}} contains value {{value}}, which is not a finite double").
+		Parameter("column", column).
+		Parameter("value", value).
+		Mitigation("Set nanasnull=1 to return NaN and infinite values as NULL."))
+}
+
+func NewInvalidNullToken(token string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-56").
+		Message("invalid NULL token {{token}}, it must not contain column separators, delimiters or line breaks").
+		Parameter("token", token))
+}
+
+func NewValueEqualsNullToken(value string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-57").
+		Message("value {{value}} equals the NULL token and would be imported as NULL").
+		Parameter("value", value).
+		Mitigation("Use a NULL token that does not occur in the data."))
+}
+
+func NewInvalidImportBuilder(reason string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-58").
+		Message("invalid IMPORT statement: {{reason|uq}}").
+		Parameter("reason", reason))
+}
+
+func NewInvalidExportBuilder(reason string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-59").
+		Message("invalid EXPORT statement: {{reason|uq}}").
+		Parameter("reason", reason))
+}
+
+func NewNoHostsAvailable(hosts string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-60").
+		Message("no hosts available, all hosts of {{hosts}} are excluded").
+		Parameter("hosts", hosts).
+		Mitigation("Exclude fewer hosts with excludehosts or pin at least one host that is not excluded."))
+}
+
+func NewInvalidRowRange(offset, count int) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-63").
+		Message("invalid row range with offset {{offset|uq}} and count {{count|uq}}, both must not be negative").
+		Parameter("offset", offset).
+		Parameter("count", count))
+}
+
+func NewMissingParquetColumn(path, column string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-66").
+		Message("Parquet file {{path}} has no column {{column}} of the target table").
+		Parameter("path", path).
+		Parameter("column", column).
+		Mitigation("Import only the columns contained in the file by passing them to IntoTable."))
+}
+
+func NewWarmUpExceedsPool(count, maxOpen int) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-67").
+		Message("cannot warm up {{count|uq}} connections, the pool allows at most {{maxOpen|uq}} open connections").
+		Parameter("count", count).
+		Parameter("maxOpen", maxOpen).
+		Mitigation("Increase the limit with SetMaxOpenConns or warm up fewer connections."))
+}
+
+func NewEmptySecretFile(path string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-69").
+		Message("secret file {{path}} is empty").
+		Parameter("path", path).
+		Mitigation("Check that the secret is mounted and the file contains the password or token."))
+}
+
+func NewServiceLookupFailed(service string, err error) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-70").
+		Message("could not look up the SRV records of service {{service}}: {{error}}").
+		Parameter("service", service).
+		Parameter("error", err.Error()).
+		Mitigation("Check the name of the service and the DNS configuration."))
+}
+
+func NewInvalidScanDestination(destination string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-71").
+		Message("invalid scan destination {{destination}}").
+		Parameter("destination", destination).
+		Mitigation("Pass a pointer to a struct to ScanStruct and a pointer to a slice of structs or struct pointers to ScanSlice."))
+}
+
+func NewInvalidConnectionStringInvalidConsumerGroup(value string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-72").
+		Message("invalid consumergroup value {{value}}, expected a regular identifier").
+		Parameter("value", value).
+		Mitigation("Use the name of the consumer group without quotes, e.g. 'BATCH'."))
+}
+
+func NewInvalidConnectionStringUnknownParam(paramName, suggestion string, supported []string) DriverErr {
+	err := exaerror.New("E-EGOD-73").
+		Message("unknown option {{parameter name}} in connection string").
+		Parameter("parameter name", paramName).
+		Parameter("supported", strings.Join(supported, ", "))
+	if suggestion != "" {
+		return NewDriverErr(err.Mitigation("Did you mean {{suggestion}}? Supported options are {{supported|uq}}. Set strict=0 to accept unknown options.").
+			Parameter("suggestion", suggestion))
+	}
+	return NewDriverErr(err.Mitigation("Supported options are {{supported|uq}}. Set strict=0 to accept unknown options."))
+}
+
+func NewInvalidConnectionStringInvalidBoolParam(paramName, value string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-74").
+		Message("invalid {{parameter name}} value {{value}}, 0 or 1 expected").
+		Parameter("parameter name", paramName).
+		Parameter("value", value))
+}
+
+func NewInvalidConnectionStringInvalidTimezone(value string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-77").
+		Message("invalid timezone value {{value}}, expected a time zone of the IANA time zone database").
+		Parameter("value", value).
+		Mitigation("Use a name like 'Europe/Berlin' or 'UTC'. Names are accepted in upper case as well."))
+}
+
+func NewInvalidConnectionStringInvalidDateFormat(value string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-78").
+		Message("invalid dateformat value {{value}}, expected format elements and separators, e.g. 'YYYY-MM-DD'").
+		Parameter("value", value))
+}
+
+func NewUnsupportedFeature(feature, databaseVersion string, protocolVersion int) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-75").
+		Message("feature {{feature}} is not supported by Exasol {{database version|uq}} with protocol version {{protocol version|uq}}").
+		Parameter("feature", feature).
+		Parameter("database version", databaseVersion).
+		Parameter("protocol version", protocolVersion).
+		Mitigation("Check with connection.Supports before using the feature or upgrade the database."))
+}
+
+func NewMixedPlaceholders(style string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-51").
+		Message("statement mixes ? placeholders with placeholders of style {{style}}").
+		Parameter("style", style).
+		Mitigation("Use only placeholders of the configured placeholderstyle."))
+}
+
+func NewMissingPlaceholderArgument(placeholder string) DriverErr {
+	return NewDriverErr(exaerror.New("E-EGOD-52").
+		Message("no argument given for placeholder {{placeholder}}").
+		Parameter("placeholder", placeholder))
+}
@@ -2,9 +2,15 @@ package errors
 
 import (
 	"bytes"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"math"
 	"net/url"
+	"os"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -22,6 +28,18 @@ func (suite *ErrorsTestSuite) SetupTest() {
 	suite.logBuffer = bytes.NewBuffer(make([]byte, 0, 64))
 }
 
+func (suite *ErrorsTestSuite) TestErrorCodesAreUnique() {
+	source, err := os.ReadFile("errors.go")
+	suite.Require().NoError(err)
+	codes := map[string]int{}
+	for _, code := range regexp.MustCompile(`[EW]-EGOD-\d+`).FindAll(source, -1) {
+		codes[string(code)]++
+	}
+	for code, count := range codes {
+		suite.Equal(1, count, "error code %s is used %d times", code, count)
+	}
+}
+
 func (suite *ErrorsTestSuite) TestErrInvalidConn() {
 	suite.EqualError(ErrInvalidConn, "E-EGOD-1: invalid connection")
 }
@@ -62,6 +80,15 @@ func (suite *ErrorsTestSuite) TestNewErrCertificateFingerprintMismatch() {
 	suite.EqualError(NewErrCertificateFingerprintMismatch("actual", "expected"), "E-EGOD-10: the server's certificate fingerprint 'actual' does not match the expected fingerprint 'expected'")
 }
 
+func (suite *ErrorsTestSuite) TestSQLErrorWithoutDetails() {
+	suite.EqualError(NewSQLError("42000", "text", nil), "E-EGOD-11: execution failed with SQL error code '42000' and message 'text'")
+}
+
+func (suite *ErrorsTestSuite) TestSQLErrorWithDetails() {
+	err := NewSQLError("42000", "text", map[string]json.RawMessage{"stack": json.RawMessage(`["a"]`), "position": json.RawMessage(`{"line":120}`)})
+	suite.EqualError(err, `E-EGOD-11: execution failed with SQL error code '42000' and message 'text' (position={"line":120}, stack=["a"])`)
+}
+
 func (suite *ErrorsTestSuite) TestNewSqlErr() {
 	suite.EqualError(NewSqlErr("sqlCode", "text"), "E-EGOD-11: execution failed with SQL error code 'sqlCode' and message 'text'")
 }
@@ -100,3 +127,223 @@ func (suite *ErrorsTestSuite) TestLogJsonDecodingError() {
 func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidPort() {
 	suite.EqualError(NewInvalidConnectionStringInvalidPort("port"), "E-EGOD-23: invalid `port` value 'port', numeric port expected")
 }
+
+func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidDurationParam() {
+	suite.EqualError(NewInvalidConnectionStringInvalidDurationParam("param", "value"), "E-EGOD-30: invalid 'param' value 'value', duration with unit expected, e.g. 500ms or 2s")
+}
+
+func (suite *ErrorsTestSuite) TestErrInvalidExportQuery() {
+	suite.EqualError(ErrInvalidExportQuery, "E-EGOD-36: could not parse export query")
+}
+
+func (suite *ErrorsTestSuite) TestErrShutdown() {
+	suite.EqualError(ErrShutdown, "E-EGOD-43: connector is shut down")
+}
+
+func (suite *ErrorsTestSuite) TestErrReadOnly() {
+	suite.EqualError(ErrReadOnly, "E-EGOD-44: only SELECT statements are allowed on a read-only connection")
+}
+
+func (suite *ErrorsTestSuite) TestErrTransactionInProgress() {
+	suite.EqualError(ErrTransactionInProgress, "E-EGOD-62: transaction already in progress, commit or roll back the open transaction before beginning a new one")
+}
+
+func (suite *ErrorsTestSuite) TestErrInvalidListParam() {
+	suite.EqualError(ErrInvalidListParam, "E-EGOD-49: list parameters require exactly one value per placeholder and are not supported by prepared statements")
+}
+
+func (suite *ErrorsTestSuite) TestErrUnsupportedConnection() {
+	suite.EqualError(ErrUnsupportedConnection, "E-EGOD-31: connection is not an Exasol connection")
+}
+
+func (suite *ErrorsTestSuite) TestErrExplainRequiresQuery() {
+	suite.EqualError(ErrExplainRequiresQuery, "E-EGOD-76: only SELECT statements can be explained Use DryRun to validate other statements without executing them.")
+}
+
+func (suite *ErrorsTestSuite) TestErrProfileNotFound() {
+	suite.EqualError(ErrProfileNotFound, "E-EGOD-32: no profiling information found for statement")
+}
+
+func (suite *ErrorsTestSuite) TestNewErrTableNotFound() {
+	suite.EqualError(NewErrTableNotFound("MY_SCHEMA", "MY_TABLE"), "E-EGOD-33: table 'MY_SCHEMA'.'MY_TABLE' not found")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidDebugParam() {
+	suite.EqualError(NewInvalidConnectionStringInvalidDebugParam("all"), "E-EGOD-34: invalid debug value 'all', expected comma-separated list of 'frames, protocol, payloads'")
+}
+
+func (suite *ErrorsTestSuite) TestNewKeepaliveError() {
+	suite.EqualError(NewKeepaliveError(fmt.Errorf("broken pipe")), "W-EGOD-35: could not send keepalive ping: 'broken pipe'")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidWebsocketScheme() {
+	suite.EqualError(NewInvalidConnectionStringInvalidWebsocketScheme("http"), "E-EGOD-46: invalid websocketscheme value 'http', expected 'ws' or 'wss'")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidImportEncoding() {
+	suite.EqualError(NewInvalidConnectionStringInvalidImportEncoding("EBCDIC"), "E-EGOD-37: invalid importencoding value 'EBCDIC', expected one of 'ISO-8859-1, WINDOWS-1252, UTF-16, UTF-16LE, UTF-16BE'")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidFilePattern() {
+	suite.EqualError(NewInvalidFilePattern("data/[.csv"), "E-EGOD-38: invalid file pattern 'data/[.csv'")
+}
+
+func (suite *ErrorsTestSuite) TestNewNoCSVFilesInDirectory() {
+	suite.EqualError(NewNoCSVFilesInDirectory("/data"), "E-EGOD-39: directory '/data' contains no CSV files")
+}
+
+func (suite *ErrorsTestSuite) TestNewFileReadError() {
+	suite.EqualError(NewFileReadError("/data/a.csv", fmt.Errorf("input/output error")), "E-EGOD-40: could not read file '/data/a.csv': 'input/output error'")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidStreamRowLength() {
+	suite.EqualError(NewInvalidStreamRowLength(3, 2), "E-EGOD-41: row has 3 values but 2 columns are inserted")
+}
+
+func (suite *ErrorsTestSuite) TestNewCloseTimeout() {
+	suite.EqualError(NewCloseTimeout(5*time.Second), "E-EGOD-42: disconnect did not complete within 5s, closed websocket forcibly")
+}
+
+func (suite *ErrorsTestSuite) TestNewSaaSPasswordLoginFailed() {
+	suite.EqualError(NewSaaSPasswordLoginFailed("abc.clusters.exasol.com", fmt.Errorf("mock error")),
+		"E-EGOD-47: password login to Exasol SaaS host 'abc.clusters.exasol.com' failed: mock error Exasol SaaS requires a personal access token, use exasol.NewConfigForSaaS(host, token) or set the accesstoken property.")
+}
+
+func (suite *ErrorsTestSuite) TestNewDatabaseWaitTimeout() {
+	suite.EqualError(NewDatabaseWaitTimeout(2*time.Minute, fmt.Errorf("mock error")), "E-EGOD-48: database not available after waiting 2m0s: mock error")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidPlaceholderStyle() {
+	suite.EqualError(NewInvalidConnectionStringInvalidPlaceholderStyle("at"), "E-EGOD-50: invalid placeholderstyle value 'at', expected 'question', 'colon' or 'dollar'")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidNumericCharacters() {
+	suite.EqualError(NewInvalidConnectionStringInvalidNumericCharacters("."), "E-EGOD-53: invalid numericcharacters value '.', expected a decimal and a group separator, e.g. '.,'")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidTimezone() {
+	suite.EqualError(NewInvalidConnectionStringInvalidTimezone("MARS/OLYMPUS"), "E-EGOD-77: invalid timezone value 'MARS/OLYMPUS', expected a time zone of the IANA time zone database Use a name like 'Europe/Berlin' or 'UTC'. Names are accepted in upper case as well.")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidDateFormat() {
+	suite.EqualError(NewInvalidConnectionStringInvalidDateFormat("DD'MM"), "E-EGOD-78: invalid dateformat value 'DD'MM', expected format elements and separators, e.g. 'YYYY-MM-DD'")
+}
+
+func (suite *ErrorsTestSuite) TestNewNonFiniteParameter() {
+	suite.EqualError(NewNonFiniteParameter(2, math.Inf(-1)), "E-EGOD-54: parameter 2 has value -Inf, which is not supported by Exasol Use nil for missing values or set nanasnull=1 to bind NaN and infinite values as NULL.")
+}
+
+func (suite *ErrorsTestSuite) TestNewNonFiniteValue() {
+	suite.EqualError(NewNonFiniteValue("D", "NaN"), "E-EGOD-55: column 'D' contains value 'NaN', which is not a finite double Set nanasnull=1 to return NaN and infinite values as NULL.")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidNullToken() {
+	suite.EqualError(NewInvalidNullToken("a,b"), "E-EGOD-56: invalid NULL token 'a,b', it must not contain column separators, delimiters or line breaks")
+}
+
+func (suite *ErrorsTestSuite) TestNewValueEqualsNullToken() {
+	suite.EqualError(NewValueEqualsNullToken(`\N`), `E-EGOD-57: value '\N' equals the NULL token and would be imported as NULL Use a NULL token that does not occur in the data.`)
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidImportBuilder() {
+	suite.EqualError(NewInvalidImportBuilder("no target table given"), "E-EGOD-58: invalid IMPORT statement: no target table given")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidExportBuilder() {
+	suite.EqualError(NewInvalidExportBuilder("no files or writer given"), "E-EGOD-59: invalid EXPORT statement: no files or writer given")
+}
+
+func (suite *ErrorsTestSuite) TestNewNoHostsAvailable() {
+	suite.EqualError(NewNoHostsAvailable("exasol1,exasol2"), "E-EGOD-60: no hosts available, all hosts of 'exasol1,exasol2' are excluded Exclude fewer hosts with excludehosts or pin at least one host that is not excluded.")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidRowRange() {
+	suite.EqualError(NewInvalidRowRange(-1, 10), "E-EGOD-63: invalid row range with offset -1 and count 10, both must not be negative")
+}
+
+func (suite *ErrorsTestSuite) TestWebsocketCloseError() {
+	suite.EqualError(NewWebsocketCloseError(1001, "shutdown"), "E-EGOD-61: database closed the connection with code 1001 (going away): 'shutdown'")
+	suite.EqualError(NewWebsocketCloseError(4000, ""), "E-EGOD-61: database closed the connection with code 4000 (unknown close code)")
+}
+
+func (suite *ErrorsTestSuite) TestWebsocketCloseErrorRetryable() {
+	for code, retryable := range map[int]bool{1000: true, 1001: true, 1002: false, 1006: true, 1008: false, 1009: false, 1011: false, 1012: true, 1013: true, 4000: false} {
+		suite.Equal(retryable, NewWebsocketCloseError(code, "").Retryable(), code)
+	}
+}
+
+func (suite *ErrorsTestSuite) TestBatchError() {
+	cause := fmt.Errorf("constraint violation")
+	err := NewBatchError(2, []int64{1, 3}, cause)
+	suite.EqualError(err, "E-EGOD-68: batch failed at index 2 after 2 successful executions: constraint violation")
+	suite.ErrorIs(err, cause)
+}
+
+func (suite *ErrorsTestSuite) TestDriverSaturatedError() {
+	suite.EqualError(NewQueueFullError(5), "E-EGOD-64: connection is saturated, 5 commands are already waiting for other commands to finish Use more connections or increase maxqueuedcommands.")
+	suite.EqualError(NewQueueTimeoutError(2*time.Second), "E-EGOD-79: connection is saturated, command waited longer than 2s for other commands to finish Use more connections or increase commandwaittimeout.")
+}
+
+func (suite *ErrorsTestSuite) TestNewLoginTimeout() {
+	suite.EqualError(NewLoginTimeout(10*time.Second), "E-EGOD-65: login did not complete within 10s Check the connection to the database or increase logintimeout.")
+}
+
+func (suite *ErrorsTestSuite) TestNewMissingParquetColumn() {
+	suite.EqualError(NewMissingParquetColumn("data.parquet", "NAME"), "E-EGOD-66: Parquet file 'data.parquet' has no column 'NAME' of the target table Import only the columns contained in the file by passing them to IntoTable.")
+}
+
+func (suite *ErrorsTestSuite) TestNewWarmUpExceedsPool() {
+	suite.EqualError(NewWarmUpExceedsPool(10, 4), "E-EGOD-67: cannot warm up 10 connections, the pool allows at most 4 open connections Increase the limit with SetMaxOpenConns or warm up fewer connections.")
+}
+
+func (suite *ErrorsTestSuite) TestNewEmptySecretFile() {
+	suite.EqualError(NewEmptySecretFile("/run/secrets/password"), "E-EGOD-69: secret file '/run/secrets/password' is empty Check that the secret is mounted and the file contains the password or token.")
+}
+
+func (suite *ErrorsTestSuite) TestNewServiceLookupFailed() {
+	suite.EqualError(NewServiceLookupFailed("_exasol._tcp.example.com", fmt.Errorf("no such host")), "E-EGOD-70: could not look up the SRV records of service '_exasol._tcp.example.com': 'no such host' Check the name of the service and the DNS configuration.")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidScanDestination() {
+	suite.EqualError(NewInvalidScanDestination("[]int"), "E-EGOD-71: invalid scan destination '[]int' Pass a pointer to a struct to ScanStruct and a pointer to a slice of structs or struct pointers to ScanSlice.")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidConsumerGroup() {
+	suite.EqualError(NewInvalidConnectionStringInvalidConsumerGroup("A;B"), "E-EGOD-72: invalid consumergroup value 'A;B', expected a regular identifier Use the name of the consumer group without quotes, e.g. 'BATCH'.")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringUnknownParam() {
+	suite.EqualError(NewInvalidConnectionStringUnknownParam("comperssion", "compression", []string{"compression", "user"}), "E-EGOD-73: unknown option 'comperssion' in connection string Did you mean 'compression'? Supported options are compression, user. Set strict=0 to accept unknown options.")
+	suite.EqualError(NewInvalidConnectionStringUnknownParam("custom", "", []string{"compression", "user"}), "E-EGOD-73: unknown option 'custom' in connection string Supported options are compression, user. Set strict=0 to accept unknown options.")
+}
+
+func (suite *ErrorsTestSuite) TestNewInvalidConnectionStringInvalidBoolParam() {
+	suite.EqualError(NewInvalidConnectionStringInvalidBoolParam("compression", "yes"), "E-EGOD-74: invalid 'compression' value 'yes', 0 or 1 expected")
+}
+
+func (suite *ErrorsTestSuite) TestNewUnsupportedFeature() {
+	suite.EqualError(NewUnsupportedFeature("consumer groups", "6.2.15", 1), "E-EGOD-75: feature 'consumer groups' is not supported by Exasol 6.2.15 with protocol version 1 Check with connection.Supports before using the feature or upgrade the database.")
+}
+
+func (suite *ErrorsTestSuite) TestNewMixedPlaceholders() {
+	suite.EqualError(NewMixedPlaceholders("colon"), "E-EGOD-51: statement mixes ? placeholders with placeholders of style 'colon' Use only placeholders of the configured placeholderstyle.")
+}
+
+func (suite *ErrorsTestSuite) TestNewMissingPlaceholderArgument() {
+	suite.EqualError(NewMissingPlaceholderArgument(":name"), "E-EGOD-52: no argument given for placeholder ':name'")
+}
+
+func (suite *ErrorsTestSuite) TestNewTokenRefreshFailed() {
+	suite.EqualError(NewTokenRefreshFailed(fmt.Errorf("mock error")), "E-EGOD-45: access token expired and a new token could not be obtained: mock error")
+}
+
+func (suite *ErrorsTestSuite) TestBadConnError() {
+	cause := fmt.Errorf("mock error")
+	err := NewBadConnError(cause)
+	suite.EqualError(err, "driver: bad connection: mock error")
+	suite.ErrorIs(err, driver.ErrBadConn)
+	suite.ErrorIs(err, cause)
+	var badConn *BadConnError
+	suite.ErrorAs(err, &badConn)
+	suite.Same(cause, badConn.Cause)
+}
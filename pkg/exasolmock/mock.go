@@ -0,0 +1,382 @@
+// Package exasolmock provides an in-process fake Exasol database for unit tests.
+//
+// The fake answers the websocket protocol used by the driver with programmable responses,
+// so applications can test their Exasol interactions without docker or network access:
+//
+//	mock := exasolmock.New()
+//	mock.ExpectStatement("SELECT .* FROM CUSTOMERS").WillReturnRows(exasolmock.NewRows("ID", "NAME").AddRow(1, "Alice"))
+//	database, err := sql.Open("exasolmock", mock.DSN())
+//
+// Libraries built on top of the driver can also check the protocol commands it emits: [Mock.ExpectPrepare] only
+// matches statements executed as prepared statements, [Mock.ExpectImport] checks the data uploaded by an import
+// of local files and [Mock.Commands] returns the commands received by the fake.
+package exasolmock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	exasol "github.com/exasol/exasol-driver-go"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/dsn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+)
+
+// DriverName is the name of the fake driver registered in [database/sql].
+const DriverName = "exasolmock"
+
+func init() {
+	sql.Register(DriverName, &mockDriver{})
+}
+
+var (
+	mocksMutex sync.Mutex
+	mocks      = map[string]*Mock{}
+	mockCount  int
+)
+
+// Mock is a fake Exasol database with programmable responses. It is safe for concurrent use.
+type Mock struct {
+	dsn             string
+	mutex           sync.Mutex
+	expectations    []*Expectation
+	sessionID       int
+	connector       *exasol.Connector
+	commands        []string
+	listener        net.Listener
+	transfers       map[int]net.Conn // Connections of imports and exports by the port sent in the proxy handshake
+	transferPort    int
+	releaseVersion  string
+	protocolVersion int
+}
+
+// New creates a new fake database and registers it for opening via [Mock.DSN].
+func New() *Mock {
+	mocksMutex.Lock()
+	defer mocksMutex.Unlock()
+	mockCount++
+	mock := &Mock{dsn: fmt.Sprintf("exasolmock_%d", mockCount), releaseVersion: "7.1.0", protocolVersion: 3}
+	dsnConfig, err := dsn.ParseDSN(exasol.NewConfig("sys", "exasol").Host("exasolmock").ClientName("exasolmock").String())
+	if err != nil {
+		panic(fmt.Errorf("exasolmock: invalid default configuration: %w", err))
+	}
+	config := dsn.ToInternalConfig(dsnConfig)
+	// Imports and exports of local files connect to the proxy of the fake instead of the database hosts
+	mock.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Errorf("exasolmock: failed to listen for local file transfers: %w", err))
+	}
+	mock.transfers = map[int]net.Conn{}
+	config.TransferHosts = "127.0.0.1"
+	config.Port = mock.listener.Addr().(*net.TCPAddr).Port
+	go mock.acceptTransfers()
+	mock.connector = exasol.NewConnector(config)
+	mock.connector.DialFunc = mock.dial
+	mocks[mock.dsn] = mock
+	return mock
+}
+
+// Close unregisters the fake database, so that new connections to its DSN fail, and stops accepting imports and
+// exports of local files. Open connections to the fake database are not closed.
+func (m *Mock) Close() error {
+	mocksMutex.Lock()
+	delete(mocks, m.dsn)
+	mocksMutex.Unlock()
+	return m.listener.Close()
+}
+
+// DSN returns the data source name for opening the fake database with driver [DriverName].
+func (m *Mock) DSN() string {
+	return m.dsn
+}
+
+// Connector returns an Exasol connector connected to the fake database, e.g. for use with [sql.OpenDB].
+func (m *Mock) Connector() *exasol.Connector {
+	return m.connector
+}
+
+// SetDatabaseVersion sets the release version and the protocol version reported at the login of new connections
+// (default: 7.1.0 and 3), e.g. for testing code that checks the features of the database with connection.Supports.
+func (m *Mock) SetDatabaseVersion(releaseVersion string, protocolVersion int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.releaseVersion = releaseVersion
+	m.protocolVersion = protocolVersion
+}
+
+// ExpectStatement adds an expectation for a statement matching the given regular expression.
+// Expectations are matched in the order they were added and each expectation is used once.
+func (m *Mock) ExpectStatement(sqlRegex string) *Expectation {
+	return m.expect(&Expectation{sqlRegex: regexp.MustCompile(sqlRegex)})
+}
+
+// ExpectPrepare adds an expectation for a statement matching the given regular expression that is created with
+// createPreparedStatement and run with executePreparedStatement. Unlike [Mock.ExpectStatement] it does not match
+// statements sent with a plain execute command, e.g. because the driver interpolated the parameters.
+func (m *Mock) ExpectPrepare(sqlRegex string) *Expectation {
+	return m.expect(&Expectation{sqlRegex: regexp.MustCompile(sqlRegex), prepared: true})
+}
+
+// ExpectImport adds an expectation for an IMPORT of local files uploading exactly the content of the given file.
+// Row separators at the end of the data are ignored. The import returns the number of uploaded rows by default.
+// ExpectImport panics if the file can't be read.
+func (m *Mock) ExpectImport(file string) *Expectation {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		panic(fmt.Errorf("exasolmock: failed to read expected import file: %w", err))
+	}
+	data = trimRowSeparators(data)
+	rows := 0
+	if len(data) > 0 {
+		rows = strings.Count(string(data), "\n") + 1
+	}
+	return m.expect(&Expectation{sqlRegex: importRegex, importFile: file, importData: data, rowsAffected: rows})
+}
+
+func (m *Mock) expect(expectation *Expectation) *Expectation {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.expectations = append(m.expectations, expectation)
+	return expectation
+}
+
+// Commands returns the names of the protocol commands received by all connections in the order they were received,
+// e.g. login, createPreparedStatement, executePreparedStatement and closePreparedStatement.
+// The unnamed message sending the credentials after login is omitted.
+func (m *Mock) Commands() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]string(nil), m.commands...)
+}
+
+func (m *Mock) recordCommand(command string) {
+	if command == "" {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.commands = append(m.commands, command)
+}
+
+// ExpectationsWereMet returns an error if any expectation was not used.
+func (m *Mock) ExpectationsWereMet() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	var missing []string
+	for _, expectation := range m.expectations {
+		if !expectation.triggered {
+			missing = append(missing, expectation.String())
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("exasolmock: expected statements not executed: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// match returns the first unused expectation matching the given statement or nil if none matches.
+func (m *Mock) match(statement statement) *Expectation {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, expectation := range m.expectations {
+		if !expectation.triggered && expectation.matches(statement) {
+			expectation.triggered = true
+			return expectation
+		}
+	}
+	return nil
+}
+
+func (m *Mock) nextSessionID() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sessionID++
+	return m.sessionID
+}
+
+func (m *Mock) versions() (string, int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.releaseVersion, m.protocolVersion
+}
+
+func (m *Mock) dial(_ context.Context, _ url.URL) (wsconn.WebsocketConnection, error) {
+	return newServer(m), nil
+}
+
+// acceptTransfers answers the proxy handshake of imports and exports. The port sent to the driver identifies
+// the connection in the statement, e.g. CSV AT 'http://127.0.0.1:1' FILE 'data.csv'.
+func (m *Mock) acceptTransfers() {
+	for {
+		conn, err := m.listener.Accept()
+		if err != nil {
+			return
+		}
+		go m.startTransfer(conn)
+	}
+}
+
+func (m *Mock) startTransfer(conn net.Conn) {
+	var magicWords [3]uint32
+	if err := binary.Read(conn, binary.LittleEndian, &magicWords); err != nil {
+		conn.Close()
+		return
+	}
+	m.mutex.Lock()
+	m.transferPort++
+	port := m.transferPort
+	m.transfers[port] = conn
+	m.mutex.Unlock()
+	reply := struct {
+		Start uint32
+		Port  uint32
+		Host  [16]byte
+	}{Port: uint32(port)}
+	copy(reply.Host[:], "127.0.0.1")
+	if err := binary.Write(conn, binary.LittleEndian, reply); err != nil {
+		conn.Close()
+	}
+}
+
+// takeTransfer returns the connection of the import or export with the given port sent in the proxy handshake.
+func (m *Mock) takeTransfer(port int) (net.Conn, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	conn, ok := m.transfers[port]
+	delete(m.transfers, port)
+	return conn, ok
+}
+
+// Expectation describes the response of the fake database for a statement.
+type Expectation struct {
+	sqlRegex     *regexp.Regexp
+	args         [][]any
+	rows         *Rows
+	rowsAffected int
+	exception    *types.Exception
+	delay        time.Duration
+	triggered    bool
+	prepared     bool   // Matches only executions of prepared statements
+	importFile   string // Expected file of an import, empty for other statements
+	importData   []byte
+}
+
+// statement is a statement received by the fake database.
+type statement struct {
+	query    string
+	args     [][]any
+	prepared bool
+	imported []byte // Data uploaded by an import of local files, nil for other statements
+}
+
+var importRegex = regexp.MustCompile(`(?is)^\s*IMPORT\s`)
+
+// WithArgs restricts the expectation to prepared statements executed with the given arguments.
+// Pass multiple rows of arguments for batch executions.
+func (e *Expectation) WithArgs(args ...any) *Expectation {
+	e.args = append(e.args, args)
+	return e
+}
+
+// WillReturnRows lets the statement return the given result set.
+func (e *Expectation) WillReturnRows(rows *Rows) *Expectation {
+	e.rows = rows
+	return e
+}
+
+// WillReturnRowsAffected lets the statement return the given number of affected rows.
+func (e *Expectation) WillReturnRowsAffected(rowsAffected int) *Expectation {
+	e.rowsAffected = rowsAffected
+	return e
+}
+
+// WillReturnError lets the statement fail with the given SQL code and message.
+func (e *Expectation) WillReturnError(sqlCode, text string) *Expectation {
+	e.exception = &types.Exception{SQLCode: sqlCode, Text: text}
+	return e
+}
+
+// WillDelayFor delays the response of the statement by the given duration, e.g. for testing timeouts.
+func (e *Expectation) WillDelayFor(delay time.Duration) *Expectation {
+	e.delay = delay
+	return e
+}
+
+// String describes the expected statement, e.g. in the error of [Mock.ExpectationsWereMet].
+func (e *Expectation) String() string {
+	switch {
+	case e.importFile != "":
+		return "IMPORT of " + e.importFile
+	case e.prepared:
+		return "prepared " + e.sqlRegex.String()
+	default:
+		return e.sqlRegex.String()
+	}
+}
+
+func (e *Expectation) matches(statement statement) bool {
+	if !e.sqlRegex.MatchString(statement.query) || (e.prepared && !statement.prepared) {
+		return false
+	}
+	if e.importFile != "" && (statement.imported == nil || string(e.importData) != string(trimRowSeparators(statement.imported))) {
+		return false
+	}
+	if e.args == nil {
+		return true
+	}
+	return string(wsconn.JsonMarshall(e.args)) == string(wsconn.JsonMarshall(statement.args))
+}
+
+// trimRowSeparators removes the row separators at the end of CSV data, which the driver adds to files without them.
+func trimRowSeparators(data []byte) []byte {
+	return []byte(strings.TrimRight(string(data), "\r\n"))
+}
+
+// Rows is a result set returned by the fake database.
+type Rows struct {
+	columns []types.SqlQueryColumn
+	rows    [][]any
+}
+
+// NewRows creates an empty result set with the given columns of type VARCHAR.
+func NewRows(columns ...string) *Rows {
+	sqlColumns := make([]types.SqlQueryColumn, 0, len(columns))
+	for _, column := range columns {
+		sqlColumns = append(sqlColumns, types.SqlQueryColumn{Name: column, DataType: types.SqlQueryColumnType{Type: "VARCHAR"}})
+	}
+	return NewRowsWithColumns(sqlColumns...)
+}
+
+// NewRowsWithColumns creates an empty result set with the given column metadata.
+func NewRowsWithColumns(columns ...types.SqlQueryColumn) *Rows {
+	return &Rows{columns: columns}
+}
+
+// AddRow adds a row with one value per column to the result set.
+func (r *Rows) AddRow(values ...any) *Rows {
+	r.rows = append(r.rows, values)
+	return r
+}
+
+// mockDriver opens connections to mocks registered with [New].
+type mockDriver struct{}
+
+func (d *mockDriver) Open(name string) (driver.Conn, error) {
+	mocksMutex.Lock()
+	mock, ok := mocks[name]
+	mocksMutex.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("exasolmock: no mock registered for DSN %q", name)
+	}
+	return mock.connector.Connect(context.Background())
+}
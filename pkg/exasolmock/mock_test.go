@@ -0,0 +1,528 @@
+package exasolmock
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	exasol "github.com/exasol/exasol-driver-go"
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type MockTestSuite struct {
+	suite.Suite
+	mock     *Mock
+	database *sql.DB
+}
+
+func TestMockSuite(t *testing.T) {
+	suite.Run(t, new(MockTestSuite))
+}
+
+func (suite *MockTestSuite) SetupTest() {
+	suite.mock = New()
+	database, err := sql.Open(DriverName, suite.mock.DSN())
+	suite.NoError(err)
+	suite.database = database
+}
+
+func (suite *MockTestSuite) TearDownTest() {
+	suite.NoError(suite.database.Close())
+	suite.NoError(suite.mock.Close())
+}
+
+func (suite *MockTestSuite) TestQuery() {
+	suite.mock.ExpectStatement("SELECT .* FROM CUSTOMERS").WillReturnRows(NewRows("ID", "NAME").AddRow(1, "Alice").AddRow(2, "Bob"))
+	rows, err := suite.database.Query("SELECT ID, NAME FROM CUSTOMERS")
+	suite.NoError(err)
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var id int
+		var name string
+		suite.NoError(rows.Scan(&id, &name))
+		names = append(names, name)
+	}
+	suite.Equal([]string{"Alice", "Bob"}, names)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestExecPreparedStatementWithArgs() {
+	suite.mock.ExpectStatement("INSERT INTO CUSTOMERS").WithArgs(1, "Alice").WillReturnRowsAffected(1)
+	result, err := suite.database.Exec("INSERT INTO CUSTOMERS VALUES (?, ?)", 1, "Alice")
+	suite.NoError(err)
+	rowsAffected, err := result.RowsAffected()
+	suite.NoError(err)
+	suite.Equal(int64(1), rowsAffected)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestExecWithWrongArgs() {
+	suite.mock.ExpectStatement("INSERT INTO CUSTOMERS").WithArgs(1, "Alice")
+	_, err := suite.database.Exec("INSERT INTO CUSTOMERS VALUES (?, ?)", 2, "Bob")
+	suite.ErrorContains(err, "exasolmock: unexpected statement")
+	suite.EqualError(suite.mock.ExpectationsWereMet(), "exasolmock: expected statements not executed: INSERT INTO CUSTOMERS")
+}
+
+func (suite *MockTestSuite) TestExecBatch() {
+	suite.mock.ExpectStatement("DELETE FROM A").WillReturnRowsAffected(2)
+	suite.mock.ExpectStatement("DELETE FROM B").WillReturnRowsAffected(3)
+	conn := suite.conn()
+	result, err := exasol.ExecBatch(context.Background(), conn, []string{"DELETE FROM A", "DELETE FROM B"})
+	suite.NoError(err)
+	suite.Equal([]int64{2, 3}, result.RowCounts())
+	rowsAffected, err := result.RowsAffected()
+	suite.NoError(err)
+	suite.Equal(int64(5), rowsAffected)
+}
+
+func (suite *MockTestSuite) TestExecBatchReportsFailedStatement() {
+	suite.mock.ExpectStatement("DELETE FROM A").WillReturnRowsAffected(2)
+	suite.mock.ExpectStatement("DELETE FROM B").WillReturnError("42000", "object B not found")
+	conn := suite.conn()
+	_, err := exasol.ExecBatch(context.Background(), conn, []string{"DELETE FROM A", "DELETE FROM B", "DELETE FROM C"})
+	var batchError *errors.BatchError
+	suite.ErrorAs(err, &batchError)
+	suite.Equal(1, batchError.Index)
+	suite.Equal([]int64{2}, batchError.RowCounts)
+	suite.ErrorContains(err, "object B not found")
+}
+
+func (suite *MockTestSuite) TestExecBatchWithScriptDefinition() {
+	script := "CREATE LUA SCRIPT s AS\n  query([[DELETE FROM t]]);\n  output('done');"
+	suite.mock.ExpectStatement("^" + regexp.QuoteMeta(script) + "$")
+	suite.mock.ExpectStatement("^EXECUTE SCRIPT s$").WillReturnRowsAffected(1)
+	statements := exasol.SplitStatements(script + "\n/\nEXECUTE SCRIPT s;\n")
+	result, err := exasol.ExecBatch(context.Background(), suite.conn(), statements)
+	suite.NoError(err)
+	suite.Equal([]int64{0, 1}, result.RowCounts())
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestExecPreparedBatch() {
+	suite.mock.ExpectStatement("INSERT INTO CUSTOMERS").WithArgs(1, "Alice").WithArgs(2, "Bob").WillReturnRowsAffected(2)
+	suite.mock.ExpectStatement("INSERT INTO CUSTOMERS").WithArgs(3, "Carol").WillReturnRowsAffected(1)
+	conn := suite.conn()
+	rows := [][]any{{1, "Alice"}, {2, "Bob"}, {3, "Carol"}}
+	result, err := exasol.ExecPreparedBatch(context.Background(), conn, "INSERT INTO CUSTOMERS VALUES (?, ?)", rows, 2)
+	suite.NoError(err)
+	suite.Equal([]int64{2, 1}, result.RowCounts())
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestExecPreparedBatchReportsFailedChunk() {
+	suite.mock.ExpectStatement("INSERT INTO CUSTOMERS").WithArgs(1, "Alice").WillReturnRowsAffected(1)
+	suite.mock.ExpectStatement("INSERT INTO CUSTOMERS").WithArgs(2, "Bob").WillReturnError("27001", "constraint violation")
+	conn := suite.conn()
+	rows := [][]any{{1, "Alice"}, {2, "Bob"}, {3, "Carol"}}
+	_, err := exasol.ExecPreparedBatch(context.Background(), conn, "INSERT INTO CUSTOMERS VALUES (?, ?)", rows, 1)
+	var batchError *errors.BatchError
+	suite.ErrorAs(err, &batchError)
+	suite.Equal(1, batchError.Index)
+	suite.Equal([]int64{1}, batchError.RowCounts)
+}
+
+func (suite *MockTestSuite) TestExecPreparedBatchWithWrongRowLength() {
+	_, err := exasol.ExecPreparedBatch(context.Background(), suite.conn(), "INSERT INTO CUSTOMERS VALUES (?, ?)", [][]any{{1}}, 0)
+	suite.ErrorIs(err, errors.ErrInvalidValuesCount)
+}
+
+func (suite *MockTestSuite) TestExecPreparedBatchImportsLargeChunks() {
+	suite.mock.Connector().Config.BatchImportRows = 2
+	suite.mock.ExpectImport(suite.writeFile("expected.csv", "1,Alice\n2,\n3,\"Carol, Jr.\"\n"))
+	suite.mock.ExpectPrepare("INSERT INTO CUSTOMERS").WithArgs(4, "Dave").WillReturnRowsAffected(1)
+	rows := [][]any{{1, "Alice"}, {2, nil}, {3, "Carol, Jr."}, {4, "Dave"}}
+	result, err := exasol.ExecPreparedBatch(context.Background(), suite.conn(), "INSERT INTO CUSTOMERS (ID, NAME) VALUES (?, ?)", rows, 3)
+	suite.Require().NoError(err)
+	suite.Equal([]int64{3, 1}, result.RowCounts())
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestExpectPrepare() {
+	suite.mock.ExpectPrepare("INSERT INTO CUSTOMERS").WithArgs(1, "Alice").WillReturnRowsAffected(1)
+	_, err := suite.database.Exec("INSERT INTO CUSTOMERS VALUES (?, ?)", 1, "Alice")
+	suite.NoError(err)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+	suite.Equal([]string{"login", "createPreparedStatement", "executePreparedStatement", "closePreparedStatement"}, suite.mock.Commands())
+}
+
+func (suite *MockTestSuite) TestExpectPrepareRejectsPlainExecute() {
+	suite.mock.ExpectPrepare("DELETE FROM CUSTOMERS")
+	_, err := suite.database.Exec("DELETE FROM CUSTOMERS")
+	suite.ErrorContains(err, `exasolmock: unexpected statement "DELETE FROM CUSTOMERS"`)
+	suite.EqualError(suite.mock.ExpectationsWereMet(), "exasolmock: expected statements not executed: prepared DELETE FROM CUSTOMERS")
+}
+
+func (suite *MockTestSuite) TestExpectImport() {
+	file := suite.writeFile("customers.csv", "1,Alice\n2,Bob")
+	suite.mock.ExpectImport(file)
+	result, err := suite.database.Exec("IMPORT INTO CUSTOMERS FROM LOCAL CSV FILE '" + file + "'")
+	suite.Require().NoError(err)
+	rowsAffected, err := result.RowsAffected()
+	suite.NoError(err)
+	suite.Equal(int64(2), rowsAffected)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestExpectImportWithDifferentData() {
+	suite.mock.ExpectImport(suite.writeFile("expected.csv", "1,Alice\n"))
+	file := suite.writeFile("actual.csv", "2,Bob\n")
+	_, err := suite.database.Exec("IMPORT INTO CUSTOMERS FROM LOCAL CSV FILE '" + file + "'")
+	suite.ErrorContains(err, "exasolmock: unexpected import")
+	suite.ErrorContains(suite.mock.ExpectationsWereMet(), "IMPORT of ")
+}
+
+func (suite *MockTestSuite) TestExpectImportOfMissingFile() {
+	suite.Panics(func() { suite.mock.ExpectImport(filepath.Join(suite.T().TempDir(), "missing.csv")) })
+}
+
+func (suite *MockTestSuite) writeFile(name, content string) string {
+	path := filepath.Join(suite.T().TempDir(), name)
+	suite.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func (suite *MockTestSuite) conn() *sql.Conn {
+	conn, err := suite.database.Conn(context.Background())
+	suite.NoError(err)
+	suite.T().Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func (suite *MockTestSuite) TestError() {
+	suite.mock.ExpectStatement("DROP TABLE").WillReturnError("42000", "object T not found")
+	_, err := suite.database.Exec("DROP TABLE T")
+	suite.EqualError(err, "E-EGOD-11: execution failed with SQL error code '42000' and message 'object T not found'")
+}
+
+func (suite *MockTestSuite) TestUnexpectedStatement() {
+	_, err := suite.database.Exec("DROP TABLE T")
+	suite.ErrorContains(err, `exasolmock: unexpected statement "DROP TABLE T"`)
+}
+
+func (suite *MockTestSuite) TestDelay() {
+	suite.mock.ExpectStatement("SELECT").WillDelayFor(100 * time.Millisecond)
+	start := time.Now()
+	_, err := suite.database.Exec("SELECT 1")
+	suite.NoError(err)
+	suite.GreaterOrEqual(time.Since(start), 100*time.Millisecond)
+}
+
+func (suite *MockTestSuite) TestConnector() {
+	database := sql.OpenDB(suite.mock.Connector())
+	defer database.Close()
+	suite.NoError(database.PingContext(context.Background()))
+}
+
+func (suite *MockTestSuite) TestWarmUp() {
+	suite.database.SetMaxIdleConns(3)
+	readiness := &exasol.Readiness{}
+	err := exasol.WarmUpWithOptions(context.Background(), suite.database, 3, exasol.WarmUpOptions{SpreadHosts: true, Readiness: readiness})
+	suite.NoError(err)
+	suite.True(readiness.Ready())
+	suite.Equal(3, suite.database.Stats().Idle)
+}
+
+func (suite *MockTestSuite) TestHealthCheck() {
+	suite.mock.ExpectStatement(`^SELECT 1$`).WillReturnRows(NewRows("1").AddRow(1))
+	check, err := exasol.NewHealthCheck(suite.database, "")
+	suite.NoError(err)
+	status := check.Check(context.Background())
+	suite.True(status.Healthy, status.Error)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestSessionInfo() {
+	conn, err := suite.database.Conn(context.Background())
+	suite.NoError(err)
+	defer conn.Close()
+	info, err := exasol.GetSessionInfo(conn)
+	suite.NoError(err)
+	suite.Equal(connection.SessionInfo{SessionID: 1, ProtocolVersion: 3, DatabaseVersion: "7.1.0", DatabaseName: "EXASOLMOCK"}, info)
+}
+
+func (suite *MockTestSuite) TestSessionDetails() {
+	column := func(name, dataType string) types.SqlQueryColumn {
+		return types.SqlQueryColumn{Name: name, DataType: types.SqlQueryColumnType{Type: dataType}}
+	}
+	suite.mock.ExpectStatement(`FROM EXA_ALL_SESSIONS WHERE SESSION_ID = CURRENT_SESSION$`).WillReturnRows(NewRowsWithColumns(
+		column("USER_NAME", "VARCHAR"), column("LOGIN_TIME", "TIMESTAMP"), column("CLIENT", "VARCHAR"), column("DRIVER", "VARCHAR"),
+		column("HOST", "VARCHAR"), column("OS_USER", "VARCHAR"), column("STATUS", "VARCHAR"), column("QUERY_TIMEOUT", "DECIMAL"),
+		column("CONSUMER_GROUP", "VARCHAR"), column("TEMP_DB_RAM", "DECIMAL"),
+	).AddRow("SYS", "2023-01-02 10:11:12.000000", "exasolmock", "Go driver", "10.0.0.1", "alice", "EXECUTE SQL", 60, "BATCH", 12))
+	details, err := exasol.GetSessionDetails(context.Background(), suite.conn())
+	suite.NoError(err)
+	suite.Equal(exasol.SessionDetails{
+		SessionInfo: connection.SessionInfo{SessionID: 1, ProtocolVersion: 3, DatabaseVersion: "7.1.0", DatabaseName: "EXASOLMOCK"},
+		User:        "SYS", LoginTime: time.Date(2023, 1, 2, 10, 11, 12, 0, time.UTC), Client: "exasolmock", Driver: "Go driver",
+		Host: "10.0.0.1", OSUser: "alice", Status: "EXECUTE SQL", QueryTimeout: 60, ConsumerGroup: "BATCH", TempDBRAM: 12,
+	}, details)
+}
+
+func (suite *MockTestSuite) TestSessionDetailsWithoutConsumerGroups() {
+	suite.mock.SetDatabaseVersion("6.2.15", 1)
+	suite.mock.ExpectStatement(`^SELECT USER_NAME, LOGIN_TIME, CLIENT, DRIVER, HOST, OS_USER, STATUS, QUERY_TIMEOUT, TEMP_DB_RAM FROM`).
+		WillReturnRows(NewRows("USER_NAME", "LOGIN_TIME", "CLIENT", "DRIVER", "HOST", "OS_USER", "STATUS", "QUERY_TIMEOUT", "TEMP_DB_RAM").
+			AddRow("SYS", nil, "exasolmock", "Go driver", "10.0.0.1", "alice", "IDLE", 0, 0))
+	details, err := exasol.GetSessionDetails(context.Background(), suite.conn())
+	suite.NoError(err)
+	suite.Equal("SYS", details.User)
+	suite.Empty(details.ConsumerGroup)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestServerVersion() {
+	suite.mock.SetDatabaseVersion("8.24.0", 4)
+	conn := suite.conn()
+	version, err := exasol.GetServerVersion(conn)
+	suite.NoError(err)
+	suite.Equal(connection.Version{Major: 8, Minor: 24}, version)
+	supported, err := exasol.Supports(conn, connection.FeatureSnapshotMode)
+	suite.NoError(err)
+	suite.True(supported)
+}
+
+func (suite *MockTestSuite) TestFeatureNotSupportedByOldDatabase() {
+	suite.mock.SetDatabaseVersion("7.0.18", 3)
+	supported, err := exasol.Supports(suite.conn(), connection.FeatureSnapshotMode)
+	suite.NoError(err)
+	suite.False(supported)
+}
+
+func (suite *MockTestSuite) TestQueryWithListParam() {
+	suite.mock.ExpectStatement(`ID IN \(\?, \?, \?\) AND NAME = \?`).WithArgs(1, 2, 3, "Alice").WillReturnRows(NewRows("NAME").AddRow("Alice"))
+	rows, err := suite.database.Query("SELECT NAME FROM CUSTOMERS WHERE ID IN (?) AND NAME = ?", exasol.In([]int{1, 2, 3}), "Alice")
+	suite.Require().NoError(err)
+	defer rows.Close()
+	suite.True(rows.Next())
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestWarningsEmptyWithoutWarnings() {
+	conn, err := suite.database.Conn(context.Background())
+	suite.NoError(err)
+	defer conn.Close()
+	warnings, err := exasol.GetWarnings(conn)
+	suite.NoError(err)
+	suite.Empty(warnings)
+}
+
+func (suite *MockTestSuite) TestShutdownWaitsForOpenResultSets() {
+	database := sql.OpenDB(suite.mock.Connector())
+	defer database.Close()
+	suite.mock.ExpectStatement("SELECT").WillReturnRows(NewRows("VALUE").AddRow("a"))
+	rows, err := database.Query("SELECT 'a'")
+	suite.NoError(err)
+
+	done := make(chan error, 1)
+	go func() { done <- suite.mock.Connector().Shutdown(context.Background()) }()
+	select {
+	case <-done:
+		suite.Fail("shutdown did not wait for the result set")
+	case <-time.After(20 * time.Millisecond):
+	}
+	suite.NoError(rows.Close())
+	suite.NoError(<-done)
+
+	_, err = database.Exec("SELECT 'b'")
+	suite.ErrorIs(err, errors.ErrShutdown)
+}
+
+func (suite *MockTestSuite) TestQueryInterceptor() {
+	connector := suite.mock.Connector().WithQueryInterceptor(func(ctx context.Context, query string) (string, error) {
+		return query + " LIMIT 10", nil
+	})
+	database := sql.OpenDB(connector)
+	defer database.Close()
+	suite.mock.ExpectStatement("SELECT .* LIMIT 10").WillReturnRows(NewRows("VALUE").AddRow("a"))
+	var value string
+	suite.NoError(database.QueryRow("SELECT VALUE FROM T").Scan(&value))
+	suite.Equal("a", value)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestQueryTag() {
+	suite.mock.ExpectStatement(`^/\* job=nightly-etl \*/ SELECT 1$`).WillReturnRows(NewRows("1").AddRow(1))
+	suite.mock.ExpectPrepare(`^/\* job=nightly-etl \*/ INSERT INTO T VALUES \(\?\)$`).WithArgs(1).WillReturnRowsAffected(1)
+	ctx := exasol.WithQueryTag(context.Background(), "job=nightly-etl")
+	var value int
+	suite.NoError(suite.database.QueryRowContext(ctx, "SELECT 1").Scan(&value))
+	_, err := suite.database.ExecContext(ctx, "INSERT INTO T VALUES (?)", 1)
+	suite.NoError(err)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestConsumerGroup() {
+	suite.mock.Connector().Config.ConsumerGroup = "BATCH"
+	suite.mock.ExpectStatement(`^ALTER SESSION SET CONSUMER_GROUP = BATCH$`)
+	suite.NoError(suite.database.Ping())
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestConsumerGroupNotPermitted() {
+	suite.mock.Connector().Config.ConsumerGroup = "BATCH"
+	suite.mock.ExpectStatement(`^ALTER SESSION SET CONSUMER_GROUP = BATCH$`).WillReturnError("42500", "insufficient privileges for consumer group BATCH")
+	suite.ErrorContains(suite.database.Ping(), "insufficient privileges for consumer group BATCH")
+}
+
+func (suite *MockTestSuite) TestConsumerGroupNotSupported() {
+	suite.mock.SetDatabaseVersion("6.2.15", 1)
+	suite.mock.Connector().Config.ConsumerGroup = "BATCH"
+	suite.ErrorContains(suite.database.Ping(), "E-EGOD-75: feature 'consumer groups' is not supported by Exasol 6.2.15")
+	suite.NotContains(suite.mock.Commands(), "execute")
+}
+
+func (suite *MockTestSuite) TestOpenUnknownDSN() {
+	database, err := sql.Open(DriverName, "unknown")
+	suite.NoError(err)
+	suite.EqualError(database.Ping(), `exasolmock: no mock registered for DSN "unknown"`)
+}
+
+func (suite *MockTestSuite) TestCompression() {
+	suite.mock.Connector().Config.Compression = true
+	suite.mock.ExpectStatement("SELECT").WillReturnRows(NewRows("VALUE").AddRow("a"))
+	var value string
+	suite.NoError(suite.database.QueryRow("SELECT 'a'").Scan(&value))
+	suite.Equal("a", value)
+}
+
+func (suite *MockTestSuite) TestDryRun() {
+	description, err := exasol.DryRun(context.Background(), suite.database, "SELECT * FROM CUSTOMERS WHERE ID = ? AND NAME = ?")
+	suite.NoError(err)
+	suite.Len(description.Parameters, 2)
+	suite.Empty(description.Columns)
+	suite.Equal([]string{"login", "createPreparedStatement", "closePreparedStatement"}, suite.mock.Commands())
+}
+
+func (suite *MockTestSuite) TestExplain() {
+	suite.mock.ExpectStatement(`^SELECT \* FROM CUSTOMERS WHERE ID = \?$`).WithArgs(42)
+	suite.expectProfile(NewRows("STMT_ID", "COMMAND_NAME", "PART_ID", "PART_NAME", "PART_INFO", "OBJECT_SCHEMA", "OBJECT_NAME",
+		"OBJECT_ROWS", "OUT_ROWS", "DURATION", "CPU", "TEMP_DB_RAM_PEAK", "PERSISTENT_DB_RAM_PEAK", "REMARKS").
+		AddRow(6, "SELECT", 1, "COMPILE / EXECUTE", nil, nil, nil, nil, nil, 0.25, 12.5, 1.5, 0, nil).
+		AddRow(6, "SELECT", 2, "SCAN", nil, "SHOP", "CUSTOMERS", 1000, 1, 0.5, 99.5, 2, 4.5, "index"))
+	plan, err := exasol.Explain(context.Background(), suite.conn(), "SELECT * FROM CUSTOMERS WHERE ID = ?", 42)
+	suite.NoError(err)
+	suite.Equal(&exasol.ProfileReport{StatementID: 6, CommandName: "SELECT", Duration: 750 * time.Millisecond, Parts: []exasol.ProfilePart{
+		{PartID: 1, PartName: "COMPILE / EXECUTE", Duration: 250 * time.Millisecond, CPU: 12.5, TempDBRAMPeak: 1.5},
+		{PartID: 2, PartName: "SCAN", ObjectSchema: "SHOP", ObjectName: "CUSTOMERS", ObjectRows: 1000, OutRows: 1,
+			Duration: 500 * time.Millisecond, CPU: 99.5, TempDBRAMPeak: 2, PersistentDBRAMPeak: 4.5, Remarks: "index"},
+	}}, plan)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestExplainRejectsOtherStatements() {
+	_, err := exasol.Explain(context.Background(), suite.conn(), "DELETE FROM CUSTOMERS")
+	suite.ErrorIs(err, errors.ErrExplainRequiresQuery)
+	suite.NotContains(suite.mock.Commands(), "execute")
+}
+
+func (suite *MockTestSuite) TestExplainVirtual() {
+	suite.mock.ExpectStatement(`^EXPLAIN VIRTUAL SELECT \* FROM VS\.CUSTOMERS$`).WillReturnRows(
+		NewRows("PUSHDOWN_ID", "PUSHDOWN_INVOLVED_TABLES", "PUSHDOWN_SQL", "PUSHDOWN_JSON").
+			AddRow(1, "CUSTOMERS", "SELECT * FROM CUSTOMERS", `{"type":"pushdown"}`).
+			AddRow(2, nil, "SELECT 1", nil))
+	plans, err := exasol.ExplainVirtual(context.Background(), suite.database, "SELECT * FROM VS.CUSTOMERS")
+	suite.NoError(err)
+	suite.Equal([]exasol.PushdownPlan{
+		{PushdownID: 1, InvolvedTables: "CUSTOMERS", PushdownSQL: "SELECT * FROM CUSTOMERS", PushdownJSON: `{"type":"pushdown"}`},
+		{PushdownID: 2, PushdownSQL: "SELECT 1"},
+	}, plans)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestExplainVirtualOnReadOnlyConnection() {
+	suite.mock.Connector().Config.ReadOnly = true
+	suite.mock.ExpectStatement(`^EXPLAIN VIRTUAL SELECT`).WillReturnRows(
+		NewRows("PUSHDOWN_ID", "PUSHDOWN_INVOLVED_TABLES", "PUSHDOWN_SQL", "PUSHDOWN_JSON").AddRow(1, "T", "SELECT 1", nil))
+	plans, err := exasol.ExplainVirtual(context.Background(), suite.database, "SELECT * FROM VS.T")
+	suite.NoError(err)
+	suite.Len(plans, 1)
+}
+
+// expectProfile expects the statements of exasol.Profile around the profiled statement, which must be expected before.
+// The statement ID before the profiled statement is 5.
+func (suite *MockTestSuite) expectProfile(profile *Rows) {
+	suite.mock.ExpectStatement(`^ALTER SESSION SET PROFILE = 'ON'$`)
+	suite.mock.ExpectStatement(`^SELECT CURRENT_STATEMENT$`).WillReturnRows(NewRows("CURRENT_STATEMENT").AddRow(5))
+	suite.mock.ExpectStatement(`^ALTER SESSION SET PROFILE = 'OFF'$`)
+	suite.mock.ExpectStatement(`^FLUSH STATISTICS$`)
+	suite.mock.ExpectStatement(`FROM EXA_STATISTICS\.EXA_USER_PROFILE_LAST_DAY`).WithArgs(5).WillReturnRows(profile)
+}
+
+func (suite *MockTestSuite) TestProfile() {
+	suite.mock.ExpectStatement(`^UPDATE CUSTOMERS SET NAME = \? WHERE ID = \?$`).WithArgs("Bob", 7).WillReturnRowsAffected(1)
+	suite.expectProfile(NewRows("STMT_ID", "COMMAND_NAME", "PART_ID", "PART_NAME", "PART_INFO", "OBJECT_SCHEMA", "OBJECT_NAME",
+		"OBJECT_ROWS", "OUT_ROWS", "DURATION", "CPU", "TEMP_DB_RAM_PEAK", "PERSISTENT_DB_RAM_PEAK", "REMARKS").
+		AddRow(6, "UPDATE", 1, "COMPILE / EXECUTE", "on replicated table", nil, nil, nil, nil, 0.001, nil, nil, nil, nil).
+		AddRow(6, "UPDATE", 2, "UPDATE", nil, "SHOP", "CUSTOMERS", 500, 1, 1.5, 50, 0.5, 1, nil))
+	report, err := exasol.Profile(context.Background(), suite.conn(), "UPDATE CUSTOMERS SET NAME = ? WHERE ID = ?", "Bob", 7)
+	suite.NoError(err)
+	suite.Equal(&exasol.ProfileReport{StatementID: 6, CommandName: "UPDATE", Duration: 1501 * time.Millisecond, Parts: []exasol.ProfilePart{
+		{PartID: 1, PartName: "COMPILE / EXECUTE", PartInfo: "on replicated table", Duration: time.Millisecond},
+		{PartID: 2, PartName: "UPDATE", ObjectSchema: "SHOP", ObjectName: "CUSTOMERS", ObjectRows: 500, OutRows: 1,
+			Duration: 1500 * time.Millisecond, CPU: 50, TempDBRAMPeak: 0.5, PersistentDBRAMPeak: 1},
+	}}, report)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestProfileWithoutProfilingInformation() {
+	suite.mock.ExpectStatement(`^SELECT 1$`).WillReturnRows(NewRows("1").AddRow(1))
+	suite.expectProfile(NewRows("STMT_ID"))
+	_, err := exasol.Profile(context.Background(), suite.conn(), "SELECT 1")
+	suite.ErrorIs(err, errors.ErrProfileNotFound)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestProfileDisablesProfilingWhenStatementFails() {
+	suite.mock.ExpectStatement(`^ALTER SESSION SET PROFILE = 'ON'$`)
+	suite.mock.ExpectStatement(`^SELECT CURRENT_STATEMENT$`).WillReturnRows(NewRows("CURRENT_STATEMENT").AddRow(5))
+	suite.mock.ExpectStatement(`^DROP TABLE T$`).WillReturnError("42000", "object T not found")
+	suite.mock.ExpectStatement(`^ALTER SESSION SET PROFILE = 'OFF'$`)
+	_, err := exasol.Profile(context.Background(), suite.conn(), "DROP TABLE T")
+	suite.ErrorContains(err, "object T not found")
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestCloneSession() {
+	suite.mock.Connector().WithQueryInterceptor(func(ctx context.Context, query string) (string, error) {
+		return query + " /* tenant */", nil
+	})
+	suite.mock.ExpectStatement(`^DELETE FROM T /\* tenant \*/$`).WillReturnRowsAffected(3)
+	clone, err := exasol.CloneSession(context.Background(), suite.conn())
+	suite.NoError(err)
+	defer clone.Close()
+
+	result, err := clone.Exec("DELETE FROM T")
+	suite.NoError(err)
+	rowsAffected, err := result.RowsAffected()
+	suite.NoError(err)
+	suite.Equal(int64(3), rowsAffected)
+	suite.Equal(uint64(2), suite.mock.Connector().Stats().Logins)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MockTestSuite) TestCloseUnregistersMock() {
+	mock := New()
+	suite.NoError(mock.Close())
+	database, err := sql.Open(DriverName, mock.DSN())
+	suite.NoError(err)
+	defer database.Close()
+	suite.EqualError(database.Ping(), fmt.Sprintf("exasolmock: no mock registered for DSN %q", mock.DSN()))
+	other := New()
+	defer other.Close()
+	suite.NotEqual(mock.DSN(), other.DSN())
+}
+
+func (suite *MockTestSuite) TestPlaceholdersInLiteralsAndCommentsAreNotParameters() {
+	description, err := exasol.DryRun(context.Background(), suite.database, `SELECT '?', "?" FROM T /* ? */ WHERE ID = ? -- ?`)
+	suite.NoError(err)
+	suite.Len(description.Parameters, 1)
+}
@@ -0,0 +1,289 @@
+package exasolmock
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/internal/utils"
+	"github.com/exasol/exasol-driver-go/pkg/connection/wsconn"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/gorilla/websocket"
+)
+
+var (
+	loginKeyOnce sync.Once
+	loginKey     *rsa.PrivateKey
+)
+
+// publicKey returns the public key used by the driver for encrypting the password during login.
+func publicKey() *rsa.PublicKey {
+	loginKeyOnce.Do(func() {
+		var err error
+		loginKey, err = rsa.GenerateKey(rand.Reader, 1024)
+		if err != nil {
+			panic(fmt.Errorf("exasolmock: failed to generate login key: %w", err))
+		}
+	})
+	return &loginKey.PublicKey
+}
+
+type response struct {
+	delay      time.Duration
+	compressed bool
+	data       []byte
+}
+
+// server is a fake websocket connection answering the commands of a single session.
+type server struct {
+	mock                *Mock
+	responses           chan response
+	mutex               sync.Mutex
+	preparedStatements  map[int]string
+	nextStatementHandle int
+	closed              bool
+}
+
+func newServer(mock *Mock) *server {
+	return &server{mock: mock, responses: make(chan response, 16), preparedStatements: map[int]string{}}
+}
+
+var errClosed = errors.New("exasolmock: connection closed")
+
+func (s *server) WriteMessage(messageType int, data []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.closed {
+		return errClosed
+	}
+	compressed := messageType == websocket.BinaryMessage
+	if compressed {
+		reader, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		if data, err = io.ReadAll(reader); err != nil {
+			return err
+		}
+	}
+	command := &types.Command{}
+	if err := json.Unmarshal(data, command); err != nil {
+		return err
+	}
+	s.mock.recordCommand(command.Command)
+	if command.Command == "abortQuery" {
+		return nil
+	}
+	result, delay := s.handle(command.Command, data)
+	s.responses <- response{delay: delay, compressed: compressed, data: wsconn.JsonMarshall(result)}
+	return nil
+}
+
+func (s *server) ReadMessage() (int, []byte, error) {
+	r, ok := <-s.responses
+	if !ok {
+		return 0, nil, errClosed
+	}
+	time.Sleep(r.delay)
+	if !r.compressed {
+		return websocket.TextMessage, r.data, nil
+	}
+	var buffer bytes.Buffer
+	writer := zlib.NewWriter(&buffer)
+	if _, err := writer.Write(r.data); err != nil {
+		return 0, nil, err
+	}
+	writer.Close()
+	return websocket.BinaryMessage, buffer.Bytes(), nil
+}
+
+func (s *server) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.responses)
+	}
+	return nil
+}
+
+func (s *server) handle(command string, data []byte) (types.BaseResponse, time.Duration) {
+	switch command {
+	case "login":
+		key := publicKey()
+		return okResponse(types.PublicKeyResponse{
+			PublicKeyModulus:  key.N.Text(16),
+			PublicKeyExponent: fmt.Sprintf("%x", key.E),
+		}), 0
+	case "":
+		releaseVersion, protocolVersion := s.mock.versions()
+		return okResponse(types.AuthResponse{
+			SessionID:             s.mock.nextSessionID(),
+			ProtocolVersion:       protocolVersion,
+			ReleaseVersion:        releaseVersion,
+			DatabaseName:          "EXASOLMOCK",
+			ProductName:           "EXASolution",
+			MaxDataMessageSize:    1024 * 1024 * 1024,
+			MaxIdentifierLength:   128,
+			MaxVarcharLength:      2000000,
+			IdentifierQuoteString: "\"",
+			TimeZone:              "UTC",
+			TimeZoneBehavior:      "INVALID SHIFT AMBIGUOUS ST",
+		}), 0
+	case "execute":
+		request := &types.SqlCommand{}
+		if err := json.Unmarshal(data, request); err != nil {
+			return errorResponse("EGOMK", err.Error()), 0
+		}
+		return s.execute(request.SQLText, nil, false)
+	case "createPreparedStatement":
+		request := &types.CreatePreparedStatementCommand{}
+		if err := json.Unmarshal(data, request); err != nil {
+			return errorResponse("EGOMK", err.Error()), 0
+		}
+		return s.createPreparedStatement(request.SQLText), 0
+	case "executePreparedStatement":
+		request := &types.ExecutePreparedStatementCommand{}
+		if err := json.Unmarshal(data, request); err != nil {
+			return errorResponse("EGOMK", err.Error()), 0
+		}
+		query, ok := s.preparedStatements[request.StatementHandle]
+		if !ok {
+			return errorResponse("EGOMK", fmt.Sprintf("exasolmock: unknown statement handle %d", request.StatementHandle)), 0
+		}
+		return s.execute(query, toRows(request.Data, request.NumRows), true)
+	case "closePreparedStatement":
+		request := &types.ClosePreparedStatementCommand{}
+		if err := json.Unmarshal(data, request); err == nil {
+			delete(s.preparedStatements, request.StatementHandle)
+		}
+		return okResponse(nil), 0
+	case "getAttributes":
+		return types.BaseResponse{Status: "ok", Attributes: wsconn.JsonMarshall(types.Attributes{})}, 0
+	case "loginToken", "disconnect", "closeResultSet", "setAttributes":
+		return okResponse(nil), 0
+	default:
+		return errorResponse("EGOMK", fmt.Sprintf("exasolmock: unsupported command %q", command)), 0
+	}
+}
+
+func (s *server) createPreparedStatement(query string) types.BaseResponse {
+	s.nextStatementHandle++
+	s.preparedStatements[s.nextStatementHandle] = query
+	_, numParameters, _ := utils.ReplacePlaceholders(query, func(int) (string, bool) { return "?", true })
+	parameters := make([]types.SqlQueryColumn, 0, numParameters)
+	for i := 0; i < numParameters; i++ {
+		parameters = append(parameters, types.SqlQueryColumn{DataType: types.SqlQueryColumnType{Type: "VARCHAR"}})
+	}
+	return okResponse(types.CreatePreparedStatementResponse{
+		StatementHandle: s.nextStatementHandle,
+		ParameterData:   types.ParameterData{NumColumns: numParameters, Columns: parameters},
+	})
+}
+
+func (s *server) execute(query string, args [][]any, prepared bool) (types.BaseResponse, time.Duration) {
+	imported, err := s.receiveTransfers(query)
+	if err != nil {
+		return errorResponse("EGOMK", err.Error()), 0
+	}
+	expectation := s.mock.match(statement{query: query, args: args, prepared: prepared, imported: imported})
+	if expectation == nil {
+		if imported != nil {
+			return errorResponse("EGOMK", fmt.Sprintf("exasolmock: unexpected import %q of %d bytes", query, len(imported))), 0
+		}
+		return errorResponse("EGOMK", fmt.Sprintf("exasolmock: unexpected statement %q with arguments %v", query, args)), 0
+	}
+	if expectation.exception != nil {
+		return errorResponse(expectation.exception.SQLCode, expectation.exception.Text), expectation.delay
+	}
+	var result any = types.SqlQueryResponseRowCount{ResultType: "rowCount", RowCount: expectation.rowsAffected}
+	if expectation.rows != nil {
+		result = toResultSet(expectation.rows)
+	}
+	return okResponse(types.SqlQueriesResponse{NumResults: 1, Results: []json.RawMessage{wsconn.JsonMarshall(result)}}), expectation.delay
+}
+
+// proxyURLRegex matches the URLs of the proxy connections of imports and exports of local files.
+var proxyURLRegex = regexp.MustCompile(`AT\s+'http://[^':]+:(\d+)'`)
+
+// receiveTransfers reads the data uploaded by an import of local files. It returns nil if the statement does not
+// transfer local files. Exports are not supported and fail.
+func (s *server) receiveTransfers(query string) ([]byte, error) {
+	matches := proxyURLRegex.FindAllStringSubmatch(query, -1)
+	if matches == nil {
+		return nil, nil
+	}
+	var conns []io.ReadCloser
+	for _, match := range matches {
+		port, _ := strconv.Atoi(match[1])
+		if conn, ok := s.mock.takeTransfer(port); ok {
+			defer conn.Close()
+			conns = append(conns, conn)
+		}
+	}
+	if !importRegex.MatchString(query) || len(conns) != 1 {
+		return nil, fmt.Errorf("exasolmock: unsupported transfer of local files %q", query)
+	}
+	upload, err := http.ReadResponse(bufio.NewReader(conns[0]), nil)
+	if err != nil {
+		return nil, fmt.Errorf("exasolmock: failed to receive import: %w", err)
+	}
+	defer upload.Body.Close()
+	data, err := io.ReadAll(upload.Body)
+	if err != nil {
+		return nil, fmt.Errorf("exasolmock: failed to receive import: %w", err)
+	}
+	return append([]byte{}, data...), nil
+}
+
+// toRows converts column-wise data of a prepared statement to rows.
+func toRows(data [][]any, numRows int) [][]any {
+	rows := make([][]any, numRows)
+	for row := range rows {
+		rows[row] = make([]any, len(data))
+		for column := range data {
+			rows[row][column] = data[column][row]
+		}
+	}
+	return rows
+}
+
+// toResultSet converts rows to a result set with column-wise data.
+func toResultSet(rows *Rows) types.SqlQueryResponseResultSet {
+	data := make([][]any, len(rows.columns))
+	for column := range data {
+		data[column] = make([]any, len(rows.rows))
+		for row := range rows.rows {
+			data[column][row] = rows.rows[row][column]
+		}
+	}
+	return types.SqlQueryResponseResultSet{
+		ResultType: "resultSet",
+		ResultSet: types.SqlQueryResponseResultSetData{
+			NumColumns:       len(rows.columns),
+			NumRows:          len(rows.rows),
+			NumRowsInMessage: len(rows.rows),
+			Columns:          rows.columns,
+			Data:             data,
+		},
+	}
+}
+
+func okResponse(responseData any) types.BaseResponse {
+	return types.BaseResponse{Status: "ok", ResponseData: wsconn.JsonMarshall(responseData)}
+}
+
+func errorResponse(sqlCode, text string) types.BaseResponse {
+	return types.BaseResponse{Status: "error", Exception: &types.Exception{SQLCode: sqlCode, Text: text}}
+}
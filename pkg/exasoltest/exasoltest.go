@@ -0,0 +1,153 @@
+// Package exasoltest provides an Exasol database for integration tests.
+//
+// [Start] connects to the database given by the environment variables EXASOL_HOST, EXASOL_PORT,
+// EXASOL_USER and EXASOL_PASSWORD. If EXASOL_HOST is not set, it starts an Exasol docker container
+// with the version given by DB_VERSION.
+package exasoltest
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"testing"
+
+	exasol "github.com/exasol/exasol-driver-go"
+	"github.com/exasol/exasol-driver-go/pkg/dsn"
+	testSetupAbstraction "github.com/exasol/exasol-test-setup-abstraction-server/go-client"
+)
+
+const defaultExasolDbVersion = "8.22.0"
+
+// Environment variables for connecting to an existing database instead of starting a docker container.
+const (
+	EnvHost      = "EXASOL_HOST"
+	EnvPort      = "EXASOL_PORT"
+	EnvUser      = "EXASOL_USER"
+	EnvPassword  = "EXASOL_PASSWORD"
+	EnvDbVersion = "DB_VERSION"
+)
+
+// Database is an Exasol database used by integration tests.
+type Database struct {
+	Host     string // Host name of the database
+	Port     int    // Port of the database
+	User     string // User name
+	Password string // Password
+	exasol   *testSetupAbstraction.TestSetupAbstraction
+}
+
+// Start connects to the database given by the environment or starts a new docker container.
+// Start skips the test in short mode and fails it if the database is not available.
+// The caller must call [Database.Stop] when the database is no longer needed.
+func Start(t testing.TB) *Database {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+	if host := os.Getenv(EnvHost); host != "" {
+		return fromEnvironment(t, host)
+	}
+	dbVersion := getDbVersion()
+	t.Logf("Starting Exasol %s...", dbVersion)
+	setup, err := testSetupAbstraction.New().DockerDbVersion(dbVersion).Start()
+	if err != nil {
+		t.Fatalf("failed to create test setup abstraction: %v", err)
+	}
+	connectionInfo, err := setup.GetConnectionInfo()
+	if err != nil {
+		t.Fatalf("error getting connection info: %v", err)
+	}
+	return &Database{Host: connectionInfo.Host, Port: connectionInfo.Port, User: connectionInfo.User,
+		Password: connectionInfo.Password, exasol: setup}
+}
+
+func fromEnvironment(t testing.TB, host string) *Database {
+	database := &Database{Host: host, Port: 8563, User: getEnv(EnvUser, "sys"), Password: getEnv(EnvPassword, "exasol")}
+	if port := os.Getenv(EnvPort); port != "" {
+		var err error
+		if database.Port, err = strconv.Atoi(port); err != nil {
+			t.Fatalf("invalid port %q in environment variable %s: %v", port, EnvPort, err)
+		}
+	}
+	return database
+}
+
+func getDbVersion() string {
+	return getEnv(EnvDbVersion, defaultExasolDbVersion)
+}
+
+func getEnv(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// Stop stops the docker container if it was started by [Start].
+func (d *Database) Stop() error {
+	if d.exasol == nil {
+		return nil
+	}
+	return d.exasol.Stop()
+}
+
+// TestSetup returns the test setup abstraction of the docker container started by [Start]
+// or nil if the database is given by the environment.
+func (d *Database) TestSetup() *testSetupAbstraction.TestSetupAbstraction {
+	return d.exasol
+}
+
+// URL returns the websocket URL of the database.
+func (d *Database) URL() url.URL {
+	return url.URL{Scheme: "wss", Host: fmt.Sprintf("%s:%d", d.Host, d.Port)}
+}
+
+// Config returns a new connection configuration for the database without certificate validation.
+func (d *Database) Config() *dsn.DSNConfigBuilder {
+	return exasol.NewConfig(d.User, d.Password).Host(d.Host).Port(d.Port).ValidateServerCertificate(false)
+}
+
+// Open opens a connection to the database with the given configuration or [Database.Config] if it is nil.
+// The connection is closed when the test finishes.
+func (d *Database) Open(t testing.TB, config *dsn.DSNConfigBuilder) *sql.DB {
+	t.Helper()
+	if config == nil {
+		config = d.Config()
+	}
+	db, err := sql.Open("exasol", config.String())
+	if err != nil {
+		t.Fatalf("failed to open connection: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err = db.Ping(); err != nil {
+		t.Fatalf("failed to connect to %s: %v", d.Host, err)
+	}
+	return db
+}
+
+// MajorVersion returns the major version of the database, e.g. "8".
+func (d *Database) MajorVersion(t testing.TB) string {
+	t.Helper()
+	db := d.Open(t, nil)
+	var majorVersion string
+	err := db.QueryRow("SELECT PARAM_VALUE FROM SYS.EXA_METADATA WHERE PARAM_NAME='databaseMajorVersion'").Scan(&majorVersion)
+	if err != nil {
+		t.Fatalf("querying exasol version failed: %v", err)
+	}
+	return majorVersion
+}
+
+// CreateSchema creates a new schema and drops it including all its objects when the test finishes.
+func CreateSchema(t testing.TB, db *sql.DB, schemaName string) {
+	t.Helper()
+	if _, err := db.Exec("CREATE SCHEMA " + schemaName); err != nil {
+		t.Fatalf("failed to create schema %s: %v", schemaName, err)
+	}
+	t.Cleanup(func() {
+		if _, err := db.Exec("DROP SCHEMA IF EXISTS " + schemaName + " CASCADE"); err != nil {
+			t.Errorf("failed to drop schema %s: %v", schemaName, err)
+		}
+	})
+}
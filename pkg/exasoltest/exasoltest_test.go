@@ -0,0 +1,38 @@
+package exasoltest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromEnvironment(t *testing.T) {
+	t.Setenv(EnvPort, "1234")
+	t.Setenv(EnvUser, "user")
+	t.Setenv(EnvPassword, "password")
+	database := fromEnvironment(t, "host")
+	assert.Equal(t, &Database{Host: "host", Port: 1234, User: "user", Password: "password"}, database)
+}
+
+func TestFromEnvironmentWithDefaults(t *testing.T) {
+	t.Setenv(EnvPort, "")
+	t.Setenv(EnvUser, "")
+	t.Setenv(EnvPassword, "")
+	database := fromEnvironment(t, "host")
+	assert.Equal(t, &Database{Host: "host", Port: 8563, User: "sys", Password: "exasol"}, database)
+}
+
+func TestURL(t *testing.T) {
+	database := &Database{Host: "host", Port: 1234}
+	url := database.URL()
+	assert.Equal(t, "wss://host:1234", url.String())
+}
+
+func TestConfig(t *testing.T) {
+	database := &Database{Host: "host", Port: 1234, User: "user", Password: "password"}
+	assert.Equal(t, "exa:host:1234;user=user;password=password;validateservercertificate=0", database.Config().String())
+}
+
+func TestStopWithoutContainer(t *testing.T) {
+	assert.NoError(t, (&Database{}).Stop())
+}
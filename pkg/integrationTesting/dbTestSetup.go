@@ -1,99 +1,69 @@
+// Package integrationTesting starts an Exasol database for integration tests.
+//
+// Deprecated: use package [github.com/exasol/exasol-driver-go/pkg/exasoltest] instead.
 package integrationTesting
 
 import (
-	"database/sql"
-	"fmt"
 	"net/url"
 	"os"
-	"testing"
 
+	"github.com/exasol/exasol-driver-go/pkg/exasoltest"
 	testSetupAbstraction "github.com/exasol/exasol-test-setup-abstraction-server/go-client"
 	"github.com/stretchr/testify/suite"
 )
 
 const defaultExasolDbVersion = "8.22.0"
 
+// DbTestSetup is an Exasol database used by integration tests.
+//
+// Deprecated: use [exasoltest.Database] instead.
 type DbTestSetup struct {
 	suite          *suite.Suite
-	Exasol         *testSetupAbstraction.TestSetupAbstraction
+	database       *exasoltest.Database
+	Exasol         *testSetupAbstraction.TestSetupAbstraction // nil if the database is given by the environment
 	ConnectionInfo *testSetupAbstraction.ConnectionInfo
 	DbVersion      string
 }
 
+// StartDbSetup connects to the database given by the environment or starts a new docker container.
+//
+// Deprecated: use [exasoltest.Start] instead.
 func StartDbSetup(suite *suite.Suite) *DbTestSetup {
-	if testing.Short() {
-		suite.T().Skip()
+	database := exasoltest.Start(suite.T())
+	return &DbTestSetup{
+		suite:    suite,
+		database: database,
+		Exasol:   database.TestSetup(),
+		ConnectionInfo: &testSetupAbstraction.ConnectionInfo{Host: database.Host, Port: database.Port,
+			User: database.User, Password: database.Password},
+		DbVersion: getDbVersion(),
 	}
-	exasolDbVersion := getDbVersion()
-	suite.T().Logf("Starting Exasol %s...", exasolDbVersion)
-	exasol, err := testSetupAbstraction.New().DockerDbVersion(exasolDbVersion).Start()
-	if err != nil {
-		suite.FailNowf("failed to create test setup abstraction: %v", err.Error())
-	}
-	connectionInfo, err := exasol.GetConnectionInfo()
-	if err != nil {
-		suite.FailNowf("error getting connection info: %v", err.Error())
-	}
-	setup := DbTestSetup{suite: suite, Exasol: exasol, ConnectionInfo: connectionInfo, DbVersion: exasolDbVersion}
-	return &setup
 }
 
-func getDbVersion() string {
-	dbVersion := os.Getenv("DB_VERSION")
-	if dbVersion != "" {
-		return dbVersion
-	}
-	return defaultExasolDbVersion
-}
-
-// hostAndPort returns a string with host and port
-func (setup *DbTestSetup) hostAndPort() string {
-	return fmt.Sprintf("%s:%d", setup.ConnectionInfo.Host, setup.ConnectionInfo.Port)
-}
-
-// HostAndPort returns a string with host and port
+// GetUrl returns the websocket URL of the database.
+//
+// Deprecated: use [exasoltest.Database.URL] instead.
 func (setup *DbTestSetup) GetUrl() url.URL {
-	return url.URL{Scheme: "wss", Host: setup.hostAndPort()}
+	return setup.database.URL()
 }
 
+// IsExasolVersion8 checks if the major version of the database is 8.
+//
+// Deprecated: use [exasoltest.Database.MajorVersion] instead.
 func (setup *DbTestSetup) IsExasolVersion8() bool {
-	version, err := setup.getExasolMajorVersion()
-	if err != nil {
-		setup.suite.FailNow("error getting exasol version: " + err.Error())
-	}
-	return version == "8"
+	return setup.database.MajorVersion(setup.suite.T()) == "8"
 }
 
-func (setup *DbTestSetup) getExasolMajorVersion() (string, error) {
-	db := setup.createConnection()
-	defer db.Close()
-	result, err := db.Query("SELECT PARAM_VALUE FROM SYS.EXA_METADATA WHERE PARAM_NAME='databaseMajorVersion'")
-	if err != nil {
-		return "", fmt.Errorf("querying exasol version failed: %w", err)
-	}
-	defer result.Close()
-	if !result.Next() {
-		if result.Err() != nil {
-			return "", fmt.Errorf("failed to iterate exasol version: %w", result.Err())
-		}
-		return "", fmt.Errorf("no result found for exasol version query")
-	}
-	var majorVersion string
-	err = result.Scan(&majorVersion)
-	if err != nil {
-		return "", fmt.Errorf("failed to read exasol version result: %w", err)
-	}
-	return majorVersion, nil
+// StopDb stops the docker container if it was started by [StartDbSetup].
+//
+// Deprecated: use [exasoltest.Database.Stop] instead.
+func (setup *DbTestSetup) StopDb() {
+	setup.suite.NoError(setup.database.Stop())
 }
 
-func (setup *DbTestSetup) createConnection() *sql.DB {
-	conn, err := setup.Exasol.CreateConnection()
-	if err != nil {
-		setup.suite.FailNow("failed to create connection: " + err.Error())
+func getDbVersion() string {
+	if dbVersion := os.Getenv(exasoltest.EnvDbVersion); dbVersion != "" {
+		return dbVersion
 	}
-	return conn
-}
-
-func (setup *DbTestSetup) StopDb() {
-	setup.suite.NoError(setup.Exasol.Stop())
+	return defaultExasolDbVersion
 }
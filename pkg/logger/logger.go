@@ -9,6 +9,9 @@ import (
 
 var ErrorLogger = Logger(log.New(os.Stderr, "[exasol] ", log.LstdFlags|log.Lshortfile))
 
+// TraceLogger is used for opt-in diagnostic output like the query log.
+var TraceLogger = Logger(log.New(os.Stderr, "[exasol] ", log.LstdFlags))
+
 // Logger is used to log critical error messages.
 type Logger interface {
 	Print(v ...interface{})
@@ -24,3 +27,13 @@ func SetLogger(logger Logger) error {
 	ErrorLogger = logger
 	return nil
 }
+
+// SetTraceLogger is used to set the logger for diagnostic output that must be enabled explicitly,
+// e.g. the query log. The initial logger is os.Stderr.
+func SetTraceLogger(logger Logger) error {
+	if logger == nil {
+		return errors.ErrLoggerNil
+	}
+	TraceLogger = logger
+	return nil
+}
@@ -32,3 +32,19 @@ func TestErrorsSetLogger(t *testing.T) {
 func TestLoggerIsNil(t *testing.T) {
 	assert.EqualError(t, SetLogger(nil), "E-EGOD-8: logger is nil")
 }
+
+func TestSetTraceLogger(t *testing.T) {
+	previous := TraceLogger
+	defer func() {
+		TraceLogger = previous
+	}()
+
+	buffer := bytes.NewBuffer(make([]byte, 0, 64))
+	assert.NoError(t, SetTraceLogger(log.New(buffer, "prefix: ", 0)))
+	TraceLogger.Print("test")
+	assert.Equal(t, "prefix: test\n", buffer.String())
+}
+
+func TestTraceLoggerIsNil(t *testing.T) {
+	assert.EqualError(t, SetTraceLogger(nil), "E-EGOD-8: logger is nil")
+}
@@ -0,0 +1,78 @@
+package metadata
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// Column describes a column of a table or view.
+type Column struct {
+	Schema          string         // Schema of the table
+	Table           string         // Name of the table or view
+	Name            string         // Name of the column
+	Type            string         // Exasol data type, e.g. DECIMAL(18,0) or VARCHAR(100) UTF8
+	Precision       int64          // Precision of numeric types
+	Scale           int64          // Scale of numeric types
+	Length          int64          // Maximum length of string types or precision of numeric types
+	OrdinalPosition int64          // Position of the column in the table, starting at 1
+	Nullable        bool           // Whether the column accepts NULL values
+	Identity        bool           // Whether the column is an identity column
+	Default         sql.NullString // Default value of the column
+	Comment         string         // Comment on the column
+}
+
+const columnsQuery = `SELECT COLUMN_SCHEMA, COLUMN_TABLE, COLUMN_NAME, COLUMN_TYPE, COLUMN_NUM_PREC, COLUMN_NUM_SCALE,
+ COLUMN_MAXSIZE, COLUMN_ORDINAL_POSITION, COLUMN_IS_NULLABLE, COLUMN_IDENTITY, COLUMN_DEFAULT, COLUMN_COMMENT
+ FROM SYS.EXA_ALL_COLUMNS`
+
+// Describe returns the columns of the given table or view ordered by their position.
+// Schema and table names are case-sensitive, unquoted identifiers are stored in upper case.
+func Describe(ctx context.Context, db Querier, schema, table string) ([]Column, error) {
+	columns, err := queryColumns(ctx, db, columnsQuery+` WHERE COLUMN_SCHEMA = ? AND COLUMN_TABLE = ?
+ ORDER BY COLUMN_ORDINAL_POSITION`, schema, table)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, errors.NewErrTableNotFound(schema, table)
+	}
+	return columns, nil
+}
+
+// ListColumns returns the columns of all tables and views matching the given LIKE patterns.
+// Empty patterns match all names.
+func ListColumns(ctx context.Context, db Querier, schemaPattern, tablePattern, columnPattern string) ([]Column, error) {
+	return queryColumns(ctx, db, columnsQuery+` WHERE COLUMN_SCHEMA LIKE ? AND COLUMN_TABLE LIKE ? AND COLUMN_NAME LIKE ?
+ ORDER BY COLUMN_SCHEMA, COLUMN_TABLE, COLUMN_ORDINAL_POSITION`, pattern(schemaPattern), pattern(tablePattern), pattern(columnPattern))
+}
+
+func queryColumns(ctx context.Context, db Querier, query string, args ...any) ([]Column, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var columns []Column
+	for rows.Next() {
+		var precision, scale, length, position sql.NullFloat64
+		var nullable sql.NullBool
+		var identity, comment sql.NullString
+		column := Column{}
+		err = rows.Scan(&column.Schema, &column.Table, &column.Name, &column.Type, &precision, &scale, &length, &position,
+			&nullable, &identity, &column.Default, &comment)
+		if err != nil {
+			return nil, err
+		}
+		column.Precision = toInt64(precision)
+		column.Scale = toInt64(scale)
+		column.Length = toInt64(length)
+		column.OrdinalPosition = toInt64(position)
+		column.Nullable = nullable.Valid && nullable.Bool
+		column.Identity = identity.Valid
+		column.Comment = comment.String
+		columns = append(columns, column)
+	}
+	return columns, rows.Err()
+}
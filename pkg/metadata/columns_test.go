@@ -0,0 +1,94 @@
+package metadata
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+	"github.com/exasol/exasol-driver-go/pkg/exasolmock"
+	"github.com/exasol/exasol-driver-go/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetadataTestSuite struct {
+	suite.Suite
+	mock     *exasolmock.Mock
+	database *sql.DB
+}
+
+func TestMetadataSuite(t *testing.T) {
+	suite.Run(t, new(MetadataTestSuite))
+}
+
+func (suite *MetadataTestSuite) SetupTest() {
+	suite.mock = exasolmock.New()
+	database, err := sql.Open(exasolmock.DriverName, suite.mock.DSN())
+	suite.NoError(err)
+	suite.database = database
+}
+
+func (suite *MetadataTestSuite) TearDownTest() {
+	suite.NoError(suite.database.Close())
+	suite.NoError(suite.mock.Close())
+}
+
+func (suite *MetadataTestSuite) TestDescribe() {
+	suite.mock.ExpectStatement(`(?s)^SELECT COLUMN_SCHEMA, .* FROM SYS\.EXA_ALL_COLUMNS WHERE COLUMN_SCHEMA = \? AND COLUMN_TABLE = \?\s+ORDER BY COLUMN_ORDINAL_POSITION$`).
+		WithArgs("SHOP", "CUSTOMERS").WillReturnRows(columnRows().
+		AddRow("SHOP", "CUSTOMERS", "ID", "DECIMAL(18,0)", 18, 0, 18, 1, false, "1", nil, "primary key").
+		AddRow("SHOP", "CUSTOMERS", "NAME", "VARCHAR(100) UTF8", nil, nil, 100, 2, true, nil, "'unknown'", nil))
+	columns, err := Describe(context.Background(), suite.database, "SHOP", "CUSTOMERS")
+	suite.NoError(err)
+	suite.Equal([]Column{
+		{Schema: "SHOP", Table: "CUSTOMERS", Name: "ID", Type: "DECIMAL(18,0)", Precision: 18, Length: 18, OrdinalPosition: 1,
+			Identity: true, Comment: "primary key"},
+		{Schema: "SHOP", Table: "CUSTOMERS", Name: "NAME", Type: "VARCHAR(100) UTF8", Length: 100, OrdinalPosition: 2,
+			Nullable: true, Default: sql.NullString{String: "'unknown'", Valid: true}},
+	}, columns)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MetadataTestSuite) TestDescribeUnknownTable() {
+	suite.mock.ExpectStatement(`FROM SYS\.EXA_ALL_COLUMNS`).WithArgs("SHOP", "MISSING").WillReturnRows(columnRows())
+	_, err := Describe(context.Background(), suite.database, "SHOP", "MISSING")
+	suite.EqualError(err, errors.NewErrTableNotFound("SHOP", "MISSING").Error())
+}
+
+func (suite *MetadataTestSuite) TestDescribeFails() {
+	suite.mock.ExpectStatement(`FROM SYS\.EXA_ALL_COLUMNS`).WillReturnError("42500", "insufficient privileges")
+	_, err := Describe(context.Background(), suite.database, "SHOP", "CUSTOMERS")
+	suite.ErrorContains(err, "insufficient privileges")
+}
+
+func (suite *MetadataTestSuite) TestListColumns() {
+	suite.mock.ExpectStatement(`(?s)FROM SYS\.EXA_ALL_COLUMNS WHERE COLUMN_SCHEMA LIKE \? AND COLUMN_TABLE LIKE \? AND COLUMN_NAME LIKE \?\s+ORDER BY COLUMN_SCHEMA, COLUMN_TABLE, COLUMN_ORDINAL_POSITION$`).
+		WithArgs("SHOP", "%", "%\\_ID").WillReturnRows(columnRows().
+		AddRow("SHOP", "ORDERS", "CUSTOMER_ID", "DECIMAL(18,0)", 18, 0, 18, 2, false, nil, nil, nil))
+	columns, err := ListColumns(context.Background(), suite.database, "SHOP", "", "%\\_ID")
+	suite.NoError(err)
+	suite.Equal([]Column{{Schema: "SHOP", Table: "ORDERS", Name: "CUSTOMER_ID", Type: "DECIMAL(18,0)", Precision: 18,
+		Length: 18, OrdinalPosition: 2}}, columns)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+// columnRows returns an empty result set with the columns and types of columnsQuery.
+func columnRows() *exasolmock.Rows {
+	return exasolmock.NewRowsWithColumns(varchar("COLUMN_SCHEMA"), varchar("COLUMN_TABLE"), varchar("COLUMN_NAME"),
+		varchar("COLUMN_TYPE"), decimal("COLUMN_NUM_PREC"), decimal("COLUMN_NUM_SCALE"), decimal("COLUMN_MAXSIZE"),
+		decimal("COLUMN_ORDINAL_POSITION"), boolean("COLUMN_IS_NULLABLE"), varchar("COLUMN_IDENTITY"),
+		varchar("COLUMN_DEFAULT"), varchar("COLUMN_COMMENT"))
+}
+
+func varchar(name string) types.SqlQueryColumn {
+	return types.SqlQueryColumn{Name: name, DataType: types.SqlQueryColumnType{Type: "VARCHAR"}}
+}
+
+func decimal(name string) types.SqlQueryColumn {
+	precision, scale := int64(18), int64(0)
+	return types.SqlQueryColumn{Name: name, DataType: types.SqlQueryColumnType{Type: "DECIMAL", Precision: &precision, Scale: &scale}}
+}
+
+func boolean(name string) types.SqlQueryColumn {
+	return types.SqlQueryColumn{Name: name, DataType: types.SqlQueryColumnType{Type: "BOOLEAN"}}
+}
@@ -0,0 +1,42 @@
+package metadata
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Function describes a user defined SQL function.
+type Function struct {
+	Schema  string // Schema of the function
+	Name    string // Name of the function
+	Owner   string // Owner of the function
+	Text    string // Definition of the function
+	Comment string // Comment on the function
+}
+
+const functionsQuery = `SELECT FUNCTION_SCHEMA, FUNCTION_NAME, FUNCTION_OWNER, FUNCTION_TEXT, FUNCTION_COMMENT
+ FROM SYS.EXA_ALL_FUNCTIONS
+ WHERE FUNCTION_SCHEMA LIKE ? AND FUNCTION_NAME LIKE ?
+ ORDER BY FUNCTION_SCHEMA, FUNCTION_NAME`
+
+// ListFunctions returns all functions matching the given LIKE patterns. Empty patterns match all names.
+func ListFunctions(ctx context.Context, db Querier, schemaPattern, functionPattern string) ([]Function, error) {
+	rows, err := db.QueryContext(ctx, functionsQuery, pattern(schemaPattern), pattern(functionPattern))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var functions []Function
+	for rows.Next() {
+		var owner, text, comment sql.NullString
+		function := Function{}
+		if err = rows.Scan(&function.Schema, &function.Name, &owner, &text, &comment); err != nil {
+			return nil, err
+		}
+		function.Owner = owner.String
+		function.Text = text.String
+		function.Comment = comment.String
+		functions = append(functions, function)
+	}
+	return functions, rows.Err()
+}
@@ -0,0 +1,22 @@
+package metadata
+
+import (
+	"context"
+
+	"github.com/exasol/exasol-driver-go/pkg/exasolmock"
+)
+
+func (suite *MetadataTestSuite) TestListFunctions() {
+	suite.mock.ExpectStatement(`(?s)^SELECT FUNCTION_SCHEMA, .* FROM SYS\.EXA_ALL_FUNCTIONS\s+WHERE FUNCTION_SCHEMA LIKE \? AND FUNCTION_NAME LIKE \?\s+ORDER BY FUNCTION_SCHEMA, FUNCTION_NAME$`).
+		WithArgs("%", "NET_%").WillReturnRows(exasolmock.NewRowsWithColumns(varchar("FUNCTION_SCHEMA"), varchar("FUNCTION_NAME"),
+		varchar("FUNCTION_OWNER"), varchar("FUNCTION_TEXT"), varchar("FUNCTION_COMMENT")).
+		AddRow("SHOP", "NET_PRICE", "SYS", "FUNCTION NET_PRICE(P DECIMAL) RETURN DECIMAL ...", "price without tax").
+		AddRow("UTIL", "NET_VALUE", nil, nil, nil))
+	functions, err := ListFunctions(context.Background(), suite.database, "", "NET_%")
+	suite.NoError(err)
+	suite.Equal([]Function{
+		{Schema: "SHOP", Name: "NET_PRICE", Owner: "SYS", Text: "FUNCTION NET_PRICE(P DECIMAL) RETURN DECIMAL ...", Comment: "price without tax"},
+		{Schema: "UTIL", Name: "NET_VALUE"},
+	}, functions)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,26 @@
+// Package metadata provides typed access to the metadata of database objects stored in the Exasol system views.
+package metadata
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Querier executes queries. It is implemented by *sql.DB, *sql.Conn and *sql.Tx.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// toInt64 converts a numeric value returned by the driver to int64. The numbers of the system views are scanned as
+// float64, so that integer and decimal columns are handled alike.
+func toInt64(value sql.NullFloat64) int64 {
+	return int64(value.Float64)
+}
+
+// pattern returns the given LIKE pattern or a pattern matching all names if it is empty.
+func pattern(namePattern string) string {
+	if namePattern == "" {
+		return "%"
+	}
+	return namePattern
+}
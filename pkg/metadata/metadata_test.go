@@ -0,0 +1,24 @@
+package metadata
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToInt64(t *testing.T) {
+	assert.Equal(t, int64(18), toInt64(sql.NullFloat64{Float64: 18, Valid: true}))
+}
+
+func TestToInt64Null(t *testing.T) {
+	assert.Equal(t, int64(0), toInt64(sql.NullFloat64{}))
+}
+
+func TestPattern(t *testing.T) {
+	assert.Equal(t, "MY\\_%", pattern("MY\\_%"))
+}
+
+func TestPatternEmpty(t *testing.T) {
+	assert.Equal(t, "%", pattern(""))
+}
@@ -0,0 +1,42 @@
+package metadata
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Schema describes a schema.
+type Schema struct {
+	Name    string // Name of the schema
+	Owner   string // Owner of the schema
+	Virtual bool   // Whether the schema is a virtual schema
+	Comment string // Comment on the schema
+}
+
+const schemasQuery = `SELECT SCHEMA_NAME, SCHEMA_OWNER, SCHEMA_IS_VIRTUAL, SCHEMA_COMMENT
+ FROM SYS.EXA_SCHEMAS
+ WHERE SCHEMA_NAME LIKE ?
+ ORDER BY SCHEMA_NAME`
+
+// ListSchemas returns all schemas matching the given LIKE pattern. An empty pattern matches all schemas.
+func ListSchemas(ctx context.Context, db Querier, schemaPattern string) ([]Schema, error) {
+	rows, err := db.QueryContext(ctx, schemasQuery, pattern(schemaPattern))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var schemas []Schema
+	for rows.Next() {
+		var owner, comment sql.NullString
+		var virtual sql.NullBool
+		schema := Schema{}
+		if err = rows.Scan(&schema.Name, &owner, &virtual, &comment); err != nil {
+			return nil, err
+		}
+		schema.Owner = owner.String
+		schema.Virtual = virtual.Bool
+		schema.Comment = comment.String
+		schemas = append(schemas, schema)
+	}
+	return schemas, rows.Err()
+}
@@ -0,0 +1,28 @@
+package metadata
+
+import (
+	"context"
+
+	"github.com/exasol/exasol-driver-go/pkg/exasolmock"
+)
+
+func (suite *MetadataTestSuite) TestListSchemas() {
+	suite.mock.ExpectStatement(`(?s)^SELECT SCHEMA_NAME, .* FROM SYS\.EXA_SCHEMAS\s+WHERE SCHEMA_NAME LIKE \?\s+ORDER BY SCHEMA_NAME$`).
+		WithArgs("%").WillReturnRows(exasolmock.NewRowsWithColumns(varchar("SCHEMA_NAME"), varchar("SCHEMA_OWNER"),
+		boolean("SCHEMA_IS_VIRTUAL"), varchar("SCHEMA_COMMENT")).
+		AddRow("SHOP", "SYS", false, "online shop").
+		AddRow("VS", nil, true, nil))
+	schemas, err := ListSchemas(context.Background(), suite.database, "")
+	suite.NoError(err)
+	suite.Equal([]Schema{
+		{Name: "SHOP", Owner: "SYS", Comment: "online shop"},
+		{Name: "VS", Virtual: true},
+	}, schemas)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
+
+func (suite *MetadataTestSuite) TestListSchemasFails() {
+	suite.mock.ExpectStatement(`FROM SYS\.EXA_SCHEMAS`).WillReturnError("42500", "insufficient privileges")
+	_, err := ListSchemas(context.Background(), suite.database, "")
+	suite.ErrorContains(err, "insufficient privileges")
+}
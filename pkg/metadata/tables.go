@@ -0,0 +1,46 @@
+package metadata
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Table describes a table.
+type Table struct {
+	Schema   string // Schema of the table
+	Name     string // Name of the table
+	Owner    string // Owner of the table
+	Virtual  bool   // Whether the table belongs to a virtual schema
+	RowCount int64  // Number of rows in the table
+	Comment  string // Comment on the table
+}
+
+const tablesQuery = `SELECT TABLE_SCHEMA, TABLE_NAME, TABLE_OWNER, TABLE_IS_VIRTUAL, TABLE_ROW_COUNT, TABLE_COMMENT
+ FROM SYS.EXA_ALL_TABLES
+ WHERE TABLE_SCHEMA LIKE ? AND TABLE_NAME LIKE ?
+ ORDER BY TABLE_SCHEMA, TABLE_NAME`
+
+// ListTables returns all tables matching the given LIKE patterns. Empty patterns match all names.
+func ListTables(ctx context.Context, db Querier, schemaPattern, tablePattern string) ([]Table, error) {
+	rows, err := db.QueryContext(ctx, tablesQuery, pattern(schemaPattern), pattern(tablePattern))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var tables []Table
+	for rows.Next() {
+		var owner, comment sql.NullString
+		var virtual sql.NullBool
+		var rowCount sql.NullFloat64
+		table := Table{}
+		if err = rows.Scan(&table.Schema, &table.Name, &owner, &virtual, &rowCount, &comment); err != nil {
+			return nil, err
+		}
+		table.Owner = owner.String
+		table.Virtual = virtual.Bool
+		table.RowCount = toInt64(rowCount)
+		table.Comment = comment.String
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
@@ -0,0 +1,22 @@
+package metadata
+
+import (
+	"context"
+
+	"github.com/exasol/exasol-driver-go/pkg/exasolmock"
+)
+
+func (suite *MetadataTestSuite) TestListTables() {
+	suite.mock.ExpectStatement(`(?s)^SELECT TABLE_SCHEMA, .* FROM SYS\.EXA_ALL_TABLES\s+WHERE TABLE_SCHEMA LIKE \? AND TABLE_NAME LIKE \?\s+ORDER BY TABLE_SCHEMA, TABLE_NAME$`).
+		WithArgs("SHOP", "SALES_%").WillReturnRows(exasolmock.NewRowsWithColumns(varchar("TABLE_SCHEMA"), varchar("TABLE_NAME"),
+		varchar("TABLE_OWNER"), boolean("TABLE_IS_VIRTUAL"), decimal("TABLE_ROW_COUNT"), varchar("TABLE_COMMENT")).
+		AddRow("SHOP", "SALES_2023", "SYS", false, 1500, "archived").
+		AddRow("SHOP", "SALES_2024", nil, nil, nil, nil))
+	tables, err := ListTables(context.Background(), suite.database, "SHOP", "SALES_%")
+	suite.NoError(err)
+	suite.Equal([]Table{
+		{Schema: "SHOP", Name: "SALES_2023", Owner: "SYS", RowCount: 1500, Comment: "archived"},
+		{Schema: "SHOP", Name: "SALES_2024"},
+	}, tables)
+	suite.NoError(suite.mock.ExpectationsWereMet())
+}
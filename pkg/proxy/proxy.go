@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"net/http/httputil"
-	"os"
+	"strconv"
 
+	"github.com/exasol/exasol-driver-go/internal/utils"
 	"github.com/exasol/exasol-driver-go/pkg/errors"
 	"github.com/exasol/exasol-driver-go/pkg/logger"
 )
@@ -27,7 +29,11 @@ var magicWords = []interface{}{uint32(0x02212102), uint32(1), uint32(1)}
 func NewProxy(hosts []string, port int) (*Proxy, error) {
 	var wrappedErr error
 	for _, host := range hosts {
-		uri := fmt.Sprintf("%s:%d", host, port)
+		name, hostPort, err := utils.SplitHostPort(host, port)
+		if err != nil {
+			return nil, err
+		}
+		uri := net.JoinHostPort(name, strconv.Itoa(hostPort))
 		con, err := net.Dial("tcp", uri)
 		if err == nil {
 			p := &Proxy{
@@ -72,7 +78,7 @@ func (p *Proxy) StartProxy() error {
 	return nil
 }
 
-func (p *Proxy) Write(ctx context.Context, files []*os.File, rowSeparator string) error {
+func (p *Proxy) Write(ctx context.Context, files []io.Reader, rowSeparator string) error {
 	err := p.sendHeaders([]string{
 		"HTTP/1.1 200 OK",
 		"Content-Type: application/octet-stream",
@@ -94,7 +100,7 @@ func (p *Proxy) Write(ctx context.Context, files []*os.File, rowSeparator string
 	return err
 }
 
-func (p *Proxy) SendFile(ctx context.Context, file *os.File, rowSeparator string, chunkedWriter io.WriteCloser) error {
+func (p *Proxy) SendFile(ctx context.Context, file io.Reader, rowSeparator string, chunkedWriter io.WriteCloser) error {
 	reader := bufio.NewReader(file)
 
 	for {
@@ -109,6 +115,9 @@ func (p *Proxy) SendFile(ctx context.Context, file *os.File, rowSeparator string
 			delimiter = '\r'
 		}
 		line, err := reader.ReadBytes(byte(delimiter))
+		if err != nil && err != io.EOF {
+			return err
+		}
 		if err != nil && len(line) == 0 {
 			break
 		}
@@ -128,6 +137,41 @@ func (p *Proxy) SendFile(ctx context.Context, file *os.File, rowSeparator string
 	return nil
 }
 
+// Read receives a file sent by the database as HTTP request and writes its content to the given writer.
+func (p *Proxy) Read(ctx context.Context, writer io.Writer) error {
+	request, err := http.ReadRequest(bufio.NewReader(p.connection))
+	if err != nil {
+		return fmt.Errorf("%w: could not read export request, %s", errors.ErrInvalidProxyConn, err.Error())
+	}
+	defer request.Body.Close()
+
+	buffer := make([]byte, 32*1024)
+	for {
+		if ctx.Err() != nil {
+			p.Close()
+			return ctx.Err()
+		}
+		n, err := request.Body.Read(buffer)
+		if n > 0 {
+			if _, writeErr := writer.Write(buffer[:n]); writeErr != nil {
+				return writeErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: could not read exported data, %s", errors.ErrInvalidProxyConn, err.Error())
+		}
+	}
+
+	return p.sendHeaders([]string{
+		"HTTP/1.1 200 OK",
+		"Content-Length: 0",
+		"Connection: close",
+	})
+}
+
 func (p *Proxy) sendHeaders(headers []string) error {
 	headers = append(headers, "")
 	for _, header := range headers {
@@ -0,0 +1,78 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ProxyTestSuite struct {
+	suite.Suite
+}
+
+func TestProxySuite(t *testing.T) {
+	suite.Run(t, new(ProxyTestSuite))
+}
+
+type fakeConnection struct {
+	io.Reader
+	written bytes.Buffer
+	closed  bool
+}
+
+func (c *fakeConnection) Write(p []byte) (int, error) {
+	return c.written.Write(p)
+}
+
+func (c *fakeConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (suite *ProxyTestSuite) TestReadChunkedRequest() {
+	connection := &fakeConnection{Reader: strings.NewReader("PUT /data_1.csv HTTP/1.1\r\nHost: 10.0.0.1:4333\r\nTransfer-Encoding: chunked\r\n\r\n" +
+		"6\r\n1,abc\n\r\n6\r\n2,def\n\r\n0\r\n\r\n")}
+	p := &Proxy{connection: connection}
+	var file bytes.Buffer
+
+	suite.NoError(p.Read(context.Background(), &file))
+	suite.Equal("1,abc\n2,def\n", file.String())
+	suite.Equal("HTTP/1.1 200 OK\r\nContent-Length: 0\r\nConnection: close\r\n\r\n", connection.written.String())
+}
+
+func (suite *ProxyTestSuite) TestReadInvalidRequest() {
+	p := &Proxy{connection: &fakeConnection{Reader: strings.NewReader("invalid")}}
+	err := p.Read(context.Background(), &bytes.Buffer{})
+	suite.ErrorContains(err, "E-EGOD-26: could not create proxy connection to import file: could not read export request")
+}
+
+func (suite *ProxyTestSuite) TestReadCanceled() {
+	connection := &fakeConnection{Reader: strings.NewReader("PUT /data_1.csv HTTP/1.1\r\nContent-Length: 6\r\n\r\n1,abc\n")}
+	p := &Proxy{connection: connection}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	suite.ErrorIs(p.Read(ctx, &bytes.Buffer{}), context.Canceled)
+	suite.True(connection.closed)
+}
+
+func (suite *ProxyTestSuite) TestWriteReturnsReadError() {
+	p := &Proxy{connection: &fakeConnection{Reader: strings.NewReader("")}}
+	file := iotest.ErrReader(fmt.Errorf("read failed"))
+
+	suite.EqualError(p.Write(context.Background(), []io.Reader{file}, "\n"), "read failed")
+}
+
+func (suite *ProxyTestSuite) TestWriteAddsMissingRowSeparator() {
+	connection := &fakeConnection{Reader: strings.NewReader("")}
+	p := &Proxy{connection: connection}
+
+	suite.NoError(p.Write(context.Background(), []io.Reader{strings.NewReader("1,a"), strings.NewReader("2,b\n")}, "\n"))
+	suite.Contains(connection.written.String(), "4\r\n1,a\n\r\n4\r\n2,b\n\r\n0\r\n\r\n")
+}
@@ -16,6 +16,11 @@ type LoginTokenCommand struct {
 	Attributes      Attributes `json:"attributes,omitempty"`
 }
 
+type SetAttributesCommand struct {
+	Command
+	Attributes Attributes `json:"attributes"`
+}
+
 type CloseResultSetCommand struct {
 	Command
 	ResultSetHandles []int      `json:"resultSetHandles"`
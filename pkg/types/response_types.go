@@ -3,14 +3,60 @@ package types
 import "encoding/json"
 
 type BaseResponse struct {
-	Status       string          `json:"status"`
-	ResponseData json.RawMessage `json:"responseData"`
-	Exception    *Exception      `json:"exception"`
+	Status       string            `json:"status"`
+	ResponseData json.RawMessage   `json:"responseData"`
+	Attributes   json.RawMessage   `json:"attributes,omitempty"`
+	Exception    *Exception        `json:"exception"`
+	Warnings     []json.RawMessage `json:"warnings,omitempty"`
 }
 
 type Exception struct {
 	Text    string `json:"text"`
 	SQLCode string `json:"sqlCode"`
+	// Details contains all other fields of the exception, e.g. the position of the error, as raw JSON values.
+	Details map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the exception and keeps all fields except text and sqlCode in Details.
+func (e *Exception) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*e = Exception{}
+	for name, value := range fields {
+		var err error
+		switch name {
+		case "text":
+			err = json.Unmarshal(value, &e.Text)
+		case "sqlCode":
+			err = json.Unmarshal(value, &e.SQLCode)
+		default:
+			if e.Details == nil {
+				e.Details = make(map[string]json.RawMessage)
+			}
+			e.Details[name] = value
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes the exception including the fields in Details.
+func (e Exception) MarshalJSON() ([]byte, error) {
+	if len(e.Details) == 0 {
+		type plainException Exception
+		return json.Marshal(plainException(e))
+	}
+	fields := make(map[string]interface{}, len(e.Details)+2)
+	for name, value := range e.Details {
+		fields[name] = value
+	}
+	fields["text"] = e.Text
+	fields["sqlCode"] = e.SQLCode
+	return json.Marshal(fields)
 }
 
 type AuthResponse struct {
@@ -76,6 +122,7 @@ type SqlQueryColumnType struct {
 type CreatePreparedStatementResponse struct {
 	StatementHandle int           `json:"statementHandle"`
 	ParameterData   ParameterData `json:"parameterData,omitempty"`
+	SqlQueriesResponse
 }
 
 type ParameterData struct {
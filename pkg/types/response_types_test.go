@@ -0,0 +1,45 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ResponseTypesTestSuite struct {
+	suite.Suite
+}
+
+func TestResponseTypesTestSuite(t *testing.T) {
+	suite.Run(t, new(ResponseTypesTestSuite))
+}
+
+func (suite *ResponseTypesTestSuite) TestUnmarshalExceptionKeepsAllFields() {
+	exception := Exception{}
+	err := json.Unmarshal([]byte(`{"text":"syntax error","sqlCode":"42000","position":{"line":120,"column":7},"stack":["a","b"]}`), &exception)
+	suite.NoError(err)
+	suite.Equal("syntax error", exception.Text)
+	suite.Equal("42000", exception.SQLCode)
+	suite.Equal(map[string]json.RawMessage{
+		"position": json.RawMessage(`{"line":120,"column":7}`),
+		"stack":    json.RawMessage(`["a","b"]`),
+	}, exception.Details)
+}
+
+func (suite *ResponseTypesTestSuite) TestUnmarshalExceptionWithoutDetails() {
+	exception := Exception{}
+	suite.NoError(json.Unmarshal([]byte(`{"text":"error","sqlCode":"42000"}`), &exception))
+	suite.Equal(Exception{Text: "error", SQLCode: "42000"}, exception)
+}
+
+func (suite *ResponseTypesTestSuite) TestUnmarshalExceptionFailsForInvalidField() {
+	exception := Exception{}
+	suite.Error(json.Unmarshal([]byte(`{"text":42}`), &exception))
+}
+
+func (suite *ResponseTypesTestSuite) TestMarshalException() {
+	data, err := json.Marshal(Exception{Text: "error", SQLCode: "42000", Details: map[string]json.RawMessage{"position": json.RawMessage(`7`)}})
+	suite.NoError(err)
+	suite.JSONEq(`{"text":"error","sqlCode":"42000","position":7}`, string(data))
+}
@@ -0,0 +1,117 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// ProfileReport contains the profiling information of a single statement.
+type ProfileReport struct {
+	StatementID int           // ID of the statement in the session
+	CommandName string        // Name of the statement, e.g. SELECT
+	Duration    time.Duration // Sum of the durations of all parts
+	Parts       []ProfilePart // Execution parts of the statement
+}
+
+// ProfilePart contains the profiling information of a single execution part of a statement.
+type ProfilePart struct {
+	PartID              int           // ID of the part within the statement
+	PartName            string        // Name of the part, e.g. COMPILE / EXECUTE or SCAN
+	PartInfo            string        // Extended information about the part
+	ObjectSchema        string        // Schema of the processed object
+	ObjectName          string        // Name of the processed object
+	ObjectRows          int64         // Number of rows of the processed object
+	OutRows             int64         // Number of result rows of the part
+	Duration            time.Duration // Duration of the part
+	CPU                 float64       // CPU utilization in percent
+	TempDBRAMPeak       float64       // Peak of temporary DB RAM usage in MiB
+	PersistentDBRAMPeak float64       // Peak of persistent DB RAM usage in MiB
+	Remarks             string        // Additional information
+}
+
+const profileQuery = `SELECT STMT_ID, COMMAND_NAME, PART_ID, PART_NAME, PART_INFO, OBJECT_SCHEMA, OBJECT_NAME,
+ OBJECT_ROWS, OUT_ROWS, DURATION, CPU, TEMP_DB_RAM_PEAK, PERSISTENT_DB_RAM_PEAK, REMARKS
+ FROM EXA_STATISTICS.EXA_USER_PROFILE_LAST_DAY
+ WHERE SESSION_ID = CURRENT_SESSION AND STMT_ID = (
+  SELECT MIN(STMT_ID) FROM EXA_STATISTICS.EXA_USER_PROFILE_LAST_DAY WHERE SESSION_ID = CURRENT_SESSION AND STMT_ID > ?)
+ ORDER BY PART_ID`
+
+// Profile executes the given statement with the session attribute profile enabled and returns its profiling information.
+// The statement is executed on the given connection because profiling information is collected per session.
+// Profiling is disabled again afterwards. Any result set of the statement is discarded.
+func Profile(ctx context.Context, conn *sql.Conn, query string, args ...any) (*ProfileReport, error) {
+	if _, err := conn.ExecContext(ctx, "ALTER SESSION SET PROFILE = 'ON'"); err != nil {
+		return nil, err
+	}
+	profileEnabled := true
+	defer func() {
+		if profileEnabled {
+			_, _ = conn.ExecContext(ctx, "ALTER SESSION SET PROFILE = 'OFF'")
+		}
+	}()
+	var previousStatementID float64
+	if err := conn.QueryRowContext(ctx, "SELECT CURRENT_STATEMENT").Scan(&previousStatementID); err != nil {
+		return nil, err
+	}
+	if _, err := conn.ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+	profileEnabled = false
+	if _, err := conn.ExecContext(ctx, "ALTER SESSION SET PROFILE = 'OFF'"); err != nil {
+		return nil, err
+	}
+	if _, err := conn.ExecContext(ctx, "FLUSH STATISTICS"); err != nil {
+		return nil, err
+	}
+	return readProfile(ctx, conn, previousStatementID)
+}
+
+func readProfile(ctx context.Context, conn *sql.Conn, previousStatementID float64) (*ProfileReport, error) {
+	rows, err := conn.QueryContext(ctx, profileQuery, previousStatementID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	report := &ProfileReport{}
+	for rows.Next() {
+		var statementID, partID, objectRows, outRows, duration, cpu, tempDBRAMPeak, persistentDBRAMPeak sql.NullFloat64
+		var commandName, partName, partInfo, objectSchema, objectName, remarks sql.NullString
+		err = rows.Scan(&statementID, &commandName, &partID, &partName, &partInfo, &objectSchema, &objectName,
+			&objectRows, &outRows, &duration, &cpu, &tempDBRAMPeak, &persistentDBRAMPeak, &remarks)
+		if err != nil {
+			return nil, err
+		}
+		part := ProfilePart{
+			PartID:              int(partID.Float64),
+			PartName:            partName.String,
+			PartInfo:            partInfo.String,
+			ObjectSchema:        objectSchema.String,
+			ObjectName:          objectName.String,
+			ObjectRows:          int64(objectRows.Float64),
+			OutRows:             int64(outRows.Float64),
+			Duration:            secondsToDuration(duration.Float64),
+			CPU:                 cpu.Float64,
+			TempDBRAMPeak:       tempDBRAMPeak.Float64,
+			PersistentDBRAMPeak: persistentDBRAMPeak.Float64,
+			Remarks:             remarks.String,
+		}
+		report.StatementID = int(statementID.Float64)
+		report.CommandName = commandName.String
+		report.Duration += part.Duration
+		report.Parts = append(report.Parts, part)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(report.Parts) == 0 {
+		return nil, errors.ErrProfileNotFound
+	}
+	return report, nil
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
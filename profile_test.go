@@ -0,0 +1,13 @@
+package exasol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecondsToDuration(t *testing.T) {
+	assert.Equal(t, 1500*time.Millisecond, secondsToDuration(1.5))
+	assert.Equal(t, time.Duration(0), secondsToDuration(0))
+}
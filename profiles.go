@@ -0,0 +1,50 @@
+package exasol
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/exasol/exasol-driver-go/pkg/dsn"
+)
+
+// Driver names registered with preconfigured properties for tools that accept only a driver name and a connection string.
+const (
+	// CompressedDriverName is the driver with compression enabled by default.
+	CompressedDriverName = "exasol-compressed"
+	// InsecureDevDriverName is the driver that skips the verification of TLS certificates by default,
+	// e.g. for local development databases with self-signed certificates. Don't use it in production.
+	InsecureDevDriverName = "exasol-insecure-dev"
+)
+
+func init() {
+	registerProfile(CompressedDriverName, "compression=1")
+	registerProfile(InsecureDevDriverName, "validateservercertificate=0")
+}
+
+// RegisterProfile registers the driver under an additional name with the given properties as defaults,
+// e.g. RegisterProfile("exasol-analytics", "fetchsize=8192;compression=1"). Properties of the connection string
+// take precedence over the defaults. Like sql.Register it panics if the name is already registered.
+func RegisterProfile(name string, properties string) error {
+	if _, err := dsn.ParseDSN(applyProfile("exa:localhost:8563", properties)); err != nil {
+		return err
+	}
+	registerProfile(name, properties)
+	return nil
+}
+
+func registerProfile(name string, properties string) {
+	sql.Register(name, &ExasolDriver{profile: properties})
+}
+
+// applyProfile inserts the properties of the profile before the properties of the connection string,
+// so that the latter override them.
+func applyProfile(dataSourceName string, profile string) string {
+	if profile == "" || !strings.HasPrefix(dataSourceName, "exa:") {
+		return dataSourceName
+	}
+	index := strings.Index(dataSourceName, ";")
+	if index < 0 {
+		return dataSourceName + ";" + profile
+	}
+	return dataSourceName[:index+1] + profile + ";" + dataSourceName[index+1:]
+}
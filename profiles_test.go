@@ -0,0 +1,65 @@
+package exasol
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyProfile(t *testing.T) {
+	tests := []struct {
+		dataSourceName string
+		profile        string
+		expected       string
+	}{
+		{"exa:localhost:8563", "", "exa:localhost:8563"},
+		{"exa:localhost:8563", "compression=1", "exa:localhost:8563;compression=1"},
+		{"exa:localhost:8563;user=sys", "compression=1", "exa:localhost:8563;compression=1;user=sys"},
+		{"exa:localhost:8563;compression=0", "compression=1;fetchsize=10", "exa:localhost:8563;compression=1;fetchsize=10;compression=0"},
+		{"invalid", "compression=1", "invalid"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, applyProfile(test.dataSourceName, test.profile))
+	}
+}
+
+func TestPresetProfiles(t *testing.T) {
+	assert.Contains(t, sql.Drivers(), CompressedDriverName)
+	assert.Contains(t, sql.Drivers(), InsecureDevDriverName)
+
+	connector := openProfileConnector(t, CompressedDriverName, "exa:localhost:8563;user=sys")
+	assert.True(t, connector.Config.Compression)
+	assert.True(t, connector.Config.ValidateServerCertificate)
+	assert.Equal(t, "sys", connector.Config.User)
+
+	connector = openProfileConnector(t, CompressedDriverName, "exa:localhost:8563;compression=0")
+	assert.False(t, connector.Config.Compression)
+
+	connector = openProfileConnector(t, InsecureDevDriverName, "exa:localhost:8563")
+	assert.False(t, connector.Config.ValidateServerCertificate)
+	assert.True(t, connector.Config.Encryption)
+}
+
+func TestRegisterProfile(t *testing.T) {
+	assert.NoError(t, RegisterProfile("exasol-profile-test", "fetchsize=8192;autocommit=0"))
+	connector := openProfileConnector(t, "exasol-profile-test", "exa:localhost:8563")
+	assert.Equal(t, 8192, connector.Config.FetchSize)
+	assert.False(t, connector.Config.Autocommit)
+	assert.Panics(t, func() { _ = RegisterProfile("exasol-profile-test", "") })
+}
+
+func TestRegisterProfileWithInvalidProperties(t *testing.T) {
+	assert.ErrorContains(t, RegisterProfile("exasol-invalid-profile", "fetchsize=many"), "E-EGOD-")
+	assert.NotContains(t, sql.Drivers(), "exasol-invalid-profile")
+}
+
+// openProfileConnector opens a connector of the driver registered with the given name without connecting.
+func openProfileConnector(t *testing.T, driverName string, dataSourceName string) *Connector {
+	db, err := sql.Open(driverName, dataSourceName)
+	assert.NoError(t, err)
+	defer db.Close()
+	connector, err := db.Driver().(*ExasolDriver).OpenConnector(dataSourceName)
+	assert.NoError(t, err)
+	return connector.(*Connector)
+}
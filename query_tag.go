@@ -0,0 +1,15 @@
+package exasol
+
+import (
+	"context"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+)
+
+// WithQueryTag returns a context whose statements are sent to the database with the tag as leading comment,
+// so that DBAs can attribute the load in EXA_ALL_SESSIONS and the auditing views to application jobs:
+//
+//	rows, err := database.QueryContext(exasol.WithQueryTag(ctx, "job=nightly-etl"), "SELECT * FROM SALES")
+func WithQueryTag(ctx context.Context, tag string) context.Context {
+	return connection.WithQueryTag(ctx, tag)
+}
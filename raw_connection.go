@@ -0,0 +1,30 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// withConnection runs the given function with an Exasol connection from the given database pool.
+func withConnection(ctx context.Context, db *sql.DB, f func(conn *connection.Connection) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return withRawConnection(conn, f)
+}
+
+// withRawConnection runs the given function with the Exasol connection underlying the given connection.
+func withRawConnection(conn *sql.Conn, f func(conn *connection.Connection) error) error {
+	return conn.Raw(func(driverConn any) error {
+		exasolConn, ok := driverConn.(*connection.Connection)
+		if !ok {
+			return errors.ErrUnsupportedConnection
+		}
+		return f(exasolConn)
+	})
+}
@@ -0,0 +1,156 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// CloneSession opens a new database with the same connector as the given connection.
+// Each of its connections copies the current schema, autocommit, timezone and format attributes
+// of the given connection at the time of cloning. This is useful for fan-out work that must observe identical session semantics.
+// The connections of the clone count towards the statistics of the connector and are stopped by its shutdown.
+// The first connection is opened immediately. The caller must close the returned database.
+func CloneSession(ctx context.Context, conn *sql.Conn) (*sql.DB, error) {
+	var connector *Connector
+	err := withRawConnection(conn, func(exasolConn *connection.Connection) error {
+		original, ok := exasolConn.Connector.(*Connector)
+		if !ok {
+			return errors.ErrUnsupportedConnection
+		}
+		attributes, err := exasolConn.SessionAttributes(ctx)
+		if err != nil {
+			return err
+		}
+		config := *exasolConn.Config
+		config.Schema = attributes.CurrentSchema
+		if attributes.Autocommit != nil {
+			config.Autocommit = *attributes.Autocommit
+		}
+		connector = original.clone()
+		connector.Config = &config
+		connector.SessionAttributes = attributes
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	database := sql.OpenDB(connector)
+	if err = database.PingContext(ctx); err != nil {
+		database.Close()
+		return nil, err
+	}
+	return database, nil
+}
+
+// GetSessionInfo returns the session ID, database version and negotiated protocol version of the given connection,
+// e.g. for correlating log entries with the sessions in EXA_ALL_SESSIONS.
+func GetSessionInfo(conn *sql.Conn) (connection.SessionInfo, error) {
+	var info connection.SessionInfo
+	err := withRawConnection(conn, func(exasolConn *connection.Connection) error {
+		info = exasolConn.SessionInfo()
+		return nil
+	})
+	return info, err
+}
+
+// GetServerVersion returns the release version of the database of the given connection, e.g. for choosing between
+// statements for different database versions. It is the zero version if the database reported no valid version.
+func GetServerVersion(conn *sql.Conn) (connection.Version, error) {
+	var version connection.Version
+	err := withRawConnection(conn, func(exasolConn *connection.Connection) error {
+		version = exasolConn.ServerVersion()
+		return nil
+	})
+	return version, err
+}
+
+// Supports checks if the database version and the negotiated protocol version of the given connection support the
+// feature. Features are assumed to be supported if the database reported no valid version.
+func Supports(conn *sql.Conn, feature connection.Feature) (bool, error) {
+	var supported bool
+	err := withRawConnection(conn, func(exasolConn *connection.Connection) error {
+		supported = exasolConn.Supports(feature)
+		return nil
+	})
+	return supported, err
+}
+
+// SessionDetails describes the session of a connection as seen by the database.
+type SessionDetails struct {
+	connection.SessionInfo
+	User          string    `exasol:"USER_NAME"` // Name of the user logged in
+	LoginTime     time.Time // Time of the login in the time zone of the session
+	Client        string    // Client name and version reported at login
+	Driver        string    // Driver name and version reported at login
+	Host          string    // Host of the client
+	OSUser        string    // Operating system user of the client
+	Status        string    // Status of the session, e.g. IDLE or EXECUTE SQL
+	CurrentSchema string    // Schema opened in the session, empty if none
+	Autocommit    bool      // True if each statement is committed automatically
+	QueryTimeout  int       // Query timeout of the session in seconds, 0 if statements don't time out
+	ConsumerGroup string    // Consumer group whose resource limits apply to the session, empty before Exasol 7.0
+	TempDBRAM     int64     // Temporary database memory used by the session in MiB
+}
+
+// sessionDetailsQuery selects the columns of SessionDetails from the system view. Databases without consumer groups
+// have no CONSUMER_GROUP column.
+func sessionDetailsQuery(consumerGroups bool) string {
+	consumerGroupColumn := ""
+	if consumerGroups {
+		consumerGroupColumn = "CONSUMER_GROUP, "
+	}
+	return "SELECT USER_NAME, LOGIN_TIME, CLIENT, DRIVER, HOST, OS_USER, STATUS, QUERY_TIMEOUT, " + consumerGroupColumn +
+		"TEMP_DB_RAM FROM EXA_ALL_SESSIONS WHERE SESSION_ID = CURRENT_SESSION"
+}
+
+// GetSessionDetails returns the information of GetSessionInfo completed with the user, client, schema and resource
+// limits of the session, which are read from the session attributes and EXA_ALL_SESSIONS. This saves monitoring code
+// from building queries against the system views.
+func GetSessionDetails(ctx context.Context, conn *sql.Conn) (SessionDetails, error) {
+	var details SessionDetails
+	var consumerGroups bool
+	err := withRawConnection(conn, func(exasolConn *connection.Connection) error {
+		attributes, err := exasolConn.SessionAttributes(ctx)
+		if err != nil {
+			return err
+		}
+		details.SessionInfo = exasolConn.SessionInfo()
+		details.CurrentSchema = attributes.CurrentSchema
+		details.Autocommit = attributes.Autocommit != nil && *attributes.Autocommit
+		consumerGroups = exasolConn.Supports(connection.FeatureConsumerGroups)
+		return nil
+	})
+	if err != nil {
+		return SessionDetails{}, err
+	}
+	rows, err := conn.QueryContext(ctx, sessionDetailsQuery(consumerGroups))
+	if err != nil {
+		return SessionDetails{}, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err == nil {
+			err = sql.ErrNoRows
+		}
+		return SessionDetails{}, err
+	}
+	if err = ScanStruct(rows, &details); err != nil {
+		return SessionDetails{}, err
+	}
+	return details, rows.Close()
+}
+
+// GetWarnings returns the warnings that the database returned for the last statement of the given connection,
+// including the warnings of fetching its rows.
+func GetWarnings(conn *sql.Conn) ([]connection.Warning, error) {
+	var warnings []connection.Warning
+	err := withRawConnection(conn, func(exasolConn *connection.Connection) error {
+		warnings = exasolConn.Warnings()
+		return nil
+	})
+	return warnings, err
+}
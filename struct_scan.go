@@ -0,0 +1,279 @@
+package exasol
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// ScanStruct scans the current row into the struct pointed to by dest, e.g. after rows.Next() returned true.
+//
+// Columns are mapped to the exported fields whose tag `exasol:"NAME"` equals the column name ignoring case,
+// or else whose name equals the column name ignoring case and underscores, so that field CustomerID receives column
+// CUSTOMER_ID. Fields tagged with `exasol:"-"` and columns without field are skipped.
+//
+// Values are converted using the type of their column: DECIMAL values fill integer fields exactly, DATE and TIMESTAMP
+// values fill time.Time fields in UTC and NULL values set fields to their zero value. Pointer fields are set to nil
+// for NULL values and fields implementing sql.Scanner, e.g. sql.NullString, receive the converted values.
+func ScanStruct(rows *sql.Rows, dest any) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return errors.NewInvalidScanDestination(fmt.Sprintf("%T", dest))
+	}
+	scanner, err := newStructScanner(rows, value.Elem().Type())
+	if err != nil {
+		return err
+	}
+	return scanner.scan(value.Elem())
+}
+
+// ScanSlice reads the remaining rows and appends them to the slice pointed to by dest.
+// The elements of the slice are structs or pointers to structs filled like ScanStruct.
+// The rows are read until the end but not closed.
+func ScanSlice(rows *sql.Rows, dest any) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Slice {
+		return errors.NewInvalidScanDestination(fmt.Sprintf("%T", dest))
+	}
+	slice := value.Elem()
+	elementType := slice.Type().Elem()
+	structType := elementType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return errors.NewInvalidScanDestination(fmt.Sprintf("%T", dest))
+	}
+	scanner, err := newStructScanner(rows, structType)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		element := reflect.New(structType)
+		if err = scanner.scan(element.Elem()); err != nil {
+			return err
+		}
+		if elementType.Kind() == reflect.Pointer {
+			slice.Set(reflect.Append(slice, element))
+		} else {
+			slice.Set(reflect.Append(slice, element.Elem()))
+		}
+	}
+	return rows.Err()
+}
+
+// structScanner scans rows into structs of a type.
+type structScanner struct {
+	rows        *sql.Rows
+	columnTypes []*sql.ColumnType
+	fields      [][]int // Index of the field of each column, nil for columns without field
+	values      []any
+	pointers    []any
+}
+
+func newStructScanner(rows *sql.Rows, structType reflect.Type) (*structScanner, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	scanner := &structScanner{rows: rows, columnTypes: columnTypes, fields: make([][]int, len(columnTypes)),
+		values: make([]any, len(columnTypes)), pointers: make([]any, len(columnTypes))}
+	for i, columnType := range columnTypes {
+		scanner.fields[i] = structField(structType, columnType.Name())
+		scanner.pointers[i] = &scanner.values[i]
+	}
+	return scanner, nil
+}
+
+// structField returns the index of the field receiving the given column or nil if there is none.
+func structField(structType reflect.Type, column string) []int {
+	var byName []int
+	for _, field := range reflect.VisibleFields(structType) {
+		if !field.IsExported() || field.Anonymous || throughPointer(structType, field.Index) {
+			continue
+		}
+		tag, hasTag := field.Tag.Lookup("exasol")
+		switch {
+		case tag == "-":
+			continue
+		case hasTag && strings.EqualFold(tag, column):
+			return field.Index
+		case !hasTag && byName == nil && strings.EqualFold(field.Name, strings.ReplaceAll(column, "_", "")):
+			byName = field.Index
+		}
+	}
+	return byName
+}
+
+// throughPointer returns true if the field with the given index is promoted from an embedded pointer to a struct.
+func throughPointer(structType reflect.Type, index []int) bool {
+	for i := 1; i < len(index); i++ {
+		if structType.FieldByIndex(index[:i]).Type.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *structScanner) scan(dest reflect.Value) error {
+	if err := s.rows.Scan(s.pointers...); err != nil {
+		return err
+	}
+	for i, value := range s.values {
+		if s.fields[i] == nil {
+			continue
+		}
+		columnType := s.columnTypes[i]
+		field := dest.FieldByIndex(s.fields[i])
+		if err := assignColumnValue(field, columnType, value); err != nil {
+			return fmt.Errorf("invalid value %v of column %q for field of type %s: %w", value, columnType.Name(), field.Type(), err)
+		}
+	}
+	return nil
+}
+
+// columnValue converts DECIMAL values without scale to int64 and DATE and TIMESTAMP values to time.Time.
+func columnValue(columnType *sql.ColumnType, value any) any {
+	switch v := value.(type) {
+	case float64:
+		if _, scale, ok := columnType.DecimalSize(); ok && scale == 0 && v == math.Trunc(v) && math.Abs(v) < math.MaxInt64 {
+			return int64(v)
+		}
+	case string:
+		switch columnType.DatabaseTypeName() {
+		case "DATE":
+			if date, err := time.Parse(parquetDateLayout, v); err == nil {
+				return date
+			}
+		case "TIMESTAMP", "TIMESTAMP WITH LOCAL TIME ZONE":
+			if timestamp, err := time.Parse(parquetTimestampLayout, v); err == nil {
+				return timestamp
+			}
+		}
+	}
+	return value
+}
+
+func assignColumnValue(field reflect.Value, columnType *sql.ColumnType, value any) error {
+	if scanner, ok := field.Addr().Interface().(sql.Scanner); ok {
+		return scanner.Scan(columnValue(columnType, value))
+	}
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	if field.Kind() == reflect.Pointer {
+		element := reflect.New(field.Type().Elem())
+		if err := assignColumnValue(element.Elem(), columnType, value); err != nil {
+			return err
+		}
+		field.Set(element)
+		return nil
+	}
+	if bytes, ok := value.([]byte); ok {
+		value = string(bytes)
+	}
+	switch field.Kind() {
+	case reflect.String:
+		// Strings keep the format of the database, e.g. of dates, and numbers are written without exponent
+		text, err := csvField(value, "")
+		field.SetString(text)
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return setInt(field, value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return setUint(field, value)
+	case reflect.Float32, reflect.Float64:
+		return setFloat(field, value)
+	case reflect.Bool:
+		if v, ok := value.(string); ok {
+			parsed, err := strconv.ParseBool(v)
+			field.SetBool(parsed)
+			return err
+		}
+	}
+	converted := reflect.ValueOf(columnValue(columnType, value))
+	if !converted.Type().ConvertibleTo(field.Type()) {
+		return fmt.Errorf("unsupported conversion from %T", value)
+	}
+	field.Set(converted.Convert(field.Type()))
+	return nil
+}
+
+func setInt(field reflect.Value, value any) error {
+	var number int64
+	switch v := value.(type) {
+	case int64:
+		number = v
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) >= math.MaxInt64 {
+			return fmt.Errorf("not an integer")
+		}
+		number = int64(v)
+	case string:
+		var err error
+		if number, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported conversion from %T", value)
+	}
+	if field.OverflowInt(number) {
+		return fmt.Errorf("value out of range")
+	}
+	field.SetInt(number)
+	return nil
+}
+
+func setUint(field reflect.Value, value any) error {
+	var number uint64
+	switch v := value.(type) {
+	case int64:
+		if v < 0 {
+			return fmt.Errorf("value out of range")
+		}
+		number = uint64(v)
+	case float64:
+		if v != math.Trunc(v) || v < 0 || v >= math.MaxUint64 {
+			return fmt.Errorf("not an unsigned integer")
+		}
+		number = uint64(v)
+	case string:
+		var err error
+		if number, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported conversion from %T", value)
+	}
+	if field.OverflowUint(number) {
+		return fmt.Errorf("value out of range")
+	}
+	field.SetUint(number)
+	return nil
+}
+
+func setFloat(field reflect.Value, value any) error {
+	var number float64
+	switch v := value.(type) {
+	case int64:
+		number = float64(v)
+	case float64:
+		number = v
+	case string:
+		var err error
+		if number, err = strconv.ParseFloat(v, 64); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported conversion from %T", value)
+	}
+	field.SetFloat(number)
+	return nil
+}
@@ -0,0 +1,94 @@
+package exasol
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type scannedCustomer struct {
+	CustomerID int64
+	Name       string `exasol:"CUSTOMER_NAME"`
+	Balance    float64
+	Created    time.Time
+	LastLogin  *time.Time
+	Email      sql.NullString
+	Active     bool
+	Ignored    string `exasol:"-"`
+	unexported string
+}
+
+var scannedCustomerColumns = []staticColumn{
+	{name: "CUSTOMER_ID", databaseType: "DECIMAL", precision: 18},
+	{name: "CUSTOMER_NAME", databaseType: "VARCHAR"},
+	{name: "BALANCE", databaseType: "DECIMAL", precision: 18, scale: 2},
+	{name: "CREATED", databaseType: "DATE"},
+	{name: "LAST_LOGIN", databaseType: "TIMESTAMP"},
+	{name: "EMAIL", databaseType: "VARCHAR"},
+	{name: "ACTIVE", databaseType: "BOOLEAN"},
+	{name: "IGNORED", databaseType: "VARCHAR"},
+	{name: "UNKNOWN", databaseType: "VARCHAR"},
+}
+
+func TestScanStruct(t *testing.T) {
+	rows := queryStaticRows(t, scannedCustomerColumns, [][]driver.Value{
+		{float64(1e17), "Alice", 12.5, "2023-01-02", "2023-01-02 10:11:12.345000", "alice@example.com", true, "x", "y"},
+	})
+	assert.True(t, rows.Next())
+	var customer scannedCustomer
+	assert.NoError(t, ScanStruct(rows, &customer))
+	lastLogin := time.Date(2023, 1, 2, 10, 11, 12, 345000000, time.UTC)
+	assert.Equal(t, scannedCustomer{CustomerID: 1e17, Name: "Alice", Balance: 12.5, Created: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+		LastLogin: &lastLogin, Email: sql.NullString{String: "alice@example.com", Valid: true}, Active: true}, customer)
+}
+
+func TestScanStructNullValues(t *testing.T) {
+	rows := queryStaticRows(t, scannedCustomerColumns, [][]driver.Value{{float64(1), nil, nil, nil, nil, nil, nil, nil, nil}})
+	assert.True(t, rows.Next())
+	customer := scannedCustomer{Name: "previous", Active: true}
+	assert.NoError(t, ScanStruct(rows, &customer))
+	assert.Equal(t, scannedCustomer{CustomerID: 1}, customer)
+}
+
+func TestScanStructInvalidValue(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{{name: "CUSTOMER_ID", databaseType: "DECIMAL", precision: 18, scale: 1}}, [][]driver.Value{{1.5}})
+	assert.True(t, rows.Next())
+	var customer scannedCustomer
+	assert.EqualError(t, ScanStruct(rows, &customer), `invalid value 1.5 of column "CUSTOMER_ID" for field of type int64: not an integer`)
+}
+
+func TestScanStructInvalidDestination(t *testing.T) {
+	rows := queryStaticRows(t, scannedCustomerColumns, nil)
+	assert.ErrorContains(t, ScanStruct(rows, scannedCustomer{}), "E-EGOD-71: invalid scan destination 'exasol.scannedCustomer'")
+	assert.ErrorContains(t, ScanSlice(rows, &[]int{}), "E-EGOD-71: invalid scan destination '*[]int'")
+}
+
+func TestScanSlice(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{{name: "ID", databaseType: "DECIMAL", precision: 36}, {name: "NAME", databaseType: "VARCHAR"}},
+		[][]driver.Value{{"123456789012345678", "Alice"}, {float64(2), []byte("Bob")}})
+	type customer struct {
+		ID   uint64
+		Name string
+	}
+	var customers []*customer
+	assert.NoError(t, ScanSlice(rows, &customers))
+	assert.Equal(t, []*customer{{ID: 123456789012345678, Name: "Alice"}, {ID: 2, Name: "Bob"}}, customers)
+}
+
+func TestScanSliceEmbeddedStruct(t *testing.T) {
+	rows := queryStaticRows(t, []staticColumn{{name: "ID", databaseType: "DECIMAL", precision: 18}, {name: "NAME", databaseType: "VARCHAR"}},
+		[][]driver.Value{{float64(1), "Alice"}})
+	type entity struct {
+		ID int
+	}
+	type customer struct {
+		entity
+		Name string
+	}
+	var customers []customer
+	assert.NoError(t, ScanSlice(rows, &customers))
+	assert.Equal(t, []customer{{entity: entity{ID: 1}, Name: "Alice"}}, customers)
+}
@@ -0,0 +1,133 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"sync"
+
+	"github.com/exasol/exasol-driver-go/pkg/connection"
+	"github.com/exasol/exasol-driver-go/pkg/errors"
+)
+
+// WarmUp opens and authenticates n connections of the given database pool in parallel and returns them to the pool,
+// so that the first requests don't wait for the login. Connections that are already idle in the pool count towards n.
+// The pool closes returned connections exceeding its idle limit, so set it to at least n with SetMaxIdleConns.
+func WarmUp(ctx context.Context, db *sql.DB, n int) error {
+	return WarmUpWithOptions(ctx, db, n, WarmUpOptions{})
+}
+
+// WarmUpOptions configures WarmUpWithOptions.
+type WarmUpOptions struct {
+	// SpreadHosts opens the connections round-robin across the hosts of the connection string instead of connecting
+	// to random hosts, so that the load is evenly distributed across the cluster nodes from the start.
+	// Connections to unavailable hosts fail over to the other hosts as usual.
+	SpreadHosts bool
+	// Readiness is marked as finished with the result of the warm-up, if not nil.
+	Readiness *Readiness
+}
+
+// WarmUpWithOptions works like WarmUp but opens the connections as configured by the given options.
+func WarmUpWithOptions(ctx context.Context, db *sql.DB, n int, options WarmUpOptions) error {
+	err := warmUp(ctx, db, n, options.SpreadHosts)
+	if options.Readiness != nil {
+		options.Readiness.finish(err)
+	}
+	return err
+}
+
+func warmUp(ctx context.Context, db *sql.DB, n int, spreadHosts bool) error {
+	if maxOpen := db.Stats().MaxOpenConnections; maxOpen > 0 && n > maxOpen {
+		return errors.NewWarmUpExceedsPool(n, maxOpen)
+	}
+	// All connections are held until the last one is open, otherwise the pool would hand out the same connection again
+	conns := make([]*sql.Conn, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			connCtx := ctx
+			if spreadHosts {
+				connCtx = connection.WithPreferredHost(ctx, i)
+			}
+			conns[i], errs[i] = db.Conn(connCtx)
+			if errs[i] == nil {
+				errs[i] = conns[i].PingContext(ctx)
+			}
+		}(i)
+	}
+	wg.Wait()
+	var firstErr error
+	for i, conn := range conns {
+		if conn != nil {
+			conn.Close()
+		}
+		if firstErr == nil {
+			firstErr = errs[i]
+		}
+	}
+	return firstErr
+}
+
+// Readiness signals the completion of a warm-up, e.g. for the readiness probe of a Kubernetes pod.
+// The zero value is not ready. A Readiness is safe for concurrent use and finishes only once.
+type Readiness struct {
+	mutex    sync.Mutex
+	done     chan struct{}
+	finished bool
+	err      error
+}
+
+// Done returns a channel that is closed when the warm-up finished, successfully or not.
+func (r *Readiness) Done() <-chan struct{} {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.done == nil {
+		r.done = make(chan struct{})
+	}
+	return r.done
+}
+
+// Ready returns true if the warm-up finished successfully.
+func (r *Readiness) Ready() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.finished && r.err == nil
+}
+
+// Err returns the error of the warm-up or nil if it has not finished or was successful.
+func (r *Readiness) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.err
+}
+
+// ServeHTTP responds with status 200 if the warm-up finished successfully and with 503 otherwise,
+// so that the Readiness can be registered as the handler of a readiness endpoint.
+func (r *Readiness) ServeHTTP(writer http.ResponseWriter, _ *http.Request) {
+	if r.Ready() {
+		writer.WriteHeader(http.StatusOK)
+		return
+	}
+	message := "warm-up in progress"
+	if err := r.Err(); err != nil {
+		message = "warm-up failed: " + err.Error()
+	}
+	http.Error(writer, message, http.StatusServiceUnavailable)
+}
+
+func (r *Readiness) finish(err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.finished {
+		return
+	}
+	r.finished = true
+	r.err = err
+	if r.done == nil {
+		r.done = make(chan struct{})
+	}
+	close(r.done)
+}
@@ -0,0 +1,88 @@
+package exasol
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmUpOpensConnections(t *testing.T) {
+	connector := &countingConnector{}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxIdleConns(5)
+	assert.NoError(t, WarmUp(context.Background(), db, 5))
+	assert.EqualValues(t, 5, connector.connects.Load())
+	assert.Equal(t, 5, db.Stats().Idle)
+}
+
+func TestWarmUpExceedsPool(t *testing.T) {
+	db := sql.OpenDB(&countingConnector{})
+	defer db.Close()
+	db.SetMaxOpenConns(2)
+	assert.ErrorContains(t, WarmUp(context.Background(), db, 3), "E-EGOD-67")
+}
+
+func TestWarmUpMarksReadiness(t *testing.T) {
+	db := sql.OpenDB(&countingConnector{})
+	defer db.Close()
+	readiness := &Readiness{}
+	done := readiness.Done()
+	assert.False(t, readiness.Ready())
+	assert.NoError(t, WarmUpWithOptions(context.Background(), db, 2, WarmUpOptions{SpreadHosts: true, Readiness: readiness}))
+	<-done
+	assert.True(t, readiness.Ready())
+	assert.NoError(t, readiness.Err())
+}
+
+func TestWarmUpFailureMarksReadiness(t *testing.T) {
+	db := sql.OpenDB(&countingConnector{err: driver.ErrBadConn})
+	defer db.Close()
+	readiness := &Readiness{}
+	err := WarmUpWithOptions(context.Background(), db, 1, WarmUpOptions{Readiness: readiness})
+	assert.ErrorIs(t, err, driver.ErrBadConn)
+	<-readiness.Done()
+	assert.False(t, readiness.Ready())
+	assert.ErrorIs(t, readiness.Err(), driver.ErrBadConn)
+}
+
+func TestReadinessServeHTTP(t *testing.T) {
+	readiness := &Readiness{}
+	recorder := httptest.NewRecorder()
+	readiness.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "warm-up in progress")
+
+	readiness.finish(nil)
+	recorder = httptest.NewRecorder()
+	readiness.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestReadinessFinishesOnce(t *testing.T) {
+	readiness := &Readiness{}
+	readiness.finish(nil)
+	readiness.finish(driver.ErrBadConn)
+	assert.True(t, readiness.Ready())
+}
+
+// countingConnector counts the opened connections of a fake driver.
+type countingConnector struct {
+	staticConnector
+	connects atomic.Int32
+	err      error
+}
+
+func (c *countingConnector) Connect(context.Context) (driver.Conn, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.connects.Add(1)
+	return &staticConnector{}, nil
+}